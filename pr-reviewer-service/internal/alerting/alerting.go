@@ -0,0 +1,144 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Payload formats of the alert webhook
+const (
+	FormatWebhook   = "webhook"
+	FormatSlack     = "slack"
+	FormatPagerDuty = "pagerduty"
+)
+
+// Alert - SLA breach of a single review assignment
+type Alert struct {
+	DedupKey        string    `json:"dedup_key"`
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	ReviewerID      string    `json:"reviewer_id"`
+	TeamName        string    `json:"team_name"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	Deadline        time.Time `json:"deadline"`
+}
+
+func (a Alert) Summary() string {
+	return fmt.Sprintf("Review of %q (%s) by %s missed its deadline %s",
+		a.PullRequestName, a.PullRequestID, a.ReviewerID, a.Deadline.Format(time.RFC3339))
+}
+
+// Config - alert channel settings
+type Config struct {
+	URL        string
+	Format     string
+	RoutingKey string // PagerDuty integration key
+}
+
+// ConfigFromEnv reads ALERT_WEBHOOK_URL, ALERT_FORMAT and ALERT_PAGERDUTY_ROUTING_KEY
+func ConfigFromEnv() Config {
+	format := os.Getenv("ALERT_FORMAT")
+	if format == "" {
+		format = FormatWebhook
+	}
+	return Config{
+		URL:        os.Getenv("ALERT_WEBHOOK_URL"),
+		Format:     format,
+		RoutingKey: os.Getenv("ALERT_PAGERDUTY_ROUTING_KEY"),
+	}
+}
+
+// Enabled reports whether alert channel is configured
+func (c Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// Sender - channel receiving SLA breach alerts
+type Sender interface {
+	Send(ctx context.Context, alert Alert) error
+}
+
+type WebhookSender struct {
+	cfg  Config
+	http *http.Client
+}
+
+func NewWebhookSender(cfg Config) (*WebhookSender, error) {
+	switch cfg.Format {
+	case FormatWebhook, FormatSlack, FormatPagerDuty:
+	default:
+		return nil, fmt.Errorf("unknown alert format %q", cfg.Format)
+	}
+	if cfg.Format == FormatPagerDuty && cfg.RoutingKey == "" {
+		return nil, fmt.Errorf("pagerduty format requires routing key")
+	}
+	
+	return &WebhookSender{
+		cfg:  cfg,
+		http: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (s *WebhookSender) Send(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(s.payload(alert))
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Failed to close response body: %v", err)
+		}
+	}()
+	
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("alert channel returned %d", resp.StatusCode)
+	}
+	
+	return nil
+}
+
+func (s *WebhookSender) payload(alert Alert) interface{} {
+	switch s.cfg.Format {
+	case FormatSlack:
+		return map[string]interface{}{
+			"text": ":rotating_light: " + alert.Summary(),
+		}
+	case FormatPagerDuty:
+		// Events API v2, dedup_key makes PagerDuty collapse repeated triggers
+		return map[string]interface{}{
+			"routing_key":  s.cfg.RoutingKey,
+			"event_action": "trigger",
+			"dedup_key":    alert.DedupKey,
+			"payload": map[string]interface{}{
+				"summary":        alert.Summary(),
+				"source":         "pr-reviewer-service",
+				"severity":       "warning",
+				"component":      alert.TeamName,
+				"custom_details": alert,
+			},
+		}
+	}
+	
+	return map[string]interface{}{
+		"event":   "review.sla_breached",
+		"summary": alert.Summary(),
+		"alert":   alert,
+	}
+}
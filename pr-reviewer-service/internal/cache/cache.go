@@ -0,0 +1,107 @@
+// Package cache keeps results of expensive loads for a short time and makes concurrent
+// callers of the same key share one load
+package cache
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Load results as counted by metrics
+const (
+	ResultHit    = "hit"    // fresh cached value
+	ResultShared = "shared" // waited for a load another caller started
+	ResultMiss   = "miss"   // ran the load
+)
+
+var errPanicked = errors.New("cache: load panicked")
+
+type entry[V any] struct {
+	done    chan struct{} // closed when the load finished
+	value   V
+	err     error
+	expires time.Time
+}
+
+// Cache - values by key, zero TTL keeps nothing after the load but still shares loads in flight.
+// Failed loads are never kept.
+type Cache[V any] struct {
+	ttl     time.Duration
+	observe func(result string)
+
+	mu      sync.Mutex
+	entries map[string]*entry[V]
+}
+
+// New creates a cache, observe is called with the result of every Get and may be nil
+func New[V any](ttl time.Duration, observe func(result string)) *Cache[V] {
+	return &Cache[V]{
+		ttl:     ttl,
+		observe: observe,
+		entries: make(map[string]*entry[V]),
+	}
+}
+
+// Get returns the value of key, calling load unless a fresh value is cached or another
+// caller is loading the key already
+func (c *Cache[V]) Get(key string, load func() (V, error)) (V, error) {
+	now := time.Now()
+	
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		select {
+		case <-e.done:
+			if now.Before(e.expires) {
+				c.mu.Unlock()
+				c.record(ResultHit)
+				return e.value, nil
+			}
+		default:
+			c.mu.Unlock()
+			c.record(ResultShared)
+			<-e.done
+			return e.value, e.err
+		}
+	}
+	
+	c.evictExpired(now)
+	e := &entry[V]{done: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+	c.record(ResultMiss)
+	
+	// a panicking load must not leave waiters blocked
+	defer func() {
+		c.mu.Lock()
+		if e.err != nil || c.ttl <= 0 {
+			delete(c.entries, key)
+		} else {
+			e.expires = time.Now().Add(c.ttl)
+		}
+		c.mu.Unlock()
+		close(e.done)
+	}()
+	e.err = errPanicked
+	e.value, e.err = load()
+	return e.value, e.err
+}
+
+// evictExpired drops finished stale entries, caller holds the lock
+func (c *Cache[V]) evictExpired(now time.Time) {
+	for key, e := range c.entries {
+		select {
+		case <-e.done:
+			if !now.Before(e.expires) {
+				delete(c.entries, key)
+			}
+		default:
+		}
+	}
+}
+
+func (c *Cache[V]) record(result string) {
+	if c.observe != nil {
+		c.observe(result)
+	}
+}
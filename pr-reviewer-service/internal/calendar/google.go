@@ -0,0 +1,286 @@
+package calendar
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	authEndpoint   = "https://accounts.google.com/o/oauth2/v2/auth"
+	tokenEndpoint  = "https://oauth2.googleapis.com/token"
+	eventsEndpoint = "https://www.googleapis.com/calendar/v3/calendars/primary/events"
+	calendarScope  = "https://www.googleapis.com/auth/calendar.events.readonly"
+)
+
+// Config - OAuth client settings of the Google Cloud project
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+}
+
+// ConfigFromEnv reads GOOGLE_CLIENT_ID, GOOGLE_CLIENT_SECRET and GOOGLE_REDIRECT_URL
+func ConfigFromEnv() Config {
+	return Config{
+		ClientID:     os.Getenv("GOOGLE_CLIENT_ID"),
+		ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("GOOGLE_REDIRECT_URL"),
+	}
+}
+
+// Enabled reports whether the integration is configured
+func (c Config) Enabled() bool {
+	return c.ClientID != "" && c.ClientSecret != "" && c.RedirectURL != ""
+}
+
+// Token - OAuth tokens of a single user
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// Event - out-of-office period from user's calendar
+type Event struct {
+	ID       string
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+type GoogleClient struct {
+	cfg  Config
+	http *http.Client
+}
+
+func NewGoogleClient(cfg Config) *GoogleClient {
+	return &GoogleClient{
+		cfg:  cfg,
+		http: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// AuthURL returns consent page URL, state is passed back to the callback
+func (c *GoogleClient) AuthURL(state string) string {
+	params := url.Values{}
+	params.Set("client_id", c.cfg.ClientID)
+	params.Set("redirect_uri", c.cfg.RedirectURL)
+	params.Set("response_type", "code")
+	params.Set("scope", calendarScope)
+	params.Set("access_type", "offline")
+	params.Set("prompt", "consent")
+	params.Set("state", state)
+	return authEndpoint + "?" + params.Encode()
+}
+
+// SignState binds user ID, a one-time nonce and the issue time to the OAuth state so the
+// callback can't be forged, the caller rejects stale and reused states
+func (c *GoogleClient) SignState(userID, nonce string, issuedAt time.Time) string {
+	payload := strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString([]byte(userID)),
+		nonce,
+		strconv.FormatInt(issuedAt.Unix(), 10),
+	}, ".")
+	return payload + "." + c.stateMAC(payload)
+}
+
+// VerifyState returns user ID, nonce and issue time from a state produced by SignState
+func (c *GoogleClient) VerifyState(state string) (userID, nonce string, issuedAt time.Time, err error) {
+	idx := strings.LastIndex(state, ".")
+	if idx <= 0 {
+		return "", "", time.Time{}, fmt.Errorf("malformed state")
+	}
+	
+	payload, mac := state[:idx], state[idx+1:]
+	if !hmac.Equal([]byte(mac), []byte(c.stateMAC(payload))) {
+		return "", "", time.Time{}, fmt.Errorf("invalid state signature")
+	}
+	
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 || parts[1] == "" {
+		return "", "", time.Time{}, fmt.Errorf("malformed state")
+	}
+	rawUserID, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("malformed state user: %w", err)
+	}
+	unix, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", "", time.Time{}, fmt.Errorf("malformed state time: %w", err)
+	}
+	
+	return string(rawUserID), parts[1], time.Unix(unix, 0).UTC(), nil
+}
+
+func (c *GoogleClient) stateMAC(payload string) string {
+	mac := hmac.New(sha256.New, []byte(c.cfg.ClientSecret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Exchange trades authorization code for tokens
+func (c *GoogleClient) Exchange(ctx context.Context, code string) (*Token, error) {
+	form := url.Values{}
+	form.Set("code", code)
+	form.Set("client_id", c.cfg.ClientID)
+	form.Set("client_secret", c.cfg.ClientSecret)
+	form.Set("redirect_uri", c.cfg.RedirectURL)
+	form.Set("grant_type", "authorization_code")
+	
+	return c.requestToken(ctx, form)
+}
+
+// Refresh obtains new access token, refresh token stays the same
+func (c *GoogleClient) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	form := url.Values{}
+	form.Set("refresh_token", refreshToken)
+	form.Set("client_id", c.cfg.ClientID)
+	form.Set("client_secret", c.cfg.ClientSecret)
+	form.Set("grant_type", "refresh_token")
+	
+	token, err := c.requestToken(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	
+	return token, nil
+}
+
+func (c *GoogleClient) requestToken(ctx context.Context, form url.Values) (*Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	
+	var resp struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := c.do(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to request token: %w", err)
+	}
+	
+	return &Token{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		ExpiresAt:    time.Now().UTC().Add(time.Duration(resp.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// OutOfOffice returns OOO events overlapping [from, to)
+func (c *GoogleClient) OutOfOffice(ctx context.Context, accessToken string, from, to time.Time) ([]Event, error) {
+	params := url.Values{}
+	params.Set("eventTypes", "outOfOffice")
+	params.Set("singleEvents", "true")
+	params.Set("timeMin", from.UTC().Format(time.RFC3339))
+	params.Set("timeMax", to.UTC().Format(time.RFC3339))
+	
+	var events []Event
+	pageToken := ""
+	for {
+		if pageToken != "" {
+			params.Set("pageToken", pageToken)
+		}
+	
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, eventsEndpoint+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build events request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	
+		var resp struct {
+			Items []struct {
+				ID     string    `json:"id"`
+				Status string    `json:"status"`
+				Start  eventTime `json:"start"`
+				End    eventTime `json:"end"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := c.do(req, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+	
+		for _, item := range resp.Items {
+			if item.Status == "cancelled" {
+				continue
+			}
+			startsAt, err := item.Start.parse()
+			if err != nil {
+				return nil, err
+			}
+			endsAt, err := item.End.parse()
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, Event{ID: item.ID, StartsAt: startsAt, EndsAt: endsAt})
+		}
+	
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	
+	return events, nil
+}
+
+func (c *GoogleClient) do(req *http.Request, v interface{}) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Failed to close response body: %v", err)
+		}
+	}()
+	
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		if err != nil {
+			return fmt.Errorf("google api returned %d", resp.StatusCode)
+		}
+		return fmt.Errorf("google api returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// eventTime - either dateTime (timed event) or date (all-day event)
+type eventTime struct {
+	DateTime string `json:"dateTime"`
+	Date     string `json:"date"`
+}
+
+func (t eventTime) parse() (time.Time, error) {
+	if t.DateTime != "" {
+		parsed, err := time.Parse(time.RFC3339, t.DateTime)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse event time: %w", err)
+		}
+		return parsed.UTC(), nil
+	}
+	
+	parsed, err := time.Parse("2006-01-02", t.Date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse event date: %w", err)
+	}
+	return parsed, nil
+}
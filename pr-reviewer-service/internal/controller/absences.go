@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/service"
+	"time"
+)
+
+// ABSENCES
+
+// AddAbsence - POST /users/absences
+func (c *Controller) AddAbsence(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.addAbsence)(w, r)
+}
+
+func (c *Controller) addAbsence(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID   string    `json:"user_id"`
+		StartsAt time.Time `json:"starts_at"`
+		EndsAt   time.Time `json:"ends_at"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	absence, err := c.service.AddAbsence(TokenFromContext(r.Context()).UserID, &models.Vacation{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+	})
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusCreated, absence)
+}
+
+// ListAbsences - GET /users/absences
+func (c *Controller) ListAbsences(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		c.respondError(w, errcode.InvalidRequest, "user_id is required")
+		return
+	}
+	
+	absences, err := c.service.ListAbsences(userID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":  userID,
+		"absences": absences,
+	})
+}
+
+// DeleteAbsence - POST /users/absences/delete
+func (c *Controller) DeleteAbsence(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.deleteAbsence)(w, r)
+}
+
+func (c *Controller) deleteAbsence(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID string `json:"user_id"`
+		ID     int64  `json:"id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	if err := c.service.DeleteAbsence(TokenFromContext(r.Context()).UserID, req.UserID, req.ID); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"id":      req.ID,
+		"deleted": true,
+	})
+}
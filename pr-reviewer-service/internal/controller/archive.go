@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+)
+
+// GetArchivedPR - GET /pullRequest/archived
+func (c *Controller) GetArchivedPR(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		c.respondError(w, errcode.InvalidRequest, "pull_request_id is required")
+		return
+	}
+	
+	pr, err := c.service.GetArchivedPullRequest(prID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// GetArchivedPRHistory - GET /pullRequest/archived/history
+func (c *Controller) GetArchivedPRHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		c.respondError(w, errcode.InvalidRequest, "pull_request_id is required")
+		return
+	}
+	
+	history, err := c.service.GetArchivedPRHistory(r.URL.Query().Get("actor_id"), prID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"history":         history,
+	})
+}
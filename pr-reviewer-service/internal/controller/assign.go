@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/service"
+	"strconv"
+)
+
+// ASSIGNMENT
+
+// ForceAssignReviewer - POST /pullRequest/assignReviewer
+func (c *Controller) ForceAssignReviewer(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.forceAssignReviewer)(w, r)
+}
+
+func (c *Controller) forceAssignReviewer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, err := c.service.ForceAssignReviewer(req.PullRequestID, req.UserID, TokenFromContext(r.Context()).UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// AddExtraReviewer - POST /pullRequest/addReviewer
+func (c *Controller) AddExtraReviewer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, newReviewerID, err := c.service.AddExtraReviewer(req.PullRequestID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	hidden, ok := c.maskPR(w, r, pr)
+	if !ok {
+		return
+	}
+	if hidden {
+		newReviewerID = ""
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr":       pr,
+		"added_id": newReviewerID,
+	})
+}
+
+// Volunteer - POST /pullRequest/volunteer
+func (c *Controller) Volunteer(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		ReplaceUserID string `json:"replace_user_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, err := c.service.Volunteer(req.PullRequestID, req.UserID, req.ReplaceUserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// PreviewReviewers - GET /team/nextReviewers
+func (c *Controller) PreviewReviewers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	teamName := query.Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	count := 0
+	if raw := query.Get("count"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.respondError(w, errcode.InvalidRequest, "count must be a number")
+			return
+		}
+		count = parsed
+	}
+	
+	var seed *int64
+	if raw := query.Get("seed"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.respondError(w, errcode.InvalidRequest, "seed must be a number")
+			return
+		}
+		seed = &parsed
+	}
+	
+	reviewers, err := c.service.PreviewReviewers(teamName, query.Get("author_id"), query.Get("reviewer_pool"), count, seed)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": teamName,
+		"author_id": query.Get("author_id"),
+		"reviewers": reviewers,
+	})
+}
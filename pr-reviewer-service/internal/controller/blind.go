@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/models"
+)
+
+// BLIND REVIEW
+
+// viewerID returns the user authenticated by RequireScope, empty on unguarded routes
+func viewerID(r *http.Request) string {
+	if token := TokenFromContext(r.Context()); token != nil {
+		return token.UserID
+	}
+	return ""
+}
+
+// maskPR hides reviewers of a blind review from the PR author, ok is false after an error was written
+func (c *Controller) maskPR(w http.ResponseWriter, r *http.Request, pr *models.PullRequest) (hidden bool, ok bool) {
+	hidden, err := c.service.MaskPullRequest(pr, viewerID(r))
+	if err != nil {
+		c.respondServiceError(w, err)
+		return false, false
+	}
+	return hidden, true
+}
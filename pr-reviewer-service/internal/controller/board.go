@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/service"
+)
+
+// Board - GET /board
+func (c *Controller) Board(w http.ResponseWriter, r *http.Request) {
+	if c.privateBoard {
+		c.RequireScope(service.ScopeRead, c.board)(w, r)
+		return
+	}
+	c.board(w, r)
+}
+
+func (c *Controller) board(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	board, err := c.service.GetTeamBoard(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	if err := c.service.MaskTeamBoard(board, viewerID(r)); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	// wall displays poll, a cached board would show stale SLA states
+	w.Header().Set("Cache-Control", "no-store")
+	c.respondJSON(w, http.StatusOK, board)
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/models"
+)
+
+// BootstrapTeam - POST /team/bootstrap
+func (c *Controller) BootstrapTeam(w http.ResponseWriter, r *http.Request) {
+	var req models.TeamBootstrap
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	result, err := c.service.BootstrapTeam(&req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusCreated, result)
+}
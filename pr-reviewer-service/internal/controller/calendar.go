@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/service"
+)
+
+// CALENDAR
+
+// ConnectCalendar - GET /users/calendar/connect
+func (c *Controller) ConnectCalendar(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.connectCalendar)(w, r)
+}
+
+func (c *Controller) connectCalendar(w http.ResponseWriter, r *http.Request) {
+	authURL, err := c.service.ConnectCalendar(TokenFromContext(r.Context()).UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// CalendarCallback - GET /users/calendar/callback
+func (c *Controller) CalendarCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if errMsg := query.Get("error"); errMsg != "" {
+		c.respondError(w, errcode.InvalidRequest, "calendar access denied: "+errMsg)
+		return
+	}
+	
+	code, state := query.Get("code"), query.Get("state")
+	if code == "" || state == "" {
+		c.respondError(w, errcode.InvalidRequest, "code and state are required")
+		return
+	}
+	
+	userID, err := c.service.CompleteCalendarConnect(r.Context(), code, state)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":            userID,
+		"calendar_connected": true,
+	})
+}
+
+// DisconnectCalendar - POST /users/calendar/disconnect
+func (c *Controller) DisconnectCalendar(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.disconnectCalendar)(w, r)
+}
+
+func (c *Controller) disconnectCalendar(w http.ResponseWriter, r *http.Request) {
+	userID := TokenFromContext(r.Context()).UserID
+	if err := c.service.DisconnectCalendar(userID); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":            userID,
+		"calendar_connected": false,
+	})
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+)
+
+// CAPACITY
+
+// GetTeamCapacity - GET /team/capacity
+func (c *Controller) GetTeamCapacity(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	capacity, err := c.service.GetTeamCapacity(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, capacity)
+}
+
+// GetTeamAvailability - GET /team/availability
+func (c *Controller) GetTeamAvailability(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	availability, err := c.service.GetTeamAvailability(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, availability)
+}
+
+// SetUserMaxOpenReviews - POST /users/setMaxOpenReviews
+func (c *Controller) SetUserMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID         string `json:"user_id"`
+		MaxOpenReviews *int   `json:"max_open_reviews"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	user, err := c.service.SetUserMaxOpenReviews(req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
+
+// SetUserMaxDailyAssignments - POST /users/setMaxDailyAssignments
+func (c *Controller) SetUserMaxDailyAssignments(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID              string `json:"user_id"`
+		MaxDailyAssignments *int   `json:"max_daily_assignments"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	user, err := c.service.SetUserMaxDailyAssignments(req.UserID, req.MaxDailyAssignments)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
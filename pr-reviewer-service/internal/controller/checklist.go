@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// CHECKLISTS
+
+// GetTeamChecklist - GET /team/checklist
+func (c *Controller) GetTeamChecklist(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	checklist, err := c.service.GetTeamChecklist(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, checklist)
+}
+
+// SetTeamChecklist - POST /team/checklist
+func (c *Controller) SetTeamChecklist(w http.ResponseWriter, r *http.Request) {
+	var req models.TeamChecklist
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	checklist, err := c.service.SetTeamChecklist(&req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, checklist)
+}
+
+// GetReviewChecklist - GET /review/checklist
+func (c *Controller) GetReviewChecklist(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	userID := r.URL.Query().Get("user_id")
+	if prID == "" || userID == "" {
+		c.respondError(w, errcode.InvalidRequest, "pull_request_id and user_id are required")
+		return
+	}
+	
+	items, err := c.service.GetReviewChecklist(prID, userID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"user_id":         userID,
+		"items":           items,
+	})
+}
+
+// CheckChecklistItem - POST /review/checklist/check
+func (c *Controller) CheckChecklistItem(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		ItemID        int64  `json:"item_id"`
+		Checked       *bool  `json:"checked"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	checked := req.Checked == nil || *req.Checked
+	items, err := c.service.CheckChecklistItem(req.PullRequestID, req.UserID, req.ItemID, checked)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": req.PullRequestID,
+		"user_id":         req.UserID,
+		"items":           items,
+	})
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/metrics"
+	"strconv"
+	"time"
+)
+
+// CONCURRENCY LIMITS
+
+// Groups of heavy endpoints sharing a concurrency limit, so one report consumer
+// can't take the whole DB pool
+const (
+	ConcurrencyStats  = "stats"  // team and user statistics, weekly report
+	ConcurrencyAdmin  = "admin"  // event replay and read model rebuilds
+	ConcurrencyBatch  = "batch"  // batch merge and team transfers
+	ConcurrencyExport = "export" // streaming exports, each holds a connection for its whole run
+)
+
+// ConcurrencyLimit - requests of a group running at once, how many more may wait for
+// a slot and for how long. Zero MaxConcurrent turns the limit off.
+type ConcurrencyLimit struct {
+	MaxConcurrent int
+	MaxQueued     int
+	Timeout       time.Duration
+}
+
+var defaultConcurrencyLimits = map[string]ConcurrencyLimit{
+	ConcurrencyStats:  {MaxConcurrent: 4, MaxQueued: 16, Timeout: 10 * time.Second},
+	ConcurrencyAdmin:  {MaxConcurrent: 1, MaxQueued: 4, Timeout: 30 * time.Second},
+	ConcurrencyBatch:  {MaxConcurrent: 2, MaxQueued: 8, Timeout: 10 * time.Second},
+	ConcurrencyExport: {MaxConcurrent: 2, MaxQueued: 2, Timeout: 10 * time.Second},
+}
+
+// concurrencyLimiter - running holds a token per executing request, queued per waiting one
+type concurrencyLimiter struct {
+	group   string
+	timeout time.Duration
+	running chan struct{}
+	queued  chan struct{}
+}
+
+func newConcurrencyLimiter(group string, limit ConcurrencyLimit) *concurrencyLimiter {
+	if limit.MaxConcurrent <= 0 {
+		return nil
+	}
+	return &concurrencyLimiter{
+		group:   group,
+		timeout: limit.Timeout,
+		running: make(chan struct{}, limit.MaxConcurrent),
+		queued:  make(chan struct{}, max(limit.MaxQueued, 0)),
+	}
+}
+
+// WithConcurrencyLimit replaces the default limit of the endpoint group
+func WithConcurrencyLimit(group string, limit ConcurrencyLimit) Option {
+	return func(c *Controller) {
+		c.concurrencyLimits[group] = limit
+	}
+}
+
+// LimitConcurrency guards handler with the group limit. A request over the limit waits
+// in the queue up to the group timeout, a full queue or the timeout respond 503 right away.
+func (c *Controller) LimitConcurrency(group string, next http.HandlerFunc) http.HandlerFunc {
+	limiter, ok := c.limiters[group]
+	if !ok {
+		log.Printf("No concurrency limit for endpoint group %q, requests are not limited", group)
+	}
+	if limiter == nil {
+		return next
+	}
+	
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !limiter.acquire(r) {
+			if r.Context().Err() != nil {
+				return
+			}
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(limiter.timeout.Seconds()))))
+			c.respondError(w, errcode.Overloaded, "too many concurrent "+group+" requests, retry later")
+			return
+		}
+		defer limiter.release()
+		next(w, r)
+	}
+}
+
+// acquire takes a running slot, waiting in the queue if all are busy, false if the
+// queue is full, the wait timed out or the client went away
+func (l *concurrencyLimiter) acquire(r *http.Request) bool {
+	select {
+	case l.running <- struct{}{}:
+		return true
+	default:
+	}
+	
+	select {
+	case l.queued <- struct{}{}:
+	default:
+		metrics.ConcurrencyRejected(l.group, "queue_full")
+		return false
+	}
+	metrics.ConcurrencyWaiting(l.group, 1)
+	defer func() {
+		<-l.queued
+		metrics.ConcurrencyWaiting(l.group, -1)
+	}()
+	
+	timer := time.NewTimer(l.timeout)
+	defer timer.Stop()
+	select {
+	case l.running <- struct{}{}:
+		return true
+	case <-timer.C:
+		metrics.ConcurrencyRejected(l.group, "timeout")
+		return false
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+func (l *concurrencyLimiter) release() {
+	<-l.running
+}
@@ -2,22 +2,79 @@ package controller
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/i18n"
 	"pr-reviewer-service/internal/models"
 	"pr-reviewer-service/internal/service"
-	"log"
+	"strconv"
+	"sync"
 )
 
 type Controller struct {
 	service *service.Service
+
+	scimToken        string            // bearer token of the identity provider, SCIM is off without it
+	scimDefaultTeam  string            // team of provisioned users that come without a department
+	bootstrapSecret  string            // lets a caller without a token mint the first one of a user
+	privateBoard     bool              // status board and web dashboard require a read token instead of being public
+	maxBodyBytes     int64             // larger request bodies are rejected with 413
+	webhookMu        sync.RWMutex      // guards webhookSecrets, they are replaced on rotation
+	webhookSecrets   map[string]string // per-source secrets of inbound webhooks
+	unsignedWebhooks bool              // sources without a secret are accepted unverified
+
+	concurrencyLimits map[string]ConcurrencyLimit    // per endpoint group, defaults overridden by options
+	limiters          map[string]*concurrencyLimiter // built from the limits, nil for unlimited groups
 }
 
-func NewController(service *service.Service) *Controller {
-	return &Controller{
-		service: service,
+// Option configures optional Controller settings
+type Option func(*Controller)
+
+// WithSCIM enables SCIM provisioning endpoints guarded by the bearer token
+func WithSCIM(token, defaultTeam string) Option {
+	return func(c *Controller) {
+		c.scimToken = token
+		c.scimDefaultTeam = defaultTeam
 	}
 }
 
+// WithPrivateBoard makes the status board and the web dashboard require an API token with read scope
+func WithPrivateBoard() Option {
+	return func(c *Controller) {
+		c.privateBoard = true
+	}
+}
+
+// WithMaxBodyBytes changes the request body limit, 1 MiB by default
+func WithMaxBodyBytes(limit int64) Option {
+	return func(c *Controller) {
+		if limit > 0 {
+			c.maxBodyBytes = limit
+		}
+	}
+}
+
+func NewController(service *service.Service, opts ...Option) *Controller {
+	c := &Controller{
+		service:           service,
+		maxBodyBytes:      defaultMaxBodyBytes,
+		concurrencyLimits: make(map[string]ConcurrencyLimit, len(defaultConcurrencyLimits)),
+	}
+	for group, limit := range defaultConcurrencyLimits {
+		c.concurrencyLimits[group] = limit
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	
+	c.limiters = make(map[string]*concurrencyLimiter, len(c.concurrencyLimits))
+	for group, limit := range c.concurrencyLimits {
+		c.limiters[group] = newConcurrencyLimiter(group, limit)
+	}
+	return c
+}
+
 func (c *Controller) respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -26,17 +83,76 @@ func (c *Controller) respondJSON(w http.ResponseWriter, status int, data interfa
 	}
 }
 
-func (c *Controller) respondError(w http.ResponseWriter, status int, code, message string) {
-	c.respondJSON(w, status, models.ErrorResponse{
-		Error: models.ErrorDetail{
-			Code:    code,
-			Message: message,
-		},
+// respondError writes the error with the HTTP status of its code and the message translated
+// to the request locale
+func (c *Controller) respondError(w http.ResponseWriter, code errcode.Code, message string) {
+	c.respondErrorDetail(w, models.ErrorDetail{
+		Code:    code,
+		Message: message,
 	})
 }
 
-func (c *Controller) parseJSON(r *http.Request, v interface{}) error {
-	return json.NewDecoder(r.Body).Decode(v)
+func (c *Controller) respondErrorDetail(w http.ResponseWriter, detail models.ErrorDetail) {
+	locale := c.locale(w)
+	detail.Number = detail.Code.Number()
+	detail.Message = i18n.Translate(locale, detail.Message)
+	w.Header().Set("Content-Language", locale)
+	c.respondJSON(w, detail.Code.Status(), models.ErrorResponse{Error: detail})
+}
+
+// respondServiceError maps service errors to HTTP statuses with the error code registry,
+// unregistered codes and other errors are internal errors
+func (c *Controller) respondServiceError(w http.ResponseWriter, err error) {
+	if serviceErr, ok := err.(*service.ServiceError); ok {
+		if _, registered := errcode.Lookup(serviceErr.Code); registered {
+			c.respondError(w, serviceErr.Code, serviceErr.Message)
+			return
+		}
+		log.Printf("Unregistered error code %s: %s", serviceErr.Code, serviceErr.Message)
+		c.respondError(w, errcode.Internal, serviceErr.Message)
+		return
+	}
+	c.respondError(w, errcode.Internal, err.Error())
+}
+
+// parsePage reads optional cursor and limit parameters of list endpoints
+func (c *Controller) parsePage(w http.ResponseWriter, r *http.Request) (string, int, bool) {
+	query := r.URL.Query()
+	
+	limit := 0
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.respondError(w, errcode.InvalidRequest, "limit must be a number")
+			return "", 0, false
+		}
+		limit = parsed
+	}
+	return query.Get("cursor"), limit, true
+}
+
+// parseDryRun reads optional dry_run flag of operations that can preview their changes
+func (c *Controller) parseDryRun(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	return c.parseFlag(w, r, "dry_run")
+}
+
+// parseDebug reads optional debug flag of assignments explaining skipped candidates
+func (c *Controller) parseDebug(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	return c.parseFlag(w, r, "debug")
+}
+
+// parseFlag reads optional boolean query parameter, false when absent
+func (c *Controller) parseFlag(w http.ResponseWriter, r *http.Request, name string) (bool, bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, true
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		c.respondError(w, errcode.InvalidRequest, name+" must be true or false")
+		return false, false
+	}
+	return value, true
 }
 
 // TEAMS
@@ -45,21 +161,12 @@ func (c *Controller) parseJSON(r *http.Request, v interface{}) error {
 func (c *Controller) CreateTeam(w http.ResponseWriter, r *http.Request) {
 	var req models.TeamResponse
 	if err := c.parseJSON(r, &req); err != nil {
-		c.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid JSON")
+		c.respondParseError(w, err)
 		return
 	}
 	
 	if err := c.service.CreateTeam(&req); err != nil {
-		if serviceErr, ok := err.(*service.ServiceError); ok {
-			switch serviceErr.Code {
-			case "TEAM_EXISTS":
-				c.respondError(w, http.StatusBadRequest, serviceErr.Code, serviceErr.Message)
-			default:
-				c.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", serviceErr.Message)
-			}
-			return
-		}
-		c.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		c.respondServiceError(w, err)
 		return
 	}
 	
@@ -68,23 +175,36 @@ func (c *Controller) CreateTeam(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ListTeams - GET /team/list
+func (c *Controller) ListTeams(w http.ResponseWriter, r *http.Request) {
+	cursor, limit, ok := c.parsePage(w, r)
+	if !ok {
+		return
+	}
+	
+	teams, next, err := c.service.ListTeams(cursor, limit)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"teams":       teams,
+		"next_cursor": next,
+	})
+}
+
 // GetTeam - GET /team/get
 func (c *Controller) GetTeam(w http.ResponseWriter, r *http.Request) {
 	teamName := r.URL.Query().Get("team_name")
 	if teamName == "" {
-		c.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "team_name is required")
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
 		return
 	}
 	
 	team, err := c.service.GetTeam(teamName)
 	if err != nil {
-		if serviceErr, ok := err.(*service.ServiceError); ok {
-			if serviceErr.Code == "NOT_FOUND" {
-				c.respondError(w, http.StatusNotFound, serviceErr.Code, serviceErr.Message)
-				return
-			}
-		}
-		c.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		c.respondServiceError(w, err)
 		return
 	}
 	
@@ -101,19 +221,13 @@ func (c *Controller) SetUserActive(w http.ResponseWriter, r *http.Request) {
 	}
 	
 	if err := c.parseJSON(r, &req); err != nil {
-		c.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid JSON")
+		c.respondParseError(w, err)
 		return
 	}
 	
 	user, err := c.service.SetUserActive(req.UserID, req.IsActive)
 	if err != nil {
-		if serviceErr, ok := err.(*service.ServiceError); ok {
-			if serviceErr.Code == "NOT_FOUND" {
-				c.respondError(w, http.StatusNotFound, serviceErr.Code, serviceErr.Message)
-				return
-			}
-		}
-		c.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		c.respondServiceError(w, err)
 		return
 	}
 	
@@ -126,25 +240,26 @@ func (c *Controller) SetUserActive(w http.ResponseWriter, r *http.Request) {
 func (c *Controller) GetUserReviews(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
-		c.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "user_id is required")
+		c.respondError(w, errcode.InvalidRequest, "user_id is required")
+		return
+	}
+	
+	cursor, limit, ok := c.parsePage(w, r)
+	if !ok {
 		return
 	}
 	
-	prs, err := c.service.GetPRsByReviewer(userID)
+	query := r.URL.Query()
+	prs, next, err := c.service.GetPRsByReviewer(userID, query.Get("sort"), query.Get("order"), cursor, limit)
 	if err != nil {
-		if serviceErr, ok := err.(*service.ServiceError); ok {
-			if serviceErr.Code == "NOT_FOUND" {
-				c.respondError(w, http.StatusNotFound, serviceErr.Code, serviceErr.Message)
-				return
-			}
-		}
-		c.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		c.respondServiceError(w, err)
 		return
 	}
 	
 	c.respondJSON(w, http.StatusOK, map[string]interface{}{
 		"user_id":       userID,
 		"pull_requests": prs,
+		"next_cursor":   next,
 	})
 }
 
@@ -152,31 +267,25 @@ func (c *Controller) GetUserReviews(w http.ResponseWriter, r *http.Request) {
 
 // CreatePullRequest - POST /pullRequest/create
 func (c *Controller) CreatePullRequest(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
+	var req models.CreatePullRequestRequest
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
 	}
 	
-	if err := c.parseJSON(r, &req); err != nil {
-		c.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid JSON")
+	debug, ok := c.parseDebug(w, r)
+	if !ok {
 		return
 	}
+	req.Debug = debug
 	
-	pr, err := c.service.CreatePullRequest(req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := c.service.CreatePullRequest(&req)
 	if err != nil {
-		if serviceErr, ok := err.(*service.ServiceError); ok {
-			switch serviceErr.Code {
-			case "PR_EXISTS":
-				c.respondError(w, http.StatusConflict, serviceErr.Code, serviceErr.Message)
-			case "NOT_FOUND":
-				c.respondError(w, http.StatusNotFound, serviceErr.Code, serviceErr.Message)
-			default:
-				c.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", serviceErr.Message)
-			}
-			return
-		}
-		c.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
 		return
 	}
 	
@@ -189,22 +298,21 @@ func (c *Controller) CreatePullRequest(w http.ResponseWriter, r *http.Request) {
 func (c *Controller) MergePullRequest(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id"`
+		models.MergeInfo
 	}
 	
 	if err := c.parseJSON(r, &req); err != nil {
-		c.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid JSON")
+		c.respondParseError(w, err)
 		return
 	}
 	
-	pr, err := c.service.MergePullRequest(req.PullRequestID)
+	pr, err := c.service.MergePullRequest(req.PullRequestID, req.MergeInfo)
 	if err != nil {
-		if serviceErr, ok := err.(*service.ServiceError); ok {
-			if serviceErr.Code == "NOT_FOUND" {
-				c.respondError(w, http.StatusNotFound, serviceErr.Code, serviceErr.Message)
-				return
-			}
-		}
-		c.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
 		return
 	}
 	
@@ -213,6 +321,38 @@ func (c *Controller) MergePullRequest(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// MergePullRequests - POST /pullRequest/mergeBatch
+func (c *Controller) MergePullRequests(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		MergedBy     string                  `json:"merged_by"`
+		PullRequests []models.MergeBatchItem `json:"pull_requests"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	results, err := c.service.MergePullRequests(req.PullRequests, req.MergedBy)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	merged := 0
+	for _, result := range results {
+		if result.Status == service.MergeResultMerged {
+			merged++
+		}
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"merged":  merged,
+		"failed":  len(results) - merged,
+		"results": results,
+	})
+}
+
 // ReassignReviewer - POST /pullRequest/reassign
 func (c *Controller) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 	var req struct {
@@ -221,29 +361,31 @@ func (c *Controller) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 	}
 	
 	if err := c.parseJSON(r, &req); err != nil {
-		c.respondError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid JSON")
+		c.respondParseError(w, err)
+		return
+	}
+	
+	debug, ok := c.parseDebug(w, r)
+	if !ok {
 		return
 	}
 	
-	pr, newReviewerID, err := c.service.ReassignReviewer(req.PullRequestID, req.OldUserID)
+	pr, newReviewerID, err := c.service.ReassignReviewer(req.PullRequestID, req.OldUserID, debug)
 	if err != nil {
-		if serviceErr, ok := err.(*service.ServiceError); ok {
-			switch serviceErr.Code {
-			case "NOT_FOUND":
-				c.respondError(w, http.StatusNotFound, serviceErr.Code, serviceErr.Message)
-			case "PR_MERGED", "NOT_ASSIGNED", "NO_CANDIDATE":
-				c.respondError(w, http.StatusConflict, serviceErr.Code, serviceErr.Message)
-			default:
-				c.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", serviceErr.Message)
-			}
-			return
-		}
-		c.respondError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	hidden, ok := c.maskPR(w, r, pr)
+	if !ok {
 		return
 	}
+	if hidden {
+		newReviewerID = ""
+	}
 	
 	c.respondJSON(w, http.StatusOK, map[string]interface{}{
 		"pr":          pr,
 		"replaced_by": newReviewerID,
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/service"
+	"time"
+)
+
+// dashboardTeamsPerPage is small since every listed team loads its board
+const dashboardTeamsPerPage = 20
+
+//go:embed ui/dashboard.html
+var dashboardHTML string
+
+var dashboardTemplate = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"waiting": formatWaiting,
+}).Parse(dashboardHTML))
+
+type dashboardTeam struct {
+	TeamName    string
+	MemberCount int
+	OpenPRs     int
+	Breached    int
+}
+
+type dashboardPage struct {
+	GeneratedAt time.Time
+	Teams       []dashboardTeam
+	NextCursor  string
+	Board       *models.TeamBoard
+	Capacity    *models.TeamCapacity
+}
+
+// formatWaiting renders minutes as "2d 3h", "5h 10m" or "7m"
+func formatWaiting(minutes int) string {
+	days, hours := minutes/(24*60), minutes/60%24
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes%60)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// Dashboard - GET /ui
+func (c *Controller) Dashboard(w http.ResponseWriter, r *http.Request) {
+	if c.privateBoard {
+		c.RequireScope(service.ScopeRead, c.dashboard)(w, r)
+		return
+	}
+	c.dashboard(w, r)
+}
+
+func (c *Controller) dashboard(w http.ResponseWriter, r *http.Request) {
+	page := dashboardPage{GeneratedAt: time.Now().UTC()}
+	
+	if teamName := r.URL.Query().Get("team_name"); teamName != "" {
+		board, err := c.service.GetTeamBoard(teamName)
+		if err != nil {
+			c.respondServiceError(w, err)
+			return
+		}
+		if err := c.service.MaskTeamBoard(board, viewerID(r)); err != nil {
+			c.respondServiceError(w, err)
+			return
+		}
+		capacity, err := c.service.GetTeamCapacity(teamName)
+		if err != nil {
+			c.respondServiceError(w, err)
+			return
+		}
+		page.Board, page.Capacity = board, capacity
+	} else {
+		teams, next, err := c.service.ListTeams(r.URL.Query().Get("cursor"), dashboardTeamsPerPage)
+		if err != nil {
+			c.respondServiceError(w, err)
+			return
+		}
+		for _, team := range teams {
+			board, err := c.service.GetTeamBoard(team.TeamName)
+			if err != nil {
+				c.respondServiceError(w, err)
+				return
+			}
+			page.Teams = append(page.Teams, dashboardTeam{
+				TeamName:    team.TeamName,
+				MemberCount: team.MemberCount,
+				OpenPRs:     board.OpenPRs,
+				Breached:    board.Breached,
+			})
+		}
+		page.NextCursor = next
+	}
+	
+	// rendered into a buffer so a template error still gets a proper status
+	var buf bytes.Buffer
+	if err := dashboardTemplate.Execute(&buf, page); err != nil {
+		log.Printf("Failed to render dashboard: %v", err)
+		c.respondError(w, errcode.Internal, "failed to render dashboard")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("Failed to write dashboard: %v", err)
+	}
+}
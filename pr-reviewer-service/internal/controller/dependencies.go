@@ -0,0 +1,54 @@
+package controller
+
+import "net/http"
+
+type dependencyRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	DependsOn     string `json:"depends_on"`
+}
+
+// LinkPullRequests - POST /pullRequest/link
+func (c *Controller) LinkPullRequests(w http.ResponseWriter, r *http.Request) {
+	var req dependencyRequest
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, err := c.service.LinkPullRequests(req.PullRequestID, req.DependsOn)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// UnlinkPullRequests - POST /pullRequest/unlink
+func (c *Controller) UnlinkPullRequests(w http.ResponseWriter, r *http.Request) {
+	var req dependencyRequest
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, err := c.service.UnlinkPullRequests(req.PullRequestID, req.DependsOn)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
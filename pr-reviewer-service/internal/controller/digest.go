@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"net/http"
+)
+
+// DIGESTS
+
+// SetUserDigest - POST /users/digest
+func (c *Controller) SetUserDigest(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID     string `json:"user_id"`
+		DigestHour *int   `json:"digest_hour"`
+		Timezone   string `json:"timezone"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	user, err := c.service.SetUserDigest(req.UserID, req.DigestHour, req.Timezone)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
+
+// SetUserQuietHours - POST /users/quietHours
+func (c *Controller) SetUserQuietHours(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID    string `json:"user_id"`
+		StartHour *int   `json:"start_hour"`
+		EndHour   *int   `json:"end_hour"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	user, err := c.service.SetUserQuietHours(req.UserID, req.StartHour, req.EndHour)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/i18n"
+)
+
+// ERROR CODES
+
+// ListErrorCodes - GET /errors
+func (c *Controller) ListErrorCodes(w http.ResponseWriter, r *http.Request) {
+	locale := c.locale(w)
+	codes := errcode.All()
+	for i := range codes {
+		codes[i].Description = i18n.Translate(locale, codes[i].Description)
+	}
+	
+	w.Header().Set("Content-Language", locale)
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"errors": codes,
+	})
+}
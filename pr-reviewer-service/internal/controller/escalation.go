@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// ESCALATIONS
+
+// GetEscalationRules - GET /team/escalationRules
+func (c *Controller) GetEscalationRules(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	rules, err := c.service.GetEscalationRules(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": teamName,
+		"rules":     rules,
+	})
+}
+
+// SetEscalationRules - POST /team/escalationRules
+func (c *Controller) SetEscalationRules(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string                  `json:"team_name"`
+		Rules    []models.EscalationRule `json:"rules"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	rules, err := c.service.SetEscalationRules(req.TeamName, req.Rules)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": req.TeamName,
+		"rules":     rules,
+	})
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"time"
+)
+
+// TIMELINE
+
+// GetPRTimeline - GET /pullRequest/timeline
+func (c *Controller) GetPRTimeline(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		c.respondError(w, errcode.InvalidRequest, "pull_request_id is required")
+		return
+	}
+	
+	cursor, limit, ok := c.parsePage(w, r)
+	if !ok {
+		return
+	}
+	
+	events, next, err := c.service.GetPRTimeline(prID, cursor, limit)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	if err := c.service.MaskTimeline(prID, viewerID(r), events); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"events":          events,
+		"next_cursor":     next,
+	})
+}
+
+// NOTIFICATIONS
+
+// GetNotifications - GET /users/notifications
+func (c *Controller) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		c.respondError(w, errcode.InvalidRequest, "user_id is required")
+		return
+	}
+	
+	cursor, limit, ok := c.parsePage(w, r)
+	if !ok {
+		return
+	}
+	
+	notifications, next, err := c.service.GetNotifications(userID, cursor, limit)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":       userID,
+		"notifications": notifications,
+		"next_cursor":   next,
+	})
+}
+
+// COMMENTS
+
+// AddPRComment - POST /pullRequest/comment
+func (c *Controller) AddPRComment(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		AuthorID      string `json:"author_id"`
+		Body          string `json:"body"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	comment, err := c.service.AddPRComment(req.PullRequestID, req.AuthorID, req.Body)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"comment": comment,
+	})
+}
+
+// GetPRState - GET /pullRequest/state
+func (c *Controller) GetPRState(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		c.respondError(w, errcode.InvalidRequest, "pull_request_id is required")
+		return
+	}
+	
+	var at time.Time
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.respondError(w, errcode.InvalidRequest, "at must be RFC3339 time")
+			return
+		}
+		at = parsed
+	}
+	
+	state, err := c.service.GetPRStateAt(prID, at)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, state)
+}
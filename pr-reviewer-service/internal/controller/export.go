@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// STREAMING EXPORT
+
+const (
+	// exportFlushLines - lines written between flushes, a client sees progress without
+	// a flush per line
+	exportFlushLines = 1000
+	// exportWriteTimeout - how long a flush may take, renewed on every flush so a long export
+	// isn't cut by the server write timeout while a stalled client still is
+	exportWriteTimeout = time.Minute
+)
+
+// ndjsonStream writes one JSON value per line, compressed with zstd when the client accepts
+// it. Headers are sent with the first line, until then the handler may still respond with an error.
+type ndjsonStream struct {
+	w         http.ResponseWriter
+	rc        *http.ResponseController
+	zstd      bool
+	zw        *zstd.Encoder
+	buf       *bufio.Writer
+	encoder   *json.Encoder
+	started   bool
+	unflushed int
+}
+
+func newNDJSONStream(w http.ResponseWriter, r *http.Request) *ndjsonStream {
+	return &ndjsonStream{
+		w:    w,
+		rc:   http.NewResponseController(w),
+		zstd: acceptsEncoding(r, "zstd"),
+	}
+}
+
+func (s *ndjsonStream) start() error {
+	s.started = true
+	header := s.w.Header()
+	header.Set("Content-Type", "application/x-ndjson")
+	header.Set("Vary", "Accept-Encoding")
+	
+	var out io.Writer = s.w
+	if s.zstd {
+		header.Set("Content-Encoding", "zstd")
+		zw, err := zstd.NewWriter(s.w)
+		if err != nil {
+			return err
+		}
+		s.zw = zw
+		out = zw
+	}
+	s.w.WriteHeader(http.StatusOK)
+	
+	s.buf = bufio.NewWriter(out)
+	s.encoder = json.NewEncoder(s.buf)
+	return s.extendDeadline()
+}
+
+func (s *ndjsonStream) write(v interface{}) error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	if err := s.encoder.Encode(v); err != nil {
+		return err
+	}
+	s.unflushed++
+	if s.unflushed < exportFlushLines {
+		return nil
+	}
+	return s.flush()
+}
+
+func (s *ndjsonStream) flush() error {
+	s.unflushed = 0
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	if s.zw != nil {
+		if err := s.zw.Flush(); err != nil {
+			return err
+		}
+	}
+	if err := s.rc.Flush(); err != nil {
+		return err
+	}
+	return s.extendDeadline()
+}
+
+// close sends what's left, an empty export still gets its headers
+func (s *ndjsonStream) close() error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	if s.zw != nil {
+		return s.zw.Close()
+	}
+	return nil
+}
+
+func (s *ndjsonStream) extendDeadline() error {
+	err := s.rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
+
+// acceptsEncoding reports whether Accept-Encoding lists the coding with a non-zero weight
+func acceptsEncoding(r *http.Request, coding string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(name), coding) {
+			continue
+		}
+		raw, found := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !found {
+			return true
+		}
+		weight, err := strconv.ParseFloat(raw, 64)
+		return err == nil && weight > 0
+	}
+	return false
+}
+
+// ExportEvents - GET /admin/export/events
+func (c *Controller) ExportEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.PREventFilter{PullRequestID: query.Get("pull_request_id")}
+	for name, value := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		raw := query.Get(name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.respondError(w, errcode.InvalidRequest, name+" must be RFC3339 time")
+			return
+		}
+		*value = parsed
+	}
+	
+	var afterID int64
+	if raw := query.Get("after_id"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.respondError(w, errcode.InvalidRequest, "after_id must be a number")
+			return
+		}
+		afterID = parsed
+	}
+	_, limit, ok := c.parsePage(w, r)
+	if !ok {
+		return
+	}
+	
+	stream := newNDJSONStream(w, r)
+	exported, err := c.service.ExportEvents(r.Context(), query.Get("actor_id"), filter, afterID, limit,
+		func(event *models.PREvent) error {
+			return stream.write(event)
+		})
+	if err == nil {
+		err = stream.close()
+	}
+	if err != nil {
+		if !stream.started {
+			c.respondServiceError(w, err)
+			return
+		}
+		// the status is already sent, an aborted response tells the client to resume
+		log.Printf("Export of events aborted after %d events: %v", exported, err)
+		panic(http.ErrAbortHandler)
+	}
+}
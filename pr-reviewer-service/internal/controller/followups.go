@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// RequestFollowUp - POST /pullRequest/followUp
+func (c *Controller) RequestFollowUp(w http.ResponseWriter, r *http.Request) {
+	var req models.FollowUpRequest
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	followUp, err := c.service.RequestFollowUp(req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"follow_up": followUp,
+	})
+}
+
+// CompleteFollowUp - POST /pullRequest/followUp/complete
+func (c *Controller) CompleteFollowUp(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	followUp, err := c.service.CompleteFollowUp(req.PullRequestID, req.UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"follow_up": followUp,
+	})
+}
+
+// GetFollowUps - GET /users/followUps
+func (c *Controller) GetFollowUps(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		c.respondError(w, errcode.InvalidRequest, "user_id is required")
+		return
+	}
+	includeCompleted, ok := c.parseFlag(w, r, "include_completed")
+	if !ok {
+		return
+	}
+	
+	followUps, err := c.service.GetFollowUps(userID, includeCompleted)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":    userID,
+		"follow_ups": followUps,
+	})
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"net/http"
+)
+
+// HANDOFFS
+
+// ProposeHandoff - POST /pullRequest/handoff
+func (c *Controller) ProposeHandoff(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		FromUserID    string `json:"from_user_id"`
+		ToUserID      string `json:"to_user_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	handoff, err := c.service.ProposeHandoff(req.PullRequestID, req.FromUserID, req.ToUserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"handoff": handoff,
+	})
+}
+
+// RespondHandoff - POST /pullRequest/handoff/respond
+func (c *Controller) RespondHandoff(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		HandoffID int64  `json:"handoff_id"`
+		UserID    string `json:"user_id"`
+		Accept    bool   `json:"accept"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	handoff, err := c.service.RespondHandoff(req.HandoffID, req.UserID, req.Accept)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"handoff": handoff,
+	})
+}
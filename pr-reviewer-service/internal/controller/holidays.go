@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// HOLIDAYS
+
+// GetTeamHolidays - GET /team/holidays
+func (c *Controller) GetTeamHolidays(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	calendar, err := c.service.GetTeamHolidays(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, calendar)
+}
+
+// SetTeamHolidays - POST /team/holidays
+func (c *Controller) SetTeamHolidays(w http.ResponseWriter, r *http.Request) {
+	var req models.TeamHolidays
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	calendar, err := c.service.SetTeamHolidays(&req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, calendar)
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+)
+
+// GetLeaderboard - GET /team/leaderboard
+func (c *Controller) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	days, ok := c.parsePeriodDays(w, r)
+	if !ok {
+		return
+	}
+	anonymize, ok := c.parseAnonymize(w, r)
+	if !ok {
+		return
+	}
+	
+	board, err := c.service.GetLeaderboard(teamName, days, r.URL.Query().Get("sort_by"))
+	if err == nil && anonymize {
+		err = c.service.AnonymizeLeaderboard(board)
+	}
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, board)
+}
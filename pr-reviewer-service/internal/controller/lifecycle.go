@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/service"
+)
+
+// prTransitionRequest - body of PR close and reopen, the actor is the authenticated caller
+type prTransitionRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+// ClosePullRequest - POST /pullRequest/close
+func (c *Controller) ClosePullRequest(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.closePullRequest)(w, r)
+}
+
+func (c *Controller) closePullRequest(w http.ResponseWriter, r *http.Request) {
+	var req prTransitionRequest
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, err := c.service.ClosePullRequest(req.PullRequestID, TokenFromContext(r.Context()).UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
+
+// ReopenPullRequest - POST /pullRequest/reopen
+func (c *Controller) ReopenPullRequest(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.reopenPullRequest)(w, r)
+}
+
+func (c *Controller) reopenPullRequest(w http.ResponseWriter, r *http.Request) {
+	var req prTransitionRequest
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, err := c.service.ReopenPullRequest(req.PullRequestID, TokenFromContext(r.Context()).UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/i18n"
+)
+
+// LOCALIZATION
+
+// localeWriter carries the locale of error messages to handlers that only see the writer
+type localeWriter struct {
+	http.ResponseWriter
+	locale string // negotiated from Accept-Language, empty when the client has no preference
+	userID string // authenticated user, their locale applies without a negotiated one
+}
+
+func (w *localeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Localize negotiates the locale of error messages from Accept-Language. Without a supported
+// language the locale of the authenticated user applies, then the service default.
+func (c *Controller) Localize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(&localeWriter{ResponseWriter: w, locale: i18n.Negotiate(r.Header.Get("Accept-Language"))}, r)
+	})
+}
+
+// findLocaleWriter unwraps writers of other middlewares, nil without Localize
+func findLocaleWriter(w http.ResponseWriter) *localeWriter {
+	for {
+		switch writer := w.(type) {
+		case *localeWriter:
+			return writer
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = writer.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// setUserLocale remembers the authenticated user, their locale is looked up only if an
+// error is reported
+func setUserLocale(w http.ResponseWriter, userID string) {
+	if lw := findLocaleWriter(w); lw != nil {
+		lw.userID = userID
+	}
+}
+
+// locale returns the locale of error messages written to w
+func (c *Controller) locale(w http.ResponseWriter) string {
+	lw := findLocaleWriter(w)
+	switch {
+	case lw == nil:
+		return c.service.DefaultLocale()
+	case lw.locale != "":
+		return lw.locale
+	case lw.userID != "":
+		return c.service.UserLocale(lw.userID)
+	}
+	return c.service.DefaultLocale()
+}
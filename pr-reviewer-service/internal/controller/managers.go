@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"sort"
+)
+
+// ImportUserManagers - POST /users/managers
+func (c *Controller) ImportUserManagers(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Managers []models.UserManager `json:"managers"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	imported, err := c.service.ImportUserManagers(req.Managers)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"imported": imported,
+	})
+}
+
+// ImportOrgChart - POST /org/import
+func (c *Controller) ImportOrgChart(w http.ResponseWriter, r *http.Request) {
+	var managers []models.UserManager
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		var err error
+		if managers, err = c.parseOrgCSV(r); err != nil {
+			c.respondParseError(w, err)
+			return
+		}
+	} else {
+		var req struct {
+			Reports map[string][]string `json:"reports"`
+		}
+		if err := c.parseJSON(r, &req); err != nil {
+			c.respondParseError(w, err)
+			return
+		}
+		for managerID, reports := range req.Reports {
+			for _, userID := range reports {
+				managers = append(managers, models.UserManager{UserID: userID, ManagerID: managerID})
+			}
+		}
+		sort.Slice(managers, func(i, j int) bool {
+			return managers[i].UserID < managers[j].UserID
+		})
+	}
+	
+	imported, err := c.service.ImportUserManagers(managers)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"imported": imported,
+	})
+}
+
+// parseOrgCSV reads manager_id,user_id rows, an optional header row with these names is skipped
+func (c *Controller) parseOrgCSV(r *http.Request) ([]models.UserManager, error) {
+	reader := csv.NewReader(http.MaxBytesReader(nil, r.Body, c.maxBodyBytes))
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	
+	var managers []models.UserManager
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return managers, nil
+		}
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				return nil, c.bodyError(err)
+			}
+			return nil, &requestError{
+				code:    errcode.InvalidRequest,
+				message: "invalid CSV: " + err.Error(),
+			}
+		}
+		if len(managers) == 0 && record[0] == "manager_id" && record[1] == "user_id" {
+			continue
+		}
+		managers = append(managers, models.UserManager{UserID: record[1], ManagerID: record[0]})
+	}
+}
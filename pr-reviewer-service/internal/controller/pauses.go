@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"net/http"
+)
+
+// PauseReview - POST /review/pause
+func (c *Controller) PauseReview(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		Reason        string `json:"reason"`
+	}
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	reviewer, err := c.service.PauseReview(req.PullRequestID, req.UserID, req.Reason)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"reviewer": reviewer,
+	})
+}
+
+// RerequestReview - POST /review/rerequest
+func (c *Controller) RerequestReview(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		AuthorID      string `json:"author_id"`
+		UserID        string `json:"user_id"`
+	}
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, resumed, err := c.service.RerequestReview(req.PullRequestID, req.AuthorID, req.UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr":      pr,
+		"resumed": resumed,
+	})
+}
@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+)
+
+// GetPendingAssignments - GET /team/pendingAssignments
+func (c *Controller) GetPendingAssignments(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	pending, err := c.service.GetPendingAssignments(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": teamName,
+		"pending":   pending,
+	})
+}
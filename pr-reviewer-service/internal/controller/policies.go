@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// ASSIGNMENT RULES
+
+// GetTeamAssignmentRules - GET /team/assignmentRules
+func (c *Controller) GetTeamAssignmentRules(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	rules, err := c.service.GetTeamAssignmentRules(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, rules)
+}
+
+// SetTeamAssignmentRules - POST /team/assignmentRules
+func (c *Controller) SetTeamAssignmentRules(w http.ResponseWriter, r *http.Request) {
+	var req models.TeamAssignmentRules
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	rules, err := c.service.SetTeamAssignmentRules(&req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, rules)
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/service"
+)
+
+// POLICY SCRIPTS
+
+// GetTeamPolicy - GET /team/policy
+func (c *Controller) GetTeamPolicy(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	teamPolicy, err := c.service.GetTeamPolicy(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, teamPolicy)
+}
+
+// SetTeamPolicy - POST /team/policy
+func (c *Controller) SetTeamPolicy(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.setTeamPolicy)(w, r)
+}
+
+func (c *Controller) setTeamPolicy(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string `json:"team_name"`
+		Source   string `json:"source"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	teamPolicy, err := c.service.SetTeamPolicy(&models.TeamPolicy{TeamName: req.TeamName, Source: req.Source}, TokenFromContext(r.Context()).UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, teamPolicy)
+}
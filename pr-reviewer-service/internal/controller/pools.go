@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// REVIEWER POOLS
+
+// GetReviewerPools - GET /team/pools
+func (c *Controller) GetReviewerPools(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	pools, err := c.service.GetReviewerPools(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": teamName,
+		"pools":     pools,
+	})
+}
+
+// SetReviewerPool - POST /team/pools
+func (c *Controller) SetReviewerPool(w http.ResponseWriter, r *http.Request) {
+	var req models.ReviewerPool
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pool, err := c.service.SetReviewerPool(&req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, pool)
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/service"
+)
+
+// USER PROFILES
+
+// PatchUser - PATCH /users/{id}
+func (c *Controller) PatchUser(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.patchUser)(w, r)
+}
+
+func (c *Controller) patchUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		models.UserProfile
+		UpdateMask []string `json:"update_mask"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	user, err := c.service.PatchUser(TokenFromContext(r.Context()).UserID, r.PathValue("id"), req.UpdateMask, &req.UserProfile)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
+
+// AddUser - POST /users/add
+func (c *Controller) AddUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string `json:"team_name"`
+		UserID   string `json:"user_id"`
+		Username string `json:"username"`
+		IsActive *bool  `json:"is_active"`
+		Role     string `json:"role"`
+		Region   string `json:"region"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	member := &models.TeamMember{
+		UserID:   req.UserID,
+		Username: req.Username,
+		IsActive: req.IsActive == nil || *req.IsActive,
+		Role:     req.Role,
+		Region:   req.Region,
+	}
+	user, err := c.service.AddUser(member, req.TeamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"user": user,
+	})
+}
+
+// LinkIdentity - POST /users/linkIdentity
+func (c *Controller) LinkIdentity(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.linkIdentity)(w, r)
+}
+
+func (c *Controller) linkIdentity(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID     string `json:"user_id"`
+		Provider   string `json:"provider"`
+		ExternalID string `json:"external_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	identity, err := c.service.LinkIdentity(TokenFromContext(r.Context()).UserID, req.UserID, req.Provider, req.ExternalID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"identity": identity,
+	})
+}
+
+// UnlinkIdentity - POST /users/unlinkIdentity
+func (c *Controller) UnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.unlinkIdentity)(w, r)
+}
+
+func (c *Controller) unlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID     string `json:"user_id"`
+		Provider   string `json:"provider"`
+		ExternalID string `json:"external_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	if err := c.service.UnlinkIdentity(TokenFromContext(r.Context()).UserID, req.UserID, req.Provider, req.ExternalID); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"unlinked": true,
+	})
+}
+
+// ListIdentities - GET /users/identities
+func (c *Controller) ListIdentities(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		c.respondError(w, errcode.InvalidRequest, "user_id is required")
+		return
+	}
+	
+	identities, err := c.service.ListIdentities(userID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":    userID,
+		"identities": identities,
+	})
+}
+
+// ResolveIdentity - GET /users/identities/resolve
+func (c *Controller) ResolveIdentity(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	provider, externalID := query.Get("provider"), query.Get("external_id")
+	
+	userID, err := c.service.ResolveIdentity(provider, externalID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"provider":    provider,
+		"external_id": externalID,
+		"user_id":     userID,
+	})
+}
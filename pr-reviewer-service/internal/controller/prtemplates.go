@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// PR TEMPLATES
+
+// GetTeamPRTemplates - GET /team/prTemplates
+func (c *Controller) GetTeamPRTemplates(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	templates, err := c.service.GetTeamPRTemplates(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, templates)
+}
+
+// SetTeamPRTemplates - POST /team/prTemplates
+func (c *Controller) SetTeamPRTemplates(w http.ResponseWriter, r *http.Request) {
+	var req models.TeamPRTemplates
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	templates, err := c.service.SetTeamPRTemplates(&req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, templates)
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/models"
+)
+
+// RebalanceReviews - POST /admin/reviews/rebalance
+func (c *Controller) RebalanceReviews(w http.ResponseWriter, r *http.Request) {
+	var req models.RebalanceRequest
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	result, err := c.service.RebalanceReviews(req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/models"
+)
+
+// ADMIN
+
+// ReplayEvents - POST /admin/events/replay
+func (c *Controller) ReplayEvents(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ActorID string `json:"actor_id"`
+		models.PREventFilter
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	published, err := c.service.ReplayEvents(r.Context(), req.ActorID, req.PREventFilter)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"replayed": published,
+	})
+}
+
+// RebuildPRProjection - POST /admin/pullRequest/rebuild
+func (c *Controller) RebuildPRProjection(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ActorID       string `json:"actor_id"`
+		PullRequestID string `json:"pull_request_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	state, err := c.service.RebuildPRProjection(req.ActorID, req.PullRequestID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, state)
+}
+
+// RebuildReadModels - POST /admin/stats/rebuild
+func (c *Controller) RebuildReadModels(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ActorID string `json:"actor_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	if err := c.service.RebuildReadModels(r.Context(), req.ActorID); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"rebuilt": true,
+	})
+}
+
+// ListJobs - GET /admin/jobs
+func (c *Controller) ListJobs(w http.ResponseWriter, r *http.Request) {
+	cursor, limit, ok := c.parsePage(w, r)
+	if !ok {
+		return
+	}
+	
+	query := r.URL.Query()
+	jobs, next, err := c.service.ListJobs(query.Get("actor_id"), query.Get("status"), cursor, limit)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"jobs":        jobs,
+		"next_cursor": next,
+	})
+}
+
+// ListAuditEntries - GET /admin/audit
+func (c *Controller) ListAuditEntries(w http.ResponseWriter, r *http.Request) {
+	cursor, limit, ok := c.parsePage(w, r)
+	if !ok {
+		return
+	}
+	
+	entries, next, err := c.service.ListAuditEntries(r.URL.Query().Get("actor_id"), cursor, limit)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"entries":     entries,
+		"next_cursor": next,
+	})
+}
+
+// RetryJob - POST /admin/jobs/retry
+func (c *Controller) RetryJob(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ActorID string `json:"actor_id"`
+		JobID   int64  `json:"job_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	if err := c.service.RetryJob(req.ActorID, req.JobID); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"job_id":   req.JobID,
+		"requeued": true,
+	})
+}
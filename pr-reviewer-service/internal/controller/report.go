@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+)
+
+// REPORTS
+
+// GetTeamReport - GET /team/report
+func (c *Controller) GetTeamReport(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	anonymize, ok := c.parseAnonymize(w, r)
+	if !ok {
+		return
+	}
+	
+	report, err := c.service.GetTeamReport(teamName, r.URL.Query().Get("week"))
+	if err == nil && anonymize {
+		err = c.service.AnonymizeTeamReport(report)
+	}
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/service"
+	"strconv"
+)
+
+// REPOSITORIES
+
+// ListRepositories - GET /repository/list
+func (c *Controller) ListRepositories(w http.ResponseWriter, r *http.Request) {
+	repos, err := c.service.ListRepositories(r.URL.Query().Get("team_name"))
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"repositories": repos,
+	})
+}
+
+// SetRepositoryOwner - POST /repository/set
+func (c *Controller) SetRepositoryOwner(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.setRepositoryOwner)(w, r)
+}
+
+func (c *Controller) setRepositoryOwner(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RepositoryID string `json:"repository_id"`
+		TeamName     string `json:"team_name"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	repo, err := c.service.SetRepositoryOwner(req.RepositoryID, req.TeamName, TokenFromContext(r.Context()).UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, repo)
+}
+
+// DeleteRepository - POST /repository/delete
+func (c *Controller) DeleteRepository(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.deleteRepository)(w, r)
+}
+
+func (c *Controller) deleteRepository(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RepositoryID string `json:"repository_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	if err := c.service.DeleteRepository(req.RepositoryID, TokenFromContext(r.Context()).UserID); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"repository_id": req.RepositoryID,
+		"deleted":       true,
+	})
+}
+
+// GetPathOwners - GET /repository/paths
+func (c *Controller) GetPathOwners(w http.ResponseWriter, r *http.Request) {
+	repositoryID := r.URL.Query().Get("repository_id")
+	if repositoryID == "" {
+		c.respondError(w, errcode.InvalidRequest, "repository_id is required")
+		return
+	}
+	
+	owners, err := c.service.GetPathOwners(repositoryID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"repository_id": repositoryID,
+		"owners":        owners,
+	})
+}
+
+// SetPathOwners - POST /repository/paths
+func (c *Controller) SetPathOwners(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.setPathOwners)(w, r)
+}
+
+func (c *Controller) setPathOwners(w http.ResponseWriter, r *http.Request) {
+	var req models.PathOwners
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	owners, err := c.service.SetPathOwners(&req, TokenFromContext(r.Context()).UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, owners)
+}
+
+// githubPullRequestEvent - fields of GitHub "pull_request" webhook payload we use
+type githubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		ID             int64  `json:"id"`
+		Title          string `json:"title"`
+		Merged         bool   `json:"merged"`
+		MergeCommitSHA string `json:"merge_commit_sha"`
+		HTMLURL        string `json:"html_url"`
+		Labels         []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		MergedBy struct {
+			Login string `json:"login"`
+		} `json:"merged_by"`
+	} `json:"pull_request"`
+	RequestedReviewer struct {
+		Login string `json:"login"`
+	} `json:"requested_reviewer"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// githubPRID - external id of GitHub PR, kept across repository renames and transfers
+func githubPRID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return service.IdentityGitHub + ":" + strconv.FormatInt(id, 10)
+}
+
+// GitHubWebhook - POST /webhook/github
+func (c *Controller) GitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	// other events (and ping) are acknowledged so GitHub doesn't mark the hook as failing
+	if r.Header.Get("X-GitHub-Event") != "pull_request" {
+		c.respondJSON(w, http.StatusOK, map[string]interface{}{"ignored": true})
+		return
+	}
+	
+	var payload githubPullRequestEvent
+	if err := c.parseExternalJSON(r, &payload); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	labels := make([]string, 0, len(payload.PullRequest.Labels))
+	for _, label := range payload.PullRequest.Labels {
+		labels = append(labels, label.Name)
+	}
+	
+	// GitHub logins resolve to linked users, unlinked logins are used as user_id
+	pr, err := c.service.IngestPullRequestWebhook(&models.PullRequestWebhook{
+		Provider:     service.IdentityGitHub,
+		Action:       payload.Action,
+		RepositoryID: payload.Repository.FullName,
+		Number:       payload.Number,
+		ExternalID:   githubPRID(payload.PullRequest.ID),
+		ExternalURL:  payload.PullRequest.HTMLURL,
+		Title:        payload.PullRequest.Title,
+		AuthorID:     payload.PullRequest.User.Login,
+		Labels:       labels,
+		Merged:       payload.PullRequest.Merged,
+		Merge: models.MergeInfo{
+			MergedBy:    payload.PullRequest.MergedBy.Login,
+			MergeCommit: payload.PullRequest.MergeCommitSHA,
+			MergeURL:    payload.PullRequest.HTMLURL,
+		},
+		RequestedReviewer: payload.RequestedReviewer.Login,
+		Sender:            payload.Sender.Login,
+	})
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	if pr == nil {
+		c.respondJSON(w, http.StatusOK, map[string]interface{}{"ignored": true})
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr": pr,
+	})
+}
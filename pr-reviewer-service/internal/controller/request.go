@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+// REQUEST PARSING
+
+// defaultMaxBodyBytes - request body limit unless WithMaxBodyBytes sets another
+const defaultMaxBodyBytes int64 = 1 << 20
+
+// requestError - why a request body was rejected, Field names the offending JSON field
+type requestError struct {
+	code    errcode.Code
+	message string
+	field   string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+// parseJSON decodes a body of this API's own request: unknown fields, trailing data and
+// bodies over the limit are rejected
+func (c *Controller) parseJSON(r *http.Request, v interface{}) error {
+	return c.decodeJSON(r, v, true)
+}
+
+// parseExternalJSON decodes third-party payloads (GitHub webhooks, SCIM) that carry more
+// fields than the service models, only the size limit and syntax are enforced
+func (c *Controller) parseExternalJSON(r *http.Request, v interface{}) error {
+	return c.decodeJSON(r, v, false)
+}
+
+func (c *Controller) decodeJSON(r *http.Request, v interface{}, strict bool) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, c.maxBodyBytes))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(v); err != nil {
+		return c.bodyError(err)
+	}
+	
+	if err := decoder.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return c.bodyError(err)
+		}
+		return &requestError{
+			code:    errcode.InvalidRequest,
+			message: "request body must contain a single JSON value",
+		}
+	}
+	return nil
+}
+
+// bodyError turns a decoding failure into a client error
+func (c *Controller) bodyError(err error) *requestError {
+	var (
+		maxErr    *http.MaxBytesError
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	
+	bad := &requestError{code: errcode.InvalidRequest}
+	switch {
+	case errors.As(err, &maxErr):
+		bad.code = errcode.PayloadTooLarge
+		bad.message = fmt.Sprintf("request body exceeds %d bytes", maxErr.Limit)
+	case errors.As(err, &syntaxErr):
+		bad.message = fmt.Sprintf("malformed JSON at offset %d: %v", syntaxErr.Offset, syntaxErr)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		bad.message = "malformed JSON: unexpected end of body"
+	case errors.Is(err, io.EOF):
+		bad.message = "request body is empty"
+	case errors.As(err, &typeErr):
+		bad.field = typeErr.Field
+		bad.message = fmt.Sprintf("field %s must be %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no typed error for unknown fields
+		bad.field = strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		bad.message = "unknown field " + bad.field
+	default:
+		bad.message = "invalid JSON"
+	}
+	return bad
+}
+
+// respondParseError reports a rejected request body
+func (c *Controller) respondParseError(w http.ResponseWriter, err error) {
+	var bad *requestError
+	if !errors.As(err, &bad) {
+		c.respondError(w, errcode.InvalidRequest, "invalid JSON")
+		return
+	}
+	c.respondErrorDetail(w, models.ErrorDetail{
+		Code:    bad.code,
+		Message: bad.message,
+		Field:   bad.field,
+	})
+}
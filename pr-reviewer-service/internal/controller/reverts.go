@@ -0,0 +1,30 @@
+package controller
+
+import "net/http"
+
+// MarkRevert - POST /pullRequest/markRevert
+func (c *Controller) MarkRevert(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		RevertedID    string `json:"reverted_id"`
+		MarkedBy      string `json:"marked_by"`
+	}
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, revert, err := c.service.MarkRevert(req.PullRequestID, req.RevertedID, req.MarkedBy)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr":     pr,
+		"revert": revert,
+	})
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// AddReviewTeam - POST /pullRequest/addReviewTeam
+func (c *Controller) AddReviewTeam(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		models.ReviewTeamRequest
+	}
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, err := c.service.AddReviewTeam(req.PullRequestID, req.ReviewTeamRequest)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	teams, err := c.service.GetReviewTeams(req.PullRequestID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if err := c.service.MaskReviewTeams(req.PullRequestID, viewerID(r), teams); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr":           pr,
+		"review_teams": teams,
+	})
+}
+
+// GetReviewTeams - GET /pullRequest/reviewTeams
+func (c *Controller) GetReviewTeams(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		c.respondError(w, errcode.InvalidRequest, "pull_request_id is required")
+		return
+	}
+	
+	teams, err := c.service.GetReviewTeams(prID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	if err := c.service.MaskReviewTeams(prID, viewerID(r), teams); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"review_teams":    teams,
+	})
+}
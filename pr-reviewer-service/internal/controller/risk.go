@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// RISK RULES
+
+// GetTeamRiskRules - GET /team/riskRules
+func (c *Controller) GetTeamRiskRules(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	rules, err := c.service.GetTeamRiskRules(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, rules)
+}
+
+// SetTeamRiskRules - POST /team/riskRules
+func (c *Controller) SetTeamRiskRules(w http.ResponseWriter, r *http.Request) {
+	var req models.TeamRiskRules
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	rules, err := c.service.SetTeamRiskRules(&req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, rules)
+}
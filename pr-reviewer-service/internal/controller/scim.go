@@ -0,0 +1,720 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/service"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SCIM 2.0 (RFC 7643/7644) provisioning: userName is user_id, a group is a team,
+// enterprise department picks the team of a user and manager feeds conflict-of-interest rules
+
+const (
+	scimUserSchema       = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimGroupSchema      = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimEnterpriseSchema = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	scimListSchema       = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimErrorSchema      = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	scimDefaultCount = 100
+	scimMaxCount     = 500
+)
+
+type scimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type scimRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type scimEnterprise struct {
+	Department string   `json:"department,omitempty"`
+	Manager    *scimRef `json:"manager,omitempty"`
+}
+
+type scimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location"`
+}
+
+type scimUser struct {
+	Schemas     []string        `json:"schemas"`
+	ID          string          `json:"id,omitempty"`
+	UserName    string          `json:"userName"`
+	DisplayName string          `json:"displayName,omitempty"`
+	Name        *scimName       `json:"name,omitempty"`
+	Active      *bool           `json:"active,omitempty"`
+	Groups      []scimRef       `json:"groups,omitempty"`
+	Enterprise  *scimEnterprise `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        *scimMeta       `json:"meta,omitempty"`
+}
+
+type scimGroup struct {
+	Schemas     []string  `json:"schemas"`
+	ID          string    `json:"id,omitempty"`
+	DisplayName string    `json:"displayName"`
+	Members     []scimRef `json:"members"`
+	Meta        *scimMeta `json:"meta,omitempty"`
+}
+
+type scimListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string        `json:"schemas"`
+	Operations []scimOperation `json:"Operations"`
+}
+
+type scimOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type scimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+// scimFilter supports the only form identity providers send, `attribute eq "value"`
+var scimFilter = regexp.MustCompile(`^\s*(\w+)\s+[eE][qQ]\s+"([^"]*)"\s*$`)
+
+// scimMemberFilter - member path of group patch, `members[value eq "u1"]`
+var scimMemberFilter = regexp.MustCompile(`^\s*members\[\s*value\s+[eE][qQ]\s+"([^"]*)"\s*\]\s*$`)
+
+func (c *Controller) respondSCIM(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	if data == nil {
+		return
+	}
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		log.Printf("Failed to encode SCIM response: %v", err)
+	}
+}
+
+func (c *Controller) respondSCIMError(w http.ResponseWriter, status int, scimType, detail string) {
+	c.respondSCIM(w, status, scimError{
+		Schemas:  []string{scimErrorSchema},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	})
+}
+
+func (c *Controller) respondSCIMServiceError(w http.ResponseWriter, err error) {
+	if serviceErr, ok := err.(*service.ServiceError); ok {
+		switch serviceErr.Code {
+		case errcode.InvalidRequest:
+			c.respondSCIMError(w, http.StatusBadRequest, "invalidValue", serviceErr.Message)
+		case errcode.TeamExists:
+			// SCIM reports uniqueness violations as 409, the API keeps 400 for compatibility
+			c.respondSCIMError(w, http.StatusConflict, "uniqueness", serviceErr.Message)
+		default:
+			c.respondSCIMError(w, serviceErr.Code.Status(), "", serviceErr.Message)
+		}
+		return
+	}
+	c.respondSCIMError(w, http.StatusInternalServerError, "", err.Error())
+}
+
+// scimAuthorized checks the identity provider's bearer token
+func (c *Controller) scimAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	if c.scimToken == "" {
+		c.respondSCIMError(w, http.StatusServiceUnavailable, "", "SCIM provisioning is not configured")
+		return false
+	}
+	
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || subtle.ConstantTimeCompare([]byte(token), []byte(c.scimToken)) != 1 {
+		c.respondSCIMError(w, http.StatusUnauthorized, "", "invalid bearer token")
+		return false
+	}
+	return true
+}
+
+// scimPage reads 1-based startIndex and count
+func (c *Controller) scimPage(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	query := r.URL.Query()
+	
+	startIndex, count := 1, scimDefaultCount
+	if raw := query.Get("startIndex"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.respondSCIMError(w, http.StatusBadRequest, "invalidValue", "startIndex must be a number")
+			return 0, 0, false
+		}
+		startIndex = max(parsed, 1)
+	}
+	if raw := query.Get("count"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.respondSCIMError(w, http.StatusBadRequest, "invalidValue", "count must be a number")
+			return 0, 0, false
+		}
+		count = min(max(parsed, 0), scimMaxCount)
+	}
+	return startIndex, count, true
+}
+
+// scimEqFilter parses filter into attribute and value, attribute must be the expected one
+func (c *Controller) scimEqFilter(w http.ResponseWriter, r *http.Request, attribute string) (string, bool, bool) {
+	raw := r.URL.Query().Get("filter")
+	if raw == "" {
+		return "", false, true
+	}
+	
+	match := scimFilter.FindStringSubmatch(raw)
+	if match == nil || !strings.EqualFold(match[1], attribute) {
+		c.respondSCIMError(w, http.StatusBadRequest, "invalidFilter", fmt.Sprintf("only %s eq \"...\" filter is supported", attribute))
+		return "", false, false
+	}
+	return match[2], true, true
+}
+
+func toSCIMUser(user *models.User) scimUser {
+	active := user.IsActive
+	resource := scimUser{
+		Schemas:     []string{scimUserSchema, scimEnterpriseSchema},
+		ID:          user.UserID,
+		UserName:    user.UserID,
+		DisplayName: user.Username,
+		Name:        &scimName{Formatted: user.Username},
+		Active:      &active,
+		Groups:      []scimRef{{Value: user.TeamName, Display: user.TeamName}},
+		Enterprise:  &scimEnterprise{Department: user.TeamName},
+		Meta:        &scimMeta{ResourceType: "User", Location: "/scim/v2/Users/" + user.UserID},
+	}
+	if user.ManagerID != "" {
+		resource.Enterprise.Manager = &scimRef{Value: user.ManagerID}
+	}
+	return resource
+}
+
+func toSCIMGroup(team *models.TeamResponse) scimGroup {
+	// deactivated users stay in their team row but aren't members for the identity provider
+	members := []scimRef{}
+	for _, member := range team.Members {
+		if member.IsActive {
+			members = append(members, scimRef{Value: member.UserID, Display: member.Username})
+		}
+	}
+	return scimGroup{
+		Schemas:     []string{scimGroupSchema},
+		ID:          team.TeamName,
+		DisplayName: team.TeamName,
+		Members:     members,
+		Meta:        &scimMeta{ResourceType: "Group", Location: "/scim/v2/Groups/" + team.TeamName},
+	}
+}
+
+// fromSCIMUser maps resource to user, inactive unless active is set for existing users
+func (c *Controller) fromSCIMUser(resource *scimUser, current *models.User) *models.User {
+	user := &models.User{
+		UserID:   resource.UserName,
+		Username: resource.DisplayName,
+		IsActive: true,
+	}
+	if current != nil {
+		user.IsActive = current.IsActive
+	}
+	if resource.Active != nil {
+		user.IsActive = *resource.Active
+	}
+	if user.Username == "" && resource.Name != nil {
+		user.Username = resource.Name.Formatted
+		if user.Username == "" {
+			user.Username = strings.TrimSpace(resource.Name.GivenName + " " + resource.Name.FamilyName)
+		}
+	}
+	if resource.Enterprise != nil {
+		user.TeamName = resource.Enterprise.Department
+		if resource.Enterprise.Manager != nil {
+			user.ManagerID = resource.Enterprise.Manager.Value
+		}
+	}
+	if user.TeamName == "" && current == nil {
+		user.TeamName = c.scimDefaultTeam
+	}
+	return user
+}
+
+func (c *Controller) provisionSCIMUser(w http.ResponseWriter, resource *scimUser, current *models.User) {
+	user, created, err := c.service.ProvisionUser(c.fromSCIMUser(resource, current))
+	if err != nil {
+		c.respondSCIMServiceError(w, err)
+		return
+	}
+	
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.respondSCIM(w, status, toSCIMUser(user))
+}
+
+// SCIMListUsers - GET /scim/v2/Users
+func (c *Controller) SCIMListUsers(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	startIndex, count, ok := c.scimPage(w, r)
+	if !ok {
+		return
+	}
+	userName, filtered, ok := c.scimEqFilter(w, r, "userName")
+	if !ok {
+		return
+	}
+	
+	list := scimListResponse{Schemas: []string{scimListSchema}, StartIndex: startIndex, Resources: []interface{}{}}
+	if filtered {
+		if user, err := c.service.GetUser(userName); err == nil {
+			list.TotalResults = 1
+			if startIndex == 1 && count > 0 {
+				list.Resources = append(list.Resources, toSCIMUser(user))
+			}
+		}
+	} else {
+		users, total, err := c.service.ListUsers(startIndex-1, count)
+		if err != nil {
+			c.respondSCIMServiceError(w, err)
+			return
+		}
+		list.TotalResults = total
+		for i := range users {
+			list.Resources = append(list.Resources, toSCIMUser(&users[i]))
+		}
+	}
+	list.ItemsPerPage = len(list.Resources)
+	
+	c.respondSCIM(w, http.StatusOK, list)
+}
+
+// SCIMGetUser - GET /scim/v2/Users/{id}
+func (c *Controller) SCIMGetUser(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	
+	user, err := c.service.GetUser(r.PathValue("id"))
+	if err != nil {
+		c.respondSCIMServiceError(w, err)
+		return
+	}
+	
+	c.respondSCIM(w, http.StatusOK, toSCIMUser(user))
+}
+
+// SCIMCreateUser - POST /scim/v2/Users
+func (c *Controller) SCIMCreateUser(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	
+	var resource scimUser
+	if err := c.parseExternalJSON(r, &resource); err != nil {
+		c.respondSCIMError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	if _, err := c.service.GetUser(resource.UserName); err == nil {
+		c.respondSCIMError(w, http.StatusConflict, "uniqueness", "user already exists")
+		return
+	}
+	
+	c.provisionSCIMUser(w, &resource, nil)
+}
+
+// SCIMReplaceUser - PUT /scim/v2/Users/{id}
+func (c *Controller) SCIMReplaceUser(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	
+	current, err := c.service.GetUser(r.PathValue("id"))
+	if err != nil {
+		c.respondSCIMServiceError(w, err)
+		return
+	}
+	
+	var resource scimUser
+	if err := c.parseExternalJSON(r, &resource); err != nil {
+		c.respondSCIMError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	if resource.UserName != current.UserID {
+		c.respondSCIMError(w, http.StatusBadRequest, "mutability", "userName can't be changed")
+		return
+	}
+	
+	c.provisionSCIMUser(w, &resource, current)
+}
+
+// SCIMPatchUser - PATCH /scim/v2/Users/{id}
+func (c *Controller) SCIMPatchUser(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	
+	current, err := c.service.GetUser(r.PathValue("id"))
+	if err != nil {
+		c.respondSCIMServiceError(w, err)
+		return
+	}
+	
+	var patch scimPatchRequest
+	if err := c.parseExternalJSON(r, &patch); err != nil {
+		c.respondSCIMError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	
+	resource := toSCIMUser(current)
+	for _, op := range patch.Operations {
+		if err := applySCIMUserOperation(&resource, op); err != nil {
+			c.respondSCIMError(w, http.StatusBadRequest, "invalidValue", err.Error())
+			return
+		}
+	}
+	if resource.UserName != current.UserID {
+		c.respondSCIMError(w, http.StatusBadRequest, "mutability", "userName can't be changed")
+		return
+	}
+	
+	c.provisionSCIMUser(w, &resource, current)
+}
+
+// SCIMDeleteUser - DELETE /scim/v2/Users/{id}, user is deactivated since reviews reference it
+func (c *Controller) SCIMDeleteUser(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	
+	if _, err := c.service.SetUserActive(r.PathValue("id"), false); err != nil {
+		c.respondSCIMServiceError(w, err)
+		return
+	}
+	
+	c.respondSCIM(w, http.StatusNoContent, nil)
+}
+
+// applySCIMUserOperation applies add/replace/remove, unknown attributes are ignored
+// since identity providers send more of them than the service keeps
+func applySCIMUserOperation(resource *scimUser, op scimOperation) error {
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+	case "remove":
+		switch strings.ToLower(op.Path) {
+		case strings.ToLower(scimEnterpriseSchema + ":manager"):
+			resource.Enterprise.Manager = nil
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown patch op %q", op.Op)
+	}
+	
+	if op.Path != "" {
+		return setSCIMUserAttribute(resource, op.Path, op.Value)
+	}
+	
+	// without path the value is an object of attributes
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(op.Value, &attributes); err != nil {
+		return fmt.Errorf("patch value must be an object")
+	}
+	for path, value := range attributes {
+		if !strings.EqualFold(path, scimEnterpriseSchema) {
+			if err := setSCIMUserAttribute(resource, path, value); err != nil {
+				return err
+			}
+			continue
+		}
+		var extension map[string]json.RawMessage
+		if err := json.Unmarshal(value, &extension); err != nil {
+			return fmt.Errorf("enterprise extension must be an object")
+		}
+		for name, extensionValue := range extension {
+			if err := setSCIMUserAttribute(resource, scimEnterpriseSchema+":"+name, extensionValue); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func setSCIMUserAttribute(resource *scimUser, path string, value json.RawMessage) error {
+	switch strings.ToLower(path) {
+	case "active":
+		active, err := parseSCIMBool(value)
+		if err != nil {
+			return err
+		}
+		resource.Active = &active
+	case "username":
+		return json.Unmarshal(value, &resource.UserName)
+	case "displayname":
+		return json.Unmarshal(value, &resource.DisplayName)
+	case "name.formatted":
+		resource.DisplayName = ""
+		return json.Unmarshal(value, &resource.Name.Formatted)
+	case "name":
+		resource.DisplayName = ""
+		return json.Unmarshal(value, resource.Name)
+	case strings.ToLower(scimEnterpriseSchema + ":department"):
+		return json.Unmarshal(value, &resource.Enterprise.Department)
+	case strings.ToLower(scimEnterpriseSchema + ":manager"):
+		// manager comes as {"value": "id"} or as a bare id
+		var manager scimRef
+		if err := json.Unmarshal(value, &manager); err != nil {
+			if err := json.Unmarshal(value, &manager.Value); err != nil {
+				return fmt.Errorf("invalid manager value")
+			}
+		}
+		resource.Enterprise.Manager = &manager
+	}
+	return nil
+}
+
+// parseSCIMBool accepts JSON booleans and the "True"/"False" strings Azure AD sends
+func parseSCIMBool(value json.RawMessage) (bool, error) {
+	var flag bool
+	if err := json.Unmarshal(value, &flag); err == nil {
+		return flag, nil
+	}
+	var raw string
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return false, fmt.Errorf("active must be a boolean")
+	}
+	flag, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("active must be a boolean")
+	}
+	return flag, nil
+}
+
+// SCIMListGroups - GET /scim/v2/Groups
+func (c *Controller) SCIMListGroups(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	startIndex, count, ok := c.scimPage(w, r)
+	if !ok {
+		return
+	}
+	displayName, filtered, ok := c.scimEqFilter(w, r, "displayName")
+	if !ok {
+		return
+	}
+	
+	list := scimListResponse{Schemas: []string{scimListSchema}, StartIndex: startIndex, Resources: []interface{}{}}
+	if filtered {
+		if team, err := c.service.GetTeam(displayName); err == nil {
+			list.TotalResults = 1
+			if startIndex == 1 && count > 0 {
+				list.Resources = append(list.Resources, toSCIMGroup(team))
+			}
+		}
+	} else {
+		teams, total, err := c.service.ListTeamsWithMembers(startIndex-1, count)
+		if err != nil {
+			c.respondSCIMServiceError(w, err)
+			return
+		}
+		list.TotalResults = total
+		for i := range teams {
+			list.Resources = append(list.Resources, toSCIMGroup(&teams[i]))
+		}
+	}
+	list.ItemsPerPage = len(list.Resources)
+	
+	c.respondSCIM(w, http.StatusOK, list)
+}
+
+// SCIMGetGroup - GET /scim/v2/Groups/{id}
+func (c *Controller) SCIMGetGroup(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	
+	team, err := c.service.GetTeam(r.PathValue("id"))
+	if err != nil {
+		c.respondSCIMServiceError(w, err)
+		return
+	}
+	
+	c.respondSCIM(w, http.StatusOK, toSCIMGroup(team))
+}
+
+// SCIMCreateGroup - POST /scim/v2/Groups
+func (c *Controller) SCIMCreateGroup(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	
+	var resource scimGroup
+	if err := c.parseExternalJSON(r, &resource); err != nil {
+		c.respondSCIMError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	
+	team, err := c.service.ProvisionTeam(resource.DisplayName, scimRefValues(resource.Members))
+	if err != nil {
+		c.respondSCIMServiceError(w, err)
+		return
+	}
+	
+	c.respondSCIM(w, http.StatusCreated, toSCIMGroup(team))
+}
+
+// SCIMReplaceGroup - PUT /scim/v2/Groups/{id}
+func (c *Controller) SCIMReplaceGroup(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	
+	var resource scimGroup
+	if err := c.parseExternalJSON(r, &resource); err != nil {
+		c.respondSCIMError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	teamName := r.PathValue("id")
+	if resource.DisplayName != "" && resource.DisplayName != teamName {
+		c.respondSCIMError(w, http.StatusBadRequest, "mutability", "teams can't be renamed")
+		return
+	}
+	
+	team, err := c.service.ReplaceTeamMembers(teamName, scimRefValues(resource.Members))
+	if err != nil {
+		c.respondSCIMServiceError(w, err)
+		return
+	}
+	
+	c.respondSCIM(w, http.StatusOK, toSCIMGroup(team))
+}
+
+// SCIMPatchGroup - PATCH /scim/v2/Groups/{id}
+func (c *Controller) SCIMPatchGroup(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	
+	var patch scimPatchRequest
+	if err := c.parseExternalJSON(r, &patch); err != nil {
+		c.respondSCIMError(w, http.StatusBadRequest, "invalidSyntax", err.Error())
+		return
+	}
+	
+	teamName := r.PathValue("id")
+	var team *models.TeamResponse
+	var err error
+	for _, op := range patch.Operations {
+		team, err = c.applySCIMGroupOperation(teamName, op)
+		if err != nil {
+			if serviceErr, ok := err.(*service.ServiceError); ok {
+				c.respondSCIMServiceError(w, serviceErr)
+			} else {
+				c.respondSCIMError(w, http.StatusBadRequest, "invalidValue", err.Error())
+			}
+			return
+		}
+	}
+	if team == nil {
+		if team, err = c.service.GetTeam(teamName); err != nil {
+			c.respondSCIMServiceError(w, err)
+			return
+		}
+	}
+	
+	c.respondSCIM(w, http.StatusOK, toSCIMGroup(team))
+}
+
+// SCIMDeleteGroup - DELETE /scim/v2/Groups/{id}
+func (c *Controller) SCIMDeleteGroup(w http.ResponseWriter, r *http.Request) {
+	if !c.scimAuthorized(w, r) {
+		return
+	}
+	
+	c.respondSCIMError(w, http.StatusBadRequest, "mutability", "teams can't be deleted, remove their members instead")
+}
+
+func (c *Controller) applySCIMGroupOperation(teamName string, op scimOperation) (*models.TeamResponse, error) {
+	path := strings.TrimSpace(op.Path)
+	
+	// `members[value eq "u1"]` removes a single member
+	if match := scimMemberFilter.FindStringSubmatch(path); match != nil {
+		if !strings.EqualFold(op.Op, "remove") {
+			return nil, fmt.Errorf("member filter is supported for remove only")
+		}
+		return c.service.UpdateTeamMembers(teamName, nil, []string{match[1]})
+	}
+	
+	var members []scimRef
+	switch {
+	case strings.EqualFold(path, "members"):
+		if len(op.Value) > 0 {
+			if err := json.Unmarshal(op.Value, &members); err != nil {
+				return nil, fmt.Errorf("members must be a list")
+			}
+		}
+	case path == "":
+		var value struct {
+			DisplayName string    `json:"displayName"`
+			Members     []scimRef `json:"members"`
+		}
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("patch value must be an object")
+		}
+		if value.DisplayName != "" && value.DisplayName != teamName {
+			return nil, fmt.Errorf("teams can't be renamed")
+		}
+		members = value.Members
+	case strings.EqualFold(path, "displayName"):
+		var displayName string
+		if err := json.Unmarshal(op.Value, &displayName); err != nil || displayName != teamName {
+			return nil, fmt.Errorf("teams can't be renamed")
+		}
+		return c.service.GetTeam(teamName)
+	default:
+		return nil, fmt.Errorf("unsupported path %q", path)
+	}
+	
+	switch strings.ToLower(op.Op) {
+	case "add":
+		return c.service.UpdateTeamMembers(teamName, scimRefValues(members), nil)
+	case "remove":
+		// remove without value clears the group
+		if len(members) == 0 {
+			return c.service.ReplaceTeamMembers(teamName, nil)
+		}
+		return c.service.UpdateTeamMembers(teamName, nil, scimRefValues(members))
+	case "replace":
+		return c.service.ReplaceTeamMembers(teamName, scimRefValues(members))
+	}
+	return nil, fmt.Errorf("unknown patch op %q", op.Op)
+}
+
+func scimRefValues(refs []scimRef) []string {
+	values := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		values = append(values, ref.Value)
+	}
+	return values
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// TEAM SETTINGS
+
+// GetTeamSettings - GET /team/settings
+func (c *Controller) GetTeamSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	settings, err := c.service.GetTeamSettings(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, settings)
+}
+
+// UpdateTeamSettings - POST /team/settings, fields missing from the body keep their values
+func (c *Controller) UpdateTeamSettings(w http.ResponseWriter, r *http.Request) {
+	var patch json.RawMessage
+	if err := c.parseJSON(r, &patch); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	// decoded once more to reject unknown fields and read the team
+	var req models.TeamSettings
+	decoder := json.NewDecoder(bytes.NewReader(patch))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		c.respondParseError(w, c.bodyError(err))
+		return
+	}
+	if req.TeamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	settings, err := c.service.UpdateTeamSettings(req.TeamName, patch)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, settings)
+}
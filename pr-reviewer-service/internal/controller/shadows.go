@@ -0,0 +1,26 @@
+package controller
+
+import "net/http"
+
+// SetUserJunior - POST /users/setJunior
+func (c *Controller) SetUserJunior(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID   string `json:"user_id"`
+		IsJunior bool   `json:"is_junior"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	user, err := c.service.SetUserJunior(req.UserID, req.IsJunior)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user": user,
+	})
+}
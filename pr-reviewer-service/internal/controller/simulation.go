@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/models"
+)
+
+// SimulateAssignments - POST /simulate/assignments
+func (c *Controller) SimulateAssignments(w http.ResponseWriter, r *http.Request) {
+	var req models.SimulationRequest
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	result, err := c.service.SimulateAssignments(req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, result)
+}
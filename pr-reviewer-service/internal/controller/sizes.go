@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// SIZE RULES
+
+// GetTeamSizeRules - GET /team/sizeRules
+func (c *Controller) GetTeamSizeRules(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	rules, err := c.service.GetTeamSizeRules(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, rules)
+}
+
+// SetTeamSizeRules - POST /team/sizeRules
+func (c *Controller) SetTeamSizeRules(w http.ResponseWriter, r *http.Request) {
+	var req models.TeamSizeRules
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	rules, err := c.service.SetTeamSizeRules(&req)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, rules)
+}
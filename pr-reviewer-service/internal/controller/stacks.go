@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+)
+
+// RegisterStack - POST /pullRequest/stack
+func (c *Controller) RegisterStack(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestIDs []string `json:"pull_request_ids"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	stack, err := c.service.RegisterStack(req.PullRequestIDs)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"base_id": stack[0].BaseID,
+		"stack":   stack,
+	})
+}
+
+// GetStack - GET /pullRequest/stack
+func (c *Controller) GetStack(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		c.respondError(w, errcode.InvalidRequest, "pull_request_id is required")
+		return
+	}
+	
+	stack, err := c.service.GetStack(prID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"base_id": stack[0].BaseID,
+		"stack":   stack,
+	})
+}
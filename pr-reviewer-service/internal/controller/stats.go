@@ -0,0 +1,267 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/metrics"
+	"pr-reviewer-service/internal/service"
+	"strconv"
+)
+
+// REVIEWS
+
+// ReviewAction - POST /review/action
+func (c *Controller) ReviewAction(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		Action        string `json:"action"`
+		Revision      string `json:"revision"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, err := c.service.ReviewAction(req.PullRequestID, req.UserID, req.Action, req.Revision)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr":     pr,
+		"action": req.Action,
+	})
+}
+
+// ReviewStart - POST /review/start
+func (c *Controller) ReviewStart(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	session, err := c.service.StartReview(req.PullRequestID, req.UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"session": session,
+	})
+}
+
+// ReviewFinish - POST /review/finish
+func (c *Controller) ReviewFinish(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	session, err := c.service.FinishReview(req.PullRequestID, req.UserID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"session": session,
+	})
+}
+
+// DeclineReview - POST /review/decline
+func (c *Controller) DeclineReview(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		Reason        string `json:"reason"`
+		Comment       string `json:"comment"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	pr, decline, err := c.service.DeclineReview(req.PullRequestID, req.UserID, req.Reason, req.Comment)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	if _, ok := c.maskPR(w, r, pr); !ok {
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pr":      pr,
+		"decline": decline,
+	})
+}
+
+// GetReviewDecisions - GET /review/decisions
+func (c *Controller) GetReviewDecisions(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		c.respondError(w, errcode.InvalidRequest, "pull_request_id is required")
+		return
+	}
+	
+	decisions, err := c.service.GetReviewDecisions(prID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"decisions":       decisions,
+	})
+}
+
+// STATISTICS
+
+// GetTeamStats - GET /stats/team
+func (c *Controller) GetTeamStats(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	days, ok := c.parsePeriodDays(w, r)
+	if !ok {
+		return
+	}
+	anonymize, ok := c.parseAnonymize(w, r)
+	if !ok {
+		return
+	}
+	
+	stats, err := c.service.GetTeamStats(teamName, days)
+	if err == nil && anonymize {
+		err = c.service.AnonymizeReviewStats(stats)
+	}
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, stats)
+}
+
+// GetUserStats - GET /stats/user
+func (c *Controller) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		c.respondError(w, errcode.InvalidRequest, "user_id is required")
+		return
+	}
+	
+	days, ok := c.parsePeriodDays(w, r)
+	if !ok {
+		return
+	}
+	anonymize, ok := c.parseAnonymize(w, r)
+	if !ok {
+		return
+	}
+	if anonymize {
+		resolved, err := c.service.ResolvePseudonym(userID)
+		if err != nil {
+			c.respondServiceError(w, err)
+			return
+		}
+		userID = resolved
+	}
+	
+	stats, err := c.service.GetUserStats(userID, days)
+	if err == nil && anonymize {
+		err = c.service.AnonymizeReviewStats(stats)
+	}
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, stats)
+}
+
+// GetDeclineStats - GET /stats/declines
+func (c *Controller) GetDeclineStats(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	days, ok := c.parsePeriodDays(w, r)
+	if !ok {
+		return
+	}
+	anonymize, ok := c.parseAnonymize(w, r)
+	if !ok {
+		return
+	}
+	
+	stats, err := c.service.GetDeclineStats(teamName, days)
+	if err == nil && anonymize {
+		err = c.service.AnonymizeDeclineStats(stats)
+	}
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, stats)
+}
+
+// Metrics - GET /metrics
+func (c *Controller) Metrics(w http.ResponseWriter, r *http.Request) {
+	metrics.Handler().ServeHTTP(w, r)
+}
+
+// parseAnonymize decides whether analytics use pseudonyms instead of user ids: asked with
+// anonymize=true, always in anonymized mode and for tokens limited to analytics scope
+func (c *Controller) parseAnonymize(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	anonymize, ok := c.parseFlag(w, r, "anonymize")
+	if !ok {
+		return false, false
+	}
+	if token := TokenFromContext(r.Context()); token != nil && service.AnalyticsOnly(token) {
+		anonymize = true
+	}
+	return anonymize || c.service.AnonymizesAnalytics(), true
+}
+
+// parsePeriodDays reads optional days parameter, default is 30
+func (c *Controller) parsePeriodDays(w http.ResponseWriter, r *http.Request) (int, bool) {
+	raw := r.URL.Query().Get("days")
+	if raw == "" {
+		return 30, true
+	}
+	
+	days, err := strconv.Atoi(raw)
+	if err != nil {
+		c.respondError(w, errcode.InvalidRequest, "days must be a number")
+		return 0, false
+	}
+	return days, true
+}
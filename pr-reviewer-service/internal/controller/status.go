@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/service"
+)
+
+// GetStatus - GET /status
+func (c *Controller) GetStatus(w http.ResponseWriter, r *http.Request) {
+	status := c.service.GetPublicStatus(r.Context())
+	c.respondJSON(w, statusCode(status.Status), status)
+}
+
+// GetStatusDetails - GET /admin/status
+func (c *Controller) GetStatusDetails(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeAdmin, c.statusDetails)(w, r)
+}
+
+func (c *Controller) statusDetails(w http.ResponseWriter, r *http.Request) {
+	status := c.service.GetStatus(r.Context())
+	c.respondJSON(w, statusCode(status.Status), status)
+}
+
+func statusCode(status string) int {
+	if status != service.StatusOK {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+)
+
+// SUBSCRIPTIONS
+
+// MutePR - POST /pullRequest/mute
+func (c *Controller) MutePR(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		Muted         *bool  `json:"muted"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	muted := req.Muted == nil || *req.Muted
+	if err := c.service.MutePR(req.PullRequestID, req.UserID, muted); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": req.PullRequestID,
+		"user_id":         req.UserID,
+		"muted":           muted,
+	})
+}
+
+// WatchPR - POST /pullRequest/watch
+func (c *Controller) WatchPR(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		Watching      *bool  `json:"watching"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	watching := req.Watching == nil || *req.Watching
+	if err := c.service.WatchPR(req.PullRequestID, req.UserID, watching); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": req.PullRequestID,
+		"user_id":         req.UserID,
+		"watching":        watching,
+	})
+}
+
+// GetPRWatchers - GET /pullRequest/watchers
+func (c *Controller) GetPRWatchers(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		c.respondError(w, errcode.InvalidRequest, "pull_request_id is required")
+		return
+	}
+	
+	watchers, err := c.service.GetPRWatchers(prID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"pull_request_id": prID,
+		"watchers":        watchers,
+	})
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+)
+
+// NOTIFICATION TEMPLATES
+
+// GetNotificationTemplates - GET /team/notificationTemplates
+func (c *Controller) GetNotificationTemplates(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		c.respondError(w, errcode.InvalidRequest, "team_name is required")
+		return
+	}
+	
+	templates, err := c.service.GetNotificationTemplates(teamName)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"team_name": teamName,
+		"templates": templates,
+	})
+}
+
+// SetNotificationTemplate - POST /team/notificationTemplates
+func (c *Controller) SetNotificationTemplate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName string `json:"team_name"`
+		Kind     string `json:"kind"`
+		Body     string `json:"body"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	tmpl, err := c.service.SetNotificationTemplate(req.TeamName, req.Kind, req.Body)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"template": tmpl,
+	})
+}
@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"log"
+	"net/http"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/service"
+	"strings"
+)
+
+type tokenContextKey struct{}
+
+// TokenFromContext returns the API token that authenticated the request, nil without one
+func TokenFromContext(ctx context.Context) *models.APIToken {
+	token, _ := ctx.Value(tokenContextKey{}).(*models.APIToken)
+	return token
+}
+
+// RequireScope guards handler with a personal API token granting the scope, without
+// a bearer token a verified client certificate linked to a user authenticates the request.
+// State-changing requests are attributed to the token or certificate in the audit log.
+func (c *Controller) RequireScope(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var token *models.APIToken
+		var err error
+		raw, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		cert := clientCertificate(r)
+		switch {
+		case found:
+			token, err = c.service.AuthenticateToken(strings.TrimSpace(raw), scope)
+		case cert != nil:
+			token, err = c.service.AuthenticateCertificate(certificateNames(cert), scope)
+		default:
+			c.respondError(w, errcode.Unauthorized, "bearer token is required")
+			return
+		}
+		if err != nil {
+			c.respondServiceError(w, err)
+			return
+		}
+	
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			if err := c.service.AuditTokenUse(token, r.Method, r.URL.Path); err != nil {
+				log.Printf("Failed to audit token %d use: %v", token.TokenID, err)
+				c.respondError(w, errcode.Internal, "failed to audit request")
+				return
+			}
+		}
+	
+		setUserLocale(w, token.UserID)
+		next(w, r.WithContext(context.WithValue(r.Context(), tokenContextKey{}, token)))
+	}
+}
+
+// clientCertificate returns the client certificate verified by the TLS handshake, nil without one
+func clientCertificate(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return r.TLS.VerifiedChains[0][0]
+}
+
+// certificateNames lists identities of the certificate in matching order: URI SANs
+// (e.g. SPIFFE ids), DNS and email SANs, then the subject CN
+func certificateNames(cert *x509.Certificate) []string {
+	names := make([]string, 0, len(cert.URIs)+len(cert.DNSNames)+len(cert.EmailAddresses)+1)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	names = append(names, cert.DNSNames...)
+	names = append(names, cert.EmailAddresses...)
+	if cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+	return names
+}
+
+// bootstrapSecretHeader carries the bootstrap secret of a caller without any token yet
+const bootstrapSecretHeader = "X-Bootstrap-Secret"
+
+// WithTokenBootstrap lets a caller holding the secret mint the first token of a user
+// that has none, every other token request needs a token or a client certificate
+func WithTokenBootstrap(secret string) Option {
+	return func(c *Controller) {
+		c.bootstrapSecret = secret
+	}
+}
+
+type mintTokenRequest struct {
+	UserID        string   `json:"user_id"`
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// MintToken - POST /users/tokens
+func (c *Controller) MintToken(w http.ResponseWriter, r *http.Request) {
+	if secret := r.Header.Get(bootstrapSecretHeader); secret != "" {
+		c.mintBootstrapToken(w, r, secret)
+		return
+	}
+	c.RequireScope(service.ScopeAdmin, c.mintToken)(w, r)
+}
+
+func (c *Controller) mintToken(w http.ResponseWriter, r *http.Request) {
+	var req mintTokenRequest
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	caller := TokenFromContext(r.Context())
+	if req.UserID == "" {
+		req.UserID = caller.UserID
+	}
+	token, err := c.service.MintToken(caller, req.UserID, req.Name, req.Scopes, req.ExpiresInDays)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"token": token,
+	})
+}
+
+func (c *Controller) mintBootstrapToken(w http.ResponseWriter, r *http.Request, secret string) {
+	if c.bootstrapSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(c.bootstrapSecret)) != 1 {
+		c.respondError(w, errcode.Unauthorized, "invalid bootstrap secret")
+		return
+	}
+	
+	var req mintTokenRequest
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	token, err := c.service.MintBootstrapToken(req.UserID, req.Name, req.Scopes, req.ExpiresInDays)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusCreated, map[string]interface{}{
+		"token": token,
+	})
+}
+
+// ListTokens - GET /users/tokens
+func (c *Controller) ListTokens(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeAdmin, c.listTokens)(w, r)
+}
+
+func (c *Controller) listTokens(w http.ResponseWriter, r *http.Request) {
+	caller := TokenFromContext(r.Context())
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		userID = caller.UserID
+	}
+	
+	tokens, err := c.service.ListTokens(caller, userID)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id": userID,
+		"tokens":  tokens,
+	})
+}
+
+// RevokeToken - POST /users/tokens/revoke
+func (c *Controller) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeAdmin, c.revokeToken)(w, r)
+}
+
+func (c *Controller) revokeToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID  string `json:"user_id"`
+		TokenID int64  `json:"token_id"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	caller := TokenFromContext(r.Context())
+	if req.UserID == "" {
+		req.UserID = caller.UserID
+	}
+	if err := c.service.RevokeToken(caller, req.UserID, req.TokenID); err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"token_id": req.TokenID,
+		"revoked":  true,
+	})
+}
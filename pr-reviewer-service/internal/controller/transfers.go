@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+	"pr-reviewer-service/internal/service"
+)
+
+// TransferUser - POST /users/transferTeam
+func (c *Controller) TransferUser(w http.ResponseWriter, r *http.Request) {
+	c.RequireScope(service.ScopeReviewActions, c.transferUser)(w, r)
+}
+
+func (c *Controller) transferUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID      string `json:"user_id"`
+		TeamName    string `json:"team_name"`
+		OpenReviews string `json:"open_reviews"`
+	}
+	
+	if err := c.parseJSON(r, &req); err != nil {
+		c.respondParseError(w, err)
+		return
+	}
+	
+	dryRun, ok := c.parseDryRun(w, r)
+	if !ok {
+		return
+	}
+	
+	transfer, err := c.service.TransferUser(TokenFromContext(r.Context()).UserID, req.UserID, req.TeamName, req.OpenReviews, dryRun)
+	if err != nil {
+		c.respondServiceError(w, err)
+		return
+	}
+	
+	c.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"transfer": transfer,
+	})
+}
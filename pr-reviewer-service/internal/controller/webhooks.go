@@ -0,0 +1,241 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"pr-reviewer-service/internal/errcode"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WEBHOOK SIGNATURES
+
+// Inbound webhook sources, keys of webhook secrets
+const (
+	WebhookGitHub = "github"
+	WebhookGitLab = "gitlab"
+	WebhookSlack  = "slack"
+)
+
+// webhookMaxSkew - how old a timestamped delivery may be before it's taken for a replay
+const webhookMaxSkew = 5 * time.Minute
+
+var (
+	errWebhookSignature = errors.New("invalid webhook signature")
+	errWebhookTimestamp = errors.New("webhook timestamp is outside the allowed window")
+)
+
+// webhookDeliveryHeaders - headers with the unique id of each delivery, repeated ids are
+// replays. Slack has none, its signed timestamp bounds replays instead.
+var webhookDeliveryHeaders = map[string]string{
+	WebhookGitHub: "X-GitHub-Delivery",
+	WebhookGitLab: "X-Gitlab-Event-UUID",
+}
+
+// webhookVerifier checks that the delivery was sent by the source holding the secret
+type webhookVerifier func(r *http.Request, body []byte, secret string, now time.Time) error
+
+var webhookVerifiers = map[string]webhookVerifier{
+	WebhookGitHub: verifyGitHub,
+	WebhookGitLab: verifyGitLab,
+	WebhookSlack:  verifySlack,
+}
+
+// WebhookSecretNames - names of webhook secrets in the environment or a secret manager
+var WebhookSecretNames = map[string]string{
+	WebhookGitHub: "WEBHOOK_GITHUB_SECRET",
+	WebhookGitLab: "WEBHOOK_GITLAB_TOKEN",
+	WebhookSlack:  "WEBHOOK_SLACK_SIGNING_SECRET",
+}
+
+// WithWebhookSecrets sets secrets of inbound webhook sources, empty secrets are ignored
+func WithWebhookSecrets(secrets map[string]string) Option {
+	return func(c *Controller) {
+		c.SetWebhookSecrets(secrets)
+	}
+}
+
+// SetWebhookSecrets replaces secrets of inbound webhook sources, e.g. after rotation.
+// Deliveries being verified keep the secrets they started with.
+func (c *Controller) SetWebhookSecrets(secrets map[string]string) {
+	replaced := make(map[string]string, len(secrets))
+	for source, secret := range secrets {
+		if secret != "" {
+			replaced[source] = secret
+		}
+	}
+	c.webhookMu.Lock()
+	c.webhookSecrets = replaced
+	c.webhookMu.Unlock()
+}
+
+// WithUnsignedWebhooks accepts deliveries of sources without a configured secret unverified,
+// e.g. for local development. Without it such deliveries are rejected.
+func WithUnsignedWebhooks() Option {
+	return func(c *Controller) {
+		c.unsignedWebhooks = true
+	}
+}
+
+// WebhookSecretsFrom looks up secrets by their WebhookSecretNames
+func WebhookSecretsFrom(lookup func(name string) string) map[string]string {
+	secrets := make(map[string]string, len(WebhookSecretNames))
+	for source, name := range WebhookSecretNames {
+		secrets[source] = lookup(name)
+	}
+	return secrets
+}
+
+// WebhookSecretsFromEnv reads WEBHOOK_GITHUB_SECRET, WEBHOOK_GITLAB_TOKEN and
+// WEBHOOK_SLACK_SIGNING_SECRET
+func WebhookSecretsFromEnv() map[string]string {
+	return WebhookSecretsFrom(os.Getenv)
+}
+
+// VerifyWebhook guards handler of the source's webhook with its signature check and rejects
+// replayed deliveries. Without a configured secret deliveries are rejected unless
+// WithUnsignedWebhooks is set.
+func (c *Controller) VerifyWebhook(source string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c.webhookMu.RLock()
+		secret := c.webhookSecrets[source]
+		c.webhookMu.RUnlock()
+		if secret == "" {
+			if c.unsignedWebhooks {
+				next(w, r)
+				return
+			}
+			log.Printf("Rejected %s webhook: no secret configured", source)
+			c.respondError(w, errcode.Unauthorized, "webhook secret is not configured")
+			return
+		}
+		verify, ok := webhookVerifiers[source]
+		if !ok {
+			log.Printf("No signature verifier for webhook source %q", source)
+			c.respondError(w, errcode.Internal, "unknown webhook source")
+			return
+		}
+	
+		body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, c.maxBodyBytes))
+		if err != nil {
+			c.respondParseError(w, c.bodyError(err))
+			return
+		}
+	
+		if err := verify(r, body, secret, time.Now()); err != nil {
+			c.respondError(w, errcode.Unauthorized, err.Error())
+			return
+		}
+	
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		c.rejectReplays(source, next)(w, r)
+	}
+}
+
+// rejectReplays lets each signed delivery through once. A delivery that failed on our side
+// is forgotten so the source can retry it.
+func (c *Controller) rejectReplays(source string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		header, ok := webhookDeliveryHeaders[source]
+		if !ok {
+			next(w, r)
+			return
+		}
+		deliveryID := strings.TrimSpace(r.Header.Get(header))
+		if deliveryID == "" {
+			c.respondError(w, errcode.Unauthorized, "webhook delivery id is required")
+			return
+		}
+	
+		recorded, err := c.service.RecordWebhookDelivery(source, deliveryID)
+		if err != nil {
+			c.respondServiceError(w, err)
+			return
+		}
+		if !recorded {
+			c.respondError(w, errcode.WebhookReplayed, "webhook delivery was already received")
+			return
+		}
+	
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next(sw, r)
+		if sw.status >= http.StatusInternalServerError {
+			if err := c.service.ForgetWebhookDelivery(source, deliveryID); err != nil {
+				log.Printf("Failed to forget %s webhook delivery %s: %v", source, deliveryID, err)
+			}
+		}
+	}
+}
+
+// statusWriter remembers the status code written by the handler
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// verifyGitHub checks X-Hub-Signature-256, HMAC-SHA256 of the body
+func verifyGitHub(r *http.Request, body []byte, secret string, _ time.Time) error {
+	signature, found := strings.CutPrefix(r.Header.Get("X-Hub-Signature-256"), "sha256=")
+	if !found {
+		return errWebhookSignature
+	}
+	return checkHMAC(secret, body, signature)
+}
+
+// verifyGitLab compares X-Gitlab-Token, GitLab sends the secret itself instead of a signature
+func verifyGitLab(r *http.Request, _ []byte, secret string, _ time.Time) error {
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Gitlab-Token")), []byte(secret)) != 1 {
+		return errWebhookSignature
+	}
+	return nil
+}
+
+// verifySlack checks X-Slack-Signature over "v0:<timestamp>:<body>", stale timestamps
+// are rejected so a captured request can't be replayed
+func verifySlack(r *http.Request, body []byte, secret string, now time.Time) error {
+	timestamp := r.Header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errWebhookTimestamp
+	}
+	if skew := now.Sub(time.Unix(seconds, 0)); skew > webhookMaxSkew || skew < -webhookMaxSkew {
+		return errWebhookTimestamp
+	}
+	
+	signature, found := strings.CutPrefix(r.Header.Get("X-Slack-Signature"), "v0=")
+	if !found {
+		return errWebhookSignature
+	}
+	base := make([]byte, 0, len(timestamp)+len(body)+4)
+	base = append(base, "v0:"+timestamp+":"...)
+	base = append(base, body...)
+	return checkHMAC(secret, base, signature)
+}
+
+// checkHMAC compares hex signature with HMAC-SHA256 of the message in constant time
+func checkHMAC(secret string, message []byte, signature string) error {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return errWebhookSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(message)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errWebhookSignature
+	}
+	return nil
+}
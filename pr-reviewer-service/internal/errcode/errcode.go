@@ -0,0 +1,129 @@
+package errcode
+
+import "net/http"
+
+// Code - machine-readable error code of API responses, clients match on it rather than on messages
+type Code string
+
+// Error codes, see registry for their numbers and HTTP statuses
+const (
+	InvalidRequest  Code = "INVALID_REQUEST"
+	PayloadTooLarge Code = "PAYLOAD_TOO_LARGE"
+
+	Unauthorized        Code = "UNAUTHORIZED"
+	Forbidden           Code = "FORBIDDEN"
+	LeaderboardDisabled Code = "LEADERBOARD_DISABLED"
+
+	NotFound Code = "NOT_FOUND"
+
+	TeamExists          Code = "TEAM_EXISTS"
+	UserExists          Code = "USER_EXISTS"
+	PRExists            Code = "PR_EXISTS"
+	PRMerged            Code = "PR_MERGED"
+	NotAssigned         Code = "NOT_ASSIGNED"
+	AlreadyAssigned     Code = "ALREADY_ASSIGNED"
+	NoCandidate         Code = "NO_CANDIDATE"
+	ChecklistIncomplete Code = "CHECKLIST_INCOMPLETE"
+	OverCapacity        Code = "OVER_CAPACITY"
+	HandoffClosed       Code = "HANDOFF_CLOSED"
+	NoReviewSession     Code = "NO_REVIEW_SESSION"
+	DependenciesOpen    Code = "DEPENDENCIES_OPEN"
+	IdentityTaken       Code = "IDENTITY_TAKEN"
+	ApprovalsMissing    Code = "APPROVALS_MISSING"
+	FollowUpOpen        Code = "FOLLOW_UP_OPEN"
+	PRNotMerged         Code = "PR_NOT_MERGED"
+	RevertLinked        Code = "REVERT_LINKED"
+	PRClosed            Code = "PR_CLOSED"
+	InvalidTransition   Code = "INVALID_TRANSITION"
+	WebhookReplayed     Code = "WEBHOOK_REPLAYED"
+
+	CalendarDisabled      Code = "CALENDAR_DISABLED"
+	EventsDisabled        Code = "EVENTS_DISABLED"
+	AnonymizationDisabled Code = "ANONYMIZATION_DISABLED"
+	Overloaded            Code = "OVERLOADED"
+
+	Internal Code = "INTERNAL_ERROR"
+)
+
+// Definition - registry entry of an error code
+type Definition struct {
+	Code        Code   `json:"code"`
+	Number      int    `json:"number"`
+	HTTPStatus  int    `json:"http_status"`
+	Description string `json:"description"`
+}
+
+// registry - every code the API returns. Numbers are grouped by kind (1xxx request, 2xxx
+// auth, 3xxx lookup, 4xxx state conflicts, 5xxx unavailable features, 9xxx server errors)
+// and are never reused or renumbered; retired codes keep their entry.
+var registry = []Definition{
+	{InvalidRequest, 1001, http.StatusBadRequest, "request parameters or body failed validation"},
+	{PayloadTooLarge, 1002, http.StatusRequestEntityTooLarge, "request body exceeds the size limit"},
+
+	{Unauthorized, 2001, http.StatusUnauthorized, "credentials are missing, invalid or expired"},
+	{Forbidden, 2002, http.StatusForbidden, "caller isn't allowed to perform the operation"},
+	{LeaderboardDisabled, 2003, http.StatusForbidden, "team hasn't enabled the leaderboard"},
+
+	{NotFound, 3001, http.StatusNotFound, "referenced resource doesn't exist"},
+
+	// TEAM_EXISTS predates the 409 convention and keeps 400 for compatibility
+	{TeamExists, 4001, http.StatusBadRequest, "team with this name already exists"},
+	{UserExists, 4002, http.StatusConflict, "user with this id already exists"},
+	{PRExists, 4003, http.StatusConflict, "pull request with this id already exists"},
+	{PRMerged, 4004, http.StatusConflict, "operation isn't allowed on a merged pull request"},
+	{NotAssigned, 4005, http.StatusConflict, "user isn't assigned as reviewer to the pull request"},
+	{AlreadyAssigned, 4006, http.StatusConflict, "user is already assigned as reviewer to the pull request"},
+	{NoCandidate, 4007, http.StatusConflict, "no eligible reviewer is available"},
+	{ChecklistIncomplete, 4008, http.StatusConflict, "review checklist has unchecked items"},
+	{OverCapacity, 4009, http.StatusConflict, "reviewer has reached the open review limit"},
+	{HandoffClosed, 4010, http.StatusConflict, "handoff is no longer pending"},
+	{NoReviewSession, 4011, http.StatusConflict, "no review session is in progress"},
+	{DependenciesOpen, 4012, http.StatusConflict, "pull request depends on unmerged pull requests"},
+	{IdentityTaken, 4013, http.StatusConflict, "external identity is linked to another user"},
+	{ApprovalsMissing, 4014, http.StatusConflict, "review teams lack required approvals"},
+	{FollowUpOpen, 4015, http.StatusConflict, "pull request already has an open follow-up review"},
+	{PRNotMerged, 4016, http.StatusConflict, "operation is only allowed on a merged pull request"},
+	{RevertLinked, 4017, http.StatusConflict, "pull request is already marked as revert of another pull request"},
+	{PRClosed, 4018, http.StatusConflict, "operation isn't allowed on a closed pull request"},
+	{InvalidTransition, 4019, http.StatusConflict, "pull request can't move to the requested status"},
+	{WebhookReplayed, 4020, http.StatusConflict, "webhook delivery was already received"},
+
+	{CalendarDisabled, 5001, http.StatusServiceUnavailable, "calendar integration is not configured"},
+	{EventsDisabled, 5002, http.StatusServiceUnavailable, "event publishing is not configured"},
+	{AnonymizationDisabled, 5003, http.StatusServiceUnavailable, "pseudonym key is not configured"},
+	{Overloaded, 5004, http.StatusServiceUnavailable, "too many concurrent requests, retry later"},
+
+	{Internal, 9001, http.StatusInternalServerError, "unexpected server error"},
+}
+
+var byCode = func() map[Code]Definition {
+	m := make(map[Code]Definition, len(registry))
+	for _, def := range registry {
+		m[def.Code] = def
+	}
+	return m
+}()
+
+// All returns the registry in number order
+func All() []Definition {
+	return append([]Definition(nil), registry...)
+}
+
+// Lookup returns the definition of the code, false for codes missing from the registry
+func Lookup(code Code) (Definition, bool) {
+	def, ok := byCode[code]
+	return def, ok
+}
+
+// Status returns the HTTP status of the code, 500 for unregistered codes
+func (c Code) Status() int {
+	if def, ok := byCode[c]; ok {
+		return def.HTTPStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// Number returns the numeric identifier of the code, zero for unregistered codes
+func (c Code) Number() int {
+	return byCode[c].Number
+}
@@ -0,0 +1,111 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// Envelope - domain event as delivered to consumers, EventID is stable across replays
+type Envelope struct {
+	EventID       int64                  `json:"event_id"`
+	Type          string                 `json:"type"`
+	PullRequestID string                 `json:"pull_request_id,omitempty"`
+	UserID        string                 `json:"user_id,omitempty"` // set on user events instead of PR id
+	ActorID       string                 `json:"actor_id,omitempty"`
+	Payload       map[string]interface{} `json:"payload"`
+	OccurredAt    time.Time              `json:"occurred_at"`
+	Replay        bool                   `json:"replay"`
+}
+
+func NewEnvelope(event *models.PREvent, replay bool) Envelope {
+	return Envelope{
+		EventID:       event.ID,
+		Type:          event.EventType,
+		PullRequestID: event.PullRequestID,
+		ActorID:       event.ActorID,
+		Payload:       event.Payload,
+		OccurredAt:    event.CreatedAt,
+		Replay:        replay,
+	}
+}
+
+func NewUserEnvelope(event *models.UserEvent) Envelope {
+	return Envelope{
+		EventID:    event.ID,
+		Type:       event.EventType,
+		UserID:     event.UserID,
+		ActorID:    event.ActorID,
+		Payload:    event.Payload,
+		OccurredAt: event.CreatedAt,
+	}
+}
+
+// Publisher - downstream channel of domain events
+type Publisher interface {
+	Publish(ctx context.Context, event Envelope) error
+}
+
+// Config - event webhook settings
+type Config struct {
+	URL string
+}
+
+// ConfigFromEnv reads EVENTS_WEBHOOK_URL
+func ConfigFromEnv() Config {
+	return Config{
+		URL: os.Getenv("EVENTS_WEBHOOK_URL"),
+	}
+}
+
+// Enabled reports whether event webhook is configured
+func (c Config) Enabled() bool {
+	return c.URL != ""
+}
+
+type WebhookPublisher struct {
+	cfg  Config
+	http *http.Client
+}
+
+func NewWebhookPublisher(cfg Config) *WebhookPublisher {
+	return &WebhookPublisher{
+		cfg:  cfg,
+		http: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, event Envelope) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build event request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Failed to close response body: %v", err)
+		}
+	}()
+	
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("event webhook returned %d", resp.StatusCode)
+	}
+	
+	return nil
+}
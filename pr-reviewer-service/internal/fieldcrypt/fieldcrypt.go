@@ -0,0 +1,202 @@
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// KeySize - AES-256 keys, master and data keys alike
+const KeySize = 32
+
+// Stored value prefixes, they tell encrypted and hashed values from plaintext ones
+const (
+	encryptedPrefix = "enc:v1:"
+	indexPrefix     = "hmac:"
+)
+
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// Keyring - master keys by id, they only wrap data keys. The active one wraps new data keys,
+// the others are kept to unwrap data keys until those are rewrapped.
+type Keyring struct {
+	activeID string
+	keys     map[string][]byte
+}
+
+func NewKeyring(activeID string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[activeID]; !ok {
+		return nil, fmt.Errorf("active master key %q is missing", activeID)
+	}
+	for id, key := range keys {
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("master key %q must be %d bytes, got %d", id, KeySize, len(key))
+		}
+	}
+	return &Keyring{activeID: activeID, keys: keys}, nil
+}
+
+// KeyringFromEnv reads PII_MASTER_KEYS, comma-separated "id:base64key" pairs with the active
+// key first, nil when it isn't set
+func KeyringFromEnv() (*Keyring, error) {
+	raw := strings.TrimSpace(os.Getenv("PII_MASTER_KEYS"))
+	if raw == "" {
+		return nil, nil
+	}
+	return ParseKeyring(raw)
+}
+
+// ParseKeyring parses "id:base64key" pairs, the first one is active
+func ParseKeyring(raw string) (*Keyring, error) {
+	keys := make(map[string][]byte)
+	activeID := ""
+	for _, entry := range strings.Split(raw, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" {
+			return nil, errors.New("master keys must be comma-separated id:base64key pairs")
+		}
+		if _, ok := keys[id]; ok {
+			return nil, fmt.Errorf("duplicate master key %q", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("master key %q isn't valid base64: %w", id, err)
+		}
+		keys[id] = key
+		if activeID == "" {
+			activeID = id
+		}
+	}
+	return NewKeyring(activeID, keys)
+}
+
+func (k *Keyring) ActiveID() string {
+	return k.activeID
+}
+
+// Wrap encrypts a data key with the active master key
+func (k *Keyring) Wrap(dataKey []byte) ([]byte, error) {
+	return seal(k.keys[k.activeID], dataKey, []byte(k.activeID))
+}
+
+// Unwrap decrypts a data key wrapped by the master key with the id
+func (k *Keyring) Unwrap(masterKeyID string, wrapped []byte) ([]byte, error) {
+	key, ok := k.keys[masterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: master key %q", ErrUnknownKey, masterKeyID)
+	}
+	return open(key, wrapped, []byte(masterKeyID))
+}
+
+// NewDataKey generates a random data key
+func NewDataKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	return key, nil
+}
+
+// Encrypt seals the value with the data key, the key id and the column are authenticated
+// so a ciphertext can't be moved to another column unnoticed
+func Encrypt(keyID int64, key []byte, column, value string) (string, error) {
+	id := strconv.FormatInt(keyID, 10)
+	sealed, err := seal(key, []byte(value), []byte(column+"/"+id))
+	if err != nil {
+		return "", err
+	}
+	return encryptedPrefix + id + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// KeyID returns id of the data key that encrypted the value, false for plaintext
+func KeyID(stored string) (int64, bool) {
+	rest, ok := strings.CutPrefix(stored, encryptedPrefix)
+	if !ok {
+		return 0, false
+	}
+	id, _, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, false
+	}
+	keyID, err := strconv.ParseInt(id, 10, 64)
+	return keyID, err == nil
+}
+
+// Decrypt opens a value produced by Encrypt with the data key it names
+func Decrypt(key []byte, column, stored string) (string, error) {
+	rest, ok := strings.CutPrefix(stored, encryptedPrefix)
+	if !ok {
+		return "", errors.New("value isn't encrypted")
+	}
+	id, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", errors.New("malformed encrypted value")
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	plain, err := open(key, sealed, []byte(column+"/"+id))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// BlindIndex - keyed hash of the value for equality lookups on encrypted data
+func BlindIndex(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return indexPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsBlindIndex reports whether the stored value is a BlindIndex rather than plaintext
+func IsBlindIndex(stored string) bool {
+	return strings.HasPrefix(stored, indexPrefix)
+}
+
+// seal - AES-GCM with a random nonce prepended to the ciphertext
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(key, sealed, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, errors.New("failed to decrypt: wrong key or tampered value")
+	}
+	return plain, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
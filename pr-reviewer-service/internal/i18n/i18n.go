@@ -0,0 +1,163 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported locales, messages are written in English and translated by catalogs
+const (
+	English = "en"
+	Russian = "ru"
+)
+
+// Default - locale of messages without a catalog entry or a negotiated locale
+const Default = English
+
+// catalogs - translations by locale, keyed by the English message or its fmt format
+var catalogs = map[string]*catalog{
+	Russian: compile(russian),
+}
+
+// Locales lists supported locales, English first
+func Locales() []string {
+	return []string{English, Russian}
+}
+
+// IsSupported reports whether messages can be rendered in the locale
+func IsSupported(locale string) bool {
+	return slices.Contains(Locales(), locale)
+}
+
+// Negotiate picks the preferred supported locale of an Accept-Language header, empty when
+// the header is missing or accepts none of them. Regional tags match their language.
+func Negotiate(acceptLanguage string) string {
+	type preference struct {
+		locale string
+		q      float64
+	}
+	var prefs []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if q > 0 && IsSupported(language) {
+			prefs = append(prefs, preference{locale: language, q: q})
+		}
+	}
+	
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+	if len(prefs) == 0 {
+		return ""
+	}
+	return prefs[0].locale
+}
+
+// Translate returns the message in the locale. Messages built with fmt are matched against
+// catalog formats, their arguments are carried over as is; unknown messages stay in English.
+func Translate(locale, message string) string {
+	c, ok := catalogs[locale]
+	if !ok {
+		return message
+	}
+	if translated, ok := c.exact[message]; ok {
+		return translated
+	}
+	for _, p := range c.patterns {
+		match := p.re.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		args := make([]interface{}, len(match)-1)
+		for i, arg := range match[1:] {
+			args[i] = arg
+		}
+		return fmt.Sprintf(p.format, args...)
+	}
+	return message
+}
+
+// Sprintf formats the catalog translation of the English format, the format itself without one
+func Sprintf(locale, format string, args ...interface{}) string {
+	if c, ok := catalogs[locale]; ok {
+		if translated, ok := c.formats[format]; ok {
+			format = translated
+		}
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// DateTimeLayout - time.Format layout of dates with time in messages of the locale
+func DateTimeLayout(locale string) string {
+	if locale == Russian {
+		return "02.01.2006 15:04 MST"
+	}
+	return "Jan 2 15:04 MST"
+}
+
+type pattern struct {
+	re      *regexp.Regexp
+	format  string // translation with every verb turned into %s, arguments are captured text
+	literal int    // length of the format without verbs, more specific patterns are tried first
+}
+
+type catalog struct {
+	formats  map[string]string // every entry, for Sprintf
+	exact    map[string]string // entries without verbs
+	patterns []pattern         // entries with verbs
+}
+
+// verb matches fmt verbs the catalogs use, optionally with an explicit argument index
+var verb = regexp.MustCompile(`%(\[\d+\])?[sdqv]`)
+
+func compile(entries map[string]string) *catalog {
+	c := &catalog{
+		formats: entries,
+		exact:   make(map[string]string),
+	}
+	for format, translated := range entries {
+		if !verb.MatchString(format) {
+			c.exact[format] = translated
+			continue
+		}
+	
+		p := pattern{format: verb.ReplaceAllString(translated, "%${1}s")}
+		var expr strings.Builder
+		expr.WriteString("^")
+		last := 0
+		for _, loc := range verb.FindAllStringIndex(format, -1) {
+			expr.WriteString(regexp.QuoteMeta(format[last:loc[0]]))
+			p.literal += loc[0] - last
+			switch format[loc[1]-1] {
+			case 'd':
+				expr.WriteString(`(-?\d+)`)
+			case 'q':
+				expr.WriteString(`(".*")`)
+			default:
+				expr.WriteString(`(.+)`)
+			}
+			last = loc[1]
+		}
+		expr.WriteString(regexp.QuoteMeta(format[last:]) + "$")
+		p.literal += len(format) - last
+		p.re = regexp.MustCompile(expr.String())
+		c.patterns = append(c.patterns, p)
+	}
+	
+	sort.Slice(c.patterns, func(i, j int) bool {
+		a, b := c.patterns[i], c.patterns[j]
+		return a.literal > b.literal || (a.literal == b.literal && a.re.String() < b.re.String())
+	})
+	return c
+}
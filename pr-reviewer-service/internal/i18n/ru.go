@@ -0,0 +1,335 @@
+package i18n
+
+// russian - Russian translations of API errors and notifications. Keys are the English
+// messages; messages built by concatenation are keyed with %s in place of the variable part.
+var russian = map[string]string{
+	// request parsing and authentication
+	"invalid JSON":          "некорректный JSON",
+	"request body is empty": "тело запроса пустое",
+	"request body must contain a single JSON value":            "тело запроса должно содержать одно JSON-значение",
+	"request body exceeds %d bytes":                            "тело запроса больше %d байт",
+	"malformed JSON at offset %d: %s":                          "некорректный JSON в позиции %d: %s",
+	"malformed JSON: unexpected end of body":                   "некорректный JSON: неожиданный конец тела",
+	"field %s must be %s, got %s":                              "поле %s должно быть %s, получено %s",
+	"unknown field %s":                                         "неизвестное поле %s",
+	"%s must be true or false":                                 "%s должен быть true или false",
+	"%s must be a number":                                      "%s должен быть числом",
+	"at must be RFC3339 time":                                  "at должен быть временем в формате RFC3339",
+	"bearer token is required":                                 "требуется bearer-токен",
+	"invalid token":                                            "недействительный токен",
+	"invalid, revoked or expired token":                        "токен недействителен, отозван или истёк",
+	"token lacks scope %s":                                     "у токена нет права %s",
+	"certificate principal lacks scope %s":                     "у владельца сертификата нет права %s",
+	"caller lacks scope %s":                                    "у вызывающего нет права %s",
+	"invalid bootstrap secret":                                 "неверный bootstrap-секрет",
+	"certificate principal is missing or inactive":             "владелец сертификата не найден или неактивен",
+	"client certificate is not linked to a user":               "клиентский сертификат не привязан к пользователю",
+	"failed to audit request":                                  "не удалось записать запрос в журнал аудита",
+	"failed to render dashboard":                               "не удалось отрисовать дашборд",
+	"too many concurrent %s requests, retry later":             "слишком много одновременных запросов %s, повторите позже",
+	"unknown webhook source":                                   "неизвестный источник вебхука",
+	"code and state are required":                              "требуются code и state",
+	"calendar access denied: %s":                               "доступ к календарю запрещён: %s",
+	"invalid OAuth state":                                      "неверный параметр state OAuth",
+	"OAuth state expired, connect the calendar again":          "срок действия state OAuth истёк, подключите календарь заново",
+	"OAuth state was already used":                             "параметр state OAuth уже использован",
+	"invalid cursor":                                           "неверный курсор",
+	"cursor belongs to a different sort order":                 "курсор относится к другому порядку сортировки",
+	"order must be asc or desc":                                "order должен быть asc или desc",
+	"sort must be one of created_at, priority, deadline, name": "sort должен быть одним из created_at, priority, deadline, name",
+
+	// required fields
+	"user_id is required":                                "требуется user_id",
+	"team_name is required":                              "требуется team_name",
+	"pull_request_id is required":                        "требуется pull_request_id",
+	"repository_id is required":                          "требуется repository_id",
+	"pull_request_id and user_id are required":           "требуются pull_request_id и user_id",
+	"pull_request_id and depends_on are required":        "требуются pull_request_id и depends_on",
+	"user_id and username are required":                  "требуются user_id и username",
+	"repository and PR number are required":              "требуются репозиторий и номер PR",
+	"update_mask is required":                            "требуется update_mask",
+	"team name is required":                              "требуется название команды",
+	"team is required":                                   "требуется команда",
+	"template name is required":                          "требуется название шаблона",
+	"rule name is required":                              "требуется название правила",
+	"pool_name is required":                              "требуется pool_name",
+	"body is required":                                   "требуется текст",
+	"external_id is required":                            "требуется external_id",
+	"managers are required":                              "требуются руководители",
+	"at least one scope is required":                     "требуется хотя бы одно право",
+	"changed_paths require repository_id":                "changed_paths требуют repository_id",
+	"name is required and must be at most %d characters": "требуется название длиной не более %d символов",
+	"requested_by is required":                           "требуется requested_by",
+	"reverted_id is required":                            "требуется reverted_id",
+	"actor_id is required":                               "требуется actor_id",
+
+	// not found
+	"user not found":                        "пользователь не найден",
+	"team not found":                        "команда не найдена",
+	"pull request not found":                "pull request не найден",
+	"author not found":                      "автор не найден",
+	"reviewer not found":                    "ревьюер не найден",
+	"target user not found":                 "целевой пользователь не найден",
+	"identity not found":                    "учётная запись не найдена",
+	"handoff not found":                     "передача ревью не найдена",
+	"repository not found":                  "репозиторий не найден",
+	"absence not found":                     "отсутствие не найдено",
+	"checklist item not found":              "пункт чеклиста не найден",
+	"dependency not found":                  "зависимость не найдена",
+	"dependency pull request not found":     "pull request зависимости не найден",
+	"stacked_on pull request not found":     "pull request из stacked_on не найден",
+	"archived pull request not found":       "архивный pull request не найден",
+	"active token not found":                "активный токен не найден",
+	"dead job not found":                    "упавшая задача не найдена",
+	"parent_team not found":                 "parent_team не найдена",
+	"pull request %s not found":             "pull request %s не найден",
+	"user %s not found":                     "пользователь %s не найден",
+	"requesting user not found":             "запросивший пользователь не найден",
+	"reverted pull request not found":       "отменяемый pull request не найден",
+	"reviewer pool %s not found in team %s": "пул ревьюеров %s не найден в команде %s",
+
+	// conflicts
+	"team already exists":                                          "команда уже существует",
+	"user already exists":                                          "пользователь уже существует",
+	"pull request already exists":                                  "pull request уже существует",
+	"pull request already exists as %s":                            "pull request уже существует как %s",
+	"pull request is closed":                                       "pull request закрыт",
+	"only PR author, team lead or admin can change PR status":      "сменить статус PR могут только автор, лид команды или администратор",
+	"pull request can't move from %s to %s":                        "pull request нельзя перевести из %s в %s",
+	"user is already in team %s":                                   "пользователь уже в команде %s",
+	"user is not assigned as reviewer to this PR":                  "пользователь не назначен ревьюером этого PR",
+	"user is already assigned as reviewer to this PR":              "пользователь уже назначен ревьюером этого PR",
+	"replaced user is not assigned as reviewer to this PR":         "заменяемый пользователь не назначен ревьюером этого PR",
+	"target is already assigned as reviewer to this PR":            "целевой пользователь уже назначен ревьюером этого PR",
+	"author cannot review own PR":                                  "автор не может ревьюить свой PR",
+	"cannot review merged PR":                                      "нельзя ревьюить смёрженный PR",
+	"cannot assign on merged PR":                                   "нельзя назначать ревьюеров на смёрженный PR",
+	"cannot reassign on merged PR":                                 "нельзя переназначать ревьюеров на смёрженном PR",
+	"cannot add reviewer on merged PR":                             "нельзя добавить ревьюера на смёрженный PR",
+	"cannot volunteer on merged PR":                                "нельзя вызваться ревьюером смёрженного PR",
+	"cannot hand off review on merged PR":                          "нельзя передать ревью смёрженного PR",
+	"cannot add dependency to merged PR":                           "нельзя добавить зависимость смёрженному PR",
+	"cannot add review team to merged PR":                          "нельзя добавить команду ревью смёрженному PR",
+	"cannot pause review of merged PR":                             "нельзя приостановить ревью смёрженного PR",
+	"cannot re-request review of merged PR":                        "нельзя повторно запросить ревью смёрженного PR",
+	"follow-up review is only for merged PRs":                      "повторное ревью доступно только для смёрженных PR",
+	"failed to merge pull request":                                 "не удалось смёржить pull request",
+	"no active replacement candidate available in team":            "в команде нет активного кандидата на замену",
+	"no active reviewer candidate available in team":               "в команде нет активного кандидата в ревьюеры",
+	"no replacement candidate or team lead available in team":      "в команде нет кандидата на замену или тимлида",
+	"user has reached the open review limit":                       "пользователь достиг лимита открытых ревью",
+	"target has reached the open review limit":                     "целевой пользователь достиг лимита открытых ревью",
+	"reviewer is not active":                                       "ревьюер неактивен",
+	"reviewer is not a member of team %s":                          "ревьюер не состоит в команде %s",
+	"target must be an active member of team %s":                   "целевой пользователь должен быть активным участником команды %s",
+	"only active members of team %s can volunteer":                 "вызваться ревьюером могут только активные участники команды %s",
+	"only pending auto-assigned reviewers can be replaced":         "заменить можно только автоматически назначенных ревьюеров, которые ещё не приступили",
+	"reviewer already has a pending handoff on this PR":            "у ревьюера уже есть ожидающая передача ревью этого PR",
+	"handoff is no longer pending":                                 "передача ревью уже не ожидает ответа",
+	"review session is already finished":                           "сессия ревью уже завершена",
+	"no review in progress for this user and PR":                   "у этого пользователя нет идущего ревью этого PR",
+	"%d review checklist items are not checked":                    "не отмечено пунктов чеклиста ревью: %d",
+	"pull request depends on unmerged %s":                          "pull request зависит от несмёрженных %s",
+	"review teams lack required approvals: %s":                     "командам ревью не хватает одобрений: %s",
+	"pull request already has an open follow-up review":            "у pull request уже есть открытое повторное ревью",
+	"user has no open follow-up review of this PR":                 "у пользователя нет открытого повторного ревью этого PR",
+	"sla_hours must be between 0 and %d":                           "sla_hours должен быть от 0 до %d",
+	"pull request can't depend on itself":                          "pull request не может зависеть от самого себя",
+	"pull request can't revert itself":                             "pull request не может отменять сам себя",
+	"pull request already reverts %s":                              "pull request уже отменяет %s",
+	"only a merged pull request can be reverted":                   "отменить можно только смёрженный pull request",
+	"%s already depends on %s":                                     "%s уже зависит от %s",
+	"pull request %s is already stacked":                           "pull request %s уже в стеке",
+	"pull request is not stacked":                                  "pull request не в стеке",
+	"stack needs at least 2 pull requests":                         "в стеке должно быть хотя бы 2 pull request",
+	"pull request has no history":                                  "у pull request нет истории",
+	"pull request has no history at this time":                     "у pull request нет истории на этот момент",
+	"identity is linked to another user":                           "учётная запись привязана к другому пользователю",
+	"identity is not linked":                                       "учётная запись не привязана",
+	"absence is already over":                                      "отсутствие уже закончилось",
+	"author opened %d PRs with a similar name in the last %d days": "автор открыл %d PR с похожим названием за последние %d дн.",
+
+	// permissions
+	"only team lead or admin can do this":                           "это может сделать только тимлид или администратор",
+	"only admin can change roles":                                   "менять роли может только администратор",
+	"only admin can mint admin tokens":                              "выпускать админские токены может только администратор",
+	"only the token owner or an admin can manage tokens":            "управлять токенами может только их владелец или администратор",
+	"user already has a token, bootstrap is only for the first one": "у пользователя уже есть токен, bootstrap выпускает только первый",
+	"only admin can read audit log":                                 "журнал аудита доступен только администратору",
+	"only admin can read archived PR history":                       "читать историю архивных PR может только администратор",
+	"only admin can inspect jobs":                                   "просматривать задачи может только администратор",
+	"only admin can retry jobs":                                     "перезапускать задачи может только администратор",
+	"only admin can replay events":                                  "воспроизводить события может только администратор",
+	"only admin can export events":                                  "выгружать события может только администратор",
+	"only admin can rebuild read models":                            "перестраивать read-модели может только администратор",
+	"only admin can rebuild projections":                            "перестраивать проекции может только администратор",
+	"only admin can rebalance reviews":                              "перебалансировать ревью может только администратор",
+	"only the proposed reviewer can respond to handoff":             "ответить на передачу ревью может только предложенный ревьюер",
+	"only PR author can re-request review":                          "повторно запросить ревью может только автор PR",
+	"unknown actor":                                                 "неизвестный инициатор",
+	"seed is accepted only in non-production mode":                  "seed принимается только вне production-режима",
+
+	// validation
+	"username must not be empty":                                       "username не должен быть пустым",
+	"unknown update_mask field %q":                                     "неизвестное поле update_mask %q",
+	"unknown role %s":                                                  "неизвестная роль %s",
+	"unknown timezone %s":                                              "неизвестный часовой пояс %s",
+	"unknown locale %s":                                                "неизвестный язык %s",
+	"unknown scope %s":                                                 "неизвестное право %s",
+	"unknown size %s":                                                  "неизвестный размер %s",
+	"unknown priority %s":                                              "неизвестный приоритет %s",
+	"unknown review action %s":                                         "неизвестное действие ревью %s",
+	"unknown rule action %s":                                           "неизвестное действие правила %s",
+	"unknown condition field %s":                                       "неизвестное поле условия %s",
+	"unknown decline reason %s":                                        "неизвестная причина отказа %s",
+	"unknown pause reason %s":                                          "неизвестная причина паузы %s",
+	"unknown identity provider %s":                                     "неизвестный провайдер учётных записей %s",
+	"unknown job status %s":                                            "неизвестный статус задачи %s",
+	"unknown notification kind %s":                                     "неизвестный тип уведомления %s",
+	"unknown notification channel %q":                                  "неизвестный канал уведомлений %q",
+	"unknown escalation action %q":                                     "неизвестное действие эскалации %q",
+	"unknown transfer_reviews %s":                                      "неизвестное значение transfer_reviews %s",
+	"unknown no_candidate_fallback %s":                                 "неизвестное значение no_candidate_fallback %s",
+	"invalid settings: %s":                                             "некорректные настройки: %s",
+	"unknown dependency_policy %s":                                     "неизвестное значение dependency_policy %s",
+	"unknown risk signal kind %s":                                      "неизвестный вид признака риска %s",
+	"unknown sort_by %s":                                               "неизвестный sort_by %s",
+	"unknown strategy %s":                                              "неизвестная стратегия %s",
+	"duplicate member %s":                                              "участник %s указан дважды",
+	"notification_address must be an email address":                    "notification_address должен быть адресом электронной почты",
+	"external_id must be an email address":                             "external_id должен быть адресом электронной почты",
+	"tags must be 1 to %d characters":                                  "теги должны быть длиной от 1 до %d символов",
+	"at most %d tags allowed":                                          "допускается не более %d тегов",
+	"at most %d templates allowed":                                     "допускается не более %d шаблонов",
+	"at most %d rules allowed":                                         "допускается не более %d правил",
+	"at most %d risk rules and %d signals allowed":                     "допускается не более %d правил риска и %d признаков",
+	"days must be between 1 and 365":                                   "days должен быть от 1 до 365",
+	"days must be between 1 and %d":                                    "days должен быть от 1 до %d",
+	"count must be between 1 and %d":                                   "count должен быть от 1 до %d",
+	"max_swaps must be between 1 and %d":                               "max_swaps должен быть от 1 до %d",
+	"reviewers must be between 1 and %d":                               "reviewers должен быть от 1 до %d",
+	"reviewers must be between 0 and %d":                               "reviewers должен быть от 0 до %d",
+	"reviewer_count must be between 1 and %d":                          "reviewer_count должен быть от 1 до %d",
+	"shadow_reviewers must be between 0 and %d":                        "shadow_reviewers должен быть от 0 до %d",
+	"expires_in_days must be between 1 and %d":                         "expires_in_days должен быть от 1 до %d",
+	"absence_reserve_days must be between 0 and %d":                    "absence_reserve_days должен быть от 0 до %d",
+	"absence must be at most %d days long":                             "отсутствие может длиться не более %d дней",
+	"batch must contain between 1 and %d pull requests":                "пакет должен содержать от 1 до %d pull request",
+	"rule %s count must be between 1 and %d":                           "count правила %s должен быть от 1 до %d",
+	"min_score must be between 0 and %d":                               "min_score должен быть от 0 до %d",
+	"senior_reviewers must be between 0 and reviewers":                 "senior_reviewers должен быть от 0 до reviewers",
+	"weight must be between 1 and %d":                                  "weight должен быть от 1 до %d",
+	"risk_score must be between 0 and %d":                              "risk_score должен быть от 0 до %d",
+	"year %d has no week %d":                                           "в %d году нет недели %d",
+	"week must look like 2026-W41":                                     "неделя должна выглядеть как 2026-W41",
+	"max_open_reviews must not be negative":                            "max_open_reviews не должен быть отрицательным",
+	"max_daily_assignments must not be negative":                       "max_daily_assignments не должен быть отрицательным",
+	"max_lines must not be negative":                                   "max_lines не должен быть отрицательным",
+	"max_lines must grow with size, only the largest size may omit it": "max_lines должен расти с размером, опустить его можно только у наибольшего размера",
+	"lines_changed must not be negative":                               "lines_changed не должен быть отрицательным",
+	"overdue_hours must not be negative":                               "overdue_hours не должен быть отрицательным",
+	"review_sla_hours must be positive":                                "review_sla_hours должен быть положительным",
+	"lead_escalation_hours must be positive":                           "lead_escalation_hours должен быть положительным",
+	"digest_hour must be between 0 and 23":                             "digest_hour должен быть от 0 до 23",
+	"quiet hours must be between 0 and 23":                             "тихие часы должны быть от 0 до 23",
+	"start_hour and end_hour must differ":                              "start_hour и end_hour должны различаться",
+	"start_hour and end_hour must be set together":                     "start_hour и end_hour задаются вместе",
+	"ends_at must be after starts_at":                                  "ends_at должен быть позже starts_at",
+	"from must be before to":                                           "from должен быть раньше to",
+	"after_id must not be negative":                                    "after_id не должен быть отрицательным",
+	"limit must not be negative":                                       "limit не должен быть отрицательным",
+	"after_id must be a number":                                        "after_id должен быть числом",
+	"%s must be RFC3339 time":                                          "%s должен быть временем в формате RFC3339",
+	"open_reviews must be KEEP or REASSIGN":                            "open_reviews должен быть KEEP или REASSIGN",
+	"parent_team must differ from the team":                            "parent_team должна отличаться от команды",
+	"no_candidate_fallback PARENT_TEAM requires parent_team":           "no_candidate_fallback PARENT_TEAM требует parent_team",
+	"path prefixes must not be empty":                                  "префиксы путей не должны быть пустыми",
+	"labels must not be empty":                                         "метки не должны быть пустыми",
+	"checklist items must not be empty":                                "пункты чеклиста не должны быть пустыми",
+	"holiday date must be YYYY-MM-DD, got %s":                          "дата праздника должна быть в формате YYYY-MM-DD, получено %s",
+	"invalid template: %s":                                             "некорректный шаблон: %s",
+	"invalid policy: %s":                                               "некорректная политика: %s",
+	"policy failed: %s":                                                "ошибка политики: %s",
+	"policy is required for POLICY strategy":                           "для стратегии POLICY нужна политика",
+	"assignment rule %s: %s":                                           "правило назначения %s: %s",
+	"risk rule wanted %d more senior reviewers":                        "правилу риска не хватило опытных ревьюеров: %d",
+	"team %s assigned %d of %d required reviewers":                     "команда %s назначила %d из %d нужных ревьюверов",
+	"team %s assigned %d of %d required reviewers, it has too few eligible members":                "команда %s назначила %d из %d нужных ревьюверов, в ней слишком мало подходящих участников",
+	"team %s assigned %d of %d required reviewers, the other members are already assigned":         "команда %s назначила %d из %d нужных ревьюверов, остальные участники уже назначены",
+	"team %s assigned %d of %d required reviewers, the reviewer pool is too small":                 "команда %s назначила %d из %d нужных ревьюверов, в пуле ревьюверов слишком мало участников",
+	"team %s assigned %d of %d required reviewers, the other members are at their review capacity": "команда %s назначила %d из %d нужных ревьюверов, у остальных участников исчерпан лимит ревью",
+	"team %s assigned %d of %d required reviewers, the other members are in cooldown":              "команда %s назначила %d из %d нужных ревьюверов, остальные участники на паузе после недавних назначений",
+	"team %s assigned %d of %d required reviewers, the other members are going on leave":           "команда %s назначила %d из %d нужных ревьюверов, остальные участники скоро уходят в отсутствие",
+	"team %s assigned %d of %d required reviewers, candidate rules excluded the other members":     "команда %s назначила %d из %d нужных ревьюверов, остальных участников исключили правила отбора",
+	"template %s needs priority or reviewer_pool":                                                  "шаблону %s нужен priority или reviewer_pool",
+	"template %s needs name_prefix or labels":                                                      "шаблону %s нужен name_prefix или labels",
+	"rule %s needs user_id and adds exactly one reviewer":                                          "правилу %s нужен user_id и ровно один ревьюер",
+	"rule %s needs reviewer_pool and no user_id":                                                   "правилу %s нужен reviewer_pool без user_id",
+	"rule %s needs conditions":                                                                     "правилу %s нужны условия",
+	"rule %s has a condition without value":                                                        "у правила %s есть условие без значения",
+	"risk signal value is required":                                                                "требуется значение признака риска",
+	"duplicate user %s":                                                                            "повторяющийся пользователь %s",
+	"duplicate template %s":                                                                        "повторяющийся шаблон %s",
+	"duplicate rule %s":                                                                            "повторяющееся правило %s",
+	"duplicate rule for size %s":                                                                   "повторяющееся правило для размера %s",
+	"duplicate risk rule for min_score %d":                                                         "повторяющееся правило риска для min_score %d",
+	"duplicate risk signal %s":                                                                     "повторяющийся признак риска %s",
+	"duplicate pull request %s":                                                                    "повторяющийся pull request %s",
+	"duplicate holiday %s":                                                                         "повторяющийся праздник %s",
+	"duplicate review team %s":                                                                     "повторяющаяся команда ревью %s",
+	"team_name of review team is required":                                                         "требуется team_name команды ревью",
+	"%s owns the pull request and can't be added as review team":                                   "%s владеет pull request и не может быть командой ревью",
+	"reviewers and required_approvals can't be negative":                                           "reviewers и required_approvals не могут быть отрицательными",
+	"review team %s gets fewer reviewers than required approvals":                                  "команда ревью %s получает меньше ревьюеров, чем требуется одобрений",
+	"user %s is not a member of team %s":                                                           "пользователь %s не состоит в команде %s",
+	"invalid CSV: %s":                                                                              "некорректный CSV: %s",
+	"user %s can't be their own manager":                                                           "пользователь %s не может быть своим руководителем",
+	"user_id must be a pseudonym in anonymized analytics":                                          "в анонимизированной аналитике user_id должен быть псевдонимом",
+
+	// integrations
+	"calendar integration is not configured":             "интеграция с календарём не настроена",
+	"calendar access was granted without offline access": "доступ к календарю выдан без офлайн-доступа",
+	"event publishing is not configured":                 "публикация событий не настроена",
+	"pseudonym key is not configured":                    "ключ псевдонимов не настроен",
+
+	// error code descriptions of GET /errors
+	"request parameters or body failed validation":                     "параметры или тело запроса не прошли проверку",
+	"request body exceeds the size limit":                              "тело запроса превышает допустимый размер",
+	"credentials are missing, invalid or expired":                      "учётные данные отсутствуют, недействительны или истекли",
+	"caller isn't allowed to perform the operation":                    "у вызывающего нет прав на операцию",
+	"referenced resource doesn't exist":                                "указанный ресурс не существует",
+	"team with this name already exists":                               "команда с таким названием уже существует",
+	"user with this id already exists":                                 "пользователь с таким id уже существует",
+	"pull request with this id already exists":                         "pull request с таким id уже существует",
+	"operation isn't allowed on a merged pull request":                 "операция недоступна для смёрженного pull request",
+	"user isn't assigned as reviewer to the pull request":              "пользователь не назначен ревьюером pull request",
+	"user is already assigned as reviewer to the pull request":         "пользователь уже назначен ревьюером pull request",
+	"no eligible reviewer is available":                                "нет подходящего ревьюера",
+	"review checklist has unchecked items":                             "в чеклисте ревью есть неотмеченные пункты",
+	"reviewer has reached the open review limit":                       "ревьюер достиг лимита открытых ревью",
+	"no review session is in progress":                                 "нет идущей сессии ревью",
+	"pull request depends on unmerged pull requests":                   "pull request зависит от несмёрженных pull request",
+	"external identity is linked to another user":                      "внешняя учётная запись привязана к другому пользователю",
+	"review teams lack required approvals":                             "командам ревью не хватает обязательных одобрений",
+	"operation is only allowed on a merged pull request":               "операция доступна только для смёрженного pull request",
+	"pull request is already marked as revert of another pull request": "pull request уже отмечен как отмена другого pull request",
+	"operation isn't allowed on a closed pull request":                 "операция недоступна для закрытого pull request",
+	"pull request can't move to the requested status":                  "pull request нельзя перевести в запрошенный статус",
+	"webhook delivery was already received":                            "эта доставка webhook уже получена",
+	"webhook secret is not configured":                                 "секрет webhook не настроен",
+	"webhook delivery id is required":                                  "нужен id доставки webhook",
+	"team hasn't enabled the leaderboard":                              "команда не включила рейтинг ревьюверов",
+	"too many concurrent requests, retry later":                        "слишком много одновременных запросов, повторите позже",
+	"unexpected server error":                                          "непредвиденная ошибка сервера",
+
+	// notifications
+	"%q (%s) has no approvals %d hours after it was opened, you are added as a reviewer": "%q (%s) без одобрений %d ч после открытия, вы добавлены ревьюером",
+	"You are assigned to review %q (%s), it was waiting for a free reviewer":             "Вам назначено ревью %q (%s), оно ждало свободного ревьюера",
+	"You are assigned to review %q (%s) for team %s":                                     "Вам назначено ревью %q (%s) от команды %s",
+	"You are asked for a follow-up review of merged %q (%s), due %s":                     "Вас просят повторно отревьюить смёрженный %q (%s) до %s",
+	"Follow-up review of %q (%s) was due %s":                                             "Повторное ревью %q (%s) нужно было закончить до %s",
+	"%s assigned from the queue to review %q (%s)":                                       "%s назначены из очереди на ревью %q (%s)",
+	"%d reviewers assigned from the queue to review %q (%s)":                             "ревьюверов назначено из очереди: %d, ревью %q (%s)",
+	"%s asks you to take over review of %q (%s), handoff %d expires %s":                  "%s просит вас взять ревью %q (%s), передача %d истекает %s",
+	"%s declined to take over review of %s":                                              "%s не берёт ревью %s",
+}
@@ -0,0 +1,64 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Generator returns a new ID, it must not repeat one it returned before
+type Generator func() (string, error)
+
+// maxSequence - rand_a of UUIDv7 holds a 12-bit counter within a millisecond
+const maxSequence = 0xFFF
+
+var uuidState struct {
+	mu       sync.Mutex
+	lastMs   int64
+	sequence int
+}
+
+// UUIDv7 returns a time-ordered RFC 9562 UUID. IDs of one process strictly increase: a counter
+// in rand_a orders IDs of the same millisecond, on overflow the timestamp moves a millisecond
+// ahead. The remaining 62 bits are random, so processes don't collide either.
+func UUIDv7() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	
+	uuidState.mu.Lock()
+	ms := time.Now().UnixMilli()
+	if ms > uuidState.lastMs {
+		uuidState.lastMs = ms
+		uuidState.sequence = 0
+	} else if uuidState.sequence < maxSequence {
+		uuidState.sequence++
+	} else {
+		uuidState.lastMs++
+		uuidState.sequence = 0
+	}
+	ms, sequence := uuidState.lastMs, uuidState.sequence
+	uuidState.mu.Unlock()
+	
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> (40 - 8*i))
+	}
+	b[6] = 0x70 | byte(sequence>>8) // version 7
+	b[7] = byte(sequence)
+	b[8] = 0x80 | b[8]&0x3F // RFC 9562 variant
+	
+	var out [36]byte
+	hex.Encode(out[0:8], b[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], b[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], b[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], b[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], b[10:])
+	return string(out[:]), nil
+}
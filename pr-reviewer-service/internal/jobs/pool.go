@@ -0,0 +1,121 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"pr-reviewer-service/internal/models"
+	"sync"
+	"time"
+)
+
+// Queue - persistent job storage used by the pool
+type Queue interface {
+	ClaimJobs(workerID string, limit int, visibility time.Duration) ([]models.Job, error)
+	CompleteJob(id int64) error
+	FailJob(id int64, lastError string, retryAt *time.Time) error
+}
+
+// Handler executes one job, returned error schedules a retry
+type Handler func(ctx context.Context, job models.Job) error
+
+// Config - worker pool settings
+type Config struct {
+	Workers      int
+	PollInterval time.Duration
+	Visibility   time.Duration // claimed job becomes visible again if not finished in time
+	RetryBackoff time.Duration // doubled on every attempt
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Workers:      4,
+		PollInterval: time.Second,
+		Visibility:   5 * time.Minute,
+		RetryBackoff: 30 * time.Second,
+	}
+}
+
+type Pool struct {
+	queue   Queue
+	handler Handler
+	cfg     Config
+	id      string
+}
+
+func NewPool(queue Queue, handler Handler, cfg Config) *Pool {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "worker"
+	}
+	return &Pool{
+		queue:   queue,
+		handler: handler,
+		cfg:     cfg,
+		id:      fmt.Sprintf("%s-%d", host, os.Getpid()),
+	}
+}
+
+// Start runs workers until ctx is cancelled
+func (p *Pool) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < p.cfg.Workers; i++ {
+		wg.Add(1)
+		go func(workerID string) {
+			defer wg.Done()
+			p.loop(ctx, workerID)
+		}(fmt.Sprintf("%s/%d", p.id, i))
+	}
+	wg.Wait()
+}
+
+func (p *Pool) loop(ctx context.Context, workerID string) {
+	ticker := time.NewTicker(p.cfg.PollInterval)
+	defer ticker.Stop()
+	
+	for {
+		claimed, err := p.queue.ClaimJobs(workerID, 1, p.cfg.Visibility)
+		if err != nil {
+			log.Printf("Worker %s failed to claim jobs: %v", workerID, err)
+		}
+		for _, job := range claimed {
+			p.run(ctx, job)
+		}
+	
+		// keep draining while there is work
+		if len(claimed) > 0 && ctx.Err() == nil {
+			continue
+		}
+	
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Pool) run(ctx context.Context, job models.Job) {
+	jobCtx, cancel := context.WithTimeout(ctx, p.cfg.Visibility)
+	defer cancel()
+	
+	err := p.handler(jobCtx, job)
+	if err == nil {
+		if err := p.queue.CompleteJob(job.ID); err != nil {
+			log.Printf("Failed to complete job %d: %v", job.ID, err)
+		}
+		return
+	}
+	
+	var retryAt *time.Time
+	if job.Attempts < job.MaxAttempts {
+		at := time.Now().UTC().Add(p.cfg.RetryBackoff << (job.Attempts - 1))
+		retryAt = &at
+	}
+	log.Printf("Job %d (%s) attempt %d failed: %v", job.ID, job.Kind, job.Attempts, err)
+	
+	if err := p.queue.FailJob(job.ID, err.Error(), retryAt); err != nil {
+		log.Printf("Failed to record job %d failure: %v", job.ID, err)
+	}
+}
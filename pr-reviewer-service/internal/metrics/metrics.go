@@ -0,0 +1,189 @@
+package metrics
+
+import (
+	"log"
+	"net/http"
+	"pr-reviewer-service/internal/models"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_reviewer"
+
+// Registry - all service metrics, exposed by Handler
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		createPRStageDuration,
+		createPRDuration,
+		concurrencyWaiting,
+		concurrencyRejected,
+		cacheRequests,
+	)
+}
+
+// Handler serves metrics in Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// FirstReviewSource - provider of time-to-first-review percentiles
+type FirstReviewSource interface {
+	FirstReviewStats() (byTeam []models.FirstReviewStats, byUser []models.FirstReviewStats, err error)
+}
+
+// RegisterFirstReviewSource exports time-to-first-review gauges computed on every scrape
+func RegisterFirstReviewSource(source FirstReviewSource) error {
+	return Registry.Register(&firstReviewCollector{
+		source: source,
+		teamDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "team", "time_to_first_review_seconds"),
+			"Time from reviewer assignment to the first review action per team.",
+			[]string{"team", "quantile"}, nil,
+		),
+		userDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "user", "time_to_first_review_seconds"),
+			"Time from reviewer assignment to the first review action per user.",
+			[]string{"team", "user_id", "quantile"}, nil,
+		),
+	})
+}
+
+type firstReviewCollector struct {
+	source   FirstReviewSource
+	teamDesc *prometheus.Desc
+	userDesc *prometheus.Desc
+}
+
+func (c *firstReviewCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.teamDesc
+	ch <- c.userDesc
+}
+
+func (c *firstReviewCollector) Collect(ch chan<- prometheus.Metric) {
+	byTeam, byUser, err := c.source.FirstReviewStats()
+	if err != nil {
+		log.Printf("Failed to collect first review stats: %v", err)
+		ch <- prometheus.NewInvalidMetric(c.teamDesc, err)
+		return
+	}
+	
+	for _, st := range byTeam {
+		ch <- prometheus.MustNewConstMetric(c.teamDesc, prometheus.GaugeValue, st.P50Seconds, st.TeamName, "0.5")
+		ch <- prometheus.MustNewConstMetric(c.teamDesc, prometheus.GaugeValue, st.P90Seconds, st.TeamName, "0.9")
+	}
+	for _, st := range byUser {
+		ch <- prometheus.MustNewConstMetric(c.userDesc, prometheus.GaugeValue, st.P50Seconds, st.TeamName, st.UserID, "0.5")
+		ch <- prometheus.MustNewConstMetric(c.userDesc, prometheus.GaugeValue, st.P90Seconds, st.TeamName, st.UserID, "0.9")
+	}
+}
+
+// AssignableSource - provider of per-team counts of reviewers assignable right now
+type AssignableSource interface {
+	AssignableReviewers() (map[string]int, error)
+}
+
+// RegisterAssignableSource exports the assignable reviewer gauge computed on every scrape,
+// a team running out of candidates is about to fail assignments with NO_CANDIDATE
+func RegisterAssignableSource(source AssignableSource) error {
+	return Registry.Register(&assignableCollector{
+		source: source,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "team", "assignable_reviewers"),
+			"Team members automatic assignment can pick right now: active, below caps, not on vacation.",
+			[]string{"team"}, nil,
+		),
+	})
+}
+
+type assignableCollector struct {
+	source AssignableSource
+	desc   *prometheus.Desc
+}
+
+func (c *assignableCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *assignableCollector) Collect(ch chan<- prometheus.Metric) {
+	counts, err := c.source.AssignableReviewers()
+	if err != nil {
+		log.Printf("Failed to collect assignable reviewers: %v", err)
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	
+	for team, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), team)
+	}
+}
+
+var (
+	createPRStageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "create_pr",
+		Name:      "stage_duration_seconds",
+		Help:      "Time spent in each stage of pull request creation.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"stage"})
+
+	createPRDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "create_pr",
+		Name:      "duration_seconds",
+		Help:      "Total time of pull request creation with reviewer assignment.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"result"})
+
+	concurrencyWaiting = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "concurrency_waiting_requests",
+		Help:      "Requests of a concurrency-limited endpoint group waiting for a free slot.",
+	}, []string{"group"})
+
+	concurrencyRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "concurrency_rejected_requests_total",
+		Help:      "Requests of a concurrency-limited endpoint group rejected with 503, reason is queue_full or timeout.",
+	}, []string{"group", "reason"})
+
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Reads of cached aggregates, result is hit, shared (joined a load in flight) or miss.",
+	}, []string{"cache", "result"})
+)
+
+// ObserveCreatePRStage records time one request spent in a creation stage
+func ObserveCreatePRStage(stage string, d time.Duration) {
+	createPRStageDuration.WithLabelValues(stage).Observe(d.Seconds())
+}
+
+// ObserveCreatePR records total creation time, result is "ok" or "error"
+func ObserveCreatePR(result string, d time.Duration) {
+	createPRDuration.WithLabelValues(result).Observe(d.Seconds())
+}
+
+// ConcurrencyWaiting tracks requests queued for a slot of the endpoint group
+func ConcurrencyWaiting(group string, delta float64) {
+	concurrencyWaiting.WithLabelValues(group).Add(delta)
+}
+
+// ConcurrencyRejected counts a request turned away by the endpoint group limit
+func ConcurrencyRejected(group, reason string) {
+	concurrencyRejected.WithLabelValues(group, reason).Inc()
+}
+
+// CacheRequest counts a read of the named aggregate cache
+func CacheRequest(cache, result string) {
+	cacheRequests.WithLabelValues(cache, result).Inc()
+}
@@ -1,12 +1,60 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"pr-reviewer-service/internal/errcode"
+	"time"
+)
 
 type User struct {
-	UserID   string `json:"user_id" db:"user_id"`
-	Username string `json:"username" db:"username"`
-	TeamName string `json:"team_name" db:"team_name"`
-	IsActive bool   `json:"is_active" db:"is_active"`
+	UserID              string     `json:"user_id" db:"user_id"`
+	Username            string     `json:"username" db:"username"`
+	TeamName            string     `json:"team_name" db:"team_name"`
+	IsActive            bool       `json:"is_active" db:"is_active"`
+	Role                string     `json:"role" db:"role"`
+	MaxOpenReviews      *int       `json:"max_open_reviews,omitempty" db:"max_open_reviews"`
+	Timezone            string     `json:"timezone" db:"timezone"`
+	DigestHour          *int       `json:"digest_hour,omitempty" db:"digest_hour"`
+	LastDigestAt        *time.Time `json:"-" db:"last_digest_at"`
+	QuietHoursStart     *int       `json:"quiet_hours_start,omitempty" db:"quiet_hours_start"`
+	QuietHoursEnd       *int       `json:"quiet_hours_end,omitempty" db:"quiet_hours_end"`
+	IsJunior            bool       `json:"is_junior" db:"is_junior"`
+	Region              string     `json:"region,omitempty" db:"region"`
+	MaxDailyAssignments *int       `json:"max_daily_assignments,omitempty" db:"max_daily_assignments"`
+	ManagerID           string     `json:"manager_id,omitempty" db:"manager_id"` // from org structure import, may be outside the service
+	Tags                []string   `json:"tags,omitempty" db:"tags"`
+	NotificationAddress string     `json:"notification_address,omitempty" db:"notification_address"` // where the user wants direct notifications
+	Locale              string     `json:"locale,omitempty" db:"locale"`                             // language of notifications and API errors, the service default if empty
+}
+
+// UserProfile - user fields editable with PATCH /users/{id}
+type UserProfile struct {
+	Username            string   `json:"username"`
+	Tags                []string `json:"tags"`
+	Timezone            string   `json:"timezone"`
+	Role                string   `json:"role"`
+	NotificationAddress string   `json:"notification_address"`
+	Locale              string   `json:"locale"`
+}
+
+// APIToken - personal token, only its hash is stored and the secret is shown once on creation
+type APIToken struct {
+	TokenID     int64      `json:"token_id" db:"token_id"`
+	UserID      string     `json:"user_id" db:"user_id"`
+	Name        string     `json:"name" db:"name"`
+	Scopes      []string   `json:"scopes" db:"scopes"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	Token       string     `json:"token,omitempty"`
+	Certificate string     `json:"certificate,omitempty"` // client certificate name of mTLS principals, they have no token id
+}
+
+// UserManager - org structure entry, empty manager clears it
+type UserManager struct {
+	UserID    string `json:"user_id"`
+	ManagerID string `json:"manager_id"`
 }
 
 type Team struct {
@@ -14,19 +62,219 @@ type Team struct {
 }
 
 type PullRequest struct {
-	PullRequestID     string     `json:"pull_request_id" db:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name" db:"pull_request_name"`
-	AuthorID          string     `json:"author_id" db:"author_id"`
-	Status            string     `json:"status" db:"status"`
-	CreatedAt         time.Time  `json:"createdAt,omitempty" db:"created_at"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty" db:"merged_at"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
+	PullRequestID      string             `json:"pull_request_id" db:"pull_request_id"`
+	PullRequestName    string             `json:"pull_request_name" db:"pull_request_name"`
+	AuthorID           string             `json:"author_id" db:"author_id"`
+	TeamName           string             `json:"team_name" db:"team_name"` // owning team, reviewers come from it
+	RepositoryID       string             `json:"repository_id,omitempty" db:"repository_id"`
+	Status             string             `json:"status" db:"status"`
+	Priority           string             `json:"priority" db:"priority"`
+	Size               string             `json:"size,omitempty" db:"size"`
+	ReviewerPool       string             `json:"reviewer_pool,omitempty" db:"reviewer_pool"`
+	ReviewPhase        int                `json:"review_phase,omitempty"` // 1 or 2 in two-phase review, 0 otherwise
+	CreatedAt          time.Time          `json:"createdAt,omitempty" db:"created_at"`
+	MergedAt           *time.Time         `json:"mergedAt,omitempty" db:"merged_at"`
+	MergedBy           string             `json:"merged_by,omitempty" db:"merged_by"`
+	MergeCommit        string             `json:"merge_commit,omitempty" db:"merge_commit"`
+	MergeURL           string             `json:"merge_url,omitempty" db:"merge_url"`
+	ExternalID         string             `json:"external_id,omitempty" db:"external_id"`   // provider's PR id, unique
+	ExternalURL        string             `json:"external_url,omitempty" db:"external_url"` // provider's PR page, unique
+	AssignedReviewers  []string           `json:"assigned_reviewers"`
+	HiddenReviewers    int                `json:"hidden_reviewers,omitempty"`    // reviewer count shown to the author instead of blind reviewers
+	ShadowReviewers    []string           `json:"shadow_reviewers,omitempty"`    // observers, their approval isn't required
+	DependsOn          []string           `json:"depends_on,omitempty"`          // PRs that have to be merged first
+	Dependents         []string           `json:"dependents,omitempty"`          // PRs waiting for this one
+	OpenDependencies   []string           `json:"open_dependencies,omitempty"`   // set on merge under WARN policy
+	Warnings           []Warning          `json:"warnings,omitempty"`            // set on creation, PR is created anyway
+	Template           string             `json:"template,omitempty"`            // set on creation, PR template that filled the defaults
+	RiskScore          *int               `json:"risk_score,omitempty"`          // set on creation, 0-100, given or computed
+	AssignmentDebug    []SkippedCandidate `json:"assignment_debug,omitempty"`    // set on assignment when debug is requested
+	AssignmentFallback string             `json:"assignment_fallback,omitempty"` // set on reassignment when team's NO_CANDIDATE fallback was used
+	// set on creation when the owning team has fewer active reviewers than needed, the reason is in Warnings
+	AssignmentIncomplete bool `json:"assignment_incomplete,omitempty"`
+}
+
+// SkippedCandidate - team member not picked as reviewer and why
+type SkippedCandidate struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+	Reason   string `json:"reason"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// Warning - non-blocking problem found while handling the request
+type Warning struct {
+	Code    string    `json:"code"`
+	Message string    `json:"message"`
+	Reason  string    `json:"reason,omitempty"` // what the warning comes from, e.g. AT_CAP for ASSIGNMENT_INCOMPLETE
+	Matches []PRMatch `json:"matches,omitempty"`
+}
+
+// PRMatch - existing PR similar to the one being created
+type PRMatch struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	RepositoryID    string    `json:"repository_id,omitempty"`
+	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"created_at"`
+	Similarity      float64   `json:"similarity"` // 1 means identical normalized names
+}
+
+// StackMember - PR in a chain of stacked PRs, the base has position 0 and no parent
+type StackMember struct {
+	PullRequestID string `json:"pull_request_id" db:"pull_request_id"`
+	BaseID        string `json:"base_id" db:"base_id"`
+	ParentID      string `json:"parent_id,omitempty" db:"parent_id"`
+	Position      int    `json:"position" db:"position"`
+}
+
+// PRRevert - PR reverting a merged one, outlives both PRs in the archive for revert rates.
+// Reviewers of the reverted PR are kept for the assigner to prefer.
+type PRRevert struct {
+	PullRequestID    string    `json:"pull_request_id" db:"pull_request_id"`
+	RevertedID       string    `json:"reverted_id" db:"reverted_id"`
+	TeamName         string    `json:"team_name" db:"team_name"` // owning team of the reverted PR
+	RevertedMergedAt time.Time `json:"reverted_merged_at" db:"reverted_merged_at"`
+	Reviewers        []string  `json:"reviewers" db:"reviewers"`
+	MarkedBy         string    `json:"marked_by,omitempty" db:"marked_by"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+}
+
+// RevertStats - team's PRs merged over the period and how many of them were reverted
+type RevertStats struct {
+	Merged     int     `json:"merged"`
+	Reverted   int     `json:"reverted"`
+	RevertRate float64 `json:"revert_rate"`
+}
+
+// ReviewTeam - team reviewing a PR beside the owning team, its approvals are required for merge
+type ReviewTeam struct {
+	PullRequestID     string    `json:"pull_request_id" db:"pull_request_id"`
+	TeamName          string    `json:"team_name" db:"team_name"`
+	RequiredApprovals int       `json:"required_approvals" db:"required_approvals"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// ReviewTeamStatus - progress of a team's reviewer set on a PR
+type ReviewTeamStatus struct {
+	TeamName          string   `json:"team_name"`
+	RequiredApprovals int      `json:"required_approvals"`
+	Approvals         int      `json:"approvals"`
+	Reviewers         []string `json:"reviewers"`
+	Approved          bool     `json:"approved"`
+}
+
+// FollowUp - review of a PR after it was merged, e.g. an emergency merge, due by its own deadline
+type FollowUp struct {
+	ID            int64      `json:"id" db:"id"`
+	PullRequestID string     `json:"pull_request_id" db:"pull_request_id"`
+	UserID        string     `json:"user_id" db:"user_id"` // follow-up reviewer
+	Reason        string     `json:"reason,omitempty" db:"reason"`
+	RequestedBy   string     `json:"requested_by" db:"requested_by"`
+	SLAHours      int        `json:"sla_hours" db:"sla_hours"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	DueAt         time.Time  `json:"due_at" db:"due_at"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	SLAState      string     `json:"sla_state,omitempty"` // set on open follow-ups in listings
+}
+
+// FollowUpRequest - parameters of a follow-up review, the reviewer is picked when UserID is empty
+type FollowUpRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	RequestedBy   string `json:"requested_by"`
+	SLAHours      int    `json:"sla_hours,omitempty"` // team's review SLA when omitted
+}
+
+// PRDependency - PullRequestID can't be merged before DependsOnID
+type PRDependency struct {
+	PullRequestID string    `json:"pull_request_id" db:"pull_request_id"`
+	DependsOnID   string    `json:"depends_on_id" db:"depends_on_id"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// MergeInfo - who merged PR and where the result lives, all optional
+type MergeInfo struct {
+	MergedBy    string `json:"merged_by"`
+	MergeCommit string `json:"merge_commit,omitempty"`
+	MergeURL    string `json:"merge_url,omitempty"`
+}
+
+// ArchivedRow - row of a table referencing an archived PR, kept as it was when archived
+type ArchivedRow struct {
+	SourceTable string          `json:"source_table" db:"source_table"`
+	Row         json.RawMessage `json:"row" db:"row_data"`
+	ArchivedAt  time.Time       `json:"archived_at" db:"archived_at"`
+}
+
+// TeamTransfer - result of moving a user to another team
+type TeamTransfer struct {
+	UserID      string           `json:"user_id"`
+	FromTeam    string           `json:"from_team"`
+	ToTeam      string           `json:"to_team"`
+	OpenReviews string           `json:"open_reviews"` // KEEP or REASSIGN
+	DryRun      bool             `json:"dry_run,omitempty"`
+	Reassigned  []ReviewerChange `json:"reassigned"`
+	Kept        []string         `json:"kept"` // PRs the user still reviews
+}
+
+// ReviewerChange - reviewer replaced on a PR, a dry run lists possible replacements instead
+type ReviewerChange struct {
+	PullRequestID string   `json:"pull_request_id"`
+	NewReviewerID string   `json:"new_reviewer_id,omitempty"`
+	Candidates    []string `json:"candidates,omitempty"`
+}
+
+// MergeBatchItem - PR of a batch merge with its own merge metadata
+type MergeBatchItem struct {
+	PullRequestID string `json:"pull_request_id"`
+	MergeInfo
+}
+
+// MergeResult - outcome of one PR in a batch merge, Error is set instead of PR on failure
+type MergeResult struct {
+	PullRequestID string       `json:"pull_request_id"`
+	Status        string       `json:"status"`
+	PR            *PullRequest `json:"pr,omitempty"`
+	Error         *ErrorDetail `json:"error,omitempty"`
+}
+
+// CreatePullRequestRequest - parameters of a new PR
+type CreatePullRequestRequest struct {
+	PullRequestID   string              `json:"pull_request_id"`
+	PullRequestName string              `json:"pull_request_name"`
+	AuthorID        string              `json:"author_id"`
+	RepositoryID    string              `json:"repository_id,omitempty"` // registered repo, its team owns the PR
+	ExternalID      string              `json:"external_id,omitempty"`   // provider's PR id, another PR with it is a duplicate
+	ExternalURL     string              `json:"external_url,omitempty"`  // provider's PR page, another PR with it is a duplicate
+	ChangedPaths    []string            `json:"changed_paths,omitempty"` // routes review to teams owning the paths
+	Priority        string              `json:"priority,omitempty"`
+	Size            string              `json:"size,omitempty"`          // XS..XL, takes precedence over lines_changed
+	LinesChanged    *int                `json:"lines_changed,omitempty"` // mapped to size by team thresholds
+	RiskScore       *int                `json:"risk_score,omitempty"`    // 0-100, computed from size, paths and labels when omitted
+	ReviewerPool    string              `json:"reviewer_pool,omitempty"` // draw reviewers from this pool instead of the whole team
+	Labels          []string            `json:"labels,omitempty"`        // matched against team's PR templates
+	Seed            *int64              `json:"seed,omitempty"`          // honored only in non-production mode
+	StackedOn       string              `json:"stacked_on,omitempty"`    // parent PR of a stack, its base reviewers are preferred
+	RevertOf        string              `json:"revert_of,omitempty"`     // merged PR this one reverts, its reviewers are preferred
+	ReviewTeams     []ReviewTeamRequest `json:"review_teams,omitempty"`  // other teams that review and approve the PR
+	Debug           bool                `json:"-"`                       // explain skipped candidates in the response
+}
+
+// ReviewTeamRequest - reviewer set of another team, Reviewers defaults to RequiredApprovals
+type ReviewTeamRequest struct {
+	TeamName          string `json:"team_name"`
+	Reviewers         int    `json:"reviewers,omitempty"`
+	RequiredApprovals *int   `json:"required_approvals,omitempty"` // 1 when omitted
 }
 
 type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	Role     string `json:"role,omitempty"`
+	Region   string `json:"region,omitempty"`
 }
 
 type TeamResponse struct {
@@ -34,11 +282,652 @@ type TeamResponse struct {
 	Members  []TeamMember `json:"members"`
 }
 
+// TeamSummary - team list entry
+type TeamSummary struct {
+	TeamName    string    `json:"team_name"`
+	MemberCount int       `json:"member_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Cursor - position of the last row of a page in (created_at, id) order,
+// listings sorted by a non-time column keep its value in Value
+type Cursor struct {
+	CreatedAt time.Time `json:"t,omitzero"`
+	Value     string    `json:"v,omitempty"`
+	ID        string    `json:"id"`
+	Sort      string    `json:"s,omitempty"`
+}
+
+// Page - keyset page request, nil After starts from the first row
+type Page struct {
+	After *Cursor
+	Limit int
+}
+
 type PullRequestShort struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
-	Status          string `json:"status"`
+	PullRequestID    string     `json:"pull_request_id"`
+	PullRequestName  string     `json:"pull_request_name"`
+	AuthorID         string     `json:"author_id"`
+	Status           string     `json:"status"`
+	Priority         string     `json:"priority"`
+	CreatedAt        time.Time  `json:"created_at"`
+	AssignedAt       time.Time  `json:"assigned_at"`
+	Deadline         time.Time  `json:"deadline"`
+	Shadow           bool       `json:"shadow,omitempty"`
+	SLAPausedAt      *time.Time `json:"sla_paused_at,omitempty"`
+	SLAPausedSeconds int64      `json:"-"`
+	DependsOn        []string   `json:"depends_on,omitempty"`
+	Dependents       []string   `json:"dependents,omitempty"`
+}
+
+// PRSort - ordering of PR listings
+type PRSort struct {
+	Field string
+	Desc  bool
+}
+
+// Vacation - period when user can't be assigned as reviewer
+type Vacation struct {
+	ID         int64     `json:"id,omitempty" db:"id"`
+	UserID     string    `json:"user_id" db:"user_id"`
+	StartsAt   time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt     time.Time `json:"ends_at" db:"ends_at"`
+	Source     string    `json:"source" db:"source"`
+	ExternalID string    `json:"external_id,omitempty" db:"external_id"`
+}
+
+// CalendarToken - OAuth tokens of user's connected calendar
+type CalendarToken struct {
+	UserID       string     `db:"user_id"`
+	RefreshToken string     `db:"refresh_token"`
+	AccessToken  string     `db:"access_token"`
+	ExpiresAt    time.Time  `db:"expires_at"`
+	LastSyncedAt *time.Time `db:"last_synced_at"`
+}
+
+// TeamSettings - per-team review policy
+type TeamSettings struct {
+	TeamName            string              `json:"team_name" db:"team_name"`
+	ReviewSLAHours      int                 `json:"review_sla_hours" db:"review_sla_hours"`
+	MaxOpenReviews      *int                `json:"max_open_reviews,omitempty" db:"max_open_reviews"`
+	StrictMerge         bool                `json:"strict_merge" db:"strict_merge"`
+	TwoPhaseReview      bool                `json:"two_phase_review" db:"two_phase_review"`                     // second reviewers wait for first-pass approval
+	ShadowReviewers     int                 `json:"shadow_reviewers" db:"shadow_reviewers"`                     // junior observers added to every new PR
+	MaxDailyAssignments *int                `json:"max_daily_assignments,omitempty" db:"max_daily_assignments"` // new assignments per 24h before cooldown
+	LeadEscalationHours *int                `json:"lead_escalation_hours,omitempty" db:"lead_escalation_hours"` // unapproved PR gets team lead as reviewer
+	DependencyPolicy    string              `json:"dependency_policy" db:"dependency_policy"`                   // NONE, WARN or BLOCK merge with open dependencies
+	TransferReviews     string              `json:"transfer_reviews" db:"transfer_reviews"`                     // KEEP or REASSIGN open reviews of members moving out
+	NotificationRoutes  map[string][]string `json:"notification_routes,omitempty" db:"notification_routes"`     // channels per notification kind, unrouted kinds go everywhere
+	NoCandidateFallback string              `json:"no_candidate_fallback" db:"no_candidate_fallback"`           // what reassignment does when the team has no replacement
+	ParentTeam          string              `json:"parent_team,omitempty" db:"parent_team"`                     // team PARENT_TEAM fallback draws replacements from
+	AbsenceReserveDays  int                 `json:"absence_reserve_days" db:"absence_reserve_days"`             // days before a registered absence without new reviews, 0 is off
+	BlindReview         bool                `json:"blind_review" db:"blind_review"`                             // author sees only the reviewer count until the first approval
+	ReviewerCount       int                 `json:"reviewer_count" db:"reviewer_count"`                         // reviewers of PRs no size rule covers, 0 means 2
+	Leaderboard         bool                `json:"leaderboard" db:"leaderboard"`                               // team opted in to the reviewer leaderboard
+}
+
+// Repository - repo owned by a team, PRs in it are reviewed by that team
+type Repository struct {
+	RepositoryID string    `json:"repository_id" db:"repository_id"`
+	TeamName     string    `json:"team_name" db:"team_name"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// PathOwners - path prefixes of a repository owned by a team
+type PathOwners struct {
+	RepositoryID string   `json:"repository_id"`
+	TeamName     string   `json:"team_name"`
+	Prefixes     []string `json:"prefixes"`
+}
+
+// PullRequestWebhook - code host PR event reduced to the fields the service needs
+type PullRequestWebhook struct {
+	Provider     string
+	Action       string
+	RepositoryID string
+	Number       int
+	ExternalID   string // provider's PR id, stays the same when the repository is renamed
+	ExternalURL  string
+	Title        string
+	AuthorID     string
+	Labels       []string
+	Merged       bool
+	Merge        MergeInfo
+	// RequestedReviewer - reviewer of review_requested, empty when a team was requested
+	RequestedReviewer string
+	Sender            string // account that triggered the event
+}
+
+// ReviewerPool - named subset of a team to draw reviewers from
+type ReviewerPool struct {
+	TeamName string   `json:"team_name"`
+	PoolName string   `json:"pool_name"`
+	Members  []string `json:"members"`
+}
+
+// SizeRule - PRs up to MaxLines changed lines get this size and reviewer count, nil MaxLines has no upper bound
+type SizeRule struct {
+	Size      string `json:"size" db:"size"`
+	MaxLines  *int   `json:"max_lines,omitempty" db:"max_lines"`
+	Reviewers int    `json:"reviewers" db:"reviewers"`
+}
+
+// TeamSizeRules - per-team reviewer count by PR size
+type TeamSizeRules struct {
+	TeamName string     `json:"team_name"`
+	Rules    []SizeRule `json:"rules"`
+}
+
+// RiskRule - PRs scoring at least MinScore get Reviewers reviewers, SeniorReviewers of them not juniors
+type RiskRule struct {
+	MinScore        int `json:"min_score" db:"min_score"`
+	Reviewers       int `json:"reviewers" db:"reviewers"`
+	SeniorReviewers int `json:"senior_reviewers" db:"senior_reviewers"`
+}
+
+// RiskSignal - PR label or changed path prefix adding Weight to the computed risk score
+type RiskSignal struct {
+	Kind   string `json:"kind" db:"kind"` // LABEL or PATH
+	Value  string `json:"value" db:"value"`
+	Weight int    `json:"weight" db:"weight"`
+}
+
+// TeamRiskRules - per-team reviewer count and seniority by PR risk score, they take precedence
+// over size rules when one matches
+type TeamRiskRules struct {
+	TeamName string       `json:"team_name"`
+	Rules    []RiskRule   `json:"rules"`
+	Signals  []RiskSignal `json:"signals"`
+}
+
+// PRTemplate - defaults for PRs matching a name prefix and labels, both conditions are
+// optional but not at once
+type PRTemplate struct {
+	Name         string   `json:"name"`
+	NamePrefix   string   `json:"name_prefix,omitempty"`
+	Labels       []string `json:"labels,omitempty"` // the PR must carry all of them
+	Priority     string   `json:"priority,omitempty"`
+	ReviewerPool string   `json:"reviewer_pool,omitempty"`
+}
+
+// TeamPRTemplates - team's PR templates, the first matching one applies
+type TeamPRTemplates struct {
+	TeamName  string       `json:"team_name"`
+	Templates []PRTemplate `json:"templates"`
+}
+
+// RuleCondition - "field=value" check of a new PR, fields are label, name_prefix, priority,
+// size, repository_id and author_id
+type RuleCondition struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+// AssignmentRule - action taken at PR creation when all conditions hold
+type AssignmentRule struct {
+	Name         string          `json:"name"`
+	Conditions   []RuleCondition `json:"conditions"`
+	Action       string          `json:"action"`                  // ADD_POOL_REVIEWER or ADD_REVIEWER
+	ReviewerPool string          `json:"reviewer_pool,omitempty"` // pool of ADD_POOL_REVIEWER
+	UserID       string          `json:"user_id,omitempty"`       // reviewer of ADD_REVIEWER
+	Count        int             `json:"count,omitempty"`         // pool reviewers to add, 1 by default
+}
+
+// TeamAssignmentRules - team's assignment rules, all matching ones apply in order
+type TeamAssignmentRules struct {
+	TeamName string           `json:"team_name"`
+	Rules    []AssignmentRule `json:"rules"`
+}
+
+// TeamPolicy - team's policy script ranking reviewer candidates, empty source means none
+type TeamPolicy struct {
+	TeamName  string     `json:"team_name"`
+	Source    string     `json:"source"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// TeamChecklist - review checklist instantiated for every new assignment
+type TeamChecklist struct {
+	TeamName string   `json:"team_name"`
+	Items    []string `json:"items"`
+}
+
+// ChecklistItem - checklist entry of one reviewer on a PR
+type ChecklistItem struct {
+	ID        int64      `json:"id" db:"id"`
+	Title     string     `json:"title" db:"title"`
+	Checked   bool       `json:"checked"`
+	CheckedAt *time.Time `json:"checked_at,omitempty" db:"checked_at"`
+}
+
+// MemberCapacity - review load of a single team member
+type MemberCapacity struct {
+	UserID          string     `json:"user_id"`
+	OpenReviews     int        `json:"open_reviews"`
+	MaxOpenReviews  *int       `json:"max_open_reviews,omitempty"`
+	FreeSlots       *int       `json:"free_slots,omitempty"`
+	InCooldown      bool       `json:"in_cooldown,omitempty"`
+	AbsenceStartsAt *time.Time `json:"absence_starts_at,omitempty"` // reserved before an absence, free slots don't count
+}
+
+// TeamCapacity - how many more reviews the team can take, nil slots mean unlimited
+type TeamCapacity struct {
+	TeamName        string           `json:"team_name"`
+	ActiveMembers   int              `json:"active_members"`
+	OpenReviews     int              `json:"open_reviews"`
+	TotalCapacity   *int             `json:"total_capacity"`
+	OpenSlots       *int             `json:"open_slots"`
+	ProjectedIntake float64          `json:"projected_weekly_intake"`
+	Members         []MemberCapacity `json:"members"`
+}
+
+// MemberAvailability - why a team member can or can't get a new review right now,
+// Until is when a vacation or quiet hours end
+type MemberAvailability struct {
+	UserID            string     `json:"user_id"`
+	Username          string     `json:"username"`
+	Status            string     `json:"status"`
+	Assignable        bool       `json:"assignable"`
+	OpenReviews       int        `json:"open_reviews"`
+	MaxOpenReviews    *int       `json:"max_open_reviews,omitempty"`
+	RemainingCapacity *int       `json:"remaining_capacity"` // nil means unlimited
+	AssignedLast24h   int        `json:"assigned_last_24h"`
+	Until             *time.Time `json:"until,omitempty"`
+	AbsenceStartsAt   *time.Time `json:"absence_starts_at,omitempty"` // upcoming absence the member is reserved for
+}
+
+// TeamAvailability - current availability of every team member
+type TeamAvailability struct {
+	TeamName   string               `json:"team_name"`
+	Assignable int                  `json:"assignable"`
+	Members    []MemberAvailability `json:"members"`
+}
+
+// EscalationRule - action taken when review is OverdueHours past its deadline
+type EscalationRule struct {
+	ID           int64  `json:"id" db:"id"`
+	TeamName     string `json:"team_name" db:"team_name"`
+	OverdueHours int    `json:"overdue_hours" db:"overdue_hours"`
+	Action       string `json:"action" db:"action"`
+}
+
+// StalledPR - open PR without approvals past its team's lead escalation threshold
+type StalledPR struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	TeamName        string
+	CreatedAt       time.Time
+	ThresholdHours  int
+}
+
+// ReviewDecision - immutable record of a reviewer's verdict on a PR revision
+type ReviewDecision struct {
+	ID            int64     `json:"id" db:"id"`
+	PullRequestID string    `json:"pull_request_id" db:"pull_request_id"`
+	UserID        string    `json:"user_id" db:"user_id"`
+	Decision      string    `json:"decision" db:"decision"`
+	Revision      string    `json:"revision,omitempty" db:"revision"` // client's marker, e.g. head commit
+	DecidedAt     time.Time `json:"decided_at" db:"decided_at"`
+}
+
+// PRReviewer - state of one reviewer's assignment
+type PRReviewer struct {
+	PullRequestID  string     `json:"pull_request_id" db:"pull_request_id"`
+	UserID         string     `json:"user_id" db:"user_id"`
+	Status         string     `json:"status" db:"status"`
+	AssignmentType string     `json:"assignment_type" db:"assignment_type"`
+	TeamName       string     `json:"team_name,omitempty" db:"team_name"` // team the reviewer represents, empty means the owning team
+	AssignedAt     time.Time  `json:"assigned_at" db:"assigned_at"`
+	FirstActionAt  *time.Time `json:"first_action_at,omitempty" db:"first_action_at"`
+	// SLA clock pauses, time paused before the first action doesn't count toward turnaround
+	SLAPausedAt              *time.Time `json:"sla_paused_at,omitempty" db:"sla_paused_at"`
+	SLAPausedSeconds         int64      `json:"sla_paused_seconds,omitempty" db:"sla_paused_seconds"`
+	FirstActionPausedSeconds int64      `json:"first_action_paused_seconds,omitempty" db:"first_action_paused_seconds"`
+}
+
+// PRState - PR projection folded from its timeline events
+type PRState struct {
+	PullRequest PullRequest  `json:"pr"`
+	Reviewers   []PRReviewer `json:"reviewers"`
+	Version     int64        `json:"version"` // id of the last applied event
+}
+
+// ReviewerHandoff - reviewer's proposal to pass the review to a teammate
+type ReviewerHandoff struct {
+	ID            int64      `json:"id" db:"id"`
+	PullRequestID string     `json:"pull_request_id" db:"pull_request_id"`
+	FromUserID    string     `json:"from_user_id" db:"from_user_id"`
+	ToUserID      string     `json:"to_user_id" db:"to_user_id"`
+	Status        string     `json:"status" db:"status"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt     time.Time  `json:"expires_at" db:"expires_at"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// PendingPhase - two-phase PR whose first pass is approved and second reviewers are due
+type PendingPhase struct {
+	PullRequestID        string
+	PullRequestName      string
+	AuthorID             string
+	TeamName             string
+	Priority             string
+	SecondPhaseReviewers int
+}
+
+// PendingAssignment - reviewers an open PR still waits for because nobody in the team was eligible
+type PendingAssignment struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	TeamName        string    `json:"team_name"`
+	Priority        string    `json:"priority"`
+	Reviewers       int       `json:"reviewers"`
+	QueuedAt        time.Time `json:"queued_at"`
+}
+
+// ReviewAssignment - reviewer assigned to an open PR
+type ReviewAssignment struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	ReviewerID      string    `json:"reviewer_id"`
+	TeamName        string    `json:"team_name"` // team the reviewer represents, its settings drive the SLA
+	AssignedAt      time.Time `json:"assigned_at"`
+	ReviewerTeam    string    `json:"reviewer_team"`
+	ReviewerRegion  string    `json:"reviewer_region,omitempty"`
+	// paused SLA clock moves the deadline, see pausedDeadline
+	SLAPausedAt      *time.Time `json:"sla_paused_at,omitempty"`
+	SLAPausedSeconds int64      `json:"sla_paused_seconds,omitempty"`
+}
+
+// AuditEntry - privileged action performed by a user
+type AuditEntry struct {
+	ID            int64                  `json:"id" db:"id"`
+	ActorID       string                 `json:"actor_id" db:"actor_id"`
+	Action        string                 `json:"action" db:"action"`
+	PullRequestID string                 `json:"pull_request_id,omitempty" db:"pull_request_id"`
+	Details       map[string]interface{} `json:"details" db:"details"`
+	CreatedAt     time.Time              `json:"created_at" db:"created_at"`
+}
+
+// PREventFilter - selects stored PR events, zero fields match everything
+type PREventFilter struct {
+	PullRequestID string    `json:"pull_request_id,omitempty"`
+	From          time.Time `json:"from,omitempty"`
+	To            time.Time `json:"to,omitempty"`
+}
+
+// Job - unit of background work in the persistent queue
+type Job struct {
+	ID          int64           `json:"id" db:"id"`
+	Kind        string          `json:"kind" db:"kind"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	Status      string          `json:"status" db:"status"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	MaxAttempts int             `json:"max_attempts" db:"max_attempts"`
+	RunAt       time.Time       `json:"run_at" db:"run_at"`
+	LockedBy    string          `json:"locked_by,omitempty" db:"locked_by"`
+	LockedUntil *time.Time      `json:"locked_until,omitempty" db:"locked_until"`
+	LastError   string          `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// SchedulerRun - last run of a scheduler job, empty error means it succeeded
+type SchedulerRun struct {
+	JobName    string    `json:"job_name" db:"job_name"`
+	Holder     string    `json:"holder" db:"holder"`
+	StartedAt  time.Time `json:"started_at" db:"started_at"`
+	FinishedAt time.Time `json:"finished_at" db:"finished_at"`
+	LastError  string    `json:"last_error,omitempty" db:"last_error"`
+}
+
+// QueueDepth - work waiting in one queue, OldestAt is when the oldest pending item became due
+type QueueDepth struct {
+	Name     string     `json:"name"`
+	Pending  int        `json:"pending"`
+	Running  int        `json:"running"`
+	Dead     int        `json:"dead"`
+	OldestAt *time.Time `json:"oldest_at,omitempty"`
+}
+
+// DatabaseStatus - result of the status page database check
+type DatabaseStatus struct {
+	Connected bool    `json:"connected"`
+	LatencyMs float64 `json:"latency_ms"`
+}
+
+// PublicStatus - the only part of the status page shown without authentication
+type PublicStatus struct {
+	Status string `json:"status"` // OK or DEGRADED
+}
+
+// ServiceStatus - service internals shown on the status page
+type ServiceStatus struct {
+	Status         string         `json:"status"` // OK or DEGRADED
+	Version        string         `json:"version"`
+	StartedAt      time.Time      `json:"started_at"`
+	UptimeSeconds  int64          `json:"uptime_seconds"`
+	Database       DatabaseStatus `json:"database"`
+	Queues         []QueueDepth   `json:"queues"`
+	Scheduler      []SchedulerRun `json:"scheduler"`
+	WebhookBacklog QueueDepth     `json:"webhook_backlog"` // outbound event and notification deliveries
+}
+
+// PRComment - comment left on a PR
+type PRComment struct {
+	ID            int64     `json:"id" db:"id"`
+	PullRequestID string    `json:"pull_request_id" db:"pull_request_id"`
+	AuthorID      string    `json:"author_id" db:"author_id"`
+	Body          string    `json:"body" db:"body"`
+	Mentions      []string  `json:"mentions"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// PREvent - entry of the PR timeline
+type PREvent struct {
+	ID            int64                  `json:"id" db:"id"`
+	PullRequestID string                 `json:"pull_request_id" db:"pull_request_id"`
+	EventType     string                 `json:"event_type" db:"event_type"`
+	ActorID       string                 `json:"actor_id,omitempty" db:"actor_id"`
+	Payload       map[string]interface{} `json:"payload,omitempty" db:"payload"`
+	CreatedAt     time.Time              `json:"created_at" db:"created_at"`
+}
+
+// UserEvent - domain event about a user rather than a PR, ids are shared with PR events
+type UserEvent struct {
+	ID        int64                  `json:"id" db:"id"`
+	UserID    string                 `json:"user_id" db:"user_id"`
+	EventType string                 `json:"event_type" db:"event_type"`
+	ActorID   string                 `json:"actor_id,omitempty" db:"actor_id"`
+	Payload   map[string]interface{} `json:"payload,omitempty" db:"payload"`
+	CreatedAt time.Time              `json:"created_at" db:"created_at"`
+}
+
+// UserIdentity - external account (GitHub login, GitLab username, email) linked to a user
+type UserIdentity struct {
+	UserID     string    `json:"user_id" db:"user_id"`
+	Provider   string    `json:"provider" db:"provider"`
+	ExternalID string    `json:"external_id" db:"external_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Notification - in-app message for a user
+type Notification struct {
+	ID            int64      `json:"id" db:"id"`
+	UserID        string     `json:"user_id" db:"user_id"`
+	Kind          string     `json:"kind" db:"kind"`
+	PullRequestID string     `json:"pull_request_id,omitempty" db:"pull_request_id"`
+	Message       string     `json:"message" db:"message"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	DeliverAfter  *time.Time `json:"-" db:"deliver_after"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+	ReadAt        *time.Time `json:"read_at,omitempty" db:"read_at"`
+}
+
+// FirstReviewStats - time from assignment to reviewer's first action
+type FirstReviewStats struct {
+	TeamName   string  `json:"team_name,omitempty"`
+	UserID     string  `json:"user_id,omitempty"`
+	Samples    int     `json:"samples"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P90Seconds float64 `json:"p90_seconds"`
+}
+
+// ReviewStats - review statistics of a team or a user over PeriodDays
+type ReviewStats struct {
+	TeamName          string           `json:"team_name,omitempty"`
+	UserID            string           `json:"user_id,omitempty"`
+	PeriodDays        int              `json:"period_days"`
+	TimeToFirstReview FirstReviewStats `json:"time_to_first_review"`
+	ReviewTime        ReviewTimeStats  `json:"review_time"`
+	Reverts           *RevertStats     `json:"reverts,omitempty"` // team stats only
+}
+
+// LeaderboardEntry - reviewer's results over the leaderboard period
+type LeaderboardEntry struct {
+	Rank                    int      `json:"rank"`
+	UserID                  string   `json:"user_id"`
+	Username                string   `json:"username,omitempty"`
+	CompletedReviews        int      `json:"completed_reviews"`         // assignments approved
+	MedianTurnaroundSeconds *float64 `json:"median_turnaround_seconds"` // assignment to first action, null without actions
+	RevertedApprovals       int      `json:"reverted_approvals"`
+	ApprovalQuality         *float64 `json:"approval_quality"` // share of approved PRs not reverted, null without approvals
+}
+
+// Leaderboard - active team members ranked by the chosen metric
+type Leaderboard struct {
+	TeamName   string             `json:"team_name"`
+	PeriodDays int                `json:"period_days"`
+	SortBy     string             `json:"sort_by"`
+	Entries    []LeaderboardEntry `json:"entries"`
+}
+
+// AssignmentDecline - reviewer's refusal of an assignment, outlives the archived PR
+type AssignmentDecline struct {
+	ID            int64     `json:"id" db:"id"`
+	PullRequestID string    `json:"pull_request_id" db:"pull_request_id"`
+	UserID        string    `json:"user_id" db:"user_id"`
+	TeamName      string    `json:"team_name" db:"team_name"`
+	Reason        string    `json:"reason" db:"reason"`
+	Comment       string    `json:"comment,omitempty" db:"comment"`
+	ReplacedBy    string    `json:"replaced_by" db:"replaced_by"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// DeclineCount - declines of one reviewer for one reason
+type DeclineCount struct {
+	UserID string
+	Reason string
+	Count  int
+}
+
+// ReviewerDeclines - declines of a team member by reason
+type ReviewerDeclines struct {
+	UserID   string         `json:"user_id"`
+	Total    int            `json:"total"`
+	ByReason map[string]int `json:"by_reason"`
+}
+
+// DeclineStats - team's declined assignments over PeriodDays, reviewers with most declines first
+type DeclineStats struct {
+	TeamName   string             `json:"team_name"`
+	PeriodDays int                `json:"period_days"`
+	Total      int                `json:"total"`
+	ByReason   map[string]int     `json:"by_reason"`
+	Reviewers  []ReviewerDeclines `json:"reviewers"`
+}
+
+// Holiday - day off in team calendar, empty region applies to the whole team
+type Holiday struct {
+	Date   string `json:"date"` // YYYY-MM-DD, UTC day
+	Region string `json:"region,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// TeamHolidays - team holiday calendar
+type TeamHolidays struct {
+	TeamName string    `json:"team_name"`
+	Holidays []Holiday `json:"holidays"`
+}
+
+// ReviewSession - time a reviewer actually spent on PR between /review/start and /review/finish
+type ReviewSession struct {
+	SessionID     int64      `json:"session_id" db:"session_id"`
+	PullRequestID string     `json:"pull_request_id" db:"pull_request_id"`
+	UserID        string     `json:"user_id" db:"user_id"`
+	StartedAt     time.Time  `json:"started_at" db:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// ReviewTimeStats - tracked review time of finished sessions
+type ReviewTimeStats struct {
+	TeamName            string  `json:"team_name,omitempty"`
+	UserID              string  `json:"user_id,omitempty"`
+	Sessions            int     `json:"sessions"`
+	Reviews             int     `json:"reviews"`
+	TotalSeconds        float64 `json:"total_seconds"`
+	AvgSecondsPerReview float64 `json:"avg_seconds_per_review"`
+}
+
+// ReviewerBottleneck - reviewer holding many open reviews
+type ReviewerBottleneck struct {
+	UserID           string    `json:"user_id"`
+	OpenReviews      int       `json:"open_reviews"`
+	OldestAssignedAt time.Time `json:"oldest_assigned_at"`
+}
+
+// TeamReport - weekly summary of team's PR flow
+type TeamReport struct {
+	TeamName           string               `json:"team_name"`
+	Week               string               `json:"week"`
+	From               time.Time            `json:"from"`
+	To                 time.Time            `json:"to"`
+	PRsCreated         int                  `json:"prs_created"`
+	PRsMerged          int                  `json:"prs_merged"`
+	Assignments        int                  `json:"assignments"`
+	AvgTurnaroundHours *float64             `json:"avg_turnaround_hours"`
+	Bottlenecks        []ReviewerBottleneck `json:"bottlenecks"`
+}
+
+// NotificationTemplate - Go text/template of a notification kind
+type NotificationTemplate struct {
+	TeamName  string `json:"team_name"`
+	Kind      string `json:"kind"`
+	Body      string `json:"body"`
+	IsDefault bool   `json:"is_default"`
+}
+
+// BoardReviewer - reviewer of an open PR and their review deadline
+type BoardReviewer struct {
+	UserID      string     `json:"user_id"`
+	AssignedAt  time.Time  `json:"assigned_at"`
+	Deadline    time.Time  `json:"deadline"`
+	SLAState    string     `json:"sla_state"`
+	SLAPausedAt *time.Time `json:"sla_paused_at,omitempty"`
+}
+
+// BoardPR - open PR on a team board, SLA state is the worst of its reviewers
+type BoardPR struct {
+	PullRequestID   string          `json:"pull_request_id"`
+	PullRequestName string          `json:"pull_request_name"`
+	AuthorID        string          `json:"author_id"`
+	Priority        string          `json:"priority"`
+	CreatedAt       time.Time       `json:"created_at"`
+	WaitingMinutes  int             `json:"waiting_minutes"`
+	SLAState        string          `json:"sla_state"`
+	Reviewers       []BoardReviewer `json:"reviewers"`
+}
+
+// TeamBoard - open PRs of a team as of GeneratedAt, oldest first
+type TeamBoard struct {
+	TeamName     string    `json:"team_name"`
+	GeneratedAt  time.Time `json:"generated_at"`
+	OpenPRs      int       `json:"open_prs"`
+	Breached     int       `json:"breached"`
+	PullRequests []BoardPR `json:"pull_requests"`
 }
 
 type ErrorResponse struct {
@@ -46,6 +935,99 @@ type ErrorResponse struct {
 }
 
 type ErrorDetail struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
\ No newline at end of file
+	Code    errcode.Code `json:"code"`
+	Number  int          `json:"number,omitempty"` // stable numeric id of the code, see GET /errors
+	Message string       `json:"message"`
+	Field   string       `json:"field,omitempty"` // offending request field, when known
+}
+
+// SimulationRequest - how to replay the team's recent PR creations, empty fields keep the
+// team's current configuration
+type SimulationRequest struct {
+	TeamName       string `json:"team_name"`
+	Days           int    `json:"days,omitempty"`             // 30 when omitted
+	Strategy       string `json:"strategy,omitempty"`         // RANDOM, LEAST_LOADED or POLICY, team's current when omitted
+	Policy         string `json:"policy,omitempty"`           // POLICY script to try instead of the team's one
+	Reviewers      int    `json:"reviewers,omitempty"`        // per PR, the count each PR actually got when omitted
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"` // team cap to try, personal caps still apply
+	Seed           *int64 `json:"seed,omitempty"`             // same seed replays the same picks
+}
+
+// SimulatedPR - PR creation replayed by the simulation, open until its actual merge
+type SimulatedPR struct {
+	PullRequestID string     `json:"pull_request_id" db:"pull_request_id"`
+	AuthorID      string     `json:"author_id" db:"author_id"`
+	Priority      string     `json:"priority" db:"priority"`
+	Size          string     `json:"size" db:"size"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	MergedAt      *time.Time `json:"merged_at,omitempty" db:"merged_at"`
+	ClosedAt      *time.Time `json:"closed_at,omitempty" db:"closed_at"`
+	Reviewers     []string   `json:"reviewers" db:"reviewers"` // owning team reviewers it actually got
+}
+
+// SimulatedLoad - reviews one member would have got compared to what they actually got
+type SimulatedLoad struct {
+	UserID            string `json:"user_id"`
+	Assignments       int    `json:"assignments"`
+	ActualAssignments int    `json:"actual_assignments"`
+	PeakOpenReviews   int    `json:"peak_open_reviews"`
+}
+
+// SimulationResult - hypothetical load distribution of a replay, nothing is persisted
+type SimulationResult struct {
+	TeamName          string          `json:"team_name"`
+	Days              int             `json:"days"`
+	Strategy          string          `json:"strategy"`
+	PullRequests      int             `json:"pull_requests"`
+	Unassigned        int             `json:"unassigned"`         // reviewer slots nobody could take
+	AssignmentsStdDev float64         `json:"assignments_stddev"` // spread of simulated assignments over members
+	ActualStdDev      float64         `json:"actual_stddev"`      // the same for actual assignments
+	Load              []SimulatedLoad `json:"load"`
+}
+
+// RebalanceRequest - admin rebalancing of team's open reviews
+type RebalanceRequest struct {
+	ActorID  string `json:"actor_id"`
+	TeamName string `json:"team_name"`
+	MaxSwaps int    `json:"max_swaps,omitempty"` // 10 when omitted
+	DryRun   bool   `json:"dry_run,omitempty"`   // only report the swaps
+}
+
+// RebalanceSwap - review moved from a loaded member to a less loaded one
+type RebalanceSwap struct {
+	PullRequestID string `json:"pull_request_id"`
+	FromUserID    string `json:"from_user_id"`
+	ToUserID      string `json:"to_user_id"`
+}
+
+// RebalanceLoad - open reviews of a member before and after rebalancing
+type RebalanceLoad struct {
+	UserID string `json:"user_id"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+type RebalanceResult struct {
+	TeamName     string          `json:"team_name"`
+	DryRun       bool            `json:"dry_run"`
+	Swaps        []RebalanceSwap `json:"swaps"`
+	Load         []RebalanceLoad `json:"load"`
+	StdDevBefore float64         `json:"stddev_before"`
+	StdDevAfter  float64         `json:"stddev_after"`
+	Error        *ErrorDetail    `json:"error,omitempty"` // a swap failed, Swaps and Load reflect the ones made before it
+}
+
+// TeamBootstrap - team created in one call with its members and configuration
+type TeamBootstrap struct {
+	ActorID  string        `json:"actor_id,omitempty"` // admin or a lead among members, required with a policy
+	TeamName string        `json:"team_name"`
+	Members  []TeamMember  `json:"members"`
+	Settings *TeamSettings `json:"settings,omitempty"` // defaults when omitted, notification_routes register channels
+	Policy   string        `json:"policy,omitempty"`   // policy script ranking reviewers
+}
+
+type TeamBootstrapResult struct {
+	Team     TeamResponse  `json:"team"`
+	Settings *TeamSettings `json:"settings"`
+	Policy   *TeamPolicy   `json:"policy,omitempty"`
+}
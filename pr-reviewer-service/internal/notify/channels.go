@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const channelTimeout = 10 * time.Second
+
+// SlackNotifier posts to a Slack incoming webhook
+type SlackNotifier struct {
+	name string
+	url  string
+	http *http.Client
+}
+
+func NewSlackNotifier(name, webhookURL string) *SlackNotifier {
+	return &SlackNotifier{name: name, url: webhookURL, http: &http.Client{Timeout: channelTimeout}}
+}
+
+func (n *SlackNotifier) Name() string {
+	return n.name
+}
+
+// Notify mentions the recipient when they have a linked Slack member id
+func (n *SlackNotifier) Notify(ctx context.Context, msg Message) error {
+	recipient := msg.UserID
+	if memberID := msg.Identities["slack"]; memberID != "" {
+		recipient = "<@" + memberID + ">"
+	}
+	return postJSON(ctx, n.http, n.url, map[string]interface{}{
+		"text": fmt.Sprintf("*%s* for %s: %s", msg.Kind, recipient, msg.Text),
+	})
+}
+
+// WebhookNotifier posts the message as JSON
+type WebhookNotifier struct {
+	name string
+	url  string
+	http *http.Client
+}
+
+func NewWebhookNotifier(name, url string) *WebhookNotifier {
+	return &WebhookNotifier{name: name, url: url, http: &http.Client{Timeout: channelTimeout}}
+}
+
+func (n *WebhookNotifier) Name() string {
+	return n.name
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.http, n.url, map[string]interface{}{
+		"event":        "notification",
+		"notification": msg,
+	})
+}
+
+// TelegramNotifier sends through a bot to one chat
+type TelegramNotifier struct {
+	name   string
+	url    string
+	chatID string
+	http   *http.Client
+}
+
+func NewTelegramNotifier(name, botToken, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{
+		name:   name,
+		url:    "https://api.telegram.org/bot" + botToken + "/sendMessage",
+		chatID: chatID,
+		http:   &http.Client{Timeout: channelTimeout},
+	}
+}
+
+func (n *TelegramNotifier) Name() string {
+	return n.name
+}
+
+func (n *TelegramNotifier) Notify(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.http, n.url, map[string]interface{}{
+		"chat_id": n.chatID,
+		"text":    fmt.Sprintf("%s for %s: %s", msg.Kind, msg.UserID, msg.Text),
+	})
+}
+
+// EmailNotifier mails fixed recipients over SMTP, authenticating when username is set
+type EmailNotifier struct {
+	name     string
+	addr     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+func NewEmailNotifier(name, addr, username, password, from string, to []string) *EmailNotifier {
+	return &EmailNotifier{name: name, addr: addr, username: username, password: password, from: from, to: to}
+}
+
+func (n *EmailNotifier) Name() string {
+	return n.name
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, msg Message) error {
+	var auth smtp.Auth
+	if n.username != "" {
+		host, _, _ := strings.Cut(n.addr, ":")
+		auth = smtp.PlainAuth("", n.username, n.password, host)
+	}
+	
+	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n",
+		n.from, strings.Join(n.to, ", "), headerSafe(fmt.Sprintf("[%s] %s", msg.Kind, msg.UserID)), msg.Text)
+	
+	// net/smtp has no context support, the send runs aside so cancellation isn't blocked
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(n.addr, auth, n.from, n.to, []byte(body))
+	}()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	}
+}
+
+// headerSafe drops line breaks that would let values inject mail headers
+func headerSafe(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
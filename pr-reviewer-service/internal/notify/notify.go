@@ -0,0 +1,272 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"pr-reviewer-service/internal/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message - user notification delivered over external channels
+type Message struct {
+	NotificationID int64     `json:"notification_id"`
+	UserID         string    `json:"user_id"`
+	Kind           string    `json:"kind"`
+	PullRequestID  string    `json:"pull_request_id,omitempty"`
+	Text           string    `json:"text"`
+	CreatedAt      time.Time `json:"created_at"`
+	// recipient's external accounts by provider, channels address the user by them
+	Identities map[string]string `json:"identities,omitempty"`
+}
+
+func NewMessage(notification *models.Notification) Message {
+	return Message{
+		NotificationID: notification.ID,
+		UserID:         notification.UserID,
+		Kind:           notification.Kind,
+		PullRequestID:  notification.PullRequestID,
+		Text:           notification.Message,
+		CreatedAt:      notification.CreatedAt,
+	}
+}
+
+// Notifier - external channel receiving user notifications
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Dispatcher fans notifications out to all channels concurrently, every channel is
+// retried on its own and a failing one doesn't hold back or fail the others
+type Dispatcher struct {
+	mu       sync.RWMutex // guards channels and names, they are replaced on credential rotation
+	channels map[string]Notifier
+	names    []string
+	attempts int
+	backoff  time.Duration // doubled on every attempt
+}
+
+// NewDispatcher requires unique channel names, they identify channels in queued jobs
+func NewDispatcher(channels []Notifier, attempts int, backoff time.Duration) (*Dispatcher, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	d := &Dispatcher{attempts: attempts, backoff: backoff}
+	if err := d.Replace(channels); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Replace swaps the channels, e.g. rebuilt with rotated credentials. Deliveries in flight
+// finish on the channels they started with.
+func (d *Dispatcher) Replace(channels []Notifier) error {
+	byName := make(map[string]Notifier, len(channels))
+	names := make([]string, 0, len(channels))
+	for _, channel := range channels {
+		if _, ok := byName[channel.Name()]; ok {
+			return fmt.Errorf("duplicate notification channel %q", channel.Name())
+		}
+		byName[channel.Name()] = channel
+		names = append(names, channel.Name())
+	}
+	
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.channels = byName
+	d.names = names
+	return nil
+}
+
+// Routes - channel names per notification kind, kinds without a route go to every channel
+// and an empty route turns external delivery of the kind off
+type Routes map[string][]string
+
+// Channels returns channel names in configuration order
+func (d *Dispatcher) Channels() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.names
+}
+
+// HasChannel reports whether a channel with the name is configured
+func (d *Dispatcher) HasChannel(name string) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	_, ok := d.channels[name]
+	return ok
+}
+
+// Route returns configured channels the message kind is routed to, in configuration order.
+// Routed channels that aren't configured are skipped.
+func (d *Dispatcher) Route(msg Message, routes Routes) []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	route, ok := routes[msg.Kind]
+	if !ok {
+		return d.names
+	}
+	
+	var names []string
+	for _, name := range d.names {
+		for _, routed := range route {
+			if routed == name {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// Notify delivers to every channel the message is routed to, the error lists channels that
+// failed all attempts
+func (d *Dispatcher) Notify(ctx context.Context, msg Message, routes Routes) error {
+	names := d.Route(msg, routes)
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = d.NotifyChannel(ctx, name, msg)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// NotifyChannel delivers to one channel with retries
+func (d *Dispatcher) NotifyChannel(ctx context.Context, name string, msg Message) error {
+	d.mu.RLock()
+	channel, ok := d.channels[name]
+	d.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unknown notification channel %q", name)
+	}
+	
+	backoff := d.backoff
+	var err error
+	for attempt := 1; attempt <= d.attempts; attempt++ {
+		if err = channel.Notify(ctx, msg); err == nil {
+			return nil
+		}
+		if attempt == d.attempts {
+			break
+		}
+		log.Printf("Notification %d via %s failed (attempt %d): %v", msg.NotificationID, name, attempt, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
+
+// Config - notification channels settings, a channel is enabled when its target is set
+type Config struct {
+	SlackWebhookURL  string
+	WebhookURL       string
+	TelegramBotToken string
+	TelegramChatID   string
+	SMTPAddr         string // host:port
+	SMTPUsername     string
+	SMTPPassword     string
+	EmailFrom        string
+	EmailTo          []string
+}
+
+// SecretNames - settings holding credentials, they may come from a secret manager and rotate
+var SecretNames = []string{"NOTIFY_SLACK_WEBHOOK_URL", "NOTIFY_WEBHOOK_URL", "NOTIFY_TELEGRAM_BOT_TOKEN", "NOTIFY_SMTP_PASSWORD"}
+
+// ConfigFromEnv reads NOTIFY_SLACK_WEBHOOK_URL, NOTIFY_WEBHOOK_URL, NOTIFY_TELEGRAM_BOT_TOKEN,
+// NOTIFY_TELEGRAM_CHAT_ID, NOTIFY_SMTP_ADDR, NOTIFY_SMTP_USERNAME, NOTIFY_SMTP_PASSWORD,
+// NOTIFY_EMAIL_FROM and comma-separated NOTIFY_EMAIL_TO
+func ConfigFromEnv() Config {
+	return ConfigFrom(os.Getenv)
+}
+
+// ConfigFrom reads the settings of ConfigFromEnv with lookup, e.g. from a secret store
+func ConfigFrom(lookup func(name string) string) Config {
+	var to []string
+	for _, address := range strings.Split(lookup("NOTIFY_EMAIL_TO"), ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			to = append(to, address)
+		}
+	}
+	return Config{
+		SlackWebhookURL:  lookup("NOTIFY_SLACK_WEBHOOK_URL"),
+		WebhookURL:       lookup("NOTIFY_WEBHOOK_URL"),
+		TelegramBotToken: lookup("NOTIFY_TELEGRAM_BOT_TOKEN"),
+		TelegramChatID:   lookup("NOTIFY_TELEGRAM_CHAT_ID"),
+		SMTPAddr:         lookup("NOTIFY_SMTP_ADDR"),
+		SMTPUsername:     lookup("NOTIFY_SMTP_USERNAME"),
+		SMTPPassword:     lookup("NOTIFY_SMTP_PASSWORD"),
+		EmailFrom:        lookup("NOTIFY_EMAIL_FROM"),
+		EmailTo:          to,
+	}
+}
+
+// Channels builds the enabled channels
+func (c Config) Channels() ([]Notifier, error) {
+	var channels []Notifier
+	if c.SlackWebhookURL != "" {
+		channels = append(channels, NewSlackNotifier("slack", c.SlackWebhookURL))
+	}
+	if c.WebhookURL != "" {
+		channels = append(channels, NewWebhookNotifier("webhook", c.WebhookURL))
+	}
+	if c.TelegramBotToken != "" || c.TelegramChatID != "" {
+		if c.TelegramBotToken == "" || c.TelegramChatID == "" {
+			return nil, fmt.Errorf("telegram channel requires both bot token and chat id")
+		}
+		channels = append(channels, NewTelegramNotifier("telegram", c.TelegramBotToken, c.TelegramChatID))
+	}
+	if c.SMTPAddr != "" {
+		if c.EmailFrom == "" || len(c.EmailTo) == 0 {
+			return nil, fmt.Errorf("email channel requires sender and recipients")
+		}
+		channels = append(channels, NewEmailNotifier("email", c.SMTPAddr, c.SMTPUsername, c.SMTPPassword, c.EmailFrom, c.EmailTo))
+	}
+	return channels, nil
+}
+
+// postJSON sends body to url and treats any non-2xx status as failure
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to build notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Failed to close response body: %v", err)
+		}
+	}()
+	
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification channel returned %d", resp.StatusCode)
+	}
+	
+	return nil
+}
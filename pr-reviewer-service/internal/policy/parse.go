@@ -0,0 +1,410 @@
+package policy
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// node ops besides binary and unary operators, which use their own text
+const (
+	opNumber = "number"
+	opString = "string"
+	opBool   = "bool"
+	opVar    = "var"
+	opNeg    = "neg"
+	opCond   = "?:"
+)
+
+type node struct {
+	op   string
+	typ  Type
+	num  float64
+	str  string
+	args []*node
+}
+
+// function - builtin, a zero param type accepts a string or a list
+type function struct {
+	params []Type
+	result Type
+	call   func(args []Value) Value
+}
+
+var functions = map[string]function{
+	"min": {[]Type{Number, Number}, Number, func(args []Value) Value {
+		return NumberValue(math.Min(args[0].Num, args[1].Num))
+	}},
+	"max": {[]Type{Number, Number}, Number, func(args []Value) Value {
+		return NumberValue(math.Max(args[0].Num, args[1].Num))
+	}},
+	"abs": {[]Type{Number}, Number, func(args []Value) Value {
+		return NumberValue(math.Abs(args[0].Num))
+	}},
+	"len": {[]Type{0}, Number, func(args []Value) Value {
+		if args[0].Type == List {
+			return NumberValue(float64(len(args[0].List)))
+		}
+		return NumberValue(float64(len(args[0].Str)))
+	}},
+	"lower": {[]Type{String}, String, func(args []Value) Value {
+		return StringValue(strings.ToLower(args[0].Str))
+	}},
+	"starts_with": {[]Type{String, String}, Bool, func(args []Value) Value {
+		return BoolValue(strings.HasPrefix(args[0].Str, args[1].Str))
+	}},
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// parser is a recursive descent parser type-checking nodes as it builds them
+type parser struct {
+	src   string
+	pos   int
+	tok   token
+	vars  map[string]Type
+	nodes int
+	depth int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &Error{Pos: p.tok.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "+", "-", "*", "/", "!", "?", ":", "(", ")", ","}
+
+func (p *parser) next() error {
+	for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokEOF, pos: start}
+		return nil
+	}
+	
+	c := p.src[p.pos]
+	switch {
+	case c >= '0' && c <= '9':
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		p.tok = token{kind: tokNumber, text: p.src[start:p.pos], pos: start}
+		return nil
+	
+	case isLetter(c):
+		for p.pos < len(p.src) && (isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{kind: tokIdent, text: p.src[start:p.pos], pos: start}
+		return nil
+	
+	case c == '"':
+		var text strings.Builder
+		for p.pos++; p.pos < len(p.src); p.pos++ {
+			switch p.src[p.pos] {
+			case '"':
+				p.pos++
+				p.tok = token{kind: tokString, text: text.String(), pos: start}
+				return nil
+			case '\\':
+				p.pos++
+				if p.pos < len(p.src) {
+					text.WriteByte(p.src[p.pos])
+				}
+			default:
+				text.WriteByte(p.src[p.pos])
+			}
+		}
+		return &Error{Pos: start, Message: "unterminated string"}
+	}
+	
+	for _, op := range operators {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			p.pos += len(op)
+			p.tok = token{kind: tokOp, text: op, pos: start}
+			return nil
+		}
+	}
+	return &Error{Pos: start, Message: fmt.Sprintf("unexpected character %q", c)}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func (p *parser) isOp(ops ...string) bool {
+	if p.tok.kind != tokOp && !(p.tok.kind == tokIdent && p.tok.text == "in") {
+		return false
+	}
+	for _, op := range ops {
+		if p.tok.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.isOp(op) {
+		return p.errorf("expected %q", op)
+	}
+	return p.next()
+}
+
+// newNode counts nodes so a huge expression is rejected before it's evaluated
+func (p *parser) newNode(n *node) (*node, error) {
+	p.nodes++
+	if p.nodes > MaxNodes {
+		return nil, p.errorf("policy has more than %d nodes", MaxNodes)
+	}
+	return n, nil
+}
+
+// expr := or ["?" expr ":" expr]
+func (p *parser) expr() (*node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, p.errorf("policy is nested deeper than %d", maxDepth)
+	}
+	
+	cond, err := p.binary(0)
+	if err != nil || !p.isOp("?") {
+		return cond, err
+	}
+	pos := p.tok.pos
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	then, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	
+	if cond.typ != Bool {
+		return nil, &Error{Pos: pos, Message: "condition of ?: must be bool, got " + cond.typ.String()}
+	}
+	if then.typ != otherwise.typ {
+		return nil, &Error{Pos: pos, Message: fmt.Sprintf("branches of ?: differ: %s and %s", then.typ, otherwise.typ)}
+	}
+	return p.newNode(&node{op: opCond, typ: then.typ, args: []*node{cond, then, otherwise}})
+}
+
+// binary operators by precedence, lowest first
+var precedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">=", "in"},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+func (p *parser) binary(level int) (*node, error) {
+	if level == len(precedence) {
+		return p.unary()
+	}
+	
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp(precedence[level]...) {
+		op, pos := p.tok.text, p.tok.pos
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		typ, err := binaryType(op, left.typ, right.typ)
+		if err != nil {
+			return nil, &Error{Pos: pos, Message: err.Error()}
+		}
+		if left, err = p.newNode(&node{op: op, typ: typ, args: []*node{left, right}}); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func binaryType(op string, left, right Type) (Type, error) {
+	switch {
+	case op == "+" && left == String && right == String:
+		return String, nil
+	case op == "&&" || op == "||":
+		if left == Bool && right == Bool {
+			return Bool, nil
+		}
+	case op == "==" || op == "!=":
+		if left == right && left != List {
+			return Bool, nil
+		}
+	case op == "in":
+		if left == String && (right == List || right == String) {
+			return Bool, nil
+		}
+	case left == Number && right == Number:
+		if op == "+" || op == "-" || op == "*" || op == "/" {
+			return Number, nil
+		}
+		return Bool, nil
+	}
+	return 0, fmt.Errorf("operator %s can't take %s and %s", op, left, right)
+}
+
+func (p *parser) unary() (*node, error) {
+	if !p.isOp("!", "-") {
+		return p.primary()
+	}
+	op, pos := p.tok.text, p.tok.pos
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, p.errorf("policy is nested deeper than %d", maxDepth)
+	}
+	operand, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	
+	if op == "!" {
+		if operand.typ != Bool {
+			return nil, &Error{Pos: pos, Message: "operator ! takes bool, got " + operand.typ.String()}
+		}
+		return p.newNode(&node{op: "!", typ: Bool, args: []*node{operand}})
+	}
+	if operand.typ != Number {
+		return nil, &Error{Pos: pos, Message: "operator - takes number, got " + operand.typ.String()}
+	}
+	return p.newNode(&node{op: opNeg, typ: Number, args: []*node{operand}})
+}
+
+func (p *parser) primary() (*node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokNumber:
+		num, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", tok.text)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		return p.newNode(&node{op: opNumber, typ: Number, num: num})
+	
+	case tokString:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		return p.newNode(&node{op: opString, typ: String, str: tok.text})
+	
+	case tokIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		switch {
+		case tok.text == "true" || tok.text == "false":
+			n := &node{op: opBool, typ: Bool}
+			if tok.text == "true" {
+				n.num = 1
+			}
+			return p.newNode(n)
+		case p.isOp("("):
+			return p.call(tok)
+		}
+		typ, ok := p.vars[tok.text]
+		if !ok {
+			return nil, &Error{Pos: tok.pos, Message: "unknown variable " + tok.text}
+		}
+		return p.newNode(&node{op: opVar, typ: typ, str: tok.text})
+	
+	case tokOp:
+		if tok.text == "(" {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			inner, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			return inner, p.expect(")")
+		}
+	}
+	
+	if tok.kind == tokEOF {
+		return nil, p.errorf("unexpected end of policy")
+	}
+	return nil, p.errorf("unexpected %q", tok.text)
+}
+
+// call parses arguments of a builtin, the name is already consumed
+func (p *parser) call(name token) (*node, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, &Error{Pos: name.pos, Message: "unknown function " + name.text}
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	
+	var args []*node
+	for !p.isOp(")") {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	
+	if len(args) != len(fn.params) {
+		return nil, &Error{Pos: name.pos, Message: fmt.Sprintf("%s takes %d arguments, got %d", name.text, len(fn.params), len(args))}
+	}
+	for i, param := range fn.params {
+		typ := args[i].typ
+		if typ == param || (param == 0 && (typ == String || typ == List)) {
+			continue
+		}
+		return nil, &Error{Pos: name.pos, Message: fmt.Sprintf("argument %d of %s can't be %s", i+1, name.text, typ)}
+	}
+	return p.newNode(&node{op: name.text, typ: fn.result, args: args})
+}
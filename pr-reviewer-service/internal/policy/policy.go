@@ -0,0 +1,232 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Type - type of a policy value, checked when the program is compiled
+type Type int
+
+const (
+	Number Type = iota + 1
+	String
+	Bool
+	List // list of strings
+)
+
+func (t Type) String() string {
+	switch t {
+	case Number:
+		return "number"
+	case String:
+		return "string"
+	case Bool:
+		return "bool"
+	case List:
+		return "list"
+	}
+	return "unknown"
+}
+
+// Value - variable passed to the program, only the field of its type is used
+type Value struct {
+	Type Type
+	Num  float64
+	Str  string
+	Bool bool
+	List []string
+}
+
+func NumberValue(n float64) Value { return Value{Type: Number, Num: n} }
+func StringValue(s string) Value  { return Value{Type: String, Str: s} }
+func BoolValue(b bool) Value      { return Value{Type: Bool, Bool: b} }
+func ListValue(l []string) Value  { return Value{Type: List, List: l} }
+
+// Compile limits, a program is one expression so its size bounds a single evaluation
+const (
+	MaxSourceBytes = 4096
+	MaxNodes       = 512
+	maxDepth       = 64
+)
+
+// Limits bound evaluation of a program over all candidates of one ranking
+type Limits struct {
+	MaxSteps int
+	Timeout  time.Duration
+}
+
+var DefaultLimits = Limits{MaxSteps: 100000, Timeout: 50 * time.Millisecond}
+
+var (
+	ErrStepLimit = errors.New("policy exceeded its step limit")
+	ErrTimeout   = errors.New("policy exceeded its time limit")
+)
+
+// Error - compile error at byte offset of the source
+type Error struct {
+	Pos     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("offset %d: %s", e.Pos, e.Message)
+}
+
+// Program - compiled policy expression scoring a candidate, higher is better
+type Program struct {
+	root *node
+}
+
+// Compile parses the source and type-checks it against declared variables,
+// the expression must produce a number
+func Compile(source string, vars map[string]Type) (*Program, error) {
+	if len(source) > MaxSourceBytes {
+		return nil, &Error{Message: fmt.Sprintf("policy is longer than %d bytes", MaxSourceBytes)}
+	}
+	if strings.TrimSpace(source) == "" {
+		return nil, &Error{Message: "policy is empty"}
+	}
+	
+	p := &parser{src: source, vars: vars}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	root, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %q", p.tok.text)
+	}
+	if root.typ != Number {
+		return nil, &Error{Message: "policy must produce a number, got " + root.typ.String()}
+	}
+	return &Program{root: root}, nil
+}
+
+// Budget - steps and time left to the evaluations of one ranking
+type Budget struct {
+	steps    int
+	deadline time.Time
+}
+
+func NewBudget(limits Limits, now time.Time) *Budget {
+	return &Budget{steps: limits.MaxSteps, deadline: now.Add(limits.Timeout)}
+}
+
+func (b *Budget) step() error {
+	b.steps--
+	if b.steps < 0 {
+		return ErrStepLimit
+	}
+	// the clock is read every few steps, a step is much cheaper than time.Now
+	if b.steps%64 == 0 && time.Now().After(b.deadline) {
+		return ErrTimeout
+	}
+	return nil
+}
+
+// Eval scores one candidate, variables not passed get zero values of their types
+func (p *Program) Eval(vars map[string]Value, budget *Budget) (float64, error) {
+	result, err := eval(p.root, vars, budget)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(result.Num) {
+		return 0, errors.New("policy score is not a number")
+	}
+	return result.Num, nil
+}
+
+func eval(n *node, vars map[string]Value, budget *Budget) (Value, error) {
+	if err := budget.step(); err != nil {
+		return Value{}, err
+	}
+	
+	switch n.op {
+	case opNumber:
+		return NumberValue(n.num), nil
+	case opString:
+		return StringValue(n.str), nil
+	case opBool:
+		return BoolValue(n.num != 0), nil
+	case opVar:
+		value := vars[n.str]
+		value.Type = n.typ
+		return value, nil
+	case opCond:
+		cond, err := eval(n.args[0], vars, budget)
+		if err != nil {
+			return Value{}, err
+		}
+		if cond.Bool {
+			return eval(n.args[1], vars, budget)
+		}
+		return eval(n.args[2], vars, budget)
+	case "&&", "||":
+		left, err := eval(n.args[0], vars, budget)
+		if err != nil {
+			return Value{}, err
+		}
+		if left.Bool == (n.op == "||") {
+			return left, nil
+		}
+		return eval(n.args[1], vars, budget)
+	}
+	
+	args := make([]Value, len(n.args))
+	for i, arg := range n.args {
+		value, err := eval(arg, vars, budget)
+		if err != nil {
+			return Value{}, err
+		}
+		args[i] = value
+	}
+	
+	switch n.op {
+	case "!":
+		return BoolValue(!args[0].Bool), nil
+	case opNeg:
+		return NumberValue(-args[0].Num), nil
+	case "+":
+		if n.typ == String {
+			return StringValue(args[0].Str + args[1].Str), nil
+		}
+		return NumberValue(args[0].Num + args[1].Num), nil
+	case "-":
+		return NumberValue(args[0].Num - args[1].Num), nil
+	case "*":
+		return NumberValue(args[0].Num * args[1].Num), nil
+	case "/":
+		if args[1].Num == 0 {
+			return Value{}, errors.New("division by zero")
+		}
+		return NumberValue(args[0].Num / args[1].Num), nil
+	case "==", "!=":
+		equal := args[0].Num == args[1].Num && args[0].Str == args[1].Str && args[0].Bool == args[1].Bool
+		return BoolValue(equal == (n.op == "==")), nil
+	case "<":
+		return BoolValue(args[0].Num < args[1].Num), nil
+	case "<=":
+		return BoolValue(args[0].Num <= args[1].Num), nil
+	case ">":
+		return BoolValue(args[0].Num > args[1].Num), nil
+	case ">=":
+		return BoolValue(args[0].Num >= args[1].Num), nil
+	case "in":
+		if n.args[1].typ == String {
+			return BoolValue(strings.Contains(args[1].Str, args[0].Str)), nil
+		}
+		for _, item := range args[1].List {
+			if item == args[0].Str {
+				return BoolValue(true), nil
+			}
+		}
+		return BoolValue(false), nil
+	}
+	return functions[n.op].call(args), nil
+}
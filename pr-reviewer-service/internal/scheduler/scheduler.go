@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Job - background task executed every Interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Lease - shared lock used to elect the instance that runs jobs
+type Lease interface {
+	AcquireLease(name, holder string, ttl time.Duration) (bool, error)
+	ReleaseLease(name, holder string) error
+}
+
+// RunLog - shared record of job runs, any replica can report when jobs last ran
+type RunLog interface {
+	RecordJobRun(name, holder string, startedAt, finishedAt time.Time, runErr string) error
+}
+
+type Scheduler struct {
+	jobs []Job
+	runs RunLog
+
+	lease    Lease
+	leaseTTL time.Duration
+	holder   string
+	leader   atomic.Bool
+}
+
+// Option configures optional Scheduler behaviour
+type Option func(*Scheduler)
+
+// WithLeaderElection makes only the lease holder run jobs when several replicas share the database,
+// another replica takes over after ttl if the leader dies
+func WithLeaderElection(lease Lease, ttl time.Duration) Option {
+	return func(s *Scheduler) {
+		s.lease = lease
+		s.leaseTTL = ttl
+	}
+}
+
+// WithRunLog records the outcome of every job run
+func WithRunLog(runs RunLog) Option {
+	return func(s *Scheduler) {
+		s.runs = runs
+	}
+}
+
+const leaseName = "scheduler"
+
+func New(opts ...Option) *Scheduler {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "scheduler"
+	}
+	s := &Scheduler{
+		holder: fmt.Sprintf("%s-%d", host, os.Getpid()),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every job on its own ticker and blocks until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	if s.lease != nil {
+		s.renewLease()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.campaign(ctx)
+		}()
+	}
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+}
+
+// campaign renews the lease well before it expires and releases it on shutdown
+func (s *Scheduler) campaign(ctx context.Context) {
+	ticker := time.NewTicker(s.leaseTTL / 3)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-ctx.Done():
+			if s.leader.Load() {
+				if err := s.lease.ReleaseLease(leaseName, s.holder); err != nil {
+					log.Printf("Failed to release scheduler lease: %v", err)
+				}
+			}
+			return
+		case <-ticker.C:
+			s.renewLease()
+		}
+	}
+}
+
+func (s *Scheduler) renewLease() {
+	acquired, err := s.lease.AcquireLease(leaseName, s.holder, s.leaseTTL)
+	if err != nil {
+		// can't prove we still hold it, step down until the next attempt
+		log.Printf("Failed to renew scheduler lease: %v", err)
+		acquired = false
+	}
+	
+	if s.leader.Swap(acquired) != acquired {
+		if acquired {
+			log.Printf("Scheduler %s became leader", s.holder)
+		} else {
+			log.Printf("Scheduler %s lost leadership", s.holder)
+		}
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+	
+	for {
+		s.runOnce(ctx, job)
+	
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	if s.lease != nil && !s.leader.Load() {
+		return
+	}
+	
+	started := time.Now()
+	err := job.Run(ctx)
+	s.recordRun(job, started, err)
+	if err != nil {
+		log.Printf("Job %s failed: %v", job.Name, err)
+		return
+	}
+	log.Printf("Job %s finished in %s", job.Name, time.Since(started))
+}
+
+func (s *Scheduler) recordRun(job Job, started time.Time, err error) {
+	if s.runs == nil {
+		return
+	}
+	runErr := ""
+	if err != nil {
+		runErr = err.Error()
+	}
+	if err := s.runs.RecordJobRun(job.Name, s.holder, started.UTC(), time.Now().UTC(), runErr); err != nil {
+		log.Printf("Failed to record run of job %s: %v", job.Name, err)
+	}
+}
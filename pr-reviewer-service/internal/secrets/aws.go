@@ -0,0 +1,158 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const awsService = "secretsmanager"
+
+// AWSProvider reads one AWS Secrets Manager secret holding a JSON object, its keys are
+// secret names. Requests are signed with static credentials (SigV4).
+type AWSProvider struct {
+	region       string
+	accessKeyID  string
+	secretKey    string
+	sessionToken string
+	secretID     string
+	endpoint     string
+	http         *http.Client
+}
+
+func NewAWSProvider(cfg Config, client *http.Client) (*AWSProvider, error) {
+	if cfg.AWSRegion == "" || cfg.AWSSecretID == "" {
+		return nil, errors.New("aws provider requires AWS_REGION and SECRETS_AWS_SECRET_ID")
+	}
+	if cfg.AWSAccessKeyID == "" || cfg.AWSSecretKey == "" {
+		return nil, errors.New("aws provider requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	endpoint := cfg.AWSEndpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", awsService, cfg.AWSRegion)
+	}
+	if _, err := url.Parse(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid SECRETS_AWS_ENDPOINT: %w", err)
+	}
+	return &AWSProvider{
+		region:       cfg.AWSRegion,
+		accessKeyID:  cfg.AWSAccessKeyID,
+		secretKey:    cfg.AWSSecretKey,
+		sessionToken: cfg.AWSSessionToken,
+		secretID:     cfg.AWSSecretID,
+		endpoint:     strings.TrimSuffix(endpoint, "/"),
+		http:         client,
+	}, nil
+}
+
+func (p *AWSProvider) Name() string {
+	return ProviderAWS
+}
+
+func (p *AWSProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": p.secretID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal aws request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build aws request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	p.sign(req, body, time.Now().UTC())
+	
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aws secret: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Failed to close response body: %v", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("aws secrets manager returned %d for %s", resp.StatusCode, p.secretID)
+	}
+	
+	var secret struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return nil, fmt.Errorf("failed to decode aws response: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(secret.SecretString), &fields); err != nil {
+		return nil, fmt.Errorf("aws secret %s must be a JSON object: %w", p.secretID, err)
+	}
+	return stringFields(fields), nil
+}
+
+// sign adds Signature Version 4 headers, the body is hashed into the signature
+func (p *AWSProvider) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if p.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.sessionToken)
+	}
+	
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method, path, req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, sha256Hex(body),
+	}, "\n")
+	
+	scope := date + "/" + p.region + "/" + awsService + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+	
+	key := hmacSHA256([]byte("AWS4"+p.secretKey), date)
+	key = hmacSHA256(key, p.region)
+	key = hmacSHA256(key, awsService)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		p.accessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,200 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Secret managers
+const (
+	ProviderEnv   = "env"
+	ProviderVault = "vault"
+	ProviderAWS   = "aws"
+)
+
+// Provider - external secret manager, every fetch returns all secrets it holds by name
+type Provider interface {
+	Name() string
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// Config - secret manager settings, without a provider secrets come from the environment
+type Config struct {
+	Provider        string
+	RefreshInterval time.Duration
+
+	VaultAddr      string
+	VaultToken     string
+	VaultTokenFile string // re-read on every fetch, e.g. the sink of a Vault agent
+	VaultNamespace string
+	VaultPath      string // KV path, e.g. secret/data/pr-reviewer-service for KV v2
+
+	AWSRegion       string
+	AWSAccessKeyID  string
+	AWSSecretKey    string
+	AWSSessionToken string
+	AWSSecretID     string
+	AWSEndpoint     string // overrides the regional endpoint, e.g. a VPC endpoint
+}
+
+const defaultRefreshInterval = 5 * time.Minute
+
+// ConfigFromEnv reads SECRETS_PROVIDER, SECRETS_REFRESH_INTERVAL, VAULT_ADDR, VAULT_TOKEN,
+// VAULT_TOKEN_FILE, VAULT_NAMESPACE, VAULT_SECRET_PATH, AWS_REGION, AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, SECRETS_AWS_SECRET_ID and SECRETS_AWS_ENDPOINT
+func ConfigFromEnv() Config {
+	cfg := Config{
+		Provider:        os.Getenv("SECRETS_PROVIDER"),
+		RefreshInterval: defaultRefreshInterval,
+		VaultAddr:       os.Getenv("VAULT_ADDR"),
+		VaultToken:      os.Getenv("VAULT_TOKEN"),
+		VaultTokenFile:  os.Getenv("VAULT_TOKEN_FILE"),
+		VaultNamespace:  os.Getenv("VAULT_NAMESPACE"),
+		VaultPath:       os.Getenv("VAULT_SECRET_PATH"),
+		AWSRegion:       os.Getenv("AWS_REGION"),
+		AWSAccessKeyID:  os.Getenv("AWS_ACCESS_KEY_ID"),
+		AWSSecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		AWSSessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		AWSSecretID:     os.Getenv("SECRETS_AWS_SECRET_ID"),
+		AWSEndpoint:     os.Getenv("SECRETS_AWS_ENDPOINT"),
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = ProviderEnv
+	}
+	if raw := os.Getenv("SECRETS_REFRESH_INTERVAL"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			cfg.RefreshInterval = d
+		} else {
+			log.Printf("Invalid SECRETS_REFRESH_INTERVAL %q, using %s", raw, cfg.RefreshInterval)
+		}
+	}
+	return cfg
+}
+
+// NewProvider builds the configured secret manager, nil for the environment
+func NewProvider(cfg Config) (Provider, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	switch cfg.Provider {
+	case ProviderEnv, "":
+		return nil, nil
+	case ProviderVault:
+		return NewVaultProvider(cfg, client)
+	case ProviderAWS:
+		return NewAWSProvider(cfg, client)
+	}
+	return nil, fmt.Errorf("unknown secrets provider %q", cfg.Provider)
+}
+
+type watcher struct {
+	names []string
+	fn    func()
+}
+
+// Store caches secrets of the provider and refreshes them, names the provider doesn't
+// hold fall back to environment variables of the same name
+type Store struct {
+	provider Provider
+
+	mu       sync.RWMutex
+	values   map[string]string
+	watchers []watcher
+}
+
+// NewStore with a nil provider serves the environment only
+func NewStore(provider Provider) *Store {
+	return &Store{provider: provider, values: make(map[string]string)}
+}
+
+// Get returns the current value of the secret
+func (s *Store) Get(name string) string {
+	s.mu.RLock()
+	value, ok := s.values[name]
+	s.mu.RUnlock()
+	if ok {
+		return value
+	}
+	return os.Getenv(name)
+}
+
+// Getter binds Get to the name, for consumers reading the secret on every use
+func (s *Store) Getter(name string) func() string {
+	return func() string { return s.Get(name) }
+}
+
+// Watch calls fn after a refresh changed any of the named secrets
+func (s *Store) Watch(fn func(), names ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchers = append(s.watchers, watcher{names: names, fn: fn})
+}
+
+// Refresh fetches all secrets and notifies watchers of the changed ones. On failure the
+// previous values are kept.
+func (s *Store) Refresh(ctx context.Context) error {
+	if s.provider == nil {
+		return nil
+	}
+	values, err := s.provider.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch secrets from %s: %w", s.provider.Name(), err)
+	}
+	
+	s.mu.Lock()
+	var changed []string
+	for name, value := range values {
+		if old, ok := s.values[name]; !ok || old != value {
+			changed = append(changed, name)
+		}
+	}
+	for name := range s.values {
+		if _, ok := values[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	s.values = values
+	var notify []func()
+	for _, w := range s.watchers {
+		for _, name := range w.names {
+			if slices.Contains(changed, name) {
+				notify = append(notify, w.fn)
+				break
+			}
+		}
+	}
+	s.mu.Unlock()
+	
+	if len(changed) > 0 {
+		slices.Sort(changed)
+		log.Printf("Secrets changed in %s: %v", s.provider.Name(), changed)
+	}
+	for _, fn := range notify {
+		fn()
+	}
+	return nil
+}
+
+// Run refreshes secrets every interval until ctx is done, the first fetch is left to the
+// caller so a misconfigured provider fails the boot
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	if s.provider == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				log.Printf("Failed to refresh secrets: %v", err)
+			}
+		}
+	}
+}
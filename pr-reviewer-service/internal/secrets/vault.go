@@ -0,0 +1,113 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// VaultProvider reads one KV secret of HashiCorp Vault, its keys are secret names
+type VaultProvider struct {
+	addr      string
+	token     string
+	tokenFile string
+	namespace string
+	path      string
+	http      *http.Client
+}
+
+func NewVaultProvider(cfg Config, client *http.Client) (*VaultProvider, error) {
+	if cfg.VaultAddr == "" || cfg.VaultPath == "" {
+		return nil, errors.New("vault provider requires VAULT_ADDR and VAULT_SECRET_PATH")
+	}
+	if cfg.VaultToken == "" && cfg.VaultTokenFile == "" {
+		return nil, errors.New("vault provider requires VAULT_TOKEN or VAULT_TOKEN_FILE")
+	}
+	return &VaultProvider{
+		addr:      strings.TrimSuffix(cfg.VaultAddr, "/"),
+		token:     cfg.VaultToken,
+		tokenFile: cfg.VaultTokenFile,
+		namespace: cfg.VaultNamespace,
+		path:      strings.Trim(cfg.VaultPath, "/"),
+		http:      client,
+	}, nil
+}
+
+func (p *VaultProvider) Name() string {
+	return ProviderVault
+}
+
+// authToken prefers the token file, so a token renewed by the agent is picked up
+func (p *VaultProvider) authToken() (string, error) {
+	if p.tokenFile == "" {
+		return p.token, nil
+	}
+	raw, err := os.ReadFile(p.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read vault token file: %w", err)
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+func (p *VaultProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	token, err := p.authToken()
+	if err != nil {
+		return nil, err
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+p.path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+	
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault secret: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Failed to close response body: %v", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned %d for %s", resp.StatusCode, p.path)
+	}
+	
+	// KV v2 nests the secret under data.data next to data.metadata, KV v1 returns it as data
+	var body struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	fields := body.Data
+	if nested, ok := body.Data["data"]; ok && body.Data["metadata"] != nil {
+		fields = nil
+		if err := json.Unmarshal(nested, &fields); err != nil {
+			return nil, fmt.Errorf("failed to decode vault secret: %w", err)
+		}
+	}
+	return stringFields(fields), nil
+}
+
+// stringFields keeps string values as they are and other JSON values in their JSON form
+func stringFields(fields map[string]json.RawMessage) map[string]string {
+	values := make(map[string]string, len(fields))
+	for name, raw := range fields {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			value = string(raw)
+		}
+		values[name] = value
+	}
+	return values
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config - HTTP server settings, TLS is terminated by the service when both files are set
+type Config struct {
+	Addr              string
+	CertFile          string
+	KeyFile           string
+	ClientCAFile      string // CA bundle of client certificates, set to require mTLS
+	H2C               bool   // HTTP/2 without TLS, for a proxy speaking h2c to the service
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+	MaxHeaderBytes    int
+}
+
+// DefaultConfig bounds every stage of a request, the write timeout leaves room for
+// heavy endpoints waiting in their concurrency queue
+func DefaultConfig() Config {
+	return Config{
+		Addr:              ":8080",
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      90 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		ShutdownTimeout:   30 * time.Second,
+		MaxHeaderBytes:    64 << 10,
+	}
+}
+
+// ConfigFromEnv reads PORT, TLS_CERT_FILE, TLS_KEY_FILE, TLS_CLIENT_CA_FILE, HTTP_H2C and HTTP_READ_HEADER_TIMEOUT,
+// HTTP_READ_TIMEOUT, HTTP_WRITE_TIMEOUT, HTTP_IDLE_TIMEOUT, HTTP_SHUTDOWN_TIMEOUT,
+// invalid values keep defaults
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	if port := os.Getenv("PORT"); port != "" {
+		cfg.Addr = ":" + port
+	}
+	cfg.CertFile = os.Getenv("TLS_CERT_FILE")
+	cfg.KeyFile = os.Getenv("TLS_KEY_FILE")
+	cfg.ClientCAFile = os.Getenv("TLS_CLIENT_CA_FILE")
+	if raw := os.Getenv("HTTP_H2C"); raw != "" {
+		if h2c, err := strconv.ParseBool(raw); err == nil {
+			cfg.H2C = h2c
+		} else {
+			log.Printf("Invalid HTTP_H2C %q, using %t", raw, cfg.H2C)
+		}
+	}
+	
+	durations := map[string]*time.Duration{
+		"HTTP_READ_HEADER_TIMEOUT": &cfg.ReadHeaderTimeout,
+		"HTTP_READ_TIMEOUT":        &cfg.ReadTimeout,
+		"HTTP_WRITE_TIMEOUT":       &cfg.WriteTimeout,
+		"HTTP_IDLE_TIMEOUT":        &cfg.IdleTimeout,
+		"HTTP_SHUTDOWN_TIMEOUT":    &cfg.ShutdownTimeout,
+	}
+	for name, value := range durations {
+		raw := os.Getenv(name)
+		if raw == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			*value = d
+		} else {
+			log.Printf("Invalid %s %q, using %s", name, raw, *value)
+		}
+	}
+	return cfg
+}
+
+// TLS reports whether the server terminates TLS itself
+func (c Config) TLS() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+func (c Config) validate() error {
+	if c.TLS() && (c.CertFile == "" || c.KeyFile == "") {
+		return errors.New("TLS needs both TLS_CERT_FILE and TLS_KEY_FILE")
+	}
+	if c.ClientCAFile != "" && !c.TLS() {
+		return errors.New("TLS_CLIENT_CA_FILE needs TLS")
+	}
+	return nil
+}
+
+// New builds the server, HTTP/2 is on over TLS and, with H2C, over plain connections.
+// With a client CA every connection must present a certificate it signed.
+func New(cfg Config, handler http.Handler) (*http.Server, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(cfg.H2C)
+	
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		Protocols:         protocols,
+	}
+	if cfg.TLS() {
+		srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if cfg.ClientCAFile != "" {
+		pool, err := loadCertPool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		srv.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		srv.TLSConfig.ClientCAs = pool
+	}
+	return srv, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates in client CA file %s", path)
+	}
+	return pool, nil
+}
+
+// Run serves until ctx is done, then waits for in-flight requests up to the shutdown timeout
+func Run(ctx context.Context, srv *http.Server, cfg Config) error {
+	errs := make(chan error, 1)
+	go func() {
+		var err error
+		if cfg.TLS() {
+			log.Printf("Listening on %s with TLS", srv.Addr)
+			err = srv.ListenAndServeTLS(cfg.CertFile, cfg.KeyFile)
+		} else {
+			log.Printf("Listening on %s", srv.Addr)
+			err = srv.ListenAndServe()
+		}
+		errs <- err
+	}()
+	
+	select {
+	case err := <-errs:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
+	}
+	
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	return nil
+}
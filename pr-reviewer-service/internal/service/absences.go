@@ -0,0 +1,159 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// VacationSourceManual marks absences registered through the API
+const VacationSourceManual = "MANUAL"
+
+const (
+	maxAbsenceDays        = 365
+	maxAbsenceReserveDays = 30
+)
+
+// AddAbsence registers a planned absence, users add their own, team leads and admins
+// add them for team members
+func (s *Service) AddAbsence(actorID string, absence *models.Vacation) (*models.Vacation, error) {
+	if _, err := s.authorizeAbsences(actorID, absence.UserID); err != nil {
+		return nil, err
+	}
+	
+	absence.StartsAt = absence.StartsAt.UTC()
+	absence.EndsAt = absence.EndsAt.UTC()
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "ends_at must be after starts_at",
+		}
+	}
+	if !absence.EndsAt.After(time.Now()) {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "absence is already over",
+		}
+	}
+	if absence.EndsAt.Sub(absence.StartsAt) > maxAbsenceDays*24*time.Hour {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("absence must be at most %d days long", maxAbsenceDays),
+		}
+	}
+	
+	absence.Source = VacationSourceManual
+	absence.ExternalID = ""
+	if err := s.storage.AddVacation(absence); err != nil {
+		return nil, err
+	}
+	return absence, nil
+}
+
+// ListAbsences returns current and upcoming absences of the user, imported ones included
+func (s *Service) ListAbsences(userID string) ([]models.Vacation, error) {
+	if _, err := s.storage.GetUser(userID); err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	return s.storage.GetUserVacations(userID, time.Now().UTC())
+}
+
+// DeleteAbsence removes a registered absence, imported ones follow their calendar
+func (s *Service) DeleteAbsence(actorID, userID string, id int64) error {
+	if _, err := s.authorizeAbsences(actorID, userID); err != nil {
+		return err
+	}
+	
+	deleted, err := s.storage.DeleteVacation(userID, id, VacationSourceManual)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "absence not found",
+		}
+	}
+	return nil
+}
+
+func (s *Service) authorizeAbsences(actorID, userID string) (*models.User, error) {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	if actorID != userID {
+		if _, err := s.authorizeTeam(actorID, user.TeamName); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// absenceReserves returns start of the upcoming absence of members who would leave before
+// finishing a review assigned now: the absence starts within team's reserve days or before
+// the review is due. Empty if the team keeps no reserve.
+func (s *Service) absenceReserves(teamName string, members []models.User, now time.Time) (map[string]time.Time, error) {
+	settings, err := s.teamSettings(teamName)
+	if err != nil || settings.AbsenceReserveDays == 0 || len(members) == 0 {
+		return nil, err
+	}
+	
+	calendar, err := s.teamHolidays(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	reserveEnd := now.AddDate(0, 0, settings.AbsenceReserveDays)
+	limits := make(map[string]time.Time, len(members))
+	latest := reserveEnd
+	for _, member := range members {
+		limit := reserveEnd
+		if deadline := reviewDeadline(now, settings, calendar, member.Region); deadline.After(limit) {
+			limit = deadline
+		}
+		limits[member.UserID] = limit
+		if limit.After(latest) {
+			latest = limit
+		}
+	}
+	
+	upcoming, err := s.storage.GetUpcomingVacations(teamName, now, latest)
+	if err != nil {
+		return nil, err
+	}
+	
+	reserved := make(map[string]time.Time, len(upcoming))
+	for userID, startsAt := range upcoming {
+		if limit, ok := limits[userID]; ok && !startsAt.After(limit) {
+			reserved[userID] = startsAt
+		}
+	}
+	return reserved, nil
+}
+
+// filterByAbsence drops candidates reserved before their upcoming absence
+func (s *Service) filterByAbsence(teamName string, candidates []models.User) ([]models.User, error) {
+	reserved, err := s.absenceReserves(teamName, candidates, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if len(reserved) == 0 {
+		return candidates, nil
+	}
+	
+	available := make([]models.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		if _, ok := reserved[candidate.UserID]; !ok {
+			available = append(available, candidate)
+		}
+	}
+	return available, nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"cmp"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"slices"
+	"strings"
+)
+
+// pseudonymPrefix marks user ids replaced by pseudonyms, 64 bits of the HMAC follow
+const pseudonymPrefix = "anon_"
+
+// pseudonymScanPage - users read per page while looking a pseudonym up
+const pseudonymScanPage = 500
+
+// WithPseudonymKey enables pseudonymized analytics, the key keeps pseudonyms stable across
+// restarts and instances and must stay secret, or anyone could hash known user ids
+func WithPseudonymKey(key []byte) Option {
+	return func(s *Service) {
+		s.pseudonymKey = key
+	}
+}
+
+// WithAnonymizedAnalytics makes stats, reports and per-user metrics always use pseudonyms
+func WithAnonymizedAnalytics() Option {
+	return func(s *Service) {
+		s.anonymizeAlways = true
+	}
+}
+
+// AnonymizesAnalytics reports whether analytics use pseudonyms for every caller
+func (s *Service) AnonymizesAnalytics() bool {
+	return s.anonymizeAlways
+}
+
+// Pseudonym returns the stable pseudonym of the user id, empty ids stay empty
+func (s *Service) Pseudonym(userID string) (string, error) {
+	if len(s.pseudonymKey) == 0 {
+		return "", &ServiceError{
+			Code:    errcode.AnonymizationDisabled,
+			Message: "pseudonym key is not configured",
+		}
+	}
+	if userID == "" {
+		return "", nil
+	}
+	mac := hmac.New(sha256.New, s.pseudonymKey)
+	mac.Write([]byte(userID))
+	return pseudonymPrefix + hex.EncodeToString(mac.Sum(nil)[:8]), nil
+}
+
+// ResolvePseudonym finds the user behind the pseudonym. Pseudonyms can't be reversed, so
+// users are hashed until one matches; real ids are rejected so they can't be mapped to pseudonyms.
+func (s *Service) ResolvePseudonym(pseudonym string) (string, error) {
+	if _, err := s.Pseudonym(""); err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(pseudonym, pseudonymPrefix) {
+		return "", &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "user_id must be a pseudonym in anonymized analytics",
+		}
+	}
+	
+	for offset := 0; ; offset += pseudonymScanPage {
+		users, err := s.storage.ListUsers(offset, pseudonymScanPage)
+		if err != nil {
+			return "", err
+		}
+		for _, user := range users {
+			if candidate, _ := s.Pseudonym(user.UserID); candidate == pseudonym {
+				return user.UserID, nil
+			}
+		}
+		if len(users) < pseudonymScanPage {
+			return "", &ServiceError{
+				Code:    errcode.NotFound,
+				Message: "user not found",
+			}
+		}
+	}
+}
+
+// AnonymizeReviewStats replaces user ids of the stats with pseudonyms
+func (s *Service) AnonymizeReviewStats(stats *models.ReviewStats) error {
+	for _, userID := range []*string{&stats.UserID, &stats.TimeToFirstReview.UserID, &stats.ReviewTime.UserID} {
+		pseudonym, err := s.Pseudonym(*userID)
+		if err != nil {
+			return err
+		}
+		*userID = pseudonym
+	}
+	return nil
+}
+
+// AnonymizeDeclineStats replaces reviewer ids with pseudonyms, ties are reordered by
+// pseudonym so the order doesn't hint at the real ids
+func (s *Service) AnonymizeDeclineStats(stats *models.DeclineStats) error {
+	for i := range stats.Reviewers {
+		pseudonym, err := s.Pseudonym(stats.Reviewers[i].UserID)
+		if err != nil {
+			return err
+		}
+		stats.Reviewers[i].UserID = pseudonym
+	}
+	slices.SortStableFunc(stats.Reviewers, func(a, b models.ReviewerDeclines) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), cmp.Compare(a.UserID, b.UserID))
+	})
+	return nil
+}
+
+// AnonymizeLeaderboard replaces member ids with pseudonyms and drops usernames, members sharing
+// a rank are reordered by pseudonym so the order doesn't hint at the real ids
+func (s *Service) AnonymizeLeaderboard(board *models.Leaderboard) error {
+	for i := range board.Entries {
+		pseudonym, err := s.Pseudonym(board.Entries[i].UserID)
+		if err != nil {
+			return err
+		}
+		board.Entries[i].UserID = pseudonym
+		board.Entries[i].Username = ""
+	}
+	slices.SortStableFunc(board.Entries, func(a, b models.LeaderboardEntry) int {
+		return cmp.Or(cmp.Compare(a.Rank, b.Rank), cmp.Compare(a.UserID, b.UserID))
+	})
+	return nil
+}
+
+// AnonymizeTeamReport replaces ids of bottleneck reviewers with pseudonyms
+func (s *Service) AnonymizeTeamReport(report *models.TeamReport) error {
+	for i := range report.Bottlenecks {
+		pseudonym, err := s.Pseudonym(report.Bottlenecks[i].UserID)
+		if err != nil {
+			return err
+		}
+		report.Bottlenecks[i].UserID = pseudonym
+	}
+	return nil
+}
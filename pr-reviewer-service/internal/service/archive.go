@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// archiveBatchSize - PRs moved per transaction so a backlog doesn't hold locks for long
+const archiveBatchSize = 500
+
+// ArchiveMergedPRs moves long-merged PRs to the archive, run by the scheduler.
+// Does nothing unless WithArchiveAfter is set.
+func (s *Service) ArchiveMergedPRs(ctx context.Context) error {
+	if s.archiveAfter <= 0 {
+		return nil
+	}
+	
+	cutoff := time.Now().UTC().Add(-s.archiveAfter)
+	total := 0
+	for ctx.Err() == nil {
+		archived, err := s.storage.ArchiveMergedPRs(cutoff, archiveBatchSize)
+		if err != nil {
+			return err
+		}
+		total += len(archived)
+		if len(archived) < archiveBatchSize {
+			break
+		}
+	}
+	
+	if total > 0 {
+		log.Printf("Archived %d merged pull requests", total)
+	}
+	return ctx.Err()
+}
+
+// GetArchivedPullRequest returns an archived PR with its reviewers
+func (s *Service) GetArchivedPullRequest(prID string) (*models.PullRequest, error) {
+	pr, err := s.storage.GetArchivedPullRequest(prID)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "archived pull request not found",
+		}
+	}
+	return pr, nil
+}
+
+// GetArchivedPRHistory returns rows of other tables archived with the PR as they were stored,
+// admin only since they hold comments and blind review data
+func (s *Service) GetArchivedPRHistory(actorID, prID string) ([]models.ArchivedRow, error) {
+	actor, err := s.storage.GetUser(actorID)
+	if err != nil || actor.Role != RoleAdmin {
+		return nil, &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only admin can read archived PR history",
+		}
+	}
+	return s.storage.GetArchivedPRHistory(prID)
+}
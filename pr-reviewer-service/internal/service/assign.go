@@ -0,0 +1,339 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/storage"
+	"pr-reviewer-service/internal/strategy"
+)
+
+// ForceAssignReviewer assigns the named teammate bypassing selection strategy and caps, lead/admin only
+func (s *Service) ForceAssignReviewer(prID, userID, actorID string) (*models.PullRequest, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	if _, err := s.authorizeTeam(actorID, pr.TeamName); err != nil {
+		return nil, err
+	}
+	
+	if err := ensurePROpen(pr, "cannot assign on merged PR"); err != nil {
+		return nil, err
+	}
+	
+	reviewer, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "reviewer not found",
+		}
+	}
+	if reviewer.TeamName != pr.TeamName {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "reviewer is not a member of team " + pr.TeamName,
+		}
+	}
+	if !reviewer.IsActive {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "reviewer is not active",
+		}
+	}
+	if reviewer.UserID == pr.AuthorID {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "author cannot review own PR",
+		}
+	}
+	
+	isAssigned, err := s.storage.IsReviewerAssigned(prID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isAssigned {
+		return nil, &ServiceError{
+			Code:    errcode.AlreadyAssigned,
+			Message: "user is already assigned as reviewer to this PR",
+		}
+	}
+	
+	if err := s.addReviewer(prID, userID, pr.TeamName, AssignmentManual); err != nil {
+		return nil, err
+	}
+	
+	payload := map[string]interface{}{
+		"user_id":         userID,
+		"assignment_type": AssignmentManual,
+		"manual":          true,
+	}
+	if err := s.recordEvent(prID, EventReviewerAssigned, actorID, payload); err != nil {
+		return nil, err
+	}
+	if err := s.audit(actorID, AuditForceAssign, prID, map[string]interface{}{"user_id": userID}); err != nil {
+		return nil, err
+	}
+	
+	return s.storage.GetPullRequest(prID)
+}
+
+// AddExtraReviewer appends one more reviewer chosen by the normal selection, skipping current reviewers
+func (s *Service) AddExtraReviewer(prID string) (*models.PullRequest, string, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, "", &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	if err := ensurePROpen(pr, "cannot add reviewer on merged PR"); err != nil {
+		return nil, "", err
+	}
+	
+	assigned := make(map[string]bool, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		assigned[reviewerID] = true
+	}
+	
+	var newReviewerID string
+	err = s.storage.WithTeamLock(pr.TeamName, func() error {
+		candidates, err := s.storage.GetActiveTeamMembers(pr.TeamName, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+	
+		candidates, err = s.filterByPool(pr.TeamName, pr.ReviewerPool, candidates)
+		if err != nil {
+			return err
+		}
+	
+		candidates, err = s.filterByCapacity(pr.TeamName, candidates)
+		if err != nil {
+			return err
+		}
+	
+		candidates, err = s.filterByCooldown(pr.TeamName, candidates)
+		if err != nil {
+			return err
+		}
+	
+		candidates, err = s.filterByRules(pr.AuthorID, candidates)
+		if err != nil {
+			return err
+		}
+	
+		var availableCandidates []models.User
+		for _, candidate := range candidates {
+			if !assigned[candidate.UserID] {
+				availableCandidates = append(availableCandidates, candidate)
+			}
+		}
+	
+		if len(availableCandidates) == 0 {
+			return &ServiceError{
+				Code:    errcode.NoCandidate,
+				Message: "no active reviewer candidate available in team",
+			}
+		}
+	
+		preferredIDs, err := s.preferredReviewers(prID)
+		if err != nil {
+			return err
+		}
+		if preferred, _ := splitPreferred(availableCandidates, preferredIDs); len(preferred) > 0 {
+			availableCandidates = preferred
+		}
+	
+		newReviewerID = availableCandidates[s.rand.Intn(len(availableCandidates))].UserID
+	
+		if err := s.addReviewer(prID, newReviewerID, pr.TeamName, AssignmentAuto); err != nil {
+			return err
+		}
+	
+		payload := map[string]interface{}{
+			"user_id":         newReviewerID,
+			"assignment_type": AssignmentAuto,
+			"extra":           true,
+		}
+		return s.recordEvent(prID, EventReviewerAssigned, "", payload)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	
+	pr, err = s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	return pr, newReviewerID, nil
+}
+
+// Volunteer assigns the user to PR at their own request, optionally replacing an untouched auto-assigned reviewer
+func (s *Service) Volunteer(prID, userID, replaceUserID string) (*models.PullRequest, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	if err := ensurePROpen(pr, "cannot volunteer on merged PR"); err != nil {
+		return nil, err
+	}
+	
+	volunteer, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	if userID == pr.AuthorID {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "author cannot review own PR",
+		}
+	}
+	if volunteer.TeamName != pr.TeamName || !volunteer.IsActive {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "only active members of team " + pr.TeamName + " can volunteer",
+		}
+	}
+	
+	isAssigned, err := s.storage.IsReviewerAssigned(prID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isAssigned {
+		return nil, &ServiceError{
+			Code:    errcode.AlreadyAssigned,
+			Message: "user is already assigned as reviewer to this PR",
+		}
+	}
+	
+	err = s.storage.WithTeamLock(pr.TeamName, func() error {
+		available, err := s.filterByCapacity(pr.TeamName, []models.User{*volunteer})
+		if err != nil {
+			return err
+		}
+		if len(available) == 0 {
+			return &ServiceError{
+				Code:    errcode.OverCapacity,
+				Message: "user has reached the open review limit",
+			}
+		}
+	
+		reviewTeam := pr.TeamName
+		if replaceUserID != "" {
+			replaced, err := s.storage.GetReviewerAssignment(prID, replaceUserID)
+			if err != nil {
+				return &ServiceError{
+					Code:    errcode.NotAssigned,
+					Message: "replaced user is not assigned as reviewer to this PR",
+				}
+			}
+			if replaced.AssignmentType != AssignmentAuto || replaced.Status != ReviewerPending || replaced.FirstActionAt != nil {
+				return &ServiceError{
+					Code:    errcode.InvalidRequest,
+					Message: "only pending auto-assigned reviewers can be replaced",
+				}
+			}
+			reviewTeam = replaced.TeamName
+		}
+	
+		// replaced reviewer is dropped only together with the new assignment
+		err = s.storage.InTx(func(repos storage.Repos) error {
+			if replaceUserID != "" {
+				if err := repos.RemoveReviewer(prID, replaceUserID); err != nil {
+					return err
+				}
+			}
+			return repos.AddTeamReviewer(prID, userID, reviewTeam, AssignmentVolunteer)
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.createChecklist(prID, userID, pr.TeamName); err != nil {
+			return err
+		}
+	
+		eventType := EventReviewerAssigned
+		payload := map[string]interface{}{
+			"user_id":         userID,
+			"assignment_type": AssignmentVolunteer,
+			"volunteer":       true,
+		}
+		if replaceUserID != "" {
+			eventType = EventReviewerReassigned
+			payload = map[string]interface{}{
+				"old_user_id":     replaceUserID,
+				"new_user_id":     userID,
+				"assignment_type": AssignmentVolunteer,
+				"volunteer":       true,
+			}
+		}
+		return s.recordEvent(prID, eventType, userID, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.storage.GetPullRequest(prID)
+}
+
+// PreviewReviewers runs reviewer selection for a would-be PR without persisting anything.
+// Picks are random, so repeated calls may differ unless a request seed is given.
+func (s *Service) PreviewReviewers(teamName, authorID, poolName string, count int, seed *int64) ([]string, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	if poolName != "" {
+		if err := s.ensurePool(teamName, poolName); err != nil {
+			return nil, err
+		}
+	}
+	
+	rng, err := s.randFor(seed)
+	if err != nil {
+		return nil, err
+	}
+	
+	if count == 0 {
+		settings, err := s.teamSettings(teamName)
+		if err != nil {
+			return nil, err
+		}
+		count = settings.ReviewerCount
+	}
+	if count < 0 || count > maxReviewerCount {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("count must be between 1 and %d", maxReviewerCount),
+		}
+	}
+	
+	if authorID != "" {
+		if _, err := s.storage.GetUser(authorID); err != nil {
+			return nil, &ServiceError{
+				Code:    errcode.NotFound,
+				Message: "author not found",
+			}
+		}
+	}
+	
+	return s.assignReviewers(rng, strategy.Request{
+		TeamName:     teamName,
+		AuthorID:     authorID,
+		ReviewerPool: poolName,
+		Count:        count,
+	}, nil)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strconv"
+)
+
+// Audited actions
+const (
+	AuditForceAssign       = "FORCE_ASSIGN"
+	AuditEventReplay       = "EVENT_REPLAY"
+	AuditEventExport       = "EVENT_EXPORT"
+	AuditRebuildProjection = "REBUILD_PROJECTION"
+	AuditRebuildReadModels = "REBUILD_READ_MODELS"
+	AuditRepositoryOwner   = "REPOSITORY_OWNER"
+	AuditMerge             = "MERGE"
+)
+
+func (s *Service) audit(actorID, action, prID string, details map[string]interface{}) error {
+	return s.storage.AddAuditEntry(&models.AuditEntry{
+		ActorID:       actorID,
+		Action:        action,
+		PullRequestID: prID,
+		Details:       details,
+	})
+}
+
+// authorizeTeam checks that actor is admin or lead of the team
+func (s *Service) authorizeTeam(actorID, teamName string) (*models.User, error) {
+	actor, err := s.storage.GetUser(actorID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "unknown actor",
+		}
+	}
+	
+	if actor.Role == RoleAdmin || (actor.Role == RoleLead && actor.TeamName == teamName) {
+		return actor, nil
+	}
+	return nil, &ServiceError{
+		Code:    errcode.Forbidden,
+		Message: "only team lead or admin can do this",
+	}
+}
+
+// ListAuditEntries returns a page of audit log, newest first, admin only
+func (s *Service) ListAuditEntries(actorID, cursor string, limit int) ([]models.AuditEntry, string, error) {
+	actor, err := s.storage.GetUser(actorID)
+	if err != nil || actor.Role != RoleAdmin {
+		return nil, "", &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only admin can read audit log",
+		}
+	}
+	
+	page, err := newPage(cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	entries, err := s.storage.ListAuditEntries(page)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	entries, next := trimPage(entries, page, func(entry models.AuditEntry) models.Cursor {
+		return models.Cursor{CreatedAt: entry.CreatedAt, ID: strconv.FormatInt(entry.ID, 10)}
+	})
+	return entries, next, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// Member availability statuses, in the order they are checked
+const (
+	AvailabilityInactive    = "INACTIVE"
+	AvailabilityVacation    = "VACATION"
+	AvailabilityAtCap       = "AT_CAP"
+	AvailabilityCooldown    = "COOLDOWN"
+	AvailabilityAbsenceSoon = "ABSENCE_SOON" // reserved before a registered absence
+	AvailabilityQuietHours  = "QUIET_HOURS"  // still assignable, notifications are held
+	AvailabilityActive      = "ACTIVE"
+)
+
+// GetTeamAvailability explains for every member whether automatic assignment can pick them now
+func (s *Service) GetTeamAvailability(teamName string) (*models.TeamAvailability, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	settings, err := s.teamSettings(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	members, err := s.storage.GetTeamMembers(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	now := time.Now().UTC()
+	vacations, err := s.storage.GetTeamVacations(teamName, now)
+	if err != nil {
+		return nil, err
+	}
+	
+	calendar, err := s.teamHolidays(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	loads, err := s.storage.GetOpenReviewLoads(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	recent, err := s.recentAssignments(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	reserved, err := s.absenceReserves(teamName, members, now)
+	if err != nil {
+		return nil, err
+	}
+	
+	availability := &models.TeamAvailability{
+		TeamName: teamName,
+		Members:  make([]models.MemberAvailability, 0, len(members)),
+	}
+	for i := range members {
+		user := &members[i]
+		member := models.MemberAvailability{
+			UserID:          user.UserID,
+			Username:        user.Username,
+			OpenReviews:     loads[user.UserID],
+			MaxOpenReviews:  reviewCap(user, settings),
+			AssignedLast24h: recent[user.UserID],
+		}
+		if member.MaxOpenReviews != nil {
+			remaining := max(*member.MaxOpenReviews-member.OpenReviews, 0)
+			member.RemainingCapacity = &remaining
+		}
+		dailyCap := dailyAssignmentCap(user, settings)
+	
+		endsAt, onVacation := vacations[user.UserID]
+		absenceStartsAt, onReserve := reserved[user.UserID]
+		quietUntil := quietHoursEnd(user, now)
+		switch {
+		case !user.IsActive:
+			member.Status = AvailabilityInactive
+		case onVacation:
+			member.Status = AvailabilityVacation
+			member.Until = &endsAt
+		case calendar.observes(now, user.Region):
+			member.Status = AvailabilityVacation
+			dayEnd := now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+			member.Until = &dayEnd
+		case member.RemainingCapacity != nil && *member.RemainingCapacity == 0:
+			member.Status = AvailabilityAtCap
+		case dailyCap != nil && member.AssignedLast24h >= *dailyCap:
+			member.Status = AvailabilityCooldown
+		case onReserve:
+			member.Status = AvailabilityAbsenceSoon
+			member.AbsenceStartsAt = &absenceStartsAt
+		case !quietUntil.IsZero():
+			member.Status = AvailabilityQuietHours
+			member.Until = &quietUntil
+		default:
+			member.Status = AvailabilityActive
+		}
+	
+		member.Assignable = member.Status == AvailabilityActive || member.Status == AvailabilityQuietHours
+		if member.Assignable {
+			availability.Assignable++
+		}
+		availability.Members = append(availability.Members, member)
+	}
+	
+	return availability, nil
+}
+
+// AssignableReviewers returns the number of members automatic assignment can pick now per team,
+// used by metrics
+func (s *Service) AssignableReviewers() (map[string]int, error) {
+	const pageSize = 500
+	
+	counts := make(map[string]int)
+	for offset := 0; ; offset += pageSize {
+		teams, err := s.storage.ListTeamNames(offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, teamName := range teams {
+			availability, err := s.GetTeamAvailability(teamName)
+			if err != nil {
+				return nil, err
+			}
+			counts[teamName] = availability.Assignable
+		}
+		if len(teams) < pageSize {
+			return counts, nil
+		}
+	}
+}
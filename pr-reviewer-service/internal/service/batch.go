@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// maxMergeBatch - PRs accepted by one batch merge call
+const maxMergeBatch = 500
+
+// Batch merge item statuses
+const (
+	MergeResultMerged = "MERGED"
+	MergeResultFailed = "FAILED"
+)
+
+// MergePullRequests merges PRs one by one in the given order, each in a transaction of its own,
+// a failed PR doesn't stop the rest. Items without merged_by take defaultMergedBy.
+func (s *Service) MergePullRequests(items []models.MergeBatchItem, defaultMergedBy string) ([]models.MergeResult, error) {
+	if len(items) == 0 || len(items) > maxMergeBatch {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("batch must contain between 1 and %d pull requests", maxMergeBatch),
+		}
+	}
+	
+	results := make([]models.MergeResult, 0, len(items))
+	for _, item := range items {
+		if item.MergedBy == "" {
+			item.MergedBy = defaultMergedBy
+		}
+	
+		result := models.MergeResult{PullRequestID: item.PullRequestID, Status: MergeResultMerged}
+		pr, err := s.mergeBatchItem(item)
+		if err == nil {
+			result.PR = pr
+			results = append(results, result)
+			continue
+		}
+	
+		// each merge is one transaction, a failed item is left as it was
+		result.Status = MergeResultFailed
+		if _, ok := err.(*ServiceError); !ok {
+			log.Printf("Batch merge of %s failed: %v", item.PullRequestID, err)
+		}
+		result.Error = errorDetail(err, "failed to merge pull request")
+		results = append(results, result)
+	}
+	
+	return results, nil
+}
+
+func (s *Service) mergeBatchItem(item models.MergeBatchItem) (*models.PullRequest, error) {
+	exists, err := s.storage.PRExists(item.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	return s.MergePullRequest(item.PullRequestID, item.MergeInfo)
+}
@@ -0,0 +1,128 @@
+package service
+
+import "pr-reviewer-service/internal/models"
+
+// blindPayloadKeys - event payload fields naming reviewers, dropped from what a blind author sees
+var blindPayloadKeys = []string{"user_id", "old_user_id", "new_user_id", "reviewer_id", "reviewers", "leads", "shadows"}
+
+// reviewersHidden reports whether the viewer may be the author of an open PR of a blind review
+// team that no reviewer has approved yet. An unknown viewer is treated as the author, so
+// unauthenticated reads fail closed.
+func (s *Service) reviewersHidden(pr *models.PullRequest, viewerID string) (bool, error) {
+	if (viewerID != "" && viewerID != pr.AuthorID) || pr.Status == PRStatusMerged {
+		return false, nil
+	}
+	settings, err := s.teamSettings(pr.TeamName)
+	if err != nil || !settings.BlindReview {
+		return false, err
+	}
+	
+	reviewers, err := s.storage.GetReviewerAssignments(pr.PullRequestID)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range reviewers {
+		if r.Status == ReviewerApproved {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// MaskPullRequest replaces the reviewers with their count while they are hidden from the viewer,
+// returns whether they were
+func (s *Service) MaskPullRequest(pr *models.PullRequest, viewerID string) (bool, error) {
+	hidden, err := s.reviewersHidden(pr, viewerID)
+	if err != nil || !hidden {
+		return false, err
+	}
+	
+	pr.HiddenReviewers = len(pr.AssignedReviewers)
+	pr.AssignedReviewers = []string{}
+	pr.ShadowReviewers = nil
+	pr.AssignmentDebug = nil
+	return true, nil
+}
+
+// MaskTimeline drops who reviews the PR from its events while reviewers are hidden from the viewer
+func (s *Service) MaskTimeline(prID, viewerID string, events []models.PREvent) error {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return err
+	}
+	hidden, err := s.reviewersHidden(pr, viewerID)
+	if err != nil || !hidden {
+		return err
+	}
+	
+	for i := range events {
+		maskEvent(&events[i], pr.AuthorID)
+	}
+	return nil
+}
+
+// maskEvent drops the actor, unless it's the author, and the payload fields naming reviewers
+func maskEvent(event *models.PREvent, authorID string) {
+	if event.ActorID != authorID {
+		event.ActorID = ""
+	}
+	payload := make(map[string]interface{}, len(event.Payload))
+	for key, value := range event.Payload {
+		payload[key] = value
+	}
+	for _, key := range blindPayloadKeys {
+		delete(payload, key)
+	}
+	event.Payload = payload
+}
+
+// MaskTeamBoard drops reviewer ids from board PRs whose reviewers are hidden from the viewer,
+// deadlines and SLA states stay
+func (s *Service) MaskTeamBoard(board *models.TeamBoard, viewerID string) error {
+	for i := range board.PullRequests {
+		boardPR := &board.PullRequests[i]
+		pr := &models.PullRequest{
+			PullRequestID: boardPR.PullRequestID,
+			AuthorID:      boardPR.AuthorID,
+			TeamName:      board.TeamName,
+			Status:        PRStatusOpen,
+		}
+		hidden, err := s.reviewersHidden(pr, viewerID)
+		if err != nil {
+			return err
+		}
+		if !hidden {
+			continue
+		}
+		for j := range boardPR.Reviewers {
+			boardPR.Reviewers[j].UserID = ""
+		}
+	}
+	return nil
+}
+
+// MaskReviewTeams keeps approval progress of review teams but not who reviews for them
+func (s *Service) MaskReviewTeams(prID, viewerID string, statuses []models.ReviewTeamStatus) error {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return err
+	}
+	hidden, err := s.reviewersHidden(pr, viewerID)
+	if err != nil || !hidden {
+		return err
+	}
+	
+	for i := range statuses {
+		statuses[i].Reviewers = []string{}
+	}
+	return nil
+}
+
+// blindEvent reports whether the event reveals reviewers, such events aren't sent to a blind author
+func blindEvent(eventType string) bool {
+	switch eventType {
+	case EventReviewerAssigned, EventReviewerReassigned, EventReviewAction, EventShadowAssigned, EventEscalated, EventReviewPhase:
+		return true
+	}
+	return false
+}
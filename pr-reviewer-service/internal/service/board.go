@@ -0,0 +1,115 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// SLA states on team boards, a PR takes the worst state of its reviewers
+const (
+	SLAStateUnassigned = "UNASSIGNED"
+	SLAStatePaused     = "PAUSED" // waits for the author, see PauseReview
+	SLAStateOK         = "OK"
+	SLAStateDueSoon    = "DUE_SOON"
+	SLAStateBreached   = "BREACHED"
+)
+
+// boardDueSoonWindow - reviews due within this window are shown as due soon
+const boardDueSoonWindow = 2 * time.Hour
+
+var slaStateRank = map[string]int{
+	SLAStateUnassigned: 0,
+	SLAStatePaused:     1,
+	SLAStateOK:         2,
+	SLAStateDueSoon:    3,
+	SLAStateBreached:   4,
+}
+
+func slaState(deadline, now time.Time) string {
+	switch {
+	case !now.Before(deadline):
+		return SLAStateBreached
+	case deadline.Sub(now) <= boardDueSoonWindow:
+		return SLAStateDueSoon
+	default:
+		return SLAStateOK
+	}
+}
+
+// GetTeamBoard returns team's open PRs with reviewers, time waiting and SLA state
+func (s *Service) GetTeamBoard(teamName string) (*models.TeamBoard, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	settings, err := s.teamSettings(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	prs, err := s.storage.GetTeamOpenPRs(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	assignments, err := s.storage.GetTeamOpenAssignments(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	now := time.Now().UTC()
+	holidaysByTeam := make(map[string]holidays)
+	reviewers := make(map[string][]models.BoardReviewer, len(prs))
+	for _, a := range assignments {
+		calendar, err := s.cachedHolidays(holidaysByTeam, a.ReviewerTeam)
+		if err != nil {
+			return nil, err
+		}
+	
+		deadline := assignmentDeadline(a, settings, calendar, now)
+		state := slaState(deadline, now)
+		if a.SLAPausedAt != nil {
+			state = SLAStatePaused
+		}
+		reviewers[a.PullRequestID] = append(reviewers[a.PullRequestID], models.BoardReviewer{
+			UserID:      a.ReviewerID,
+			AssignedAt:  a.AssignedAt,
+			Deadline:    deadline,
+			SLAState:    state,
+			SLAPausedAt: a.SLAPausedAt,
+		})
+	}
+	
+	board := &models.TeamBoard{
+		TeamName:     teamName,
+		GeneratedAt:  now,
+		OpenPRs:      len(prs),
+		PullRequests: make([]models.BoardPR, 0, len(prs)),
+	}
+	for _, pr := range prs {
+		item := models.BoardPR{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Priority:        pr.Priority,
+			CreatedAt:       pr.CreatedAt,
+			WaitingMinutes:  int(now.Sub(pr.CreatedAt).Minutes()),
+			SLAState:        SLAStateUnassigned,
+			Reviewers:       reviewers[pr.PullRequestID],
+		}
+		if item.Reviewers == nil {
+			item.Reviewers = []models.BoardReviewer{}
+		}
+		for _, reviewer := range item.Reviewers {
+			if slaStateRank[reviewer.SLAState] > slaStateRank[item.SLAState] {
+				item.SLAState = reviewer.SLAState
+			}
+		}
+		if item.SLAState == SLAStateBreached {
+			board.Breached++
+		}
+		board.PullRequests = append(board.PullRequests, item)
+	}
+	
+	return board, nil
+}
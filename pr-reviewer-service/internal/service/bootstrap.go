@@ -0,0 +1,129 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/policy"
+	"pr-reviewer-service/internal/storage"
+	"strings"
+)
+
+// BootstrapTeam creates the team with its members, settings and policy script in one
+// transaction. Everything is validated first, nothing is stored if any part fails.
+func (s *Service) BootstrapTeam(req *models.TeamBootstrap) (*models.TeamBootstrapResult, error) {
+	if req.TeamName == "" {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "team_name is required",
+		}
+	}
+	exists, err := s.storage.TeamExists(req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, &ServiceError{
+			Code:    errcode.TeamExists,
+			Message: "team already exists",
+		}
+	}
+	
+	if err := normalizeMembers(req.Members); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(req.Members))
+	for _, member := range req.Members {
+		if member.UserID == "" {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "user_id is required",
+			}
+		}
+		if seen[member.UserID] {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "duplicate member " + member.UserID,
+			}
+		}
+		seen[member.UserID] = true
+	}
+	
+	settings := req.Settings
+	if settings == nil {
+		settings = &models.TeamSettings{}
+	}
+	settings.TeamName = req.TeamName
+	if err := s.validateTeamSettings(settings); err != nil {
+		return nil, err
+	}
+	
+	var teamPolicy *models.TeamPolicy
+	if source := strings.TrimSpace(req.Policy); source != "" {
+		if err := s.authorizeBootstrap(req); err != nil {
+			return nil, err
+		}
+		if _, err := policy.Compile(source, policyVars); err != nil {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "invalid policy: " + err.Error(),
+			}
+		}
+		teamPolicy = &models.TeamPolicy{
+			TeamName:  req.TeamName,
+			Source:    source,
+			UpdatedBy: req.ActorID,
+		}
+	}
+	
+	err = s.storage.InTx(func(repos storage.Repos) error {
+		if err := repos.CreateTeam(req.TeamName); err != nil {
+			return err
+		}
+		if err := createMembers(repos, req.TeamName, req.Members); err != nil {
+			return err
+		}
+		if err := repos.SaveTeamSettings(settings); err != nil {
+			return err
+		}
+		if teamPolicy != nil {
+			return repos.SaveTeamPolicy(teamPolicy)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	if teamPolicy != nil {
+		details := map[string]interface{}{
+			"team_name": req.TeamName,
+			"source":    teamPolicy.Source,
+		}
+		if err := s.audit(req.ActorID, AuditPolicyUpdate, "", details); err != nil {
+			return nil, err
+		}
+	}
+	
+	return &models.TeamBootstrapResult{
+		Team:     models.TeamResponse{TeamName: req.TeamName, Members: req.Members},
+		Settings: settings,
+		Policy:   teamPolicy,
+	}, nil
+}
+
+// authorizeBootstrap checks that actor is admin or one of the new team's leads
+func (s *Service) authorizeBootstrap(req *models.TeamBootstrap) error {
+	for _, member := range req.Members {
+		if member.UserID == req.ActorID && member.Role == RoleLead {
+			return nil
+		}
+	}
+	actor, err := s.storage.GetUser(req.ActorID)
+	if err == nil && actor.Role == RoleAdmin {
+		return nil
+	}
+	return &ServiceError{
+		Code:    errcode.Forbidden,
+		Message: "only team lead or admin can do this",
+	}
+}
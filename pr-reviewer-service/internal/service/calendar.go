@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/calendar"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+const (
+	// VacationSourceGoogle marks vacations imported from Google Calendar
+	VacationSourceGoogle = "GOOGLE_CALENDAR"
+
+	// calendarSyncHorizon - how far ahead OOO events are imported
+	calendarSyncHorizon = 60 * 24 * time.Hour
+
+	// calendarStateTTL - how long the consent page may take, older OAuth states are rejected
+	calendarStateTTL = 10 * time.Minute
+	// calendarStateClockSkew - tolerated skew between instances signing and verifying a state
+	calendarStateClockSkew = time.Minute
+)
+
+// CalendarProvider - external calendar with out-of-office events
+type CalendarProvider interface {
+	SignState(userID, nonce string, issuedAt time.Time) string
+	VerifyState(state string) (userID, nonce string, issuedAt time.Time, err error)
+	AuthURL(state string) string
+	Exchange(ctx context.Context, code string) (*calendar.Token, error)
+	Refresh(ctx context.Context, refreshToken string) (*calendar.Token, error)
+	OutOfOffice(ctx context.Context, accessToken string, from, to time.Time) ([]calendar.Event, error)
+}
+
+// ConnectCalendar returns consent page URL for the user
+func (s *Service) ConnectCalendar(userID string) (string, error) {
+	if s.calendar == nil {
+		return "", errCalendarDisabled()
+	}
+	
+	if _, err := s.storage.GetUser(userID); err != nil {
+		return "", &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate OAuth state: %w", err)
+	}
+	state := s.calendar.SignState(userID, hex.EncodeToString(nonce), time.Now().UTC())
+	return s.calendar.AuthURL(state), nil
+}
+
+// CompleteCalendarConnect handles OAuth callback and runs the first sync, a state is accepted
+// once and only within calendarStateTTL of ConnectCalendar
+func (s *Service) CompleteCalendarConnect(ctx context.Context, code, state string) (string, error) {
+	if s.calendar == nil {
+		return "", errCalendarDisabled()
+	}
+	
+	userID, nonce, issuedAt, err := s.calendar.VerifyState(state)
+	if err != nil {
+		return "", &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "invalid OAuth state",
+		}
+	}
+	age := time.Now().UTC().Sub(issuedAt)
+	if age > calendarStateTTL || age < -calendarStateClockSkew {
+		return "", &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "OAuth state expired, connect the calendar again",
+		}
+	}
+	
+	// the state is spent before the code is exchanged, so a replayed callback can't race it
+	recorded, err := s.storage.RecordCalendarState(nonce, calendarStateTTL+calendarStateClockSkew)
+	if err != nil {
+		return "", err
+	}
+	if !recorded {
+		return "", &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "OAuth state was already used",
+		}
+	}
+	
+	token, err := s.calendar.Exchange(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	if token.RefreshToken == "" {
+		return "", &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "calendar access was granted without offline access",
+		}
+	}
+	
+	calendarToken := &models.CalendarToken{
+		UserID:       userID,
+		RefreshToken: token.RefreshToken,
+		AccessToken:  token.AccessToken,
+		ExpiresAt:    token.ExpiresAt,
+	}
+	if err := s.storage.SaveCalendarToken(calendarToken); err != nil {
+		return "", err
+	}
+	
+	if err := s.syncCalendar(ctx, calendarToken); err != nil {
+		log.Printf("Initial calendar sync for %s failed: %v", userID, err)
+	}
+	
+	return userID, nil
+}
+
+// DisconnectCalendar forgets tokens and drops imported vacations
+func (s *Service) DisconnectCalendar(userID string) error {
+	if err := s.storage.DeleteCalendarToken(userID); err != nil {
+		return err
+	}
+	
+	return s.storage.ReplaceVacations(userID, VacationSourceGoogle, time.Now().UTC(), nil)
+}
+
+// SyncCalendars imports OOO events of every connected user, one failing user doesn't stop the rest
+func (s *Service) SyncCalendars(ctx context.Context) error {
+	if s.calendar == nil {
+		return nil
+	}
+	
+	tokens, err := s.storage.GetCalendarTokens()
+	if err != nil {
+		return err
+	}
+	
+	failed := 0
+	for i := range tokens {
+		if err := s.syncCalendar(ctx, &tokens[i]); err != nil {
+			log.Printf("Calendar sync for %s failed: %v", tokens[i].UserID, err)
+			failed++
+		}
+	}
+	
+	if failed > 0 {
+		return fmt.Errorf("calendar sync failed for %d of %d users", failed, len(tokens))
+	}
+	return nil
+}
+
+func (s *Service) syncCalendar(ctx context.Context, token *models.CalendarToken) error {
+	now := time.Now().UTC()
+	
+	if token.AccessToken == "" || !token.ExpiresAt.After(now.Add(time.Minute)) {
+		refreshed, err := s.calendar.Refresh(ctx, token.RefreshToken)
+		if err != nil {
+			return err
+		}
+		token.AccessToken = refreshed.AccessToken
+		token.RefreshToken = refreshed.RefreshToken
+		token.ExpiresAt = refreshed.ExpiresAt
+	}
+	
+	events, err := s.calendar.OutOfOffice(ctx, token.AccessToken, now, now.Add(calendarSyncHorizon))
+	if err != nil {
+		return err
+	}
+	
+	vacations := make([]models.Vacation, 0, len(events))
+	for _, event := range events {
+		if !event.EndsAt.After(event.StartsAt) {
+			continue
+		}
+		vacations = append(vacations, models.Vacation{
+			UserID:     token.UserID,
+			StartsAt:   event.StartsAt,
+			EndsAt:     event.EndsAt,
+			Source:     VacationSourceGoogle,
+			ExternalID: event.ID,
+		})
+	}
+	
+	if err := s.storage.ReplaceVacations(token.UserID, VacationSourceGoogle, now, vacations); err != nil {
+		return err
+	}
+	
+	token.LastSyncedAt = &now
+	return s.storage.SaveCalendarToken(token)
+}
+
+func errCalendarDisabled() error {
+	return &ServiceError{
+		Code:    errcode.CalendarDisabled,
+		Message: "calendar integration is not configured",
+	}
+}
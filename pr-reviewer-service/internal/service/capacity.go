@@ -0,0 +1,155 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// intakeWindowWeeks - history used to project weekly review intake
+const intakeWindowWeeks = 4
+
+// reviewCap returns personal cap or team cap, nil means unlimited
+func reviewCap(user *models.User, settings *models.TeamSettings) *int {
+	if user.MaxOpenReviews != nil {
+		return user.MaxOpenReviews
+	}
+	return settings.MaxOpenReviews
+}
+
+// filterByCapacity drops candidates who already reached their review cap
+func (s *Service) filterByCapacity(teamName string, candidates []models.User) ([]models.User, error) {
+	settings, err := s.teamSettings(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	loads, err := s.storage.GetOpenReviewLoads(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	available := make([]models.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		limit := reviewCap(&candidate, settings)
+		if limit != nil && loads[candidate.UserID] >= *limit {
+			continue
+		}
+		available = append(available, candidate)
+	}
+	
+	return available, nil
+}
+
+// GetTeamCapacity reports free review slots of active members and expected weekly intake
+func (s *Service) GetTeamCapacity(teamName string) (*models.TeamCapacity, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	settings, err := s.teamSettings(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	members, err := s.storage.GetActiveTeamMembers(teamName, "")
+	if err != nil {
+		return nil, err
+	}
+	
+	loads, err := s.storage.GetOpenReviewLoads(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	recent, err := s.recentAssignments(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	now := time.Now().UTC()
+	reserved, err := s.absenceReserves(teamName, members, now)
+	if err != nil {
+		return nil, err
+	}
+	
+	since := now.AddDate(0, 0, -7*intakeWindowWeeks)
+	assigned, err := s.storage.CountTeamAssignmentsSince(teamName, since)
+	if err != nil {
+		return nil, err
+	}
+	
+	capacity := &models.TeamCapacity{
+		TeamName:        teamName,
+		ActiveMembers:   len(members),
+		ProjectedIntake: float64(assigned) / intakeWindowWeeks,
+		Members:         make([]models.MemberCapacity, 0, len(members)),
+	}
+	
+	unlimited := false
+	total, free := 0, 0
+	for i := range members {
+		load := loads[members[i].UserID]
+		member := models.MemberCapacity{
+			UserID:      members[i].UserID,
+			OpenReviews: load,
+		}
+		if limit := dailyAssignmentCap(&members[i], settings); limit != nil && recent[members[i].UserID] >= *limit {
+			member.InCooldown = true
+		}
+	
+		// members about to leave take no new reviews, their free slots would be a false promise
+		absenceStartsAt, onReserve := reserved[members[i].UserID]
+		if onReserve {
+			member.AbsenceStartsAt = &absenceStartsAt
+		}
+	
+		if limit := reviewCap(&members[i], settings); limit != nil {
+			slots := *limit - load
+			if slots < 0 || onReserve {
+				slots = 0
+			}
+			member.MaxOpenReviews = limit
+			member.FreeSlots = &slots
+			total += *limit
+			free += slots
+		} else {
+			unlimited = true
+		}
+	
+		capacity.OpenReviews += load
+		capacity.Members = append(capacity.Members, member)
+	}
+	
+	if !unlimited {
+		capacity.TotalCapacity = &total
+		capacity.OpenSlots = &free
+	}
+	
+	return capacity, nil
+}
+
+// SetUserMaxOpenReviews overrides team cap for a single user, nil removes the override
+func (s *Service) SetUserMaxOpenReviews(userID string, maxOpenReviews *int) (*models.User, error) {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	if maxOpenReviews != nil && *maxOpenReviews < 0 {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "max_open_reviews must not be negative",
+		}
+	}
+	
+	if err := s.storage.SetUserMaxOpenReviews(userID, maxOpenReviews); err != nil {
+		return nil, err
+	}
+	
+	user.MaxOpenReviews = maxOpenReviews
+	return user, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+func (s *Service) GetTeamChecklist(teamName string) (*models.TeamChecklist, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	items, err := s.storage.GetChecklistTemplate(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &models.TeamChecklist{TeamName: teamName, Items: items}, nil
+}
+
+// SetTeamChecklist replaces team checklist, applies to assignments made afterwards
+func (s *Service) SetTeamChecklist(checklist *models.TeamChecklist) (*models.TeamChecklist, error) {
+	if err := s.ensureTeam(checklist.TeamName); err != nil {
+		return nil, err
+	}
+	
+	items := make([]string, 0, len(checklist.Items))
+	for _, item := range checklist.Items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "checklist items must not be empty",
+			}
+		}
+		items = append(items, item)
+	}
+	
+	if err := s.storage.ReplaceChecklistTemplate(checklist.TeamName, items); err != nil {
+		return nil, err
+	}
+	
+	checklist.Items = items
+	return checklist, nil
+}
+
+// GetReviewChecklist returns checklist of reviewer's assignment
+func (s *Service) GetReviewChecklist(prID, userID string) ([]models.ChecklistItem, error) {
+	if err := s.ensureAssigned(prID, userID); err != nil {
+		return nil, err
+	}
+	return s.storage.GetReviewChecklist(prID, userID)
+}
+
+// CheckChecklistItem ticks or unticks item of reviewer's checklist
+func (s *Service) CheckChecklistItem(prID, userID string, itemID int64, checked bool) ([]models.ChecklistItem, error) {
+	if err := s.ensureAssigned(prID, userID); err != nil {
+		return nil, err
+	}
+	
+	if err := s.storage.SetChecklistItemChecked(prID, userID, itemID, checked); err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "checklist item not found",
+		}
+	}
+	
+	return s.storage.GetReviewChecklist(prID, userID)
+}
+
+func (s *Service) ensureAssigned(prID, userID string) error {
+	exists, err := s.storage.PRExists(prID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	isAssigned, err := s.storage.IsReviewerAssigned(prID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAssigned {
+		return &ServiceError{
+			Code:    errcode.NotAssigned,
+			Message: "user is not assigned as reviewer to this PR",
+		}
+	}
+	return nil
+}
+
+// checkMergeChecklists rejects merge in strict mode while reviewers have unchecked items
+func (s *Service) checkMergeChecklists(prID string) error {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return err
+	}
+	settings, err := s.teamSettings(pr.TeamName)
+	if err != nil {
+		return err
+	}
+	if !settings.StrictMerge {
+		return nil
+	}
+	
+	unchecked, err := s.storage.CountUncheckedItems(prID)
+	if err != nil {
+		return err
+	}
+	if unchecked > 0 {
+		return &ServiceError{
+			Code:    errcode.ChecklistIncomplete,
+			Message: fmt.Sprintf("%d review checklist items are not checked", unchecked),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// mentionPattern matches @user_id not preceded by a word character (skips emails)
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.-]*\w)`)
+
+// parseMentions returns unique mentioned user IDs in order of appearance
+func parseMentions(body string) []string {
+	seen := make(map[string]bool)
+	var mentions []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		userID := match[1]
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		mentions = append(mentions, userID)
+	}
+	return mentions
+}
+
+// AddPRComment stores comment in PR timeline and notifies mentioned users
+func (s *Service) AddPRComment(prID, authorID, body string) (*models.PRComment, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "body is required",
+		}
+	}
+	
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	if _, err := s.storage.GetUser(authorID); err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	// unknown handles are left as plain text
+	mentioned := []models.User{}
+	for _, userID := range parseMentions(body) {
+		if userID == authorID {
+			continue
+		}
+		user, err := s.storage.GetUser(userID)
+		if err != nil {
+			continue
+		}
+		mentioned = append(mentioned, *user)
+	}
+	
+	comment := &models.PRComment{
+		PullRequestID: prID,
+		AuthorID:      authorID,
+		Body:          body,
+		Mentions:      make([]string, 0, len(mentioned)),
+	}
+	for _, user := range mentioned {
+		comment.Mentions = append(comment.Mentions, user.UserID)
+	}
+	
+	if err := s.storage.AddPRComment(comment); err != nil {
+		return nil, err
+	}
+	
+	payload := map[string]interface{}{
+		"comment_id": comment.ID,
+		"body":       comment.Body,
+		"mentions":   comment.Mentions,
+	}
+	if err := s.recordEvent(prID, EventCommentAdded, authorID, payload); err != nil {
+		return nil, err
+	}
+	
+	data := MentionData{
+		PRID:      pr.PullRequestID,
+		PRName:    pr.PullRequestName,
+		Author:    pr.AuthorID,
+		Commenter: authorID,
+		Comment:   body,
+		Link:      s.commentLink(prID, comment.ID),
+	}
+	for _, user := range mentioned {
+		data.TeamName = user.TeamName
+		message, err := s.renderNotification(user.TeamName, NotificationMention, s.localeOf(&user), data)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.notify(user.UserID, NotificationMention, prID, message); err != nil {
+			return nil, err
+		}
+	}
+	
+	return comment, nil
+}
+
+// commentLink points to the comment in PR timeline
+func (s *Service) commentLink(prID string, commentID int64) string {
+	link := s.prLink(prID)
+	if link == "" {
+		return ""
+	}
+	return link + "#comment-" + strconv.FormatInt(commentID, 10)
+}
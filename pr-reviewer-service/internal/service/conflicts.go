@@ -0,0 +1,124 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+// CandidateRule - org-structure constraint applied by automatic reviewer selection,
+// volunteers and forced assignments skip rules like other selection filters
+type CandidateRule interface {
+	Name() string
+	// Allows reports whether candidate may review a PR of the author
+	Allows(author, candidate *models.User) bool
+}
+
+// SameManagerRule keeps away reviewers who report to the same manager as the author
+type SameManagerRule struct{}
+
+func (SameManagerRule) Name() string {
+	return "SAME_MANAGER"
+}
+
+func (SameManagerRule) Allows(author, candidate *models.User) bool {
+	return author.ManagerID == "" || author.ManagerID != candidate.ManagerID
+}
+
+// DirectReportRule keeps away reviewers who report to the author
+type DirectReportRule struct{}
+
+func (DirectReportRule) Name() string {
+	return "DIRECT_REPORT"
+}
+
+func (DirectReportRule) Allows(author, candidate *models.User) bool {
+	return candidate.ManagerID != author.UserID
+}
+
+// WithCandidateRules adds conflict-of-interest rules to reviewer selection
+func WithCandidateRules(rules ...CandidateRule) Option {
+	return func(s *Service) {
+		s.rules = append(s.rules, rules...)
+	}
+}
+
+// filterByRules drops candidates any rule forbids for the author, previews without author skip rules
+func (s *Service) filterByRules(authorID string, candidates []models.User) ([]models.User, error) {
+	if len(s.rules) == 0 || authorID == "" {
+		return candidates, nil
+	}
+	
+	author, err := s.storage.GetUser(authorID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "author not found",
+		}
+	}
+	
+	allowed := make([]models.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		if s.allowedByRules(author, &candidate) {
+			allowed = append(allowed, candidate)
+		}
+	}
+	return allowed, nil
+}
+
+func (s *Service) allowedByRules(author, candidate *models.User) bool {
+	for _, rule := range s.rules {
+		if !rule.Allows(author, candidate) {
+			return false
+		}
+	}
+	return true
+}
+
+// ImportUserManagers loads manager of each listed user from org structure
+func (s *Service) ImportUserManagers(managers []models.UserManager) (int, error) {
+	if len(managers) == 0 {
+		return 0, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "managers are required",
+		}
+	}
+	
+	seen := make(map[string]bool, len(managers))
+	for i := range managers {
+		entry := &managers[i]
+		entry.UserID = strings.TrimSpace(entry.UserID)
+		entry.ManagerID = strings.TrimSpace(entry.ManagerID)
+		if entry.UserID == "" {
+			return 0, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "user_id is required",
+			}
+		}
+		if entry.UserID == entry.ManagerID {
+			return 0, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "user " + entry.UserID + " can't be their own manager",
+			}
+		}
+		if seen[entry.UserID] {
+			return 0, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "duplicate user " + entry.UserID,
+			}
+		}
+		seen[entry.UserID] = true
+	
+		if _, err := s.storage.GetUser(entry.UserID); err != nil {
+			return 0, &ServiceError{
+				Code:    errcode.NotFound,
+				Message: "user " + entry.UserID + " not found",
+			}
+		}
+	}
+	
+	if err := s.storage.ImportUserManagers(managers); err != nil {
+		return 0, err
+	}
+	return len(managers), nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// cooldownWindow - rolling period the daily assignment limit applies to
+const cooldownWindow = 24 * time.Hour
+
+// dailyAssignmentCap returns personal limit or team limit, nil means no cooldown
+func dailyAssignmentCap(user *models.User, settings *models.TeamSettings) *int {
+	if user.MaxDailyAssignments != nil {
+		return user.MaxDailyAssignments
+	}
+	return settings.MaxDailyAssignments
+}
+
+// recentAssignments counts team members' assignments within the cooldown window
+func (s *Service) recentAssignments(teamName string) (map[string]int, error) {
+	return s.storage.CountRecentAssignments(teamName, time.Now().UTC().Add(-cooldownWindow))
+}
+
+// filterByCooldown drops candidates who got their daily number of new assignments,
+// applied by automatic selection only, volunteers and forced assignments ignore it
+func (s *Service) filterByCooldown(teamName string, candidates []models.User) ([]models.User, error) {
+	settings, err := s.teamSettings(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	counts, err := s.recentAssignments(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	available := make([]models.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		limit := dailyAssignmentCap(&candidate, settings)
+		if limit != nil && counts[candidate.UserID] >= *limit {
+			continue
+		}
+		available = append(available, candidate)
+	}
+	
+	return available, nil
+}
+
+// SetUserMaxDailyAssignments overrides team cooldown limit for a single user, nil removes the override
+func (s *Service) SetUserMaxDailyAssignments(userID string, maxDailyAssignments *int) (*models.User, error) {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	if maxDailyAssignments != nil && *maxDailyAssignments < 0 {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "max_daily_assignments must not be negative",
+		}
+	}
+	
+	if err := s.storage.SetUserMaxDailyAssignments(userID, maxDailyAssignments); err != nil {
+		return nil, err
+	}
+	
+	user.MaxDailyAssignments = maxDailyAssignments
+	return user, nil
+}
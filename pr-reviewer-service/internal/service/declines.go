@@ -0,0 +1,134 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"slices"
+	"sort"
+)
+
+// Assignment decline reasons
+const (
+	DeclineTooBusy        = "TOO_BUSY"
+	DeclineConflict       = "CONFLICT"
+	DeclineUnfamiliarArea = "UNFAMILIAR_AREA"
+	DeclineOther          = "OTHER"
+)
+
+func isValidDeclineReason(reason string) bool {
+	switch reason {
+	case DeclineTooBusy, DeclineConflict, DeclineUnfamiliarArea, DeclineOther:
+		return true
+	}
+	return false
+}
+
+// DeclineReview hands reviewer's assignment to a replacement like a reassignment and keeps
+// the reason for decline statistics
+func (s *Service) DeclineReview(prID, userID, reason, comment string) (*models.PullRequest, *models.AssignmentDecline, error) {
+	if !isValidDeclineReason(reason) {
+		return nil, nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown decline reason " + reason,
+		}
+	}
+	
+	pr, newReviewerID, err := s.reassignReviewer(prID, userID, map[string]interface{}{"decline_reason": reason}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	// KEEP changed nothing, a declining reviewer can't be kept on the review
+	if pr.AssignmentFallback == NoCandidateKeep {
+		return nil, nil, &ServiceError{
+			Code:    errcode.NoCandidate,
+			Message: "no active replacement candidate available in team",
+		}
+	}
+	
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	decline := &models.AssignmentDecline{
+		PullRequestID: prID,
+		UserID:        userID,
+		TeamName:      user.TeamName,
+		Reason:        reason,
+		Comment:       comment,
+		ReplacedBy:    newReviewerID,
+	}
+	if err := s.storage.AddAssignmentDecline(decline); err != nil {
+		return nil, nil, err
+	}
+	
+	return pr, decline, nil
+}
+
+// GetDeclineStats aggregates team members' declines by reason and by reviewer, results are
+// shared for the stats cache TTL
+func (s *Service) GetDeclineStats(teamName string, periodDays int) (*models.DeclineStats, error) {
+	key := fmt.Sprintf("%s/%d", teamName, periodDays)
+	cached, err := s.caches.declineStats.Get(key, func() (*models.DeclineStats, error) {
+		return s.declineStats(teamName, periodDays)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	// callers anonymize and reorder reviewers in place
+	stats := *cached
+	stats.ByReason = maps.Clone(cached.ByReason)
+	stats.Reviewers = slices.Clone(cached.Reviewers)
+	return &stats, nil
+}
+
+func (s *Service) declineStats(teamName string, periodDays int) (*models.DeclineStats, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	since, err := statsSince(periodDays)
+	if err != nil {
+		return nil, err
+	}
+	
+	counts, err := s.storage.GetDeclineCounts(teamName, since)
+	if err != nil {
+		return nil, err
+	}
+	
+	stats := &models.DeclineStats{
+		TeamName:   teamName,
+		PeriodDays: periodDays,
+		ByReason:   map[string]int{},
+		Reviewers:  []models.ReviewerDeclines{},
+	}
+	for _, reason := range []string{DeclineTooBusy, DeclineConflict, DeclineUnfamiliarArea, DeclineOther} {
+		stats.ByReason[reason] = 0
+	}
+	
+	byUser := map[string]int{}
+	for _, count := range counts {
+		stats.Total += count.Count
+		stats.ByReason[count.Reason] += count.Count
+	
+		i, ok := byUser[count.UserID]
+		if !ok {
+			i = len(stats.Reviewers)
+			byUser[count.UserID] = i
+			stats.Reviewers = append(stats.Reviewers, models.ReviewerDeclines{
+				UserID:   count.UserID,
+				ByReason: map[string]int{},
+			})
+		}
+		stats.Reviewers[i].Total += count.Count
+		stats.Reviewers[i].ByReason[count.Reason] = count.Count
+	}
+	
+	sort.SliceStable(stats.Reviewers, func(i, j int) bool {
+		return stats.Reviewers[i].Total > stats.Reviewers[j].Total
+	})
+	return stats, nil
+}
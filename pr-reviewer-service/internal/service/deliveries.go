@@ -0,0 +1,17 @@
+package service
+
+import "time"
+
+// webhookDeliveryTTL - how long a delivery id is remembered, longer than providers keep
+// retrying and redelivering
+const webhookDeliveryTTL = 7 * 24 * time.Hour
+
+// RecordWebhookDelivery remembers the source's delivery, false if it was already received
+func (s *Service) RecordWebhookDelivery(source, deliveryID string) (bool, error) {
+	return s.storage.RecordWebhookDelivery(source, deliveryID, webhookDeliveryTTL)
+}
+
+// ForgetWebhookDelivery lets the source redeliver an event that failed on our side
+func (s *Service) ForgetWebhookDelivery(source, deliveryID string) error {
+	return s.storage.ForgetWebhookDelivery(source, deliveryID)
+}
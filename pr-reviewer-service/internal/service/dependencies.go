@@ -0,0 +1,184 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+// Team policies for merging PR with open dependencies
+const (
+	DependencyPolicyNone  = "NONE"
+	DependencyPolicyWarn  = "WARN"
+	DependencyPolicyBlock = "BLOCK"
+)
+
+func isValidDependencyPolicy(policy string) bool {
+	switch policy {
+	case DependencyPolicyNone, DependencyPolicyWarn, DependencyPolicyBlock:
+		return true
+	}
+	return false
+}
+
+// LinkPullRequests declares that prID can't be merged before dependsOnID
+func (s *Service) LinkPullRequests(prID, dependsOnID string) (*models.PullRequest, error) {
+	prID, dependsOnID = strings.TrimSpace(prID), strings.TrimSpace(dependsOnID)
+	if prID == "" || dependsOnID == "" {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "pull_request_id and depends_on are required",
+		}
+	}
+	if prID == dependsOnID {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "pull request can't depend on itself",
+		}
+	}
+	
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	if err := ensurePROpen(pr, "cannot add dependency to merged PR"); err != nil {
+		return nil, err
+	}
+	
+	exists, err := s.storage.PRExists(dependsOnID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "dependency pull request not found",
+		}
+	}
+	
+	cycle, err := s.storage.DependencyPathExists(dependsOnID, prID)
+	if err != nil {
+		return nil, err
+	}
+	if cycle {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: dependsOnID + " already depends on " + prID,
+		}
+	}
+	
+	if err := s.storage.AddPRDependency(prID, dependsOnID); err != nil {
+		return nil, err
+	}
+	if err := s.recordEvent(prID, EventPRLinked, "", map[string]interface{}{"depends_on": dependsOnID}); err != nil {
+		return nil, err
+	}
+	
+	if err := s.withDependencies(pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// UnlinkPullRequests removes the dependency declared by LinkPullRequests
+func (s *Service) UnlinkPullRequests(prID, dependsOnID string) (*models.PullRequest, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	removed, err := s.storage.RemovePRDependency(prID, dependsOnID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "dependency not found",
+		}
+	}
+	if err := s.recordEvent(prID, EventPRUnlinked, "", map[string]interface{}{"depends_on": dependsOnID}); err != nil {
+		return nil, err
+	}
+	
+	if err := s.withDependencies(pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// withDependencies fills both directions of PR dependency graph
+func (s *Service) withDependencies(pr *models.PullRequest) error {
+	dependencies, err := s.storage.GetPRDependencies([]string{pr.PullRequestID})
+	if err != nil {
+		return err
+	}
+	
+	pr.DependsOn, pr.Dependents = nil, nil
+	for _, dependency := range dependencies {
+		if dependency.PullRequestID == pr.PullRequestID {
+			pr.DependsOn = append(pr.DependsOn, dependency.DependsOnID)
+		} else {
+			pr.Dependents = append(pr.Dependents, dependency.PullRequestID)
+		}
+	}
+	return nil
+}
+
+// attachDependencies fills dependency graph of a PR listing with one query
+func (s *Service) attachDependencies(prs []models.PullRequestShort) error {
+	if len(prs) == 0 {
+		return nil
+	}
+	
+	index := make(map[string]int, len(prs))
+	prIDs := make([]string, 0, len(prs))
+	for i, pr := range prs {
+		index[pr.PullRequestID] = i
+		prIDs = append(prIDs, pr.PullRequestID)
+	}
+	
+	dependencies, err := s.storage.GetPRDependencies(prIDs)
+	if err != nil {
+		return err
+	}
+	for _, dependency := range dependencies {
+		if i, ok := index[dependency.PullRequestID]; ok {
+			prs[i].DependsOn = append(prs[i].DependsOn, dependency.DependsOnID)
+		}
+		if i, ok := index[dependency.DependsOnID]; ok {
+			prs[i].Dependents = append(prs[i].Dependents, dependency.PullRequestID)
+		}
+	}
+	return nil
+}
+
+// checkMergeDependencies rejects merge with open dependencies under BLOCK policy,
+// under WARN they are returned to be reported
+func (s *Service) checkMergeDependencies(pr *models.PullRequest) ([]string, error) {
+	settings, err := s.teamSettings(pr.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if settings.DependencyPolicy == DependencyPolicyNone {
+		return nil, nil
+	}
+	
+	open, err := s.storage.GetOpenDependencies(pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if len(open) > 0 && settings.DependencyPolicy == DependencyPolicyBlock {
+		return nil, &ServiceError{
+			Code:    errcode.DependenciesOpen,
+			Message: "pull request depends on unmerged " + strings.Join(open, ", "),
+		}
+	}
+	return open, nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+	_ "time/tzdata" // user timezones on images without zoneinfo
+)
+
+// digestDueWindow - deadlines within this window are listed as approaching
+const digestDueWindow = 24 * time.Hour
+
+// SetUserDigest configures daily digest delivery hour in user's timezone, nil hour disables it
+func (s *Service) SetUserDigest(userID string, digestHour *int, timezone string) (*models.User, error) {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	if digestHour != nil && (*digestHour < 0 || *digestHour > 23) {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "digest_hour must be between 0 and 23",
+		}
+	}
+	
+	if timezone == "" {
+		timezone = user.Timezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown timezone " + timezone,
+		}
+	}
+	
+	if err := s.storage.SetUserDigest(userID, digestHour, timezone); err != nil {
+		return nil, err
+	}
+	
+	user.DigestHour = digestHour
+	user.Timezone = timezone
+	return user, nil
+}
+
+// SendDigests delivers digests whose local hour has come, run by the scheduler at least hourly
+func (s *Service) SendDigests(ctx context.Context) error {
+	users, err := s.storage.GetDigestSubscribers()
+	if err != nil {
+		return err
+	}
+	
+	now := time.Now().UTC()
+	for i := range users {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	
+		if !digestDue(&users[i], now) {
+			continue
+		}
+		if s.queueJobs {
+			// marked up front so the next run doesn't enqueue it again
+			if err := s.storage.MarkDigestSent(users[i].UserID, now); err != nil {
+				return err
+			}
+			if err := s.enqueue(JobSendDigest, digestJob{UserID: users[i].UserID}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.sendDigest(&users[i], now); err != nil {
+			log.Printf("Digest for %s failed: %v", users[i].UserID, err)
+		}
+	}
+	
+	return nil
+}
+
+// digestDue - local hour matches and nothing was sent today
+func digestDue(user *models.User, now time.Time) bool {
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	
+	local := now.In(loc)
+	if user.DigestHour == nil || local.Hour() != *user.DigestHour {
+		return false
+	}
+	if user.LastDigestAt == nil {
+		return true
+	}
+	
+	last := user.LastDigestAt.In(loc)
+	return last.YearDay() != local.YearDay() || last.Year() != local.Year()
+}
+
+func (s *Service) sendDigest(user *models.User, now time.Time) error {
+	assignments, err := s.storage.GetOpenAssignmentsByReviewer(user.UserID)
+	if err != nil {
+		return err
+	}
+	
+	if len(assignments) > 0 {
+		message, err := s.buildDigest(user, assignments, now)
+		if err != nil {
+			return err
+		}
+		if err := s.notify(user.UserID, NotificationDigest, "", message); err != nil {
+			return err
+		}
+	}
+	
+	return s.storage.MarkDigestSent(user.UserID, now)
+}
+
+func (s *Service) buildDigest(user *models.User, assignments []models.ReviewAssignment, now time.Time) (string, error) {
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	
+	newSince := now.Add(-24 * time.Hour)
+	if user.LastDigestAt != nil {
+		newSince = *user.LastDigestAt
+	}
+	
+	data := DigestData{
+		UserID:      user.UserID,
+		OpenReviews: len(assignments),
+	}
+	settingsByTeam := make(map[string]*models.TeamSettings)
+	holidaysByTeam := make(map[string]holidays)
+	for _, a := range assignments {
+		settings, ok := settingsByTeam[a.TeamName]
+		if !ok {
+			if settings, err = s.teamSettings(a.TeamName); err != nil {
+				return "", err
+			}
+			settingsByTeam[a.TeamName] = settings
+		}
+	
+		calendar, err := s.cachedHolidays(holidaysByTeam, a.ReviewerTeam)
+		if err != nil {
+			return "", err
+		}
+	
+		deadline := assignmentDeadline(a, settings, calendar, now)
+		item := s.prNotificationData(a, deadline.In(loc))
+	
+		switch {
+		case a.AssignedAt.After(newSince):
+			data.New = append(data.New, item)
+		case deadline.Sub(now) <= digestDueWindow:
+			data.DueSoon = append(data.DueSoon, item)
+		default:
+			data.Pending = append(data.Pending, item)
+		}
+	}
+	
+	return s.renderNotification(user.TeamName, NotificationDigest, s.localeOf(user), data)
+}
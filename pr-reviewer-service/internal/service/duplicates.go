@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"pr-reviewer-service/internal/models"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// WarningPossibleDuplicate - author recently opened a PR with almost the same name
+	WarningPossibleDuplicate = "POSSIBLE_DUPLICATE"
+
+	duplicateWindow     = 7 * 24 * time.Hour
+	duplicateSimilarity = 0.85
+)
+
+// findDuplicates looks for recent PRs of the author in the same repository with near-identical names
+func (s *Service) findDuplicates(authorID, repositoryID, name string, now time.Time) ([]models.PRMatch, error) {
+	normalized := normalizePRName(name)
+	if normalized == "" {
+		return nil, nil
+	}
+	
+	recent, err := s.storage.GetRecentPRsByAuthor(authorID, now.Add(-duplicateWindow))
+	if err != nil {
+		return nil, err
+	}
+	
+	var matches []models.PRMatch
+	for _, pr := range recent {
+		if pr.RepositoryID != repositoryID {
+			continue
+		}
+		similarity := nameSimilarity(normalized, normalizePRName(pr.PullRequestName))
+		if similarity < duplicateSimilarity {
+			continue
+		}
+		pr.Similarity = math.Round(similarity*100) / 100
+		matches = append(matches, pr)
+	}
+	return matches, nil
+}
+
+func duplicateWarning(matches []models.PRMatch) models.Warning {
+	return models.Warning{
+		Code:    WarningPossibleDuplicate,
+		Message: fmt.Sprintf("author opened %d PRs with a similar name in the last %d days", len(matches), int(duplicateWindow.Hours()/24)),
+		Matches: matches,
+	}
+}
+
+// normalizePRName lowercases the name and collapses punctuation and spaces, "Fix: login  bug" is "fix login bug"
+func normalizePRName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// nameSimilarity - 1 minus Levenshtein distance relative to the longer name
+func nameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
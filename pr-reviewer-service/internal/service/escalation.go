@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/i18n"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// Escalation actions
+const (
+	EscalationNotifyLead    = "NOTIFY_LEAD"
+	EscalationNotifyManager = "NOTIFY_MANAGER" // manager of the PR author from org structure
+	EscalationReassign      = "REASSIGN"
+	// EscalationAddLead - timeline action of lead escalation, not a configurable rule
+	EscalationAddLead = "ADD_LEAD"
+)
+
+func (s *Service) GetEscalationRules(teamName string) ([]models.EscalationRule, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	return s.storage.GetEscalationRules(teamName)
+}
+
+// SetEscalationRules replaces team escalation policy
+func (s *Service) SetEscalationRules(teamName string, rules []models.EscalationRule) ([]models.EscalationRule, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	for _, rule := range rules {
+		if rule.OverdueHours < 0 {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "overdue_hours must not be negative",
+			}
+		}
+		if rule.Action != EscalationNotifyLead && rule.Action != EscalationNotifyManager && rule.Action != EscalationReassign {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: fmt.Sprintf("unknown escalation action %q", rule.Action),
+			}
+		}
+	}
+	
+	if err := s.storage.ReplaceEscalationRules(teamName, rules); err != nil {
+		return nil, err
+	}
+	return s.storage.GetEscalationRules(teamName)
+}
+
+// ProcessEscalations applies escalation rules to overdue reviews and brings team leads
+// into PRs left without approvals, run by the scheduler
+func (s *Service) ProcessEscalations(ctx context.Context) error {
+	assignments, err := s.storage.GetOpenAssignments()
+	if err != nil {
+		return err
+	}
+	
+	now := time.Now().UTC()
+	settingsByTeam := make(map[string]*models.TeamSettings)
+	rulesByTeam := make(map[string][]models.EscalationRule)
+	holidaysByTeam := make(map[string]holidays)
+	
+	for _, a := range assignments {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	
+		settings, ok := settingsByTeam[a.TeamName]
+		if !ok {
+			if settings, err = s.teamSettings(a.TeamName); err != nil {
+				return err
+			}
+			settingsByTeam[a.TeamName] = settings
+		}
+		rules, ok := rulesByTeam[a.TeamName]
+		if !ok {
+			if rules, err = s.storage.GetEscalationRules(a.TeamName); err != nil {
+				return err
+			}
+			rulesByTeam[a.TeamName] = rules
+		}
+	
+		calendar, err := s.cachedHolidays(holidaysByTeam, a.ReviewerTeam)
+		if err != nil {
+			return err
+		}
+	
+		// the PR waits for its author, nothing to escalate
+		if a.SLAPausedAt != nil {
+			continue
+		}
+		deadline := assignmentDeadline(a, settings, calendar, now)
+		overdue := now.Sub(deadline)
+		if overdue < 0 {
+			continue
+		}
+	
+		// rules are sorted by threshold, reassignment ends this assignment
+		for _, rule := range rules {
+			if overdue < time.Duration(rule.OverdueHours)*time.Hour {
+				break
+			}
+	
+			applied, err := s.storage.EscalationApplied(a.PullRequestID, a.ReviewerID, rule.ID)
+			if err != nil {
+				return err
+			}
+			if applied {
+				continue
+			}
+	
+			// the rule counts as applied only once it succeeded, a failed one is retried next run
+			reassigned, err := s.escalate(a, rule, deadline)
+			if err != nil {
+				log.Printf("Escalation of %s for %s failed: %v", a.PullRequestID, a.ReviewerID, err)
+				continue
+			}
+			if _, err := s.storage.RecordEscalation(a.PullRequestID, a.ReviewerID, rule.ID); err != nil {
+				return err
+			}
+			if reassigned {
+				break
+			}
+		}
+	}
+	
+	return s.escalateStalledPRs(ctx, now)
+}
+
+// escalateStalledPRs adds team leads as reviewers once per PR unapproved past lead_escalation_hours
+func (s *Service) escalateStalledPRs(ctx context.Context, now time.Time) error {
+	stalled, err := s.storage.GetStalledPRs(now)
+	if err != nil {
+		return err
+	}
+	
+	for _, pr := range stalled {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	
+		applied, err := s.storage.RecordLeadEscalation(pr.PullRequestID)
+		if err != nil {
+			return err
+		}
+		if !applied {
+			continue
+		}
+	
+		if err := s.escalateToLeads(pr); err != nil {
+			log.Printf("Lead escalation of %s failed: %v", pr.PullRequestID, err)
+		}
+	}
+	
+	return nil
+}
+
+// escalateToLeads assigns active leads bypassing caps, a lead who authored the PR is skipped
+func (s *Service) escalateToLeads(pr models.StalledPR) error {
+	leads, err := s.storage.GetTeamLeads(pr.TeamName)
+	if err != nil {
+		return err
+	}
+	
+	added := []string{}
+	err = s.storage.WithTeamLock(pr.TeamName, func() error {
+		for _, lead := range leads {
+			if lead.UserID == pr.AuthorID {
+				continue
+			}
+			isAssigned, err := s.storage.IsReviewerAssigned(pr.PullRequestID, lead.UserID)
+			if err != nil {
+				return err
+			}
+			if isAssigned {
+				continue
+			}
+	
+			if err := s.addReviewer(pr.PullRequestID, lead.UserID, pr.TeamName, AssignmentEscalation); err != nil {
+				return err
+			}
+			assigned := map[string]interface{}{
+				"user_id":         lead.UserID,
+				"assignment_type": AssignmentEscalation,
+			}
+			if err := s.recordEvent(pr.PullRequestID, EventReviewerAssigned, "", assigned); err != nil {
+				return err
+			}
+			added = append(added, lead.UserID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	
+	payload := map[string]interface{}{
+		"action":          EscalationAddLead,
+		"threshold_hours": pr.ThresholdHours,
+		"leads":           added,
+	}
+	if err := s.recordEvent(pr.PullRequestID, EventEscalated, "", payload); err != nil {
+		return err
+	}
+	
+	for _, leadID := range added {
+		message := i18n.Sprintf(s.UserLocale(leadID), "%q (%s) has no approvals %d hours after it was opened, you are added as a reviewer",
+			pr.PullRequestName, pr.PullRequestID, pr.ThresholdHours)
+		if err := s.notify(leadID, NotificationEscalation, pr.PullRequestID, message); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+func (s *Service) escalate(a models.ReviewAssignment, rule models.EscalationRule, deadline time.Time) (bool, error) {
+	payload := map[string]interface{}{
+		"rule_id":       rule.ID,
+		"action":        rule.Action,
+		"overdue_hours": rule.OverdueHours,
+		"reviewer_id":   a.ReviewerID,
+	}
+	
+	switch rule.Action {
+	case EscalationNotifyLead:
+		leads, err := s.storage.GetTeamLeads(a.TeamName)
+		if err != nil {
+			return false, err
+		}
+	
+		data := s.prNotificationData(a, deadline)
+		data.OverdueHours = rule.OverdueHours
+		render := s.notificationRenderer(a.TeamName, NotificationEscalation, data)
+	
+		notified := make([]string, 0, len(leads))
+		for _, lead := range leads {
+			message, err := render(s.localeOf(&lead))
+			if err != nil {
+				return false, err
+			}
+			if err := s.notify(lead.UserID, NotificationEscalation, a.PullRequestID, message); err != nil {
+				return false, err
+			}
+			notified = append(notified, lead.UserID)
+		}
+		payload["notified"] = notified
+	
+		return false, s.recordEvent(a.PullRequestID, EventEscalated, "", payload)
+	
+	case EscalationNotifyManager:
+		notified, err := s.notifyAuthorManager(a, rule, deadline)
+		if err != nil {
+			return false, err
+		}
+		payload["notified"] = notified
+	
+		return false, s.recordEvent(a.PullRequestID, EventEscalated, "", payload)
+	
+	case EscalationReassign:
+		if s.queueJobs {
+			return true, s.enqueue(JobEscalationReassign, escalationReassignJob{
+				PullRequestID: a.PullRequestID,
+				ReviewerID:    a.ReviewerID,
+				Payload:       payload,
+			})
+		}
+		err := s.reassignEscalated(a.PullRequestID, a.ReviewerID, payload)
+		return err == nil, err
+	}
+	
+	return false, fmt.Errorf("unknown escalation action %q", rule.Action)
+}
+
+// notifyAuthorManager notifies manager of the PR author, nobody when the author has no manager
+// or the manager isn't a user of the service
+func (s *Service) notifyAuthorManager(a models.ReviewAssignment, rule models.EscalationRule, deadline time.Time) ([]string, error) {
+	author, err := s.storage.GetUser(a.AuthorID)
+	if err != nil || author.ManagerID == "" {
+		return []string{}, nil
+	}
+	manager, err := s.storage.GetUser(author.ManagerID)
+	if err != nil {
+		return []string{}, nil
+	}
+	
+	data := s.prNotificationData(a, deadline)
+	data.OverdueHours = rule.OverdueHours
+	message, err := s.notificationRenderer(a.TeamName, NotificationEscalation, data)(s.localeOf(manager))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.notify(manager.UserID, NotificationEscalation, a.PullRequestID, message); err != nil {
+		return nil, err
+	}
+	return []string{manager.UserID}, nil
+}
+
+// reassignEscalated replaces overdue reviewer and records the outcome in PR timeline
+func (s *Service) reassignEscalated(prID, reviewerID string, payload map[string]interface{}) error {
+	pr, newReviewerID, err := s.ReassignReviewer(prID, reviewerID, false)
+	if err != nil {
+		payload["error"] = err.Error()
+		if recordErr := s.recordEvent(prID, EventEscalated, "", payload); recordErr != nil {
+			return recordErr
+		}
+		return err
+	}
+	payload["replaced_by"] = newReviewerID
+	if pr.AssignmentFallback != "" {
+		payload["fallback"] = pr.AssignmentFallback
+	}
+	
+	return s.recordEvent(prID, EventEscalated, "", payload)
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/eventbus"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/notify"
+	"strconv"
+	"time"
+)
+
+// PR timeline event types
+const (
+	EventPRCreated          = "PR_CREATED"
+	EventReviewerAssigned   = "REVIEWER_ASSIGNED"
+	EventReviewerReassigned = "REVIEWER_REASSIGNED"
+	EventPRMerged           = "PR_MERGED"
+	EventEscalated          = "ESCALATED"
+	EventReviewAction       = "REVIEW_ACTION"
+	EventCommentAdded       = "COMMENT_ADDED"
+	EventReviewPhase        = "REVIEW_PHASE_ADVANCED"
+	EventShadowAssigned     = "SHADOW_ASSIGNED"
+	EventAssignmentQueued   = "ASSIGNMENT_QUEUED"
+	EventPRLinked           = "PR_LINKED"
+	EventPRUnlinked         = "PR_UNLINKED"
+	EventPRStacked          = "PR_STACKED"
+)
+
+// Notification kinds
+const (
+	NotificationEscalation   = "ESCALATION"
+	NotificationSLABreach    = "SLA_BREACH"
+	NotificationDigest       = "DIGEST"
+	NotificationWeeklyReport = "WEEKLY_REPORT"
+	NotificationPREvent      = "PR_EVENT"
+	NotificationMention      = "MENTION"
+	NotificationHandoff      = "HANDOFF"
+	NotificationAssignment   = "ASSIGNMENT"
+)
+
+func isNotificationKind(kind string) bool {
+	switch kind {
+	case NotificationEscalation, NotificationSLABreach, NotificationDigest, NotificationWeeklyReport,
+		NotificationPREvent, NotificationMention, NotificationHandoff, NotificationAssignment:
+		return true
+	}
+	return false
+}
+
+func (s *Service) recordEvent(prID, eventType, actorID string, payload map[string]interface{}) error {
+	event := &models.PREvent{
+		PullRequestID: prID,
+		EventType:     eventType,
+		ActorID:       actorID,
+		Payload:       payload,
+	}
+	if err := s.storage.AddPREvent(event); err != nil {
+		return err
+	}
+	return s.eventStored(event)
+}
+
+// eventStored updates read models and delivers the event once it is stored, also after
+// the transaction that stored it commits
+func (s *Service) eventStored(event *models.PREvent) error {
+	if err := s.storage.ApplyStatsEvent(event); err != nil {
+		return err
+	}
+	
+	if s.eventSourced && isStateEvent(event.EventType) {
+		if err := s.rebuildProjection(event.PullRequestID); err != nil {
+			return err
+		}
+	}
+	
+	if s.queueJobs {
+		if s.events != nil {
+			if err := s.enqueue(JobPublishEvent, eventbus.NewEnvelope(event, false)); err != nil {
+				return err
+			}
+		}
+		return s.enqueue(JobNotifyWatchers, event)
+	}
+	
+	// stored event is the source of truth, lost deliveries are recovered by replay
+	if s.events != nil {
+		if err := s.events.Publish(context.Background(), eventbus.NewEnvelope(event, false)); err != nil {
+			log.Printf("Event %d publish failed: %v", event.ID, err)
+		}
+	}
+	
+	return s.notifyWatchers(event)
+}
+
+// notify stores notification, holding it until the end of user's quiet hours unless PR is urgent.
+// Notifications about PRs muted by the user are dropped.
+func (s *Service) notify(userID, kind, prID, message string) error {
+	if prID != "" {
+		muted, err := s.storage.IsPRMuted(prID, userID)
+		if err != nil {
+			return err
+		}
+		if muted {
+			return nil
+		}
+	}
+	
+	now := time.Now().UTC()
+	deliverAfter, err := s.deliverAfter(userID, prID, now)
+	if err != nil {
+		return err
+	}
+	
+	notification := &models.Notification{
+		UserID:        userID,
+		Kind:          kind,
+		PullRequestID: prID,
+		Message:       message,
+		DeliverAfter:  deliverAfter,
+	}
+	if deliverAfter == nil {
+		notification.DeliveredAt = &now
+	}
+	
+	if err := s.storage.CreateNotification(notification); err != nil {
+		return err
+	}
+	if notification.DeliveredAt == nil {
+		return nil
+	}
+	return s.dispatchNotification(notification)
+}
+
+// Background delivery without the job queue
+const (
+	maxNotifyDispatches = 32              // deliveries in flight, more are dropped
+	notifyDeadline      = 2 * time.Minute // covers all attempts of every channel
+)
+
+// dispatchNotification sends delivered notification to the channels recipient's team routes
+// its kind to, one job per channel with the job queue so a retry doesn't repeat deliveries
+// that succeeded
+func (s *Service) dispatchNotification(notification *models.Notification) error {
+	if s.notifier == nil {
+		return nil
+	}
+	
+	routes, err := s.notificationRoutes(notification.UserID)
+	if err != nil {
+		return err
+	}
+	
+	msg := notify.NewMessage(notification)
+	if msg.Identities, err = s.identityAccounts(notification.UserID); err != nil {
+		return err
+	}
+	if s.queueJobs {
+		for _, channel := range s.notifier.Route(msg, routes) {
+			if err := s.enqueue(JobDispatchNotification, dispatchJob{Channel: channel, Message: msg}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	
+	// in-app notification is stored, channels are sent in the background so a slow one
+	// doesn't hold the request, and when too many sends are in flight the delivery is dropped
+	select {
+	case s.notifySlots <- struct{}{}:
+	default:
+		log.Printf("Notification %d not dispatched: %d deliveries in flight", notification.ID, cap(s.notifySlots))
+		return nil
+	}
+	go func() {
+		defer func() { <-s.notifySlots }()
+		ctx, cancel := context.WithTimeout(context.Background(), notifyDeadline)
+		defer cancel()
+		if err := s.notifier.Notify(ctx, msg, routes); err != nil {
+			log.Printf("Notification %d dispatch failed: %v", notification.ID, err)
+		}
+	}()
+	return nil
+}
+
+// notificationRoutes returns channel routes of user's team, nil routes every kind everywhere
+func (s *Service) notificationRoutes(userID string) (notify.Routes, error) {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TeamName == "" {
+		return nil, nil
+	}
+	
+	settings, err := s.storage.GetTeamSettings(user.TeamName)
+	if err != nil || settings == nil {
+		return nil, err
+	}
+	return settings.NotificationRoutes, nil
+}
+
+// GetPRTimeline returns a page of PR events in chronological order
+func (s *Service) GetPRTimeline(prID, cursor string, limit int) ([]models.PREvent, string, error) {
+	exists, err := s.storage.PRExists(prID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !exists {
+		return nil, "", &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	page, err := newPage(cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	events, err := s.storage.GetPRTimeline(prID, page)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	events, next := trimPage(events, page, func(event models.PREvent) models.Cursor {
+		return models.Cursor{CreatedAt: event.CreatedAt, ID: strconv.FormatInt(event.ID, 10)}
+	})
+	return events, next, nil
+}
+
+// GetNotifications returns a page of user's delivered in-app notifications, newest first
+func (s *Service) GetNotifications(userID, cursor string, limit int) ([]models.Notification, string, error) {
+	if _, err := s.storage.GetUser(userID); err != nil {
+		return nil, "", &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	page, err := newPage(cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	notifications, err := s.storage.GetNotifications(userID, page)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	notifications, next := trimPage(notifications, page, func(n models.Notification) models.Cursor {
+		return models.Cursor{CreatedAt: *n.DeliveredAt, ID: strconv.FormatInt(n.ID, 10)}
+	})
+	return notifications, next, nil
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/models"
+)
+
+// Reasons a team member wasn't picked, reported in assignment debug
+const (
+	SkipAuthor          = "AUTHOR"
+	SkipInactive        = "INACTIVE"
+	SkipOnLeave         = "ON_LEAVE" // vacation or team holiday
+	SkipAlreadyAssigned = "ALREADY_ASSIGNED"
+	SkipNotInPool       = "NOT_IN_POOL"
+	SkipAtCap           = "AT_CAP"
+	SkipCooldown        = "COOLDOWN"
+	SkipAbsenceSoon     = "ABSENCE_SOON" // leaves before the review would be done
+	SkipExcluded        = "EXCLUDED"     // by a candidate rule, named in the detail
+	SkipNotSelected     = "NOT_SELECTED"
+)
+
+// skipTrace collects why candidates weren't picked, a nil trace records nothing
+// so selection code calls it unconditionally
+type skipTrace struct {
+	skipped []models.SkippedCandidate
+}
+
+func newSkipTrace(enabled bool) *skipTrace {
+	if !enabled {
+		return nil
+	}
+	return &skipTrace{skipped: []models.SkippedCandidate{}}
+}
+
+func (t *skipTrace) skip(teamName, userID, reason, detail string) {
+	if t == nil {
+		return
+	}
+	t.skipped = append(t.skipped, models.SkippedCandidate{
+		TeamName: teamName,
+		UserID:   userID,
+		Reason:   reason,
+		Detail:   detail,
+	})
+}
+
+// ids copies candidate ids before a filter, filters may reuse the slice
+func (t *skipTrace) ids(candidates []models.User) []string {
+	if t == nil {
+		return nil
+	}
+	ids := make([]string, len(candidates))
+	for i := range candidates {
+		ids[i] = candidates[i].UserID
+	}
+	return ids
+}
+
+// dropped records candidates a filter removed
+func (t *skipTrace) dropped(teamName, reason string, before []string, after []models.User) {
+	if t == nil {
+		return
+	}
+	kept := make(map[string]bool, len(after))
+	for i := range after {
+		kept[after[i].UserID] = true
+	}
+	for _, userID := range before {
+		if !kept[userID] {
+			t.skip(teamName, userID, reason, "")
+		}
+	}
+}
+
+// result returns the skipped candidates, nil when tracing is off
+func (t *skipTrace) result() []models.SkippedCandidate {
+	if t == nil {
+		return nil
+	}
+	return t.skipped
+}
+
+// traceUnavailable explains team members the active member query left out,
+// ignored ones (e.g. the reviewer being replaced) aren't candidates at all
+func (s *Service) traceUnavailable(t *skipTrace, teamName, authorID string, active []models.User, ignored ...string) error {
+	if t == nil {
+		return nil
+	}
+	
+	members, err := s.storage.GetTeamMembers(teamName)
+	if err != nil {
+		return err
+	}
+	
+	listed := make(map[string]bool, len(active)+len(ignored))
+	for i := range active {
+		listed[active[i].UserID] = true
+	}
+	for _, userID := range ignored {
+		listed[userID] = true
+	}
+	for i := range members {
+		switch {
+		case listed[members[i].UserID]:
+		case members[i].UserID == authorID:
+			t.skip(teamName, members[i].UserID, SkipAuthor, "")
+		case !members[i].IsActive:
+			t.skip(teamName, members[i].UserID, SkipInactive, "")
+		default:
+			t.skip(teamName, members[i].UserID, SkipOnLeave, "")
+		}
+	}
+	return nil
+}
+
+// traceExcluded records candidates dropped by candidate rules with the first rule that refused them
+func (s *Service) traceExcluded(t *skipTrace, teamName, authorID string, before []models.User, after []models.User) error {
+	if t == nil || len(before) == len(after) {
+		return nil
+	}
+	
+	author, err := s.storage.GetUser(authorID)
+	if err != nil {
+		return err
+	}
+	
+	kept := make(map[string]bool, len(after))
+	for i := range after {
+		kept[after[i].UserID] = true
+	}
+	for i := range before {
+		if kept[before[i].UserID] {
+			continue
+		}
+		for _, rule := range s.rules {
+			if !rule.Allows(author, &before[i]) {
+				t.skip(teamName, before[i].UserID, SkipExcluded, rule.Name())
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// traceNotSelected records eligible candidates that lost to the selected ones
+func (t *skipTrace) notSelected(teamName string, eligible []models.User, selected []string) {
+	if t == nil {
+		return
+	}
+	picked := make(map[string]bool, len(selected))
+	for _, userID := range selected {
+		picked[userID] = true
+	}
+	for i := range eligible {
+		if !picked[eligible[i].UserID] {
+			t.skip(teamName, eligible[i].UserID, SkipNotSelected, "")
+		}
+	}
+}
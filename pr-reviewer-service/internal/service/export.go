@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// exportBatchSize - events loaded per storage round trip during export, memory stays bounded
+// by one batch whatever the size of the export
+const exportBatchSize = 1000
+
+// ExportEvents passes stored PR events matching the filter to emit in id order, admin only.
+// Only events after afterID are exported, so an interrupted export resumes from the id of the
+// last event received. Zero limit exports everything. Returns the number of events emitted.
+func (s *Service) ExportEvents(ctx context.Context, actorID string, filter models.PREventFilter, afterID int64, limit int, emit func(event *models.PREvent) error) (int, error) {
+	actor, err := s.storage.GetUser(actorID)
+	if err != nil || actor.Role != RoleAdmin {
+		return 0, &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only admin can export events",
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return 0, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "from must be before to",
+		}
+	}
+	if afterID < 0 {
+		return 0, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "after_id must not be negative",
+		}
+	}
+	if limit < 0 {
+		return 0, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "limit must not be negative",
+		}
+	}
+	
+	details := map[string]interface{}{
+		"pull_request_id": filter.PullRequestID,
+		"from":            filter.From,
+		"to":              filter.To,
+		"after_id":        afterID,
+		"limit":           limit,
+	}
+	if err := s.audit(actorID, AuditEventExport, filter.PullRequestID, details); err != nil {
+		return 0, err
+	}
+	
+	// events of the admin's own blind PRs are masked as in the timeline
+	hiddenFrom := make(map[string]string)
+	exported := 0
+	for {
+		batch := exportBatchSize
+		if limit > 0 {
+			batch = min(batch, limit-exported)
+		}
+		events, err := s.storage.ListPREvents(filter, afterID, batch)
+		if err != nil {
+			return exported, err
+		}
+	
+		for i := range events {
+			if ctx.Err() != nil {
+				return exported, ctx.Err()
+			}
+			authorID, err := s.exportMask(hiddenFrom, events[i].PullRequestID, actorID)
+			if err != nil {
+				return exported, err
+			}
+			if authorID != "" {
+				maskEvent(&events[i], authorID)
+			}
+			if err := emit(&events[i]); err != nil {
+				return exported, err
+			}
+			exported++
+			afterID = events[i].ID
+		}
+	
+		if len(events) < batch || exported == limit {
+			return exported, nil
+		}
+	}
+}
+
+// exportMask returns the author of the PR when its reviewers are hidden from the exporting
+// admin, empty otherwise. Results are remembered per PR for the export.
+func (s *Service) exportMask(hiddenFrom map[string]string, prID, actorID string) (string, error) {
+	if authorID, ok := hiddenFrom[prID]; ok {
+		return authorID, nil
+	}
+	authorID := ""
+	// archived PRs are merged and no longer blind
+	if pr, err := s.storage.GetPullRequest(prID); err == nil && pr.AuthorID == actorID {
+		hidden, err := s.reviewersHidden(pr, actorID)
+		if err != nil {
+			return "", err
+		}
+		if hidden {
+			authorID = pr.AuthorID
+		}
+	}
+	hiddenFrom[prID] = authorID
+	return authorID, nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// What reassignment does when the reviewer's team has no replacement candidate
+const (
+	NoCandidateFail       = "FAIL"        // 409 NO_CANDIDATE, the reviewer stays
+	NoCandidateKeep       = "KEEP"        // the reviewer stays, reassignment succeeds without a change
+	NoCandidateParentTeam = "PARENT_TEAM" // replacement is drawn from team's parent_team
+	NoCandidateQueue      = "QUEUE"       // the reviewer is dropped, a replacement waits in the assignment queue
+	NoCandidateLead       = "LEAD"        // an active team lead takes over bypassing caps
+)
+
+func isValidNoCandidateFallback(fallback string) bool {
+	switch fallback {
+	case NoCandidateFail, NoCandidateKeep, NoCandidateParentTeam, NoCandidateQueue, NoCandidateLead:
+		return true
+	}
+	return false
+}
+
+func isNoCandidate(err error) bool {
+	serviceErr, ok := err.(*ServiceError)
+	return ok && serviceErr.Code == errcode.NoCandidate
+}
+
+// applyNoCandidateFallback handles reassignment the team found no replacement for, returns
+// the new reviewer if there is one. Caller holds the team lock, PARENT_TEAM is handled by the caller.
+func (s *Service) applyNoCandidateFallback(pr *models.PullRequest, oldReviewerID, teamName, fallback string, extra map[string]interface{}) (string, error) {
+	payload := map[string]interface{}{"fallback": fallback}
+	for key, value := range extra {
+		payload[key] = value
+	}
+	
+	switch fallback {
+	case NoCandidateKeep:
+		return "", nil
+	
+	case NoCandidateQueue:
+		if err := s.swapReviewer(pr.PullRequestID, oldReviewerID, "", teamName, AssignmentAuto, payload); err != nil {
+			return "", err
+		}
+		return "", s.queueAssignment(pr.PullRequestID, teamName, 1)
+	
+	case NoCandidateLead:
+		leads, err := s.storage.GetTeamLeads(teamName)
+		if err != nil {
+			return "", err
+		}
+		for _, lead := range leads {
+			if lead.UserID == pr.AuthorID || lead.UserID == oldReviewerID {
+				continue
+			}
+			isAssigned, err := s.storage.IsReviewerAssigned(pr.PullRequestID, lead.UserID)
+			if err != nil {
+				return "", err
+			}
+			if isAssigned {
+				continue
+			}
+			return lead.UserID, s.swapReviewer(pr.PullRequestID, oldReviewerID, lead.UserID, teamName, AssignmentEscalation, payload)
+		}
+		return "", &ServiceError{
+			Code:    errcode.NoCandidate,
+			Message: "no replacement candidate or team lead available in team",
+		}
+	}
+	
+	return "", &ServiceError{
+		Code:    errcode.NoCandidate,
+		Message: "no active replacement candidate available in team",
+	}
+}
+
+// validateNoCandidateFallback defaults the fallback to FAIL, PARENT_TEAM needs an existing
+// parent_team other than the team itself
+func (s *Service) validateNoCandidateFallback(settings *models.TeamSettings) error {
+	if settings.NoCandidateFallback == "" {
+		settings.NoCandidateFallback = NoCandidateFail
+	}
+	if !isValidNoCandidateFallback(settings.NoCandidateFallback) {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown no_candidate_fallback " + settings.NoCandidateFallback,
+		}
+	}
+	
+	if settings.ParentTeam == "" {
+		if settings.NoCandidateFallback == NoCandidateParentTeam {
+			return &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "no_candidate_fallback PARENT_TEAM requires parent_team",
+			}
+		}
+		return nil
+	}
+	if settings.ParentTeam == settings.TeamName {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "parent_team must differ from the team",
+		}
+	}
+	exists, err := s.storage.TeamExists(settings.ParentTeam)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "parent_team not found",
+		}
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/i18n"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/strategy"
+	"strings"
+	"time"
+)
+
+// Follow-up review timeline events
+const (
+	EventFollowUpRequested = "FOLLOW_UP_REQUESTED"
+	EventFollowUpCompleted = "FOLLOW_UP_COMPLETED"
+)
+
+// AuditFollowUp - follow-up review requested on a merged PR
+const AuditFollowUp = "FOLLOW_UP"
+
+// maxFollowUpSLAHours - a follow-up nobody has to look at for a month isn't tracked
+const maxFollowUpSLAHours = 30 * 24
+
+// RequestFollowUp flags a merged PR for another review, e.g. after an emergency merge. Without
+// user_id a reviewer of the owning team is picked, the PR's own reviewers first. The deadline
+// follows the team SLA and holidays unless sla_hours overrides the hours.
+func (s *Service) RequestFollowUp(req models.FollowUpRequest) (*models.FollowUp, error) {
+	req.RequestedBy = strings.TrimSpace(req.RequestedBy)
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.RequestedBy == "" {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "requested_by is required",
+		}
+	}
+	if req.SLAHours < 0 || req.SLAHours > maxFollowUpSLAHours {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("sla_hours must be between 0 and %d", maxFollowUpSLAHours),
+		}
+	}
+	
+	pr, err := s.storage.GetPullRequest(req.PullRequestID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	if pr.Status != PRStatusMerged {
+		return nil, &ServiceError{
+			Code:    errcode.PRNotMerged,
+			Message: "follow-up review is only for merged PRs",
+		}
+	}
+	if _, err := s.storage.GetUser(req.RequestedBy); err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "requesting user not found",
+		}
+	}
+	
+	settings, err := s.teamSettings(pr.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	slaHours := settings.ReviewSLAHours
+	if req.SLAHours > 0 {
+		slaHours = req.SLAHours
+	}
+	
+	var followUp *models.FollowUp
+	err = s.storage.WithTeamLock(pr.TeamName, func() error {
+		reviewer, err := s.followUpReviewer(pr, req.UserID)
+		if err != nil {
+			return err
+		}
+		calendar, err := s.teamHolidays(reviewer.TeamName)
+		if err != nil {
+			return err
+		}
+	
+		now := time.Now().UTC()
+		deadlineSettings := *settings
+		deadlineSettings.ReviewSLAHours = slaHours
+		followUp = &models.FollowUp{
+			PullRequestID: pr.PullRequestID,
+			UserID:        reviewer.UserID,
+			Reason:        req.Reason,
+			RequestedBy:   req.RequestedBy,
+			SLAHours:      slaHours,
+			CreatedAt:     now,
+			DueAt:         reviewDeadline(now, &deadlineSettings, calendar, reviewer.Region),
+		}
+		created, err := s.storage.CreateFollowUp(followUp)
+		if err != nil {
+			return err
+		}
+		if !created {
+			return &ServiceError{
+				Code:    errcode.FollowUpOpen,
+				Message: "pull request already has an open follow-up review",
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	details := map[string]interface{}{
+		"user_id":   followUp.UserID,
+		"reason":    followUp.Reason,
+		"sla_hours": followUp.SLAHours,
+		"due_at":    followUp.DueAt,
+	}
+	if err := s.recordEvent(pr.PullRequestID, EventFollowUpRequested, req.RequestedBy, details); err != nil {
+		return nil, err
+	}
+	if err := s.audit(req.RequestedBy, AuditFollowUp, pr.PullRequestID, details); err != nil {
+		return nil, err
+	}
+	
+	locale := s.UserLocale(followUp.UserID)
+	message := i18n.Sprintf(locale, "You are asked for a follow-up review of merged %q (%s), due %s",
+		pr.PullRequestName, pr.PullRequestID, followUp.DueAt.Format(i18n.DateTimeLayout(locale)))
+	if err := s.notify(followUp.UserID, NotificationAssignment, pr.PullRequestID, message); err != nil {
+		return nil, err
+	}
+	
+	followUp.SLAState = slaState(followUp.DueAt, followUp.CreatedAt)
+	return followUp, nil
+}
+
+// followUpReviewer returns the named reviewer or picks one, caller holds the owning team lock
+func (s *Service) followUpReviewer(pr *models.PullRequest, userID string) (*models.User, error) {
+	if userID == "" {
+		selected, err := s.assignReviewers(s.rand, strategy.Request{
+			TeamName:        pr.TeamName,
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Priority:        pr.Priority,
+			Size:            pr.Size,
+			RepositoryID:    pr.RepositoryID,
+			Count:           1,
+			Preferred:       append([]string{}, pr.AssignedReviewers...),
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(selected) == 0 {
+			return nil, &ServiceError{
+				Code:    errcode.NoCandidate,
+				Message: "no active reviewer candidate available in team",
+			}
+		}
+		userID = selected[0]
+	}
+	
+	reviewer, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "reviewer not found",
+		}
+	}
+	if !reviewer.IsActive {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "reviewer is not active",
+		}
+	}
+	if reviewer.UserID == pr.AuthorID {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "author cannot review own PR",
+		}
+	}
+	return reviewer, nil
+}
+
+// CompleteFollowUp closes the reviewer's open follow-up review of the PR
+func (s *Service) CompleteFollowUp(prID, userID string) (*models.FollowUp, error) {
+	exists, err := s.storage.PRExists(prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	followUp, err := s.storage.CompleteFollowUp(prID, userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if followUp == nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotAssigned,
+			Message: "user has no open follow-up review of this PR",
+		}
+	}
+	
+	if err := s.recordEvent(prID, EventFollowUpCompleted, userID, map[string]interface{}{"follow_up_id": followUp.ID}); err != nil {
+		return nil, err
+	}
+	return followUp, nil
+}
+
+// GetFollowUps returns follow-up reviews of the user with SLA state of the open ones
+func (s *Service) GetFollowUps(userID string, includeCompleted bool) ([]models.FollowUp, error) {
+	if _, err := s.storage.GetUser(userID); err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	followUps, err := s.storage.GetUserFollowUps(userID, includeCompleted)
+	if err != nil {
+		return nil, err
+	}
+	
+	now := time.Now().UTC()
+	for i := range followUps {
+		if followUps[i].CompletedAt == nil {
+			followUps[i].SLAState = slaState(followUps[i].DueAt, now)
+		}
+	}
+	if followUps == nil {
+		followUps = []models.FollowUp{}
+	}
+	return followUps, nil
+}
+
+// ProcessFollowUpBreaches reminds reviewers once of overdue follow-up reviews, run by the scheduler
+func (s *Service) ProcessFollowUpBreaches(ctx context.Context) error {
+	now := time.Now().UTC()
+	followUps, err := s.storage.GetOverdueFollowUps(now)
+	if err != nil {
+		return err
+	}
+	
+	for _, followUp := range followUps {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	
+		isNew, err := s.storage.MarkFollowUpBreached(followUp.ID, now)
+		if err != nil {
+			return err
+		}
+		if !isNew {
+			continue
+		}
+	
+		if err := s.alertFollowUpBreach(followUp); err != nil {
+			log.Printf("Follow-up breach alert for %s/%s failed: %v", followUp.PullRequestID, followUp.UserID, err)
+			if err := s.storage.UnmarkFollowUpBreached(followUp.ID); err != nil {
+				return err
+			}
+		}
+	}
+	
+	return nil
+}
+
+func (s *Service) alertFollowUpBreach(followUp models.FollowUp) error {
+	pr, err := s.storage.GetPullRequest(followUp.PullRequestID)
+	if err != nil {
+		return err
+	}
+	
+	locale := s.UserLocale(followUp.UserID)
+	message := i18n.Sprintf(locale, "Follow-up review of %q (%s) was due %s",
+		pr.PullRequestName, pr.PullRequestID, followUp.DueAt.Format(i18n.DateTimeLayout(locale)))
+	return s.notify(followUp.UserID, NotificationSLABreach, pr.PullRequestID, message)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/i18n"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// handoffTTL - how long the proposed reviewer has to accept
+const handoffTTL = 24 * time.Hour
+
+// Handoff statuses
+const (
+	HandoffPending  = "PENDING"
+	HandoffAccepted = "ACCEPTED"
+	HandoffDeclined = "DECLINED"
+	HandoffExpired  = "EXPIRED"
+)
+
+// ProposeHandoff asks a specific teammate to take over the review, swap happens after they accept
+func (s *Service) ProposeHandoff(prID, fromUserID, toUserID string) (*models.ReviewerHandoff, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	if err := s.validateHandoff(pr, fromUserID, toUserID); err != nil {
+		return nil, err
+	}
+	
+	handoff := &models.ReviewerHandoff{
+		PullRequestID: prID,
+		FromUserID:    fromUserID,
+		ToUserID:      toUserID,
+		ExpiresAt:     time.Now().UTC().Add(handoffTTL),
+	}
+	created, err := s.storage.CreateHandoff(handoff)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, &ServiceError{
+			Code:    errcode.HandoffClosed,
+			Message: "reviewer already has a pending handoff on this PR",
+		}
+	}
+	
+	locale := s.UserLocale(toUserID)
+	message := i18n.Sprintf(locale, "%s asks you to take over review of %q (%s), handoff %d expires %s",
+		fromUserID, pr.PullRequestName, prID, handoff.ID, handoff.ExpiresAt.Format(i18n.DateTimeLayout(locale)))
+	if err := s.notify(toUserID, NotificationHandoff, prID, message); err != nil {
+		return nil, err
+	}
+	
+	return handoff, nil
+}
+
+// RespondHandoff accepts or declines handoff on behalf of the proposed reviewer
+func (s *Service) RespondHandoff(handoffID int64, userID string, accept bool) (*models.ReviewerHandoff, error) {
+	handoff, err := s.storage.GetHandoff(handoffID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "handoff not found",
+		}
+	}
+	
+	if handoff.ToUserID != userID {
+		return nil, &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only the proposed reviewer can respond to handoff",
+		}
+	}
+	
+	now := time.Now().UTC()
+	if handoff.Status != HandoffPending || !now.Before(handoff.ExpiresAt) {
+		return nil, &ServiceError{
+			Code:    errcode.HandoffClosed,
+			Message: "handoff is no longer pending",
+		}
+	}
+	
+	if !accept {
+		if err := s.storage.ResolveHandoff(handoffID, HandoffDeclined, now); err != nil {
+			return nil, err
+		}
+		message := i18n.Sprintf(s.UserLocale(handoff.FromUserID), "%s declined to take over review of %s",
+			userID, handoff.PullRequestID)
+		if err := s.notify(handoff.FromUserID, NotificationHandoff, handoff.PullRequestID, message); err != nil {
+			return nil, err
+		}
+		return s.storage.GetHandoff(handoffID)
+	}
+	
+	// team state may have changed since the proposal
+	pr, err := s.storage.GetPullRequest(handoff.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	
+	from, err := s.storage.GetUser(handoff.FromUserID)
+	if err != nil {
+		return nil, err
+	}
+	
+	err = s.storage.WithTeamLock(from.TeamName, func() error {
+		if err := s.validateHandoff(pr, handoff.FromUserID, handoff.ToUserID); err != nil {
+			return err
+		}
+	
+		if err := s.storage.CompleteHandoff(handoffID, now); err != nil {
+			return &ServiceError{
+				Code:    errcode.HandoffClosed,
+				Message: "handoff is no longer pending",
+			}
+		}
+	
+		items, err := s.storage.GetChecklistTemplate(from.TeamName)
+		if err != nil {
+			return err
+		}
+		if err := s.storage.CreateReviewChecklist(pr.PullRequestID, handoff.ToUserID, items); err != nil {
+			return err
+		}
+	
+		payload := map[string]interface{}{
+			"old_user_id": handoff.FromUserID,
+			"new_user_id": handoff.ToUserID,
+			"handoff_id":  handoff.ID,
+			// CompleteHandoff stores the new reviewer as MANUAL
+			"assignment_type": AssignmentManual,
+		}
+		return s.recordEvent(pr.PullRequestID, EventReviewerReassigned, userID, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.storage.GetHandoff(handoffID)
+}
+
+func (s *Service) validateHandoff(pr *models.PullRequest, fromUserID, toUserID string) error {
+	if err := ensurePROpen(pr, "cannot hand off review on merged PR"); err != nil {
+		return err
+	}
+	
+	isAssigned, err := s.storage.IsReviewerAssigned(pr.PullRequestID, fromUserID)
+	if err != nil {
+		return err
+	}
+	if !isAssigned {
+		return &ServiceError{
+			Code:    errcode.NotAssigned,
+			Message: "user is not assigned as reviewer to this PR",
+		}
+	}
+	
+	// review stays within the team the current reviewer represents
+	from, err := s.storage.GetUser(fromUserID)
+	if err != nil {
+		return err
+	}
+	target, err := s.storage.GetUser(toUserID)
+	if err != nil {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "target user not found",
+		}
+	}
+	if toUserID == pr.AuthorID {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "author cannot review own PR",
+		}
+	}
+	if target.TeamName != from.TeamName || !target.IsActive {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "target must be an active member of team " + from.TeamName,
+		}
+	}
+	
+	isAssigned, err = s.storage.IsReviewerAssigned(pr.PullRequestID, toUserID)
+	if err != nil {
+		return err
+	}
+	if isAssigned {
+		return &ServiceError{
+			Code:    errcode.AlreadyAssigned,
+			Message: "target is already assigned as reviewer to this PR",
+		}
+	}
+	
+	available, err := s.filterByCapacity(from.TeamName, []models.User{*target})
+	if err != nil {
+		return err
+	}
+	if len(available) == 0 {
+		return &ServiceError{
+			Code:    errcode.OverCapacity,
+			Message: "target has reached the open review limit",
+		}
+	}
+	
+	return nil
+}
+
+// ExpireHandoffs closes unanswered handoffs, run by the scheduler
+func (s *Service) ExpireHandoffs(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	
+	expired, err := s.storage.ExpireHandoffs(time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if expired > 0 {
+		log.Printf("Expired %d reviewer handoffs", expired)
+	}
+	
+	return nil
+}
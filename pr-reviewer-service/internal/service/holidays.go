@@ -0,0 +1,110 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+const holidayDateLayout = "2006-01-02"
+
+// holidays - team calendar, regions by UTC day, "" region applies to everyone
+type holidays map[string][]string
+
+// observes reports whether a reviewer of region is off on the UTC day containing t
+func (h holidays) observes(t time.Time, region string) bool {
+	for _, r := range h[t.UTC().Format(holidayDateLayout)] {
+		if r == "" || r == region {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) teamHolidays(teamName string) (holidays, error) {
+	list, err := s.storage.GetTeamHolidays(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	calendar := make(holidays, len(list))
+	for _, holiday := range list {
+		calendar[holiday.Date] = append(calendar[holiday.Date], holiday.Region)
+	}
+	return calendar, nil
+}
+
+// cachedHolidays loads team calendar once per scheduler run
+func (s *Service) cachedHolidays(cache map[string]holidays, teamName string) (holidays, error) {
+	if calendar, ok := cache[teamName]; ok {
+		return calendar, nil
+	}
+	calendar, err := s.teamHolidays(teamName)
+	if err != nil {
+		return nil, err
+	}
+	cache[teamName] = calendar
+	return calendar, nil
+}
+
+func (s *Service) GetTeamHolidays(teamName string) (*models.TeamHolidays, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	list, err := s.storage.GetTeamHolidays(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &models.TeamHolidays{
+		TeamName: teamName,
+		Holidays: list,
+	}, nil
+}
+
+// SetTeamHolidays replaces the team calendar, an empty list clears it
+func (s *Service) SetTeamHolidays(calendar *models.TeamHolidays) (*models.TeamHolidays, error) {
+	if err := s.ensureTeam(calendar.TeamName); err != nil {
+		return nil, err
+	}
+	
+	seen := make(map[models.Holiday]bool, len(calendar.Holidays))
+	list := make([]models.Holiday, 0, len(calendar.Holidays))
+	for _, holiday := range calendar.Holidays {
+		if _, err := time.Parse(holidayDateLayout, holiday.Date); err != nil {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "holiday date must be YYYY-MM-DD, got " + holiday.Date,
+			}
+		}
+		holiday.Region = strings.TrimSpace(holiday.Region)
+		holiday.Name = strings.TrimSpace(holiday.Name)
+	
+		key := models.Holiday{Date: holiday.Date, Region: holiday.Region}
+		if seen[key] {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "duplicate holiday " + holiday.Date + " " + holiday.Region,
+			}
+		}
+		seen[key] = true
+		list = append(list, holiday)
+	}
+	
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Date != list[j].Date {
+			return list[i].Date < list[j].Date
+		}
+		return list[i].Region < list[j].Region
+	})
+	
+	if err := s.storage.ReplaceTeamHolidays(calendar.TeamName, list); err != nil {
+		return nil, err
+	}
+	
+	calendar.Holidays = list
+	return calendar, nil
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"net/mail"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+// External identity providers
+const (
+	IdentityGitHub      = "github"
+	IdentityGitLab      = "gitlab"
+	IdentityEmail       = "email"
+	IdentitySlack       = "slack"
+	IdentityCertificate = "certificate" // SAN or CN of an mTLS client certificate
+)
+
+// Identity audit actions
+const (
+	AuditIdentityLink   = "IDENTITY_LINK"
+	AuditIdentityUnlink = "IDENTITY_UNLINK"
+)
+
+func isIdentityProvider(provider string) bool {
+	switch provider {
+	case IdentityGitHub, IdentityGitLab, IdentityEmail, IdentitySlack, IdentityCertificate:
+		return true
+	}
+	return false
+}
+
+// normalizeIdentity validates external id, logins and emails are matched case-insensitively,
+// Slack member ids are kept as is
+func normalizeIdentity(provider, externalID string) (string, error) {
+	if !isIdentityProvider(provider) {
+		return "", &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown identity provider " + provider,
+		}
+	}
+	
+	externalID = strings.TrimSpace(externalID)
+	if externalID == "" {
+		return "", &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "external_id is required",
+		}
+	}
+	
+	if provider == IdentityEmail {
+		address, err := mail.ParseAddress(externalID)
+		if err != nil {
+			return "", &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "external_id must be an email address",
+			}
+		}
+		externalID = address.Address
+	}
+	if provider == IdentitySlack {
+		return externalID, nil
+	}
+	return strings.ToLower(externalID), nil
+}
+
+// LinkIdentity links external account to the user so PRs it opens are attributed to them.
+// Users link themselves, team leads and admins link team members. Linking again is a no-op.
+func (s *Service) LinkIdentity(actorID, userID, provider, externalID string) (*models.UserIdentity, error) {
+	externalID, err := normalizeIdentity(provider, externalID)
+	if err != nil {
+		return nil, err
+	}
+	
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	if actorID != userID {
+		if _, err := s.authorizeTeam(actorID, user.TeamName); err != nil {
+			return nil, err
+		}
+	}
+	
+	identity := &models.UserIdentity{
+		UserID:     userID,
+		Provider:   provider,
+		ExternalID: externalID,
+	}
+	linked, err := s.storage.LinkUserIdentity(identity)
+	if err != nil {
+		return nil, err
+	}
+	if !linked {
+		owner, err := s.storage.ResolveIdentity(provider, externalID)
+		if err != nil {
+			return nil, err
+		}
+		if owner != userID {
+			return nil, &ServiceError{
+				Code:    errcode.IdentityTaken,
+				Message: "identity is linked to another user",
+			}
+		}
+		return s.userIdentity(userID, provider, externalID)
+	}
+	
+	details := map[string]interface{}{
+		"user_id":     userID,
+		"provider":    provider,
+		"external_id": externalID,
+	}
+	if err := s.audit(actorID, AuditIdentityLink, "", details); err != nil {
+		return nil, err
+	}
+	
+	return identity, nil
+}
+
+// UnlinkIdentity detaches external account from the user, same access as LinkIdentity
+func (s *Service) UnlinkIdentity(actorID, userID, provider, externalID string) error {
+	externalID, err := normalizeIdentity(provider, externalID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeIdentities(actorID, userID); err != nil {
+		return err
+	}
+	
+	unlinked, err := s.storage.UnlinkUserIdentity(userID, provider, externalID)
+	if err != nil {
+		return err
+	}
+	if !unlinked {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "identity not found",
+		}
+	}
+	
+	details := map[string]interface{}{
+		"user_id":     userID,
+		"provider":    provider,
+		"external_id": externalID,
+	}
+	return s.audit(actorID, AuditIdentityUnlink, "", details)
+}
+
+// ListIdentities returns external accounts linked to the user
+func (s *Service) ListIdentities(userID string) ([]models.UserIdentity, error) {
+	if _, err := s.storage.GetUser(userID); err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	return s.storage.GetUserIdentities(userID)
+}
+
+// ResolveIdentity returns id of the user external account is linked to
+func (s *Service) ResolveIdentity(provider, externalID string) (string, error) {
+	externalID, err := normalizeIdentity(provider, externalID)
+	if err != nil {
+		return "", err
+	}
+	
+	userID, err := s.storage.ResolveIdentity(provider, externalID)
+	if err != nil {
+		return "", err
+	}
+	if userID == "" {
+		return "", &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "identity is not linked",
+		}
+	}
+	return userID, nil
+}
+
+// authorizeIdentities lets users manage their own accounts, team leads and admins manage
+// accounts of team members
+func (s *Service) authorizeIdentities(actorID, userID string) error {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	if actorID == userID {
+		return nil
+	}
+	_, err = s.authorizeTeam(actorID, user.TeamName)
+	return err
+}
+
+// identityAccounts returns user's external ids by provider, the first one of each provider
+func (s *Service) identityAccounts(userID string) (map[string]string, error) {
+	identities, err := s.storage.GetUserIdentities(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(identities) == 0 {
+		return nil, nil
+	}
+	
+	accounts := make(map[string]string, len(identities))
+	for _, identity := range identities {
+		if _, ok := accounts[identity.Provider]; !ok {
+			accounts[identity.Provider] = identity.ExternalID
+		}
+	}
+	return accounts, nil
+}
+
+func (s *Service) userIdentity(userID, provider, externalID string) (*models.UserIdentity, error) {
+	identities, err := s.storage.GetUserIdentities(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range identities {
+		if identities[i].Provider == provider && identities[i].ExternalID == externalID {
+			return &identities[i], nil
+		}
+	}
+	return nil, &ServiceError{
+		Code:    errcode.NotFound,
+		Message: "identity not found",
+	}
+}
+
+// linkedUser returns the user external account is linked to, empty if it isn't linked
+func (s *Service) linkedUser(provider, externalID string) (string, error) {
+	normalized, err := normalizeIdentity(provider, externalID)
+	if err != nil {
+		return "", nil
+	}
+	return s.storage.ResolveIdentity(provider, normalized)
+}
+
+// resolveAuthor maps "provider:external_id" author of a PR to the linked user, so the author
+// isn't picked to review their own PR opened under another account
+func (s *Service) resolveAuthor(authorID string) (string, error) {
+	provider, externalID, ok := strings.Cut(authorID, ":")
+	if !ok || !isIdentityProvider(provider) {
+		return authorID, nil
+	}
+	
+	userID, err := s.linkedUser(provider, externalID)
+	if err != nil || userID == "" {
+		return authorID, err
+	}
+	return userID, nil
+}
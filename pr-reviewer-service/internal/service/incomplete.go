@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/models"
+)
+
+// WarningAssignmentIncomplete - a team of the PR got fewer reviewers than the PR needs
+const WarningAssignmentIncomplete = "ASSIGNMENT_INCOMPLETE"
+
+// IncompleteNoMembers - the team has fewer active members than the PR needs, the other
+// reasons of an incomplete assignment are the Skip* filters
+const IncompleteNoMembers = "NO_ELIGIBLE_MEMBERS"
+
+// incompleteMessages - warning text by the filter that left too few candidates
+var incompleteMessages = map[string]string{
+	IncompleteNoMembers: "team %s assigned %d of %d required reviewers, it has too few eligible members",
+	SkipAlreadyAssigned: "team %s assigned %d of %d required reviewers, the other members are already assigned",
+	SkipNotInPool:       "team %s assigned %d of %d required reviewers, the reviewer pool is too small",
+	SkipAtCap:           "team %s assigned %d of %d required reviewers, the other members are at their review capacity",
+	SkipCooldown:        "team %s assigned %d of %d required reviewers, the other members are in cooldown",
+	SkipAbsenceSoon:     "team %s assigned %d of %d required reviewers, the other members are going on leave",
+	SkipExcluded:        "team %s assigned %d of %d required reviewers, candidate rules excluded the other members",
+}
+
+// shortfall remembers the first filter that left fewer candidates than the request needs
+type shortfall struct {
+	need   int
+	reason string
+}
+
+func (f *shortfall) check(reason string, candidates []models.User) {
+	if f.reason == "" && len(candidates) < f.need {
+		f.reason = reason
+	}
+}
+
+// incompleteWarning reports the reviewers the team could give now and why not more,
+// the rest wait in the queue
+func incompleteWarning(teamName, reason string, assigned, required int) models.Warning {
+	format, ok := incompleteMessages[reason]
+	if !ok {
+		format = "team %s assigned %d of %d required reviewers"
+	}
+	return models.Warning{
+		Code:    WarningAssignmentIncomplete,
+		Message: fmt.Sprintf(format, teamName, assigned, required),
+		Reason:  reason,
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestIncompleteWarningReason(t *testing.T) {
+	members := func(n int) []models.User {
+		return make([]models.User, n)
+	}
+	
+	tests := []struct {
+		name    string
+		stages  []int // candidates left after members, pool, capacity, cooldown, absence and rules
+		reason  string
+		message string
+	}{
+		{"no eligible members", []int{1, 1, 1, 1, 1, 1}, IncompleteNoMembers, "too few eligible members"},
+		{"capacity cap", []int{3, 3, 1, 1, 1, 1}, SkipAtCap, "review capacity"},
+		{"cooldown", []int{3, 3, 3, 1, 0, 0}, SkipCooldown, "in cooldown"},
+		{"absence", []int{3, 3, 2, 2, 1, 1}, SkipAbsenceSoon, "going on leave"},
+	}
+	stages := []string{IncompleteNoMembers, SkipNotInPool, SkipAtCap, SkipCooldown, SkipAbsenceSoon, SkipExcluded}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			short := &shortfall{need: 2}
+			for i, left := range tt.stages {
+				short.check(stages[i], members(left))
+			}
+			if short.reason != tt.reason {
+				t.Fatalf("reason = %q, want %q", short.reason, tt.reason)
+			}
+	
+			warning := incompleteWarning("backend", short.reason, tt.stages[len(tt.stages)-1], 2)
+			if warning.Code != WarningAssignmentIncomplete || warning.Reason != tt.reason {
+				t.Errorf("warning = %s/%s, want %s/%s", warning.Code, warning.Reason, WarningAssignmentIncomplete, tt.reason)
+			}
+			if !strings.HasPrefix(warning.Message, "team backend assigned") || !strings.Contains(warning.Message, tt.message) {
+				t.Errorf("message = %q, want it to mention %q", warning.Message, tt.message)
+			}
+		})
+	}
+	
+	short := &shortfall{need: 2}
+	short.check(IncompleteNoMembers, members(2))
+	if short.reason != "" {
+		t.Errorf("enough candidates got reason %q", short.reason)
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"pr-reviewer-service/internal/alerting"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/eventbus"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/notify"
+	"strconv"
+	"time"
+)
+
+// Background job kinds
+const (
+	JobPublishEvent         = "PUBLISH_EVENT"
+	JobNotifyWatchers       = "NOTIFY_WATCHERS"
+	JobSendAlert            = "SEND_ALERT"
+	JobSendDigest           = "SEND_DIGEST"
+	JobEscalationReassign   = "ESCALATION_REASSIGN"
+	JobDispatchNotification = "DISPATCH_NOTIFICATION"
+)
+
+type digestJob struct {
+	UserID string `json:"user_id"`
+}
+
+type dispatchJob struct {
+	Channel string         `json:"channel"`
+	Message notify.Message `json:"message"`
+}
+
+type escalationReassignJob struct {
+	PullRequestID string                 `json:"pull_request_id"`
+	ReviewerID    string                 `json:"reviewer_id"`
+	Payload       map[string]interface{} `json:"payload"`
+}
+
+// WithJobQueue moves deliveries, digests and reassignments to the persistent job queue,
+// jobs are executed by a jobs.Pool running HandleJob
+func WithJobQueue() Option {
+	return func(s *Service) {
+		s.queueJobs = true
+	}
+}
+
+func (s *Service) enqueue(kind string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s job: %w", kind, err)
+	}
+	return s.storage.EnqueueJob(&models.Job{Kind: kind, Payload: body})
+}
+
+// HandleJob executes a queued job, returned error makes the pool retry it
+func (s *Service) HandleJob(ctx context.Context, job models.Job) error {
+	switch job.Kind {
+	case JobPublishEvent:
+		var envelope eventbus.Envelope
+		if err := json.Unmarshal(job.Payload, &envelope); err != nil {
+			return err
+		}
+		if s.events == nil {
+			return nil
+		}
+		return s.events.Publish(ctx, envelope)
+	
+	case JobNotifyWatchers:
+		var event models.PREvent
+		if err := json.Unmarshal(job.Payload, &event); err != nil {
+			return err
+		}
+		return s.notifyWatchers(&event)
+	
+	case JobSendAlert:
+		var alert alerting.Alert
+		if err := json.Unmarshal(job.Payload, &alert); err != nil {
+			return err
+		}
+		if s.alerter == nil {
+			return nil
+		}
+		return s.alerter.Send(ctx, alert)
+	
+	case JobSendDigest:
+		var payload digestJob
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return err
+		}
+		user, err := s.storage.GetUser(payload.UserID)
+		if err != nil {
+			return err
+		}
+		return s.sendDigest(user, time.Now().UTC())
+	
+	case JobDispatchNotification:
+		var payload dispatchJob
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return err
+		}
+		if s.notifier == nil {
+			return nil
+		}
+		return s.notifier.NotifyChannel(ctx, payload.Channel, payload.Message)
+	
+	case JobEscalationReassign:
+		var payload escalationReassignJob
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return err
+		}
+		// reviewer may have acted or been replaced while the job was queued
+		isAssigned, err := s.storage.IsReviewerAssigned(payload.PullRequestID, payload.ReviewerID)
+		if err != nil || !isAssigned {
+			return err
+		}
+		err = s.reassignEscalated(payload.PullRequestID, payload.ReviewerID, payload.Payload)
+		// no candidate and similar outcomes are already in the timeline, retry won't help
+		if _, ok := err.(*ServiceError); ok {
+			return nil
+		}
+		return err
+	}
+	
+	return fmt.Errorf("unknown job kind %q", job.Kind)
+}
+
+// ListJobs returns a page of newest queue entries for inspection, empty status matches all
+func (s *Service) ListJobs(actorID, status, cursor string, limit int) ([]models.Job, string, error) {
+	actor, err := s.storage.GetUser(actorID)
+	if err != nil || actor.Role != RoleAdmin {
+		return nil, "", &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only admin can inspect jobs",
+		}
+	}
+	
+	switch status {
+	case "", "QUEUED", "RUNNING", "DONE", "DEAD":
+	default:
+		return nil, "", &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown job status " + status,
+		}
+	}
+	
+	page, err := newPage(cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	jobs, err := s.storage.ListJobs(status, page)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	jobs, next := trimPage(jobs, page, func(job models.Job) models.Cursor {
+		return models.Cursor{CreatedAt: job.CreatedAt, ID: strconv.FormatInt(job.ID, 10)}
+	})
+	return jobs, next, nil
+}
+
+// RetryJob requeues a dead job
+func (s *Service) RetryJob(actorID string, jobID int64) error {
+	actor, err := s.storage.GetUser(actorID)
+	if err != nil || actor.Role != RoleAdmin {
+		return &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only admin can retry jobs",
+		}
+	}
+	
+	if err := s.storage.RetryJob(jobID); err != nil {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "dead job not found",
+		}
+	}
+	return nil
+}
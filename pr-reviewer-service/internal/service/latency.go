@@ -0,0 +1,42 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/metrics"
+	"time"
+)
+
+// Stages of PR creation exported as latency histograms
+const (
+	stageChecks     = "checks"     // validation and existence checks before the insert
+	stageInsert     = "insert"     // PR, reviewers, queue and phase rows
+	stageLockWait   = "lock_wait"  // waiting for team locks held by concurrent creations
+	stageCandidates = "candidates" // candidate query and filtering
+	stageNotify     = "notify"     // events and notification enqueue
+)
+
+// stageTimer sums time per stage over one request, a stage can be entered many times
+type stageTimer struct {
+	started time.Time
+	spent   map[string]time.Duration
+}
+
+func newStageTimer() *stageTimer {
+	return &stageTimer{started: time.Now(), spent: make(map[string]time.Duration)}
+}
+
+// since adds time elapsed from start to the stage
+func (t *stageTimer) since(stage string, start time.Time) {
+	t.spent[stage] += time.Since(start)
+}
+
+// observe exports stages the request reached and its total time
+func (t *stageTimer) observe(err error) {
+	for stage, spent := range t.spent {
+		metrics.ObserveCreatePRStage(stage, spent)
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	metrics.ObserveCreatePR(result, time.Since(t.started))
+}
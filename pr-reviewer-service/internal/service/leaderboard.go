@@ -0,0 +1,139 @@
+package service
+
+import (
+	"cmp"
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"slices"
+	"strings"
+)
+
+// Leaderboard orderings
+const (
+	LeaderboardByReviews    = "REVIEWS"    // most completed reviews first
+	LeaderboardByTurnaround = "TURNAROUND" // fastest median turnaround first
+	LeaderboardByQuality    = "QUALITY"    // largest share of approvals not reverted first
+)
+
+// GetLeaderboard ranks active team members by the metric over the last days, only for teams that
+// opted in. Members with equal metric share the rank, members without it come last.
+func (s *Service) GetLeaderboard(teamName string, periodDays int, sortBy string) (*models.Leaderboard, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	settings, err := s.teamSettings(teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.Leaderboard {
+		return nil, &ServiceError{
+			Code:    errcode.LeaderboardDisabled,
+			Message: "team hasn't enabled the leaderboard",
+		}
+	}
+	
+	sortBy = strings.ToUpper(strings.TrimSpace(sortBy))
+	if sortBy == "" {
+		sortBy = LeaderboardByReviews
+	}
+	metric, ok := leaderboardMetrics[sortBy]
+	if !ok {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown sort_by " + sortBy,
+		}
+	}
+	if _, err := statsSince(periodDays); err != nil {
+		return nil, err
+	}
+	
+	key := fmt.Sprintf("%s/%d", teamName, periodDays)
+	cached, err := s.caches.leaderboard.Get(key, func() ([]models.LeaderboardEntry, error) {
+		return s.leaderboardEntries(teamName, periodDays)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	// sorted and ranked per request, the cached entries are shared
+	entries := slices.Clone(cached)
+	slices.SortStableFunc(entries, func(a, b models.LeaderboardEntry) int {
+		return cmp.Or(metric(a, b), cmp.Compare(a.UserID, b.UserID))
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if i > 0 && metric(entries[i-1], entries[i]) == 0 {
+			entries[i].Rank = entries[i-1].Rank
+		}
+	}
+	
+	return &models.Leaderboard{
+		TeamName:   teamName,
+		PeriodDays: periodDays,
+		SortBy:     sortBy,
+		Entries:    entries,
+	}, nil
+}
+
+// leaderboardEntries - every active member with their results, zero for members without reviews
+func (s *Service) leaderboardEntries(teamName string, periodDays int) ([]models.LeaderboardEntry, error) {
+	since, err := statsSince(periodDays)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.storage.GetActiveTeamMembers(teamName, "")
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.storage.GetLeaderboardEntries(teamName, since)
+	if err != nil {
+		return nil, err
+	}
+	
+	byUser := make(map[string]models.LeaderboardEntry, len(results))
+	for _, result := range results {
+		byUser[result.UserID] = result
+	}
+	entries := make([]models.LeaderboardEntry, 0, len(members))
+	for _, member := range members {
+		entry := byUser[member.UserID]
+		entry.UserID = member.UserID
+		entry.Username = member.Username
+		if entry.CompletedReviews > 0 {
+			quality := float64(entry.CompletedReviews-entry.RevertedApprovals) / float64(entry.CompletedReviews)
+			entry.ApprovalQuality = &quality
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// leaderboardMetrics order entries better first, zero means a tie
+var leaderboardMetrics = map[string]func(a, b models.LeaderboardEntry) int{
+	LeaderboardByReviews: func(a, b models.LeaderboardEntry) int {
+		return cmp.Compare(b.CompletedReviews, a.CompletedReviews)
+	},
+	LeaderboardByTurnaround: func(a, b models.LeaderboardEntry) int {
+		return compareMissingLast(a.MedianTurnaroundSeconds, b.MedianTurnaroundSeconds, false)
+	},
+	LeaderboardByQuality: func(a, b models.LeaderboardEntry) int {
+		return compareMissingLast(a.ApprovalQuality, b.ApprovalQuality, true)
+	},
+}
+
+// compareMissingLast orders present values before missing ones
+func compareMissingLast(a, b *float64, descending bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	case descending:
+		return cmp.Compare(*b, *a)
+	default:
+		return cmp.Compare(*a, *b)
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"slices"
+	"strings"
+)
+
+// PR statuses
+const (
+	PRStatusOpen   = "OPEN"
+	PRStatusMerged = "MERGED"
+	PRStatusClosed = "CLOSED"
+)
+
+// PR lifecycle timeline events, PR_MERGED is recorded by MergePullRequest
+const (
+	EventPRClosed   = "PR_CLOSED"
+	EventPRReopened = "PR_REOPENED"
+)
+
+// prTransitions - statuses a PR may move to from each status, merge is final
+var prTransitions = map[string][]string{
+	PRStatusOpen:   {PRStatusMerged, PRStatusClosed},
+	PRStatusClosed: {PRStatusOpen},
+}
+
+func canTransition(from, to string) bool {
+	return slices.Contains(prTransitions[from], to)
+}
+
+// checkTransition fails with INVALID_TRANSITION unless the PR may move to the status
+func checkTransition(pr *models.PullRequest, to string) error {
+	if canTransition(pr.Status, to) {
+		return nil
+	}
+	return transitionError(pr.Status, to)
+}
+
+func transitionError(from, to string) error {
+	return &ServiceError{
+		Code:    errcode.InvalidTransition,
+		Message: fmt.Sprintf("pull request can't move from %s to %s", from, to),
+	}
+}
+
+// ensurePROpen guards changes to reviews of the PR. Merged PRs keep the PR_MERGED code and
+// message of each operation, closed ones get PR_CLOSED.
+func ensurePROpen(pr *models.PullRequest, mergedMessage string) error {
+	switch pr.Status {
+	case PRStatusOpen:
+		return nil
+	case PRStatusMerged:
+		return &ServiceError{
+			Code:    errcode.PRMerged,
+			Message: mergedMessage,
+		}
+	}
+	return &ServiceError{
+		Code:    errcode.PRClosed,
+		Message: "pull request is closed",
+	}
+}
+
+// ClosePullRequest closes an open PR without merging, its reviews stop counting as open.
+// Only the PR author, a lead of its team or an admin can close it.
+func (s *Service) ClosePullRequest(prID, actorID string) (*models.PullRequest, error) {
+	if err := s.authorizeTransition(prID, actorID); err != nil {
+		return nil, err
+	}
+	return s.transitionPullRequest(prID, actorID, PRStatusClosed, EventPRClosed)
+}
+
+// ReopenPullRequest brings a closed PR back with its reviewers, same actors as ClosePullRequest
+func (s *Service) ReopenPullRequest(prID, actorID string) (*models.PullRequest, error) {
+	if err := s.authorizeTransition(prID, actorID); err != nil {
+		return nil, err
+	}
+	return s.transitionPullRequest(prID, actorID, PRStatusOpen, EventPRReopened)
+}
+
+// authorizeTransition checks that actor is the PR author, a lead of its team or an admin
+func (s *Service) authorizeTransition(prID, actorID string) error {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	if actorID != "" && actorID == pr.AuthorID {
+		return nil
+	}
+	if _, err := s.authorizeTeam(actorID, pr.TeamName); err != nil {
+		return &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only PR author, team lead or admin can change PR status",
+		}
+	}
+	return nil
+}
+
+// transitionPullRequest moves the PR without checking the actor, webhooks call it directly
+// since the provider already allowed the change
+func (s *Service) transitionPullRequest(prID, actorID, to, eventType string) (*models.PullRequest, error) {
+	actorID = strings.TrimSpace(actorID)
+	if actorID == "" {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "actor_id is required",
+		}
+	}
+	
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	if err := checkTransition(pr, to); err != nil {
+		return nil, err
+	}
+	
+	moved, err := s.storage.SetPullRequestStatus(prID, pr.Status, to)
+	if err != nil {
+		return nil, err
+	}
+	// a concurrent transition moved the PR first
+	if !moved {
+		from := pr.Status
+		if current, err := s.storage.GetPullRequest(prID); err == nil {
+			from = current.Status
+		}
+		return nil, transitionError(from, to)
+	}
+	
+	if to != PRStatusOpen {
+		if err := s.storage.DeletePendingAssignments(prID); err != nil {
+			return nil, err
+		}
+	}
+	details := map[string]interface{}{"from": pr.Status}
+	if err := s.recordEvent(prID, eventType, actorID, details); err != nil {
+		return nil, err
+	}
+	
+	pr.Status = to
+	return pr, nil
+}
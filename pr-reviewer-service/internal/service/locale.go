@@ -0,0 +1,49 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/i18n"
+	"pr-reviewer-service/internal/models"
+)
+
+// WithDefaultLocale sets the locale of notifications and API errors for users who haven't
+// chosen one, English by default. Unsupported locales are ignored.
+func WithDefaultLocale(locale string) Option {
+	return func(s *Service) {
+		if i18n.IsSupported(locale) {
+			s.defaultLocale = locale
+		}
+	}
+}
+
+// DefaultLocale returns the locale of users who haven't chosen one
+func (s *Service) DefaultLocale() string {
+	return s.defaultLocale
+}
+
+// UserLocale returns the locale the user chose, the default one for unknown users
+func (s *Service) UserLocale(userID string) string {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return s.defaultLocale
+	}
+	return s.localeOf(user)
+}
+
+func (s *Service) localeOf(user *models.User) string {
+	if user.Locale != "" {
+		return user.Locale
+	}
+	return s.defaultLocale
+}
+
+// validateLocale accepts supported locales and empty one, which falls back to the default
+func validateLocale(locale string) error {
+	if locale != "" && !i18n.IsSupported(locale) {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown locale " + locale,
+		}
+	}
+	return nil
+}
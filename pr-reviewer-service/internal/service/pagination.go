@@ -0,0 +1,61 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// Page size bounds for list endpoints
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+// newPage decodes opaque cursor from the previous response,
+// storage is asked for one extra row to know whether a next page exists
+func newPage(cursor string, limit int) (models.Page, error) {
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	page := models.Page{Limit: limit + 1}
+	
+	if cursor == "" {
+		return page, nil
+	}
+	
+	invalid := &ServiceError{
+		Code:    errcode.InvalidRequest,
+		Message: "invalid cursor",
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return page, invalid
+	}
+	var after models.Cursor
+	if err := json.Unmarshal(raw, &after); err != nil || after.ID == "" {
+		return page, invalid
+	}
+	page.After = &after
+	
+	return page, nil
+}
+
+// trimPage drops the extra row and returns cursor of the last row when more rows follow
+func trimPage[T any](rows []T, page models.Page, key func(T) models.Cursor) ([]T, string) {
+	limit := page.Limit - 1
+	if len(rows) <= limit {
+		return rows, ""
+	}
+	
+	rows = rows[:limit]
+	raw, err := json.Marshal(key(rows[limit-1]))
+	if err != nil {
+		return rows, ""
+	}
+	return rows, base64.RawURLEncoding.EncodeToString(raw)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"sort"
+	"strings"
+)
+
+func (s *Service) GetPathOwners(repositoryID string) ([]models.PathOwners, error) {
+	if _, err := s.ensureRepository(repositoryID); err != nil {
+		return nil, err
+	}
+	return s.storage.GetPathOwners(repositoryID)
+}
+
+// SetPathOwners replaces prefixes the team owns in a monorepo, lead of the team or admin only.
+// Several teams may own the same prefix, all of them review matching changes.
+func (s *Service) SetPathOwners(owners *models.PathOwners, actorID string) (*models.PathOwners, error) {
+	if _, err := s.ensureRepository(owners.RepositoryID); err != nil {
+		return nil, err
+	}
+	if err := s.ensureTeam(owners.TeamName); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorizeTeam(actorID, owners.TeamName); err != nil {
+		return nil, err
+	}
+	
+	prefixes := make([]string, 0, len(owners.Prefixes))
+	seen := make(map[string]bool, len(owners.Prefixes))
+	for _, prefix := range owners.Prefixes {
+		prefix = normalizePath(prefix)
+		if prefix == "" {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "path prefixes must not be empty",
+			}
+		}
+		if !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	
+	if err := s.storage.ReplacePathOwners(owners.RepositoryID, owners.TeamName, prefixes); err != nil {
+		return nil, err
+	}
+	
+	owners.Prefixes = prefixes
+	return owners, nil
+}
+
+func (s *Service) ensureRepository(repositoryID string) (*models.Repository, error) {
+	repo, err := s.storage.GetRepository(repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "repository not found",
+		}
+	}
+	return repo, nil
+}
+
+func normalizePath(path string) string {
+	return strings.Trim(strings.TrimSpace(path), "/")
+}
+
+// routeTeams returns teams owning the changed paths, owner team first.
+// Every path goes to the teams of its longest matching prefix, unowned paths go to the owner team.
+func (s *Service) routeTeams(repositoryID, ownerTeam string, paths []string) ([]string, error) {
+	owners, err := s.storage.GetPathOwners(repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	
+	affected := make(map[string]bool)
+	for _, path := range paths {
+		path = normalizePath(path)
+		if path == "" {
+			continue
+		}
+	
+		longest := -1
+		var teams []string
+		for _, owner := range owners {
+			for _, prefix := range owner.Prefixes {
+				if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+					continue
+				}
+				if len(prefix) > longest {
+					longest = len(prefix)
+					teams = teams[:0]
+				}
+				if len(prefix) == longest {
+					teams = append(teams, owner.TeamName)
+				}
+			}
+		}
+	
+		if longest < 0 {
+			affected[ownerTeam] = true
+		}
+		for _, teamName := range teams {
+			affected[teamName] = true
+		}
+	}
+	
+	routed := make([]string, 0, len(affected))
+	for teamName := range affected {
+		if teamName != ownerTeam {
+			routed = append(routed, teamName)
+		}
+	}
+	sort.Strings(routed)
+	if affected[ownerTeam] || len(routed) == 0 {
+		routed = append([]string{ownerTeam}, routed...)
+	}
+	return routed, nil
+}
+
+// withTeamLocks holds locks of all teams while fn runs, taken in name order to avoid deadlocks
+func (s *Service) withTeamLocks(teams []string, fn func() error) error {
+	ordered := append([]string(nil), teams...)
+	sort.Strings(ordered)
+	
+	var lock func(i int) error
+	lock = func(i int) error {
+		if i == len(ordered) {
+			return fn()
+		}
+		return s.storage.WithTeamLock(ordered[i], func() error {
+			return lock(i + 1)
+		})
+	}
+	return lock(0)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// SLA pause timeline events
+const (
+	EventSLAPaused  = "SLA_PAUSED"
+	EventSLAResumed = "SLA_RESUMED"
+)
+
+// SLA pause reasons, the PR waits for its author
+const (
+	PauseCIFailing        = "CI_FAILING"
+	PauseChangesRequested = "CHANGES_REQUESTED"
+	PauseOther            = "OTHER"
+)
+
+func isValidPauseReason(reason string) bool {
+	switch reason {
+	case PauseCIFailing, PauseChangesRequested, PauseOther:
+		return true
+	}
+	return false
+}
+
+// PauseReview stops the reviewer's SLA clock while the PR is blocked on its author, the clock
+// runs again when the author re-requests the review. Repeated calls return the running pause.
+func (s *Service) PauseReview(prID, userID, reason string) (*models.PRReviewer, error) {
+	if !isValidPauseReason(reason) {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown pause reason " + reason,
+		}
+	}
+	
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	if err := ensurePROpen(pr, "cannot pause review of merged PR"); err != nil {
+		return nil, err
+	}
+	
+	isAssigned, err := s.storage.IsReviewerAssigned(prID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAssigned {
+		return nil, &ServiceError{
+			Code:    errcode.NotAssigned,
+			Message: "user is not assigned as reviewer to this PR",
+		}
+	}
+	
+	paused, err := s.storage.PauseAssignmentSLA(prID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if paused {
+		payload := map[string]interface{}{"user_id": userID, "reason": reason}
+		if err := s.recordEvent(prID, EventSLAPaused, userID, payload); err != nil {
+			return nil, err
+		}
+	}
+	
+	return s.storage.GetReviewerAssignment(prID, userID)
+}
+
+// RerequestReview is the author asking for another look, it resumes paused SLA clocks of the
+// reviewer or of every reviewer when reviewerID is empty. Returns the resumed reviewers.
+func (s *Service) RerequestReview(prID, authorID, reviewerID string) (*models.PullRequest, []string, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	if pr.AuthorID != authorID {
+		return nil, nil, &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only PR author can re-request review",
+		}
+	}
+	if err := ensurePROpen(pr, "cannot re-request review of merged PR"); err != nil {
+		return nil, nil, err
+	}
+	
+	if reviewerID != "" {
+		isAssigned, err := s.storage.IsReviewerAssigned(prID, reviewerID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !isAssigned {
+			return nil, nil, &ServiceError{
+				Code:    errcode.NotAssigned,
+				Message: "user is not assigned as reviewer to this PR",
+			}
+		}
+	}
+	
+	resumed, err := s.resumeReview(prID, authorID, reviewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pr, resumed, nil
+}
+
+// resumeReview restarts paused SLA clocks and records who resumed them
+func (s *Service) resumeReview(prID, actorID, reviewerID string) ([]string, error) {
+	resumed, err := s.storage.ResumeAssignmentSLA(prID, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range resumed {
+		if err := s.recordEvent(prID, EventSLAResumed, actorID, map[string]interface{}{"user_id": userID}); err != nil {
+			return nil, err
+		}
+	}
+	return resumed, nil
+}
+
+// pausedDeadline moves the deadline by the time the SLA clock was paused, a running pause
+// keeps moving it until resumed
+func pausedDeadline(deadline time.Time, pausedAt *time.Time, pausedSeconds int64, now time.Time) time.Time {
+	deadline = deadline.Add(time.Duration(pausedSeconds) * time.Second)
+	if pausedAt != nil && now.After(*pausedAt) {
+		deadline = deadline.Add(now.Sub(*pausedAt))
+	}
+	return deadline
+}
+
+// assignmentDeadline - reviewDeadline of the assignment with its SLA pauses
+func assignmentDeadline(a models.ReviewAssignment, settings *models.TeamSettings, calendar holidays, now time.Time) time.Time {
+	deadline := reviewDeadline(a.AssignedAt, settings, calendar, a.ReviewerRegion)
+	return pausedDeadline(deadline, a.SLAPausedAt, a.SLAPausedSeconds, now)
+}
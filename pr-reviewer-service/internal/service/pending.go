@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"log"
+	"pr-reviewer-service/internal/i18n"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/strategy"
+	"strings"
+)
+
+// queueAssignment parks reviewers nobody in the team can take right now
+func (s *Service) queueAssignment(prID, teamName string, reviewers int) error {
+	if err := s.storage.QueuePendingAssignment(prID, teamName, reviewers); err != nil {
+		return err
+	}
+	
+	payload := map[string]interface{}{
+		"team_name": teamName,
+		"reviewers": reviewers,
+	}
+	return s.recordEvent(prID, EventAssignmentQueued, "", payload)
+}
+
+func (s *Service) GetPendingAssignments(teamName string) ([]models.PendingAssignment, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	return s.storage.GetPendingAssignments(teamName)
+}
+
+// ProcessPendingAssignments assigns queued reviewers as capacity frees up, run by the scheduler.
+// Urgent PRs go first, within a priority the queue is first come first served.
+func (s *Service) ProcessPendingAssignments(ctx context.Context) error {
+	pending, err := s.storage.GetPendingAssignments("")
+	if err != nil {
+		return err
+	}
+	
+	for _, p := range pending {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.assignPending(p); err != nil {
+			log.Printf("Failed to assign queued reviewers of PR %s: %v", p.PullRequestID, err)
+		}
+	}
+	
+	return nil
+}
+
+// assignPending runs under team lock, entries without a free reviewer stay queued
+func (s *Service) assignPending(p models.PendingAssignment) error {
+	var selected []string
+	err := s.storage.WithTeamLock(p.TeamName, func() error {
+		pr, err := s.storage.GetPullRequest(p.PullRequestID)
+		if err != nil {
+			return err
+		}
+		if pr.Status != PRStatusOpen {
+			return s.storage.DeletePendingAssignments(p.PullRequestID)
+		}
+	
+		poolName := ""
+		if p.TeamName == pr.TeamName {
+			poolName = pr.ReviewerPool
+		}
+		selected, err = s.assignReviewers(s.rand, strategy.Request{
+			TeamName:        p.TeamName,
+			PullRequestID:   p.PullRequestID,
+			PullRequestName: p.PullRequestName,
+			AuthorID:        p.AuthorID,
+			Priority:        p.Priority,
+			ReviewerPool:    poolName,
+			Count:           p.Reviewers,
+			Assigned:        pr.AssignedReviewers,
+		}, nil)
+		if err != nil || len(selected) == 0 {
+			return err
+		}
+	
+		for _, reviewerID := range selected {
+			if err := s.addReviewer(p.PullRequestID, reviewerID, p.TeamName, AssignmentAuto); err != nil {
+				return err
+			}
+			assigned := map[string]interface{}{
+				"user_id":         reviewerID,
+				"assignment_type": AssignmentAuto,
+				"queued":          true,
+			}
+			if p.TeamName != pr.TeamName {
+				assigned["team_name"] = p.TeamName
+			}
+			if err := s.recordEvent(p.PullRequestID, EventReviewerAssigned, "", assigned); err != nil {
+				return err
+			}
+		}
+	
+		return s.storage.ResolvePendingAssignment(p.PullRequestID, p.TeamName, len(selected))
+	})
+	if err != nil || len(selected) == 0 {
+		return err
+	}
+	
+	for _, reviewerID := range selected {
+		message := i18n.Sprintf(s.UserLocale(reviewerID), "You are assigned to review %q (%s), it was waiting for a free reviewer",
+			p.PullRequestName, p.PullRequestID)
+		if err := s.notify(reviewerID, NotificationAssignment, p.PullRequestID, message); err != nil {
+			return err
+		}
+	}
+	// a blind author only learns how many reviewers joined
+	pr, err := s.storage.GetPullRequest(p.PullRequestID)
+	if err != nil {
+		return err
+	}
+	hidden, err := s.reviewersHidden(pr, p.AuthorID)
+	if err != nil {
+		return err
+	}
+	message := i18n.Sprintf(s.UserLocale(p.AuthorID), "%s assigned from the queue to review %q (%s)",
+		strings.Join(selected, ", "), p.PullRequestName, p.PullRequestID)
+	if hidden {
+		message = i18n.Sprintf(s.UserLocale(p.AuthorID), "%d reviewers assigned from the queue to review %q (%s)",
+			len(selected), p.PullRequestName, p.PullRequestID)
+	}
+	return s.notify(p.AuthorID, NotificationAssignment, p.PullRequestID, message)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/strategy"
+	"time"
+)
+
+// AdvanceReviewPhases assigns second-phase reviewers to PRs whose first pass is approved, run by the scheduler
+func (s *Service) AdvanceReviewPhases(ctx context.Context) error {
+	pending, err := s.storage.GetPendingPhases()
+	if err != nil {
+		return err
+	}
+	
+	for _, phase := range pending {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.advanceReviewPhase(phase); err != nil {
+			log.Printf("Failed to advance review phase of PR %s: %v", phase.PullRequestID, err)
+		}
+	}
+	
+	return nil
+}
+
+// advanceReviewPhase runs under team lock, so the phase read here can't change until it returns.
+// PR stays in the first pass while no second reviewer is available and is retried on the next run.
+func (s *Service) advanceReviewPhase(phase models.PendingPhase) error {
+	return s.storage.WithTeamLock(phase.TeamName, func() error {
+		pr, err := s.storage.GetPullRequest(phase.PullRequestID)
+		if err != nil {
+			return err
+		}
+		if pr.ReviewPhase != 1 {
+			return nil
+		}
+	
+		reviewers, err := s.assignReviewers(s.rand, strategy.Request{
+			TeamName:        phase.TeamName,
+			PullRequestID:   phase.PullRequestID,
+			PullRequestName: phase.PullRequestName,
+			AuthorID:        phase.AuthorID,
+			Priority:        phase.Priority,
+			ReviewerPool:    pr.ReviewerPool,
+			Count:           phase.SecondPhaseReviewers,
+			Assigned:        pr.AssignedReviewers,
+		}, nil)
+		if err != nil || len(reviewers) == 0 {
+			return err
+		}
+	
+		for _, reviewerID := range reviewers {
+			if err := s.addReviewer(phase.PullRequestID, reviewerID, phase.TeamName, AssignmentAuto); err != nil {
+				return err
+			}
+			assigned := map[string]interface{}{
+				"user_id":         reviewerID,
+				"assignment_type": AssignmentAuto,
+				"phase":           2,
+			}
+			if err := s.recordEvent(phase.PullRequestID, EventReviewerAssigned, "", assigned); err != nil {
+				return err
+			}
+		}
+	
+		if _, err := s.storage.AdvanceReviewPhase(phase.PullRequestID, time.Now().UTC()); err != nil {
+			return err
+		}
+		payload := map[string]interface{}{
+			"phase":     2,
+			"reviewers": reviewers,
+		}
+		return s.recordEvent(phase.PullRequestID, EventReviewPhase, "", payload)
+	})
+}
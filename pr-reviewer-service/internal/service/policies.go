@@ -0,0 +1,315 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/strategy"
+	"slices"
+	"strings"
+)
+
+// Assignment rule actions
+const (
+	RuleAddPoolReviewer = "ADD_POOL_REVIEWER"
+	RuleAddReviewer     = "ADD_REVIEWER"
+)
+
+// Assignment rule condition fields
+const (
+	RuleFieldLabel        = "label"
+	RuleFieldNamePrefix   = "name_prefix"
+	RuleFieldPriority     = "priority"
+	RuleFieldSize         = "size"
+	RuleFieldRepositoryID = "repository_id"
+	RuleFieldAuthorID     = "author_id"
+)
+
+// WarningRuleUnsatisfied - a matching assignment rule found nobody to add
+const WarningRuleUnsatisfied = "RULE_UNSATISFIED"
+
+const maxAssignmentRules = 50
+
+func (s *Service) GetTeamAssignmentRules(teamName string) (*models.TeamAssignmentRules, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	rules, err := s.storage.GetAssignmentRules(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &models.TeamAssignmentRules{TeamName: teamName, Rules: rules}, nil
+}
+
+// SetTeamAssignmentRules replaces team rules, every matching rule is applied in the given order
+func (s *Service) SetTeamAssignmentRules(teamRules *models.TeamAssignmentRules) (*models.TeamAssignmentRules, error) {
+	if err := s.ensureTeam(teamRules.TeamName); err != nil {
+		return nil, err
+	}
+	if len(teamRules.Rules) > maxAssignmentRules {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("at most %d rules allowed", maxAssignmentRules),
+		}
+	}
+	
+	seen := make(map[string]bool, len(teamRules.Rules))
+	for i := range teamRules.Rules {
+		rule := &teamRules.Rules[i]
+		rule.Name = strings.TrimSpace(rule.Name)
+		if rule.Name == "" {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "rule name is required",
+			}
+		}
+		if seen[rule.Name] {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "duplicate rule " + rule.Name,
+			}
+		}
+		seen[rule.Name] = true
+	
+		if err := validateRuleConditions(rule); err != nil {
+			return nil, err
+		}
+		if err := s.validateRuleAction(teamRules.TeamName, rule); err != nil {
+			return nil, err
+		}
+	}
+	
+	if err := s.storage.ReplaceAssignmentRules(teamRules.TeamName, teamRules.Rules); err != nil {
+		return nil, err
+	}
+	
+	if teamRules.Rules == nil {
+		teamRules.Rules = []models.AssignmentRule{}
+	}
+	return teamRules, nil
+}
+
+// validateRuleConditions requires at least one known condition and normalizes values
+// the way they are compared
+func validateRuleConditions(rule *models.AssignmentRule) error {
+	if len(rule.Conditions) == 0 {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "rule " + rule.Name + " needs conditions",
+		}
+	}
+	
+	for i := range rule.Conditions {
+		condition := &rule.Conditions[i]
+		condition.Value = strings.TrimSpace(condition.Value)
+		if condition.Value == "" {
+			return &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "rule " + rule.Name + " has a condition without value",
+			}
+		}
+	
+		switch condition.Field {
+		case RuleFieldLabel, RuleFieldNamePrefix:
+			condition.Value = strings.ToLower(condition.Value)
+		case RuleFieldPriority:
+			condition.Value = strings.ToUpper(condition.Value)
+			if !isValidPriority(condition.Value) {
+				return &ServiceError{
+					Code:    errcode.InvalidRequest,
+					Message: "unknown priority " + condition.Value,
+				}
+			}
+		case RuleFieldSize:
+			condition.Value = strings.ToUpper(condition.Value)
+			if sizeIndex(condition.Value) < 0 {
+				return &ServiceError{
+					Code:    errcode.InvalidRequest,
+					Message: "unknown size " + condition.Value,
+				}
+			}
+		case RuleFieldRepositoryID, RuleFieldAuthorID:
+		default:
+			return &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "unknown condition field " + condition.Field,
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) validateRuleAction(teamName string, rule *models.AssignmentRule) error {
+	if rule.Count == 0 {
+		rule.Count = 1
+	}
+	if rule.Count < 0 || rule.Count > maxReviewerCount {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("rule %s count must be between 1 and %d", rule.Name, maxReviewerCount),
+		}
+	}
+	
+	switch rule.Action {
+	case RuleAddPoolReviewer:
+		if rule.ReviewerPool == "" || rule.UserID != "" {
+			return &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "rule " + rule.Name + " needs reviewer_pool and no user_id",
+			}
+		}
+		return s.ensurePool(teamName, rule.ReviewerPool)
+	
+	case RuleAddReviewer:
+		if rule.UserID == "" || rule.ReviewerPool != "" || rule.Count != 1 {
+			return &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "rule " + rule.Name + " needs user_id and adds exactly one reviewer",
+			}
+		}
+		if _, err := s.storage.GetUser(rule.UserID); err != nil {
+			return &ServiceError{
+				Code:    errcode.NotFound,
+				Message: "user " + rule.UserID + " not found",
+			}
+		}
+		return nil
+	
+	default:
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown rule action " + rule.Action,
+		}
+	}
+}
+
+// matchesRule checks that all rule conditions hold for the new PR
+func matchesRule(rule *models.AssignmentRule, pr *models.PullRequest, labels []string) bool {
+	prLabels := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		prLabels[strings.ToLower(strings.TrimSpace(label))] = true
+	}
+	
+	for _, condition := range rule.Conditions {
+		var matched bool
+		switch condition.Field {
+		case RuleFieldLabel:
+			matched = prLabels[condition.Value]
+		case RuleFieldNamePrefix:
+			matched = strings.HasPrefix(strings.ToLower(pr.PullRequestName), condition.Value)
+		case RuleFieldPriority:
+			matched = pr.Priority == condition.Value
+		case RuleFieldSize:
+			matched = pr.Size == condition.Value
+		case RuleFieldRepositoryID:
+			matched = pr.RepositoryID == condition.Value
+		case RuleFieldAuthorID:
+			matched = pr.AuthorID == condition.Value
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// applyAssignmentRules adds reviewers of owning team rules matching the new PR on top of the
+// regular ones. A rule that can't add anybody now doesn't fail the PR, it's reported as a warning.
+// Called under the owning team lock.
+func (s *Service) applyAssignmentRules(rng *rand.Rand, pr *models.PullRequest, labels, assigned []string,
+	trace *skipTrace) ([]string, []models.Warning, error) {
+	rules, err := s.storage.GetAssignmentRules(pr.TeamName)
+	if err != nil || len(rules) == 0 {
+		return nil, nil, err
+	}
+
+	var added []string
+	var warnings []models.Warning
+	taken := slices.Clone(assigned)
+	for i := range rules {
+		rule := &rules[i]
+		if !matchesRule(rule, pr, labels) {
+			continue
+		}
+
+		var selected []string
+		reviewTeam := pr.TeamName
+		switch rule.Action {
+		case RuleAddPoolReviewer:
+			// filterByPool takes a deleted pool for the whole team, the rule must not
+			if err := s.ensurePool(pr.TeamName, rule.ReviewerPool); err != nil {
+				if _, ok := err.(*ServiceError); !ok {
+					return nil, nil, err
+				}
+				warnings = append(warnings, ruleWarning(rule, "reviewer pool "+rule.ReviewerPool+" not found"))
+				continue
+			}
+			selected, err = s.assignReviewers(rng, strategy.Request{
+				TeamName:        pr.TeamName,
+				PullRequestID:   pr.PullRequestID,
+				PullRequestName: pr.PullRequestName,
+				AuthorID:        pr.AuthorID,
+				Priority:        pr.Priority,
+				Size:            pr.Size,
+				RepositoryID:    pr.RepositoryID,
+				Labels:          labels,
+				ReviewerPool:    rule.ReviewerPool,
+				Count:           rule.Count,
+				Assigned:        taken,
+			}, trace)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(selected) < rule.Count {
+				warnings = append(warnings, ruleWarning(rule,
+					fmt.Sprintf("added %d of %d reviewers from pool %s", len(selected), rule.Count, rule.ReviewerPool)))
+			}
+
+		case RuleAddReviewer:
+			user, err := s.storage.GetUser(rule.UserID)
+			if err != nil {
+				warnings = append(warnings, ruleWarning(rule, "user "+rule.UserID+" not found"))
+				continue
+			}
+			switch {
+			case slices.Contains(taken, user.UserID):
+				continue
+			case user.UserID == pr.AuthorID || !user.IsActive:
+				warnings = append(warnings, ruleWarning(rule, "user "+user.UserID+" can't review this PR"))
+				continue
+			}
+			selected = []string{user.UserID}
+			reviewTeam = user.TeamName
+		}
+
+		for _, reviewerID := range selected {
+			if err := s.addReviewer(pr.PullRequestID, reviewerID, reviewTeam, AssignmentAuto); err != nil {
+				return nil, nil, err
+			}
+			payload := map[string]interface{}{
+				"user_id":         reviewerID,
+				"assignment_type": AssignmentAuto,
+				"rule":            rule.Name,
+			}
+			if reviewTeam != pr.TeamName {
+				payload["team_name"] = reviewTeam
+			}
+			if err := s.recordEvent(pr.PullRequestID, EventReviewerAssigned, "", payload); err != nil {
+				return nil, nil, err
+			}
+		}
+		added = append(added, selected...)
+		taken = append(taken, selected...)
+	}
+	return added, warnings, nil
+}
+
+func ruleWarning(rule *models.AssignmentRule, reason string) models.Warning {
+	return models.Warning{
+		Code:    WarningRuleUnsatisfied,
+		Message: "assignment rule " + rule.Name + ": " + reason,
+	}
+}
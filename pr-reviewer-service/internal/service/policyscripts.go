@@ -0,0 +1,164 @@
+package service
+
+import (
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/policy"
+	"pr-reviewer-service/internal/strategy"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Audited policy script actions
+const (
+	AuditPolicyUpdate  = "POLICY_UPDATE"
+	AuditPolicyFailure = "POLICY_FAILURE"
+)
+
+// policyVars - variables a policy script sees, PR fields are empty for previews and
+// labels are only known at PR creation
+var policyVars = map[string]policy.Type{
+	"team_name":     policy.String,
+	"pr_name":       policy.String,
+	"author_id":     policy.String,
+	"priority":      policy.String,
+	"size":          policy.String,
+	"repository_id": policy.String,
+	"labels":        policy.List,
+	"user_id":       policy.String,
+	"username":      policy.String,
+	"role":          policy.String,
+	"region":        policy.String,
+	"tags":          policy.List,
+	"is_junior":     policy.Bool,
+	"open_reviews":  policy.Number,
+}
+
+func (s *Service) GetTeamPolicy(teamName string) (*models.TeamPolicy, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	teamPolicy, err := s.storage.GetTeamPolicy(teamName)
+	if err != nil {
+		return nil, err
+	}
+	if teamPolicy == nil {
+		return &models.TeamPolicy{TeamName: teamName}, nil
+	}
+	return teamPolicy, nil
+}
+
+// SetTeamPolicy compiles and stores team's policy script, lead of the team or admin only.
+// Empty source removes the policy. Every change is audited together with the script.
+func (s *Service) SetTeamPolicy(teamPolicy *models.TeamPolicy, actorID string) (*models.TeamPolicy, error) {
+	if err := s.ensureTeam(teamPolicy.TeamName); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorizeTeam(actorID, teamPolicy.TeamName); err != nil {
+		return nil, err
+	}
+	
+	teamPolicy.Source = strings.TrimSpace(teamPolicy.Source)
+	teamPolicy.UpdatedBy, teamPolicy.UpdatedAt = "", nil
+	if teamPolicy.Source == "" {
+		if err := s.storage.DeleteTeamPolicy(teamPolicy.TeamName); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := policy.Compile(teamPolicy.Source, policyVars); err != nil {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "invalid policy: " + err.Error(),
+			}
+		}
+		teamPolicy.UpdatedBy = actorID
+		if err := s.storage.SaveTeamPolicy(teamPolicy); err != nil {
+			return nil, err
+		}
+	}
+	
+	details := map[string]interface{}{
+		"team_name": teamPolicy.TeamName,
+		"source":    teamPolicy.Source,
+	}
+	if err := s.audit(actorID, AuditPolicyUpdate, "", details); err != nil {
+		return nil, err
+	}
+	
+	return teamPolicy, nil
+}
+
+// rankByPolicy orders candidates by team policy score, best first, ties keep their random order.
+// Returns false if the team has no policy or it failed, failures are audited and the caller
+// falls back to the usual selection.
+func (s *Service) rankByPolicy(req strategy.Request, candidates []models.User) ([]models.User, bool, error) {
+	teamPolicy, err := s.storage.GetTeamPolicy(req.TeamName)
+	if err != nil || teamPolicy == nil {
+		return candidates, false, err
+	}
+	
+	// stored policies compiled when saved, this only fails if variables were removed since
+	program, err := policy.Compile(teamPolicy.Source, policyVars)
+	if err != nil {
+		return candidates, false, s.policyFailed(req, err)
+	}
+	
+	loads, err := s.storage.GetOpenReviewLoads(req.TeamName)
+	if err != nil {
+		return nil, false, err
+	}
+	
+	scores, err := policyScores(program, req, candidates, loads)
+	if err != nil {
+		return candidates, false, s.policyFailed(req, err)
+	}
+	
+	ranked := make([]models.User, len(candidates))
+	copy(ranked, candidates)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].UserID] > scores[ranked[j].UserID]
+	})
+	return ranked, true, nil
+}
+
+// policyScores evaluates the program for every candidate with the given open review loads
+func policyScores(program *policy.Program, req strategy.Request, candidates []models.User, loads map[string]int) (map[string]float64, error) {
+	vars := map[string]policy.Value{
+		"team_name":     policy.StringValue(req.TeamName),
+		"pr_name":       policy.StringValue(req.PullRequestName),
+		"author_id":     policy.StringValue(req.AuthorID),
+		"priority":      policy.StringValue(req.Priority),
+		"size":          policy.StringValue(req.Size),
+		"repository_id": policy.StringValue(req.RepositoryID),
+		"labels":        policy.ListValue(req.Labels),
+	}
+	budget := policy.NewBudget(policy.DefaultLimits, time.Now())
+	scores := make(map[string]float64, len(candidates))
+	for _, candidate := range candidates {
+		vars["user_id"] = policy.StringValue(candidate.UserID)
+		vars["username"] = policy.StringValue(candidate.Username)
+		vars["role"] = policy.StringValue(candidate.Role)
+		vars["region"] = policy.StringValue(candidate.Region)
+		vars["tags"] = policy.ListValue(candidate.Tags)
+		vars["is_junior"] = policy.BoolValue(candidate.IsJunior)
+		vars["open_reviews"] = policy.NumberValue(float64(loads[candidate.UserID]))
+	
+		score, err := program.Eval(vars, budget)
+		if err != nil {
+			return nil, err
+		}
+		scores[candidate.UserID] = score
+	}
+	return scores, nil
+}
+
+func (s *Service) policyFailed(req strategy.Request, err error) error {
+	log.Printf("Policy of team %s failed, using usual selection: %v", req.TeamName, err)
+	return s.audit("", AuditPolicyFailure, req.PullRequestID, map[string]interface{}{
+		"team_name": req.TeamName,
+		"error":     err.Error(),
+	})
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+func (s *Service) GetReviewerPools(teamName string) ([]models.ReviewerPool, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	return s.storage.GetReviewerPools(teamName)
+}
+
+// SetReviewerPool replaces pool members, empty members delete the pool.
+// Open PRs targeting a deleted pool fall back to the whole team.
+func (s *Service) SetReviewerPool(pool *models.ReviewerPool) (*models.ReviewerPool, error) {
+	if err := s.ensureTeam(pool.TeamName); err != nil {
+		return nil, err
+	}
+	
+	pool.PoolName = strings.TrimSpace(pool.PoolName)
+	if pool.PoolName == "" {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "pool_name is required",
+		}
+	}
+	
+	members := make([]string, 0, len(pool.Members))
+	seen := make(map[string]bool, len(pool.Members))
+	for _, userID := range pool.Members {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+	
+		user, err := s.storage.GetUser(userID)
+		if err != nil || user.TeamName != pool.TeamName {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "user " + userID + " is not a member of team " + pool.TeamName,
+			}
+		}
+		members = append(members, userID)
+	}
+	
+	if err := s.storage.ReplaceReviewerPool(pool.TeamName, pool.PoolName, members); err != nil {
+		return nil, err
+	}
+	
+	pool.Members = members
+	return pool, nil
+}
+
+// ensurePool fails if the team has no pool with this name
+func (s *Service) ensurePool(teamName, poolName string) error {
+	members, err := s.storage.GetReviewerPoolMembers(teamName, poolName)
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "reviewer pool " + poolName + " not found in team " + teamName,
+		}
+	}
+	return nil
+}
+
+// filterByPool keeps pool members, no pool or a deleted one leaves candidates as is
+func (s *Service) filterByPool(teamName, poolName string, candidates []models.User) ([]models.User, error) {
+	if poolName == "" {
+		return candidates, nil
+	}
+	
+	members, err := s.storage.GetReviewerPoolMembers(teamName, poolName)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return candidates, nil
+	}
+	
+	inPool := make(map[string]bool, len(members))
+	for _, userID := range members {
+		inPool[userID] = true
+	}
+	
+	filtered := make([]models.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		if inPool[candidate.UserID] {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered, nil
+}
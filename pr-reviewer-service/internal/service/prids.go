@@ -0,0 +1,34 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/idgen"
+)
+
+// maxPRIDAttempts - generated IDs only collide with IDs a client picked in the same format
+const maxPRIDAttempts = 3
+
+// WithPRIDGenerator replaces UUIDv7 IDs of PRs created without pull_request_id
+func WithPRIDGenerator(gen idgen.Generator) Option {
+	return func(s *Service) {
+		s.prIDs = gen
+	}
+}
+
+// newPRID generates an ID no PR has yet
+func (s *Service) newPRID() (string, error) {
+	for attempt := 0; attempt < maxPRIDAttempts; attempt++ {
+		prID, err := s.prIDs()
+		if err != nil {
+			return "", err
+		}
+		exists, err := s.storage.PRExists(prID)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return prID, nil
+		}
+	}
+	return "", fmt.Errorf("no free pull request ID after %d attempts", maxPRIDAttempts)
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/mail"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/eventbus"
+	"pr-reviewer-service/internal/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Editable user profile fields, names of update_mask entries
+const (
+	ProfileUsername            = "username"
+	ProfileTags                = "tags"
+	ProfileTimezone            = "timezone"
+	ProfileRole                = "role"
+	ProfileNotificationAddress = "notification_address"
+	ProfileLocale              = "locale"
+)
+
+const (
+	maxUserTags      = 20
+	maxUserTagLength = 50
+)
+
+// AuditUserUpdate - profile change, details list the masked fields
+const AuditUserUpdate = "USER_UPDATE"
+
+// PatchUser updates the profile fields named in the mask, a masked field missing from the
+// patch is reset to its default. Users edit themselves, team leads and admins edit team
+// members, only admins change roles.
+func (s *Service) PatchUser(actorID, userID string, mask []string, patch *models.UserProfile) (*models.User, error) {
+	if len(mask) == 0 {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "update_mask is required",
+		}
+	}
+	
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	if actorID != userID {
+		if _, err := s.authorizeTeam(actorID, user.TeamName); err != nil {
+			return nil, err
+		}
+	}
+	
+	profile := models.UserProfile{
+		Username:            user.Username,
+		Tags:                user.Tags,
+		Timezone:            user.Timezone,
+		Role:                user.Role,
+		NotificationAddress: user.NotificationAddress,
+		Locale:              user.Locale,
+	}
+	seen := make(map[string]bool, len(mask))
+	for _, field := range mask {
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+	
+		switch field {
+		case ProfileUsername:
+			profile.Username = strings.TrimSpace(patch.Username)
+			if profile.Username == "" {
+				return nil, &ServiceError{
+					Code:    errcode.InvalidRequest,
+					Message: "username must not be empty",
+				}
+			}
+		case ProfileTags:
+			if profile.Tags, err = normalizeTags(patch.Tags); err != nil {
+				return nil, err
+			}
+		case ProfileTimezone:
+			profile.Timezone = patch.Timezone
+			if profile.Timezone == "" {
+				profile.Timezone = "UTC"
+			}
+			if _, err := time.LoadLocation(profile.Timezone); err != nil {
+				return nil, &ServiceError{
+					Code:    errcode.InvalidRequest,
+					Message: "unknown timezone " + profile.Timezone,
+				}
+			}
+		case ProfileRole:
+			profile.Role = patch.Role
+			if profile.Role == "" {
+				profile.Role = RoleMember
+			}
+			if !isValidRole(profile.Role) {
+				return nil, &ServiceError{
+					Code:    errcode.InvalidRequest,
+					Message: "unknown role " + profile.Role,
+				}
+			}
+			if profile.Role != user.Role {
+				actor, err := s.storage.GetUser(actorID)
+				if err != nil || actor.Role != RoleAdmin {
+					return nil, &ServiceError{
+						Code:    errcode.Forbidden,
+						Message: "only admin can change roles",
+					}
+				}
+			}
+		case ProfileNotificationAddress:
+			profile.NotificationAddress = strings.TrimSpace(patch.NotificationAddress)
+			if profile.NotificationAddress != "" {
+				address, err := mail.ParseAddress(profile.NotificationAddress)
+				if err != nil {
+					return nil, &ServiceError{
+						Code:    errcode.InvalidRequest,
+						Message: "notification_address must be an email address",
+					}
+				}
+				profile.NotificationAddress = address.Address
+			}
+		case ProfileLocale:
+			profile.Locale = strings.ToLower(strings.TrimSpace(patch.Locale))
+			if err := validateLocale(profile.Locale); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: fmt.Sprintf("unknown update_mask field %q", field),
+			}
+		}
+	}
+	
+	if err := s.storage.UpdateUserProfile(userID, &profile); err != nil {
+		return nil, err
+	}
+	
+	fields := make([]string, 0, len(seen))
+	for field := range seen {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	details := map[string]interface{}{"user_id": userID, "fields": fields}
+	if err := s.audit(actorID, AuditUserUpdate, "", details); err != nil {
+		return nil, err
+	}
+	
+	user.Username = profile.Username
+	user.Tags = profile.Tags
+	user.Timezone = profile.Timezone
+	user.Role = profile.Role
+	user.NotificationAddress = profile.NotificationAddress
+	user.Locale = profile.Locale
+	return user, nil
+}
+
+// normalizeTags trims and deduplicates tags keeping their order
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) > maxUserTags {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("at most %d tags allowed", maxUserTags),
+		}
+	}
+	
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || len(tag) > maxUserTagLength {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: fmt.Sprintf("tags must be 1 to %d characters", maxUserTagLength),
+			}
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+// EventUserJoinedTeam - user event published when a new user is added to a team
+const EventUserJoinedTeam = "USER_JOINED_TEAM"
+
+// AddUser adds a single new user to an existing team, active unless said otherwise
+func (s *Service) AddUser(member *models.TeamMember, teamName string) (*models.User, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	member.Username = strings.TrimSpace(member.Username)
+	if member.UserID == "" || member.Username == "" {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "user_id and username are required",
+		}
+	}
+	if member.Role == "" {
+		member.Role = RoleMember
+	}
+	if !isValidRole(member.Role) {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown role " + member.Role,
+		}
+	}
+	
+	user := &models.User{
+		UserID:   member.UserID,
+		Username: member.Username,
+		TeamName: teamName,
+		IsActive: member.IsActive,
+		Role:     member.Role,
+		Region:   member.Region,
+		Timezone: "UTC",
+	}
+	event := &models.UserEvent{
+		UserID:    user.UserID,
+		EventType: EventUserJoinedTeam,
+		Payload: map[string]interface{}{
+			"team_name": teamName,
+			"username":  user.Username,
+			"role":      user.Role,
+			"is_active": user.IsActive,
+		},
+	}
+	added, err := s.storage.AddTeamMember(user, event)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, &ServiceError{
+			Code:    errcode.UserExists,
+			Message: "user already exists",
+		}
+	}
+	
+	if err := s.publishUserEvent(event); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// publishUserEvent sends a stored user event downstream like PR events
+func (s *Service) publishUserEvent(event *models.UserEvent) error {
+	if s.events == nil {
+		return nil
+	}
+	if s.queueJobs {
+		return s.enqueue(JobPublishEvent, eventbus.NewUserEnvelope(event))
+	}
+	
+	if err := s.events.Publish(context.Background(), eventbus.NewUserEnvelope(event)); err != nil {
+		log.Printf("User event %d publish failed: %v", event.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// isStateEvent - event changes PR or reviewer rows
+func isStateEvent(eventType string) bool {
+	switch eventType {
+	case EventPRCreated, EventReviewerAssigned, EventReviewerReassigned, EventReviewAction, EventPRMerged,
+		EventSLAPaused, EventSLAResumed, EventPRClosed, EventPRReopened:
+		return true
+	}
+	return false
+}
+
+func payloadString(payload map[string]interface{}, key string) string {
+	v, _ := payload[key].(string)
+	return v
+}
+
+// foldPREvents builds PR state from its events in order, nil if PR_CREATED is missing
+func foldPREvents(events []models.PREvent) *models.PRState {
+	var state *models.PRState
+	for i := range events {
+		event := &events[i]
+		if event.EventType == EventPRCreated {
+			state = &models.PRState{
+				PullRequest: models.PullRequest{
+					PullRequestID:   event.PullRequestID,
+					PullRequestName: payloadString(event.Payload, "pull_request_name"),
+					AuthorID:        event.ActorID,
+					TeamName:        payloadString(event.Payload, "team_name"),
+					RepositoryID:    payloadString(event.Payload, "repository_id"),
+					Status:          PRStatusOpen,
+					Priority:        payloadString(event.Payload, "priority"),
+					Size:            payloadString(event.Payload, "size"),
+					ReviewerPool:    payloadString(event.Payload, "reviewer_pool"),
+					ExternalID:      payloadString(event.Payload, "external_id"),
+					ExternalURL:     payloadString(event.Payload, "external_url"),
+					CreatedAt:       event.CreatedAt,
+				},
+				Reviewers: []models.PRReviewer{},
+			}
+		}
+		if state == nil {
+			continue
+		}
+		applyPREvent(state, event)
+		state.Version = event.ID
+	}
+	
+	if state != nil {
+		state.PullRequest.AssignedReviewers = make([]string, 0, len(state.Reviewers))
+		for _, r := range state.Reviewers {
+			state.PullRequest.AssignedReviewers = append(state.PullRequest.AssignedReviewers, r.UserID)
+		}
+	}
+	return state
+}
+
+func applyPREvent(state *models.PRState, event *models.PREvent) {
+	assign := func(userID, teamName string) {
+		assignmentType := payloadString(event.Payload, "assignment_type")
+		if assignmentType == "" {
+			assignmentType = AssignmentAuto
+		}
+		state.Reviewers = append(state.Reviewers, models.PRReviewer{
+			PullRequestID:  event.PullRequestID,
+			UserID:         userID,
+			Status:         ReviewerPending,
+			AssignmentType: assignmentType,
+			TeamName:       teamName,
+			AssignedAt:     event.CreatedAt,
+		})
+	}
+	
+	switch event.EventType {
+	case EventReviewerAssigned:
+		teamName := payloadString(event.Payload, "team_name")
+		if teamName == "" {
+			teamName = state.PullRequest.TeamName
+		}
+		assign(payloadString(event.Payload, "user_id"), teamName)
+	
+	case EventReviewerReassigned:
+		// the replacement represents the same team as the replaced reviewer
+		oldUserID := payloadString(event.Payload, "old_user_id")
+		teamName := state.PullRequest.TeamName
+		for i, r := range state.Reviewers {
+			if r.UserID == oldUserID {
+				teamName = r.TeamName
+				state.Reviewers = append(state.Reviewers[:i], state.Reviewers[i+1:]...)
+				break
+			}
+		}
+		// QUEUE fallback drops the reviewer without a replacement
+		if newUserID := payloadString(event.Payload, "new_user_id"); newUserID != "" {
+			assign(newUserID, teamName)
+		}
+	
+	case EventReviewAction:
+		for i := range state.Reviewers {
+			r := &state.Reviewers[i]
+			if r.UserID != event.ActorID {
+				continue
+			}
+			if r.FirstActionAt == nil {
+				at := event.CreatedAt
+				r.FirstActionAt = &at
+				r.FirstActionPausedSeconds = pausedSeconds(r, at)
+			}
+			// approval is final, see RecordReviewAction
+			switch payloadString(event.Payload, "action") {
+			case ReviewActionAccept:
+				if r.Status != ReviewerApproved {
+					r.Status = ReviewerAccepted
+				}
+			case ReviewActionApprove:
+				r.Status = ReviewerApproved
+			}
+		}
+	
+	case EventSLAPaused:
+		if r := stateReviewer(state, payloadString(event.Payload, "user_id")); r != nil && r.SLAPausedAt == nil {
+			at := event.CreatedAt
+			r.SLAPausedAt = &at
+		}
+	
+	case EventSLAResumed:
+		if r := stateReviewer(state, payloadString(event.Payload, "user_id")); r != nil && r.SLAPausedAt != nil {
+			r.SLAPausedSeconds = pausedSeconds(r, event.CreatedAt)
+			r.SLAPausedAt = nil
+		}
+	
+	case EventPRClosed:
+		if canTransition(state.PullRequest.Status, PRStatusClosed) {
+			state.PullRequest.Status = PRStatusClosed
+		}
+	
+	case EventPRReopened:
+		if canTransition(state.PullRequest.Status, PRStatusOpen) {
+			state.PullRequest.Status = PRStatusOpen
+		}
+	
+	case EventPRMerged:
+		if canTransition(state.PullRequest.Status, PRStatusMerged) {
+			at := event.CreatedAt
+			state.PullRequest.Status = PRStatusMerged
+			state.PullRequest.MergedAt = &at
+			state.PullRequest.MergedBy = payloadString(event.Payload, "merged_by")
+			state.PullRequest.MergeCommit = payloadString(event.Payload, "merge_commit")
+			state.PullRequest.MergeURL = payloadString(event.Payload, "merge_url")
+		}
+	}
+}
+
+func stateReviewer(state *models.PRState, userID string) *models.PRReviewer {
+	for i := range state.Reviewers {
+		if state.Reviewers[i].UserID == userID {
+			return &state.Reviewers[i]
+		}
+	}
+	return nil
+}
+
+// pausedSeconds - whole seconds the reviewer's SLA clock was paused up to at, see ResumeAssignmentSLA
+func pausedSeconds(r *models.PRReviewer, at time.Time) int64 {
+	seconds := r.SLAPausedSeconds
+	if r.SLAPausedAt != nil && at.After(*r.SLAPausedAt) {
+		seconds += int64(at.Sub(*r.SLAPausedAt).Round(time.Second) / time.Second)
+	}
+	return seconds
+}
+
+// GetPRStateAt folds PR events recorded up to at, zero at means now
+func (s *Service) GetPRStateAt(prID string, at time.Time) (*models.PRState, error) {
+	events, err := s.storage.GetPREvents(prID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if !at.IsZero() {
+		n := 0
+		for n < len(events) && !events[n].CreatedAt.After(at) {
+			n++
+		}
+		events = events[:n]
+	}
+	
+	state := foldPREvents(events)
+	if state == nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request has no history at this time",
+		}
+	}
+	return state, nil
+}
+
+// RebuildPRProjection overwrites PR rows from the timeline, admin only
+func (s *Service) RebuildPRProjection(actorID, prID string) (*models.PRState, error) {
+	actor, err := s.storage.GetUser(actorID)
+	if err != nil || actor.Role != RoleAdmin {
+		return nil, &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only admin can rebuild projections",
+		}
+	}
+	
+	if err := s.rebuildProjection(prID); err != nil {
+		return nil, err
+	}
+	if err := s.audit(actorID, AuditRebuildProjection, prID, nil); err != nil {
+		return nil, err
+	}
+	
+	return s.GetPRStateAt(prID, time.Time{})
+}
+
+func (s *Service) rebuildProjection(prID string) error {
+	events, err := s.storage.GetPREvents(prID)
+	if err != nil {
+		return err
+	}
+	
+	state := foldPREvents(events)
+	if state == nil {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request has no history",
+		}
+	}
+	
+	// events recorded before payloads carried PR fields keep the stored values
+	if current, err := s.storage.GetPullRequest(prID); err == nil {
+		if state.PullRequest.PullRequestName == "" {
+			state.PullRequest.PullRequestName = current.PullRequestName
+		}
+		if state.PullRequest.Priority == "" {
+			state.PullRequest.Priority = current.Priority
+		}
+	}
+	if state.PullRequest.Priority == "" {
+		state.PullRequest.Priority = PriorityNormal
+	}
+	
+	return s.storage.ReplacePRProjection(state)
+}
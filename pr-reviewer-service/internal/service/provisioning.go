@@ -0,0 +1,211 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+// Identity provider provisioning: users are created, updated and deactivated from outside,
+// a group is a team and each user belongs to exactly one team.
+
+// ProvisionUser creates or updates user, a missing team is created. Role and per-user
+// review settings are managed by the service and survive updates.
+func (s *Service) ProvisionUser(user *models.User) (*models.User, bool, error) {
+	user.UserID = strings.TrimSpace(user.UserID)
+	user.Username = strings.TrimSpace(user.Username)
+	user.TeamName = strings.TrimSpace(user.TeamName)
+	user.ManagerID = strings.TrimSpace(user.ManagerID)
+	if user.UserID == "" {
+		return nil, false, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "user_id is required",
+		}
+	}
+	if user.Username == "" {
+		user.Username = user.UserID
+	}
+	
+	// team and region aren't always sent on updates, the user keeps the current ones
+	existing, err := s.storage.GetUser(user.UserID)
+	created := err != nil
+	user.Role = RoleMember
+	if !created {
+		user.Role = existing.Role
+		if user.TeamName == "" {
+			user.TeamName = existing.TeamName
+		}
+		if user.Region == "" {
+			user.Region = existing.Region
+		}
+	}
+	if user.TeamName == "" {
+		return nil, false, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "team is required",
+		}
+	}
+	
+	if err := s.ensureProvisionedTeam(user.TeamName); err != nil {
+		return nil, false, err
+	}
+	if err := s.storage.CreateOrUpdateUser(user); err != nil {
+		return nil, false, err
+	}
+	if created || existing.ManagerID != user.ManagerID {
+		manager := []models.UserManager{{UserID: user.UserID, ManagerID: user.ManagerID}}
+		if err := s.storage.ImportUserManagers(manager); err != nil {
+			return nil, false, err
+		}
+	}
+	
+	provisioned, err := s.storage.GetUser(user.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+	return provisioned, created, nil
+}
+
+// GetUser returns a single user
+func (s *Service) GetUser(userID string) (*models.User, error) {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	return user, nil
+}
+
+// ListUsers returns a page of all users ordered by id and the total count
+func (s *Service) ListUsers(offset, limit int) ([]models.User, int, error) {
+	total, err := s.storage.CountUsers()
+	if err != nil {
+		return nil, 0, err
+	}
+	users, err := s.storage.ListUsers(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// ListTeamsWithMembers returns a page of teams ordered by name and the total count
+func (s *Service) ListTeamsWithMembers(offset, limit int) ([]models.TeamResponse, int, error) {
+	total, err := s.storage.CountTeams()
+	if err != nil {
+		return nil, 0, err
+	}
+	names, err := s.storage.ListTeamNames(offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	
+	teams := make([]models.TeamResponse, 0, len(names))
+	for _, name := range names {
+		team, err := s.storage.GetTeam(name)
+		if err != nil {
+			return nil, 0, err
+		}
+		teams = append(teams, *team)
+	}
+	return teams, total, nil
+}
+
+// ProvisionTeam creates an empty team and moves the listed users into it
+func (s *Service) ProvisionTeam(teamName string, members []string) (*models.TeamResponse, error) {
+	teamName = strings.TrimSpace(teamName)
+	if teamName == "" {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "team name is required",
+		}
+	}
+	
+	exists, err := s.storage.TeamExists(teamName)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, &ServiceError{
+			Code:    errcode.TeamExists,
+			Message: "team already exists",
+		}
+	}
+	if err := s.storage.CreateTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	return s.UpdateTeamMembers(teamName, members, nil)
+}
+
+// UpdateTeamMembers moves added users into the team and activates them, removed users
+// still in the team are deactivated since a user can't be left without a team
+func (s *Service) UpdateTeamMembers(teamName string, add, remove []string) (*models.TeamResponse, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	for _, userID := range add {
+		user, err := s.GetUser(userID)
+		if err != nil {
+			return nil, err
+		}
+		if user.TeamName == teamName && user.IsActive {
+			continue
+		}
+		user.TeamName = teamName
+		user.IsActive = true
+		if err := s.storage.CreateOrUpdateUser(user); err != nil {
+			return nil, err
+		}
+	}
+	
+	for _, userID := range remove {
+		user, err := s.GetUser(userID)
+		if err != nil {
+			return nil, err
+		}
+		if user.TeamName != teamName || !user.IsActive {
+			continue
+		}
+		if err := s.storage.SetUserActive(userID, false); err != nil {
+			return nil, err
+		}
+	}
+	
+	return s.GetTeam(teamName)
+}
+
+// ReplaceTeamMembers makes the listed users the only active members of the team
+func (s *Service) ReplaceTeamMembers(teamName string, members []string) (*models.TeamResponse, error) {
+	team, err := s.GetTeam(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	keep := make(map[string]bool, len(members))
+	for _, userID := range members {
+		keep[userID] = true
+	}
+	var remove []string
+	for _, member := range team.Members {
+		if member.IsActive && !keep[member.UserID] {
+			remove = append(remove, member.UserID)
+		}
+	}
+	
+	return s.UpdateTeamMembers(teamName, members, remove)
+}
+
+func (s *Service) ensureProvisionedTeam(teamName string) error {
+	exists, err := s.storage.TeamExists(teamName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return s.storage.CreateTeam(teamName)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+const maxPRTemplates = 50
+
+func (s *Service) GetTeamPRTemplates(teamName string) (*models.TeamPRTemplates, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	templates, err := s.storage.GetPRTemplates(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &models.TeamPRTemplates{TeamName: teamName, Templates: templates}, nil
+}
+
+// SetTeamPRTemplates replaces team templates, they are matched in the given order.
+// A template needs a condition (name prefix or labels) and something to set.
+func (s *Service) SetTeamPRTemplates(teamTemplates *models.TeamPRTemplates) (*models.TeamPRTemplates, error) {
+	if err := s.ensureTeam(teamTemplates.TeamName); err != nil {
+		return nil, err
+	}
+	if len(teamTemplates.Templates) > maxPRTemplates {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("at most %d templates allowed", maxPRTemplates),
+		}
+	}
+	
+	seen := make(map[string]bool, len(teamTemplates.Templates))
+	for i := range teamTemplates.Templates {
+		template := &teamTemplates.Templates[i]
+		template.Name = strings.TrimSpace(template.Name)
+		template.NamePrefix = strings.TrimSpace(template.NamePrefix)
+		if template.Name == "" {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "template name is required",
+			}
+		}
+		if seen[template.Name] {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "duplicate template " + template.Name,
+			}
+		}
+		seen[template.Name] = true
+	
+		labels, err := normalizeLabels(template.Labels)
+		if err != nil {
+			return nil, err
+		}
+		template.Labels = labels
+		if template.NamePrefix == "" && len(template.Labels) == 0 {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "template " + template.Name + " needs name_prefix or labels",
+			}
+		}
+	
+		if template.Priority == "" && template.ReviewerPool == "" {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "template " + template.Name + " needs priority or reviewer_pool",
+			}
+		}
+		if template.Priority != "" && !isValidPriority(template.Priority) {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "unknown priority " + template.Priority,
+			}
+		}
+		if template.ReviewerPool != "" {
+			if err := s.ensurePool(teamTemplates.TeamName, template.ReviewerPool); err != nil {
+				return nil, err
+			}
+		}
+	}
+	
+	if err := s.storage.ReplacePRTemplates(teamTemplates.TeamName, teamTemplates.Templates); err != nil {
+		return nil, err
+	}
+	
+	if teamTemplates.Templates == nil {
+		teamTemplates.Templates = []models.PRTemplate{}
+	}
+	return teamTemplates, nil
+}
+
+// normalizeLabels trims and lowercases labels, code hosts compare them case-insensitively
+func normalizeLabels(labels []string) ([]string, error) {
+	normalized := make([]string, 0, len(labels))
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if label == "" {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "labels must not be empty",
+			}
+		}
+		if !seen[label] {
+			seen[label] = true
+			normalized = append(normalized, label)
+		}
+	}
+	return normalized, nil
+}
+
+// matchPRTemplate returns the first team template matching PR name and labels, nil if none does
+func (s *Service) matchPRTemplate(teamName, prName string, labels []string) (*models.PRTemplate, error) {
+	templates, err := s.storage.GetPRTemplates(teamName)
+	if err != nil || len(templates) == 0 {
+		return nil, err
+	}
+	
+	prLabels := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		prLabels[strings.ToLower(strings.TrimSpace(label))] = true
+	}
+	name := strings.ToLower(prName)
+	
+	for i := range templates {
+		template := &templates[i]
+		if !strings.HasPrefix(name, strings.ToLower(template.NamePrefix)) {
+			continue
+		}
+		matched := true
+		for _, label := range template.Labels {
+			if !prLabels[label] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return template, nil
+		}
+	}
+	return nil, nil
+}
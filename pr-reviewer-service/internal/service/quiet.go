@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// PR priorities
+const (
+	PriorityLow    = "LOW"
+	PriorityNormal = "NORMAL"
+	PriorityHigh   = "HIGH"
+	PriorityUrgent = "URGENT"
+)
+
+func isValidPriority(priority string) bool {
+	return priority == PriorityLow || priority == PriorityNormal ||
+		priority == PriorityHigh || priority == PriorityUrgent
+}
+
+// SetUserQuietHours sets local hours [start, end) when non-urgent notifications are held, nil disables
+func (s *Service) SetUserQuietHours(userID string, start, end *int) (*models.User, error) {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	if (start == nil) != (end == nil) {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "start_hour and end_hour must be set together",
+		}
+	}
+	if start != nil {
+		if *start < 0 || *start > 23 || *end < 0 || *end > 23 {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "quiet hours must be between 0 and 23",
+			}
+		}
+		if *start == *end {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "start_hour and end_hour must differ",
+			}
+		}
+	}
+	
+	if err := s.storage.SetUserQuietHours(userID, start, end); err != nil {
+		return nil, err
+	}
+	
+	user.QuietHoursStart = start
+	user.QuietHoursEnd = end
+	return user, nil
+}
+
+// quietHoursEnd returns end of user's current quiet window, zero time if user isn't in one
+func quietHoursEnd(user *models.User, now time.Time) time.Time {
+	if user.QuietHoursStart == nil || user.QuietHoursEnd == nil {
+		return time.Time{}
+	}
+	
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	
+	local := now.In(loc)
+	start, end, hour := *user.QuietHoursStart, *user.QuietHoursEnd, local.Hour()
+	
+	var quiet bool
+	if start < end {
+		quiet = hour >= start && hour < end
+	} else {
+		// window wraps midnight, e.g. 22-7
+		quiet = hour >= start || hour < end
+	}
+	if !quiet {
+		return time.Time{}
+	}
+	
+	until := time.Date(local.Year(), local.Month(), local.Day(), end, 0, 0, 0, loc)
+	if hour >= end {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until.UTC()
+}
+
+// deliverAfter decides whether a notification waits for the end of quiet hours
+func (s *Service) deliverAfter(userID, prID string, now time.Time) (*time.Time, error) {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	until := quietHoursEnd(user, now)
+	if until.IsZero() {
+		return nil, nil
+	}
+	
+	// urgent PRs break through quiet hours
+	if prID != "" {
+		pr, err := s.storage.GetPullRequest(prID)
+		if err != nil {
+			return nil, err
+		}
+		if pr.Priority == PriorityUrgent {
+			return nil, nil
+		}
+	}
+	
+	return &until, nil
+}
+
+// DeliverDeferredNotifications releases notifications whose quiet hours ended as one batch, run by the scheduler
+func (s *Service) DeliverDeferredNotifications(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	
+	released, err := s.storage.ReleaseDeferredNotifications(time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if len(released) > 0 {
+		log.Printf("Delivered %d deferred notifications", len(released))
+	}
+	
+	for i := range released {
+		if err := s.dispatchNotification(&released[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"math/rand"
+	"pr-reviewer-service/internal/errcode"
+	"sync"
+)
+
+// lockedSource makes a rand.Source safe for concurrent requests
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+func newRand(src rand.Source) *rand.Rand {
+	return rand.New(&lockedSource{src: src})
+}
+
+// WithRandSource replaces the reviewer selection source, tests pass rand.NewSource(seed) for repeatable picks
+func WithRandSource(src rand.Source) Option {
+	return func(s *Service) {
+		s.rand = newRand(src)
+	}
+}
+
+// WithRequestSeeds accepts per-request seed in PR creation and preview, never enable in production
+func WithRequestSeeds() Option {
+	return func(s *Service) {
+		s.requestSeeds = true
+	}
+}
+
+// randFor returns generator for a request, seeded one when request seeds are allowed
+func (s *Service) randFor(seed *int64) (*rand.Rand, error) {
+	if seed == nil {
+		return s.rand, nil
+	}
+	if !s.requestSeeds {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "seed is accepted only in non-production mode",
+		}
+	}
+	return rand.New(rand.NewSource(*seed)), nil
+}
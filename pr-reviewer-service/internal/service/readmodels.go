@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-service/internal/errcode"
+)
+
+// ReconcileReadModels recomputes statistics read models from OLTP tables, run by the scheduler
+// to repair drift, e.g. nightly
+func (s *Service) ReconcileReadModels(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return s.storage.RebuildReadModels()
+}
+
+// RebuildReadModels recomputes statistics read models on admin request
+func (s *Service) RebuildReadModels(ctx context.Context, actorID string) error {
+	actor, err := s.storage.GetUser(actorID)
+	if err != nil || actor.Role != RoleAdmin {
+		return &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only admin can rebuild read models",
+		}
+	}
+	
+	if err := s.ReconcileReadModels(ctx); err != nil {
+		return err
+	}
+	return s.audit(actorID, AuditRebuildReadModels, "", nil)
+}
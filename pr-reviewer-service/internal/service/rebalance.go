@@ -0,0 +1,245 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"sort"
+)
+
+// AuditRebalance - open reviews of a team were moved to even out load
+const AuditRebalance = "REVIEW_REBALANCE"
+
+const (
+	defaultRebalanceSwaps = 10
+	maxRebalanceSwaps     = 100
+)
+
+// RebalanceReviews moves open reviews of the team from its most loaded members to the least
+// loaded ones, admin only. Only automatic assignments nobody started are moved, the new reviewer
+// has to pass the usual replacement checks and stay under their cap, and a move is made only if
+// it narrows the gap. Dry run reports the same swaps without making them.
+func (s *Service) RebalanceReviews(req models.RebalanceRequest) (*models.RebalanceResult, error) {
+	actor, err := s.storage.GetUser(req.ActorID)
+	if err != nil || actor.Role != RoleAdmin {
+		return nil, &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only admin can rebalance reviews",
+		}
+	}
+	if err := s.ensureTeam(req.TeamName); err != nil {
+		return nil, err
+	}
+	if req.MaxSwaps == 0 {
+		req.MaxSwaps = defaultRebalanceSwaps
+	}
+	if req.MaxSwaps < 1 || req.MaxSwaps > maxRebalanceSwaps {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("max_swaps must be between 1 and %d", maxRebalanceSwaps),
+		}
+	}
+	
+	var result *models.RebalanceResult
+	err = s.storage.WithTeamLock(req.TeamName, func() error {
+		var err error
+		result, err = s.rebalance(req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// rebalance plans and, unless it's a dry run, makes the swaps, caller holds the team lock.
+// Every swap is stored and audited on its own, so when one fails the result lists the swaps
+// already made along with the error.
+func (s *Service) rebalance(req models.RebalanceRequest) (*models.RebalanceResult, error) {
+	settings, err := s.teamSettings(req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.storage.GetActiveTeamMembers(req.TeamName, "")
+	if err != nil {
+		return nil, err
+	}
+	loads, err := s.storage.GetOpenReviewLoads(req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.storage.GetMovableAssignments(req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	movable := make(map[string][]models.ReviewAssignment)
+	for _, a := range assignments {
+		movable[a.ReviewerID] = append(movable[a.ReviewerID], a)
+	}
+	before := make(map[string]int, len(members))
+	for _, member := range members {
+		before[member.UserID] = loads[member.UserID]
+	}
+	
+	plan := &rebalancePlan{
+		settings: settings,
+		members:  members,
+		loads:    loads,
+		movable:  movable,
+		added:    make(map[string]map[string]bool),
+	}
+	result := &models.RebalanceResult{
+		TeamName: req.TeamName,
+		DryRun:   req.DryRun,
+		Swaps:    []models.RebalanceSwap{},
+	}
+	var failure error
+	for len(result.Swaps) < req.MaxSwaps {
+		var swap *models.RebalanceSwap
+		if swap, failure = s.nextRebalanceSwap(plan, req.TeamName); failure != nil || swap == nil {
+			break
+		}
+		if !req.DryRun {
+			var made bool
+			made, failure = s.makeRebalanceSwap(req, *swap)
+			if !made {
+				break
+			}
+		}
+		plan.apply(*swap)
+		result.Swaps = append(result.Swaps, *swap)
+		if failure != nil {
+			break
+		}
+	}
+	if failure != nil {
+		// nothing is changed yet, the request fails as a whole
+		if req.DryRun || len(result.Swaps) == 0 {
+			return nil, failure
+		}
+		if _, ok := failure.(*ServiceError); !ok {
+			log.Printf("Rebalance of team %s stopped after %d swaps: %v", req.TeamName, len(result.Swaps), failure)
+		}
+		result.Error = errorDetail(failure, "failed to rebalance reviews")
+	}
+	
+	beforeValues := make([]int, 0, len(members))
+	afterValues := make([]int, 0, len(members))
+	result.Load = make([]models.RebalanceLoad, 0, len(members))
+	for _, member := range members {
+		load := models.RebalanceLoad{
+			UserID: member.UserID,
+			Before: before[member.UserID],
+			After:  loads[member.UserID],
+		}
+		beforeValues = append(beforeValues, load.Before)
+		afterValues = append(afterValues, load.After)
+		result.Load = append(result.Load, load)
+	}
+	sort.Slice(result.Load, func(i, j int) bool {
+		if result.Load[i].Before != result.Load[j].Before {
+			return result.Load[i].Before > result.Load[j].Before
+		}
+		return result.Load[i].UserID < result.Load[j].UserID
+	})
+	result.StdDevBefore = stdDev(beforeValues)
+	result.StdDevAfter = stdDev(afterValues)
+	
+	return result, nil
+}
+
+// makeRebalanceSwap moves the review and audits the move, made is set once the review is moved
+func (s *Service) makeRebalanceSwap(req models.RebalanceRequest, swap models.RebalanceSwap) (bool, error) {
+	extra := map[string]interface{}{"rebalanced_by": req.ActorID}
+	if err := s.swapReviewer(swap.PullRequestID, swap.FromUserID, swap.ToUserID, req.TeamName, AssignmentAuto, extra); err != nil {
+		return false, err
+	}
+	
+	details := map[string]interface{}{
+		"team_name":    req.TeamName,
+		"from_user_id": swap.FromUserID,
+		"to_user_id":   swap.ToUserID,
+	}
+	return true, s.audit(req.ActorID, AuditRebalance, swap.PullRequestID, details)
+}
+
+// rebalancePlan - loads and movable reviews as they are after the swaps made so far
+type rebalancePlan struct {
+	settings *models.TeamSettings
+	members  []models.User
+	loads    map[string]int
+	movable  map[string][]models.ReviewAssignment
+	added    map[string]map[string]bool // new reviewers by PR, a dry run doesn't store them
+}
+
+func (p *rebalancePlan) apply(swap models.RebalanceSwap) {
+	p.loads[swap.FromUserID]--
+	p.loads[swap.ToUserID]++
+	
+	remaining := p.movable[swap.FromUserID][:0]
+	for _, a := range p.movable[swap.FromUserID] {
+		if a.PullRequestID != swap.PullRequestID {
+			remaining = append(remaining, a)
+		}
+	}
+	p.movable[swap.FromUserID] = remaining
+	
+	if p.added[swap.PullRequestID] == nil {
+		p.added[swap.PullRequestID] = make(map[string]bool)
+	}
+	p.added[swap.PullRequestID][swap.ToUserID] = true
+}
+
+// nextRebalanceSwap finds a move from the most loaded member that has one to the least loaded
+// eligible replacement, nil when no move narrows the gap
+func (s *Service) nextRebalanceSwap(plan *rebalancePlan, teamName string) (*models.RebalanceSwap, error) {
+	members := make([]models.User, len(plan.members))
+	copy(members, plan.members)
+	sort.SliceStable(members, func(i, j int) bool {
+		if plan.loads[members[i].UserID] != plan.loads[members[j].UserID] {
+			return plan.loads[members[i].UserID] > plan.loads[members[j].UserID]
+		}
+		return members[i].UserID < members[j].UserID
+	})
+	
+	for _, from := range members {
+		for _, a := range plan.movable[from.UserID] {
+			pr, err := s.storage.GetPullRequest(a.PullRequestID)
+			if err != nil {
+				return nil, err
+			}
+			candidates, err := s.replacementCandidates(pr, from.UserID, teamName, nil)
+			if isNoCandidate(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+	
+			var best *models.User
+			for i := range candidates {
+				candidate := &candidates[i]
+				load := plan.loads[candidate.UserID]
+				if plan.added[pr.PullRequestID][candidate.UserID] || plan.loads[from.UserID]-load < 2 {
+					continue
+				}
+				if limit := reviewCap(candidate, plan.settings); limit != nil && load >= *limit {
+					continue
+				}
+				if best == nil || load < plan.loads[best.UserID] || (load == plan.loads[best.UserID] && candidate.UserID < best.UserID) {
+					best = candidate
+				}
+			}
+			if best != nil {
+				return &models.RebalanceSwap{
+					PullRequestID: pr.PullRequestID,
+					FromUserID:    from.UserID,
+					ToUserID:      best.UserID,
+				}, nil
+			}
+		}
+	}
+	return nil, nil
+}
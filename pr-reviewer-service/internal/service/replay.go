@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/eventbus"
+	"pr-reviewer-service/internal/models"
+)
+
+// replayBatchSize - events loaded per storage round trip during replay
+const replayBatchSize = 500
+
+// ReplayEvents re-publishes stored PR events matching the filter in original order, admin only.
+// Consumers deduplicate by event_id, so replaying an overlapping range is safe.
+func (s *Service) ReplayEvents(ctx context.Context, actorID string, filter models.PREventFilter) (int, error) {
+	actor, err := s.storage.GetUser(actorID)
+	if err != nil || actor.Role != RoleAdmin {
+		return 0, &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only admin can replay events",
+		}
+	}
+	
+	if s.events == nil {
+		return 0, &ServiceError{
+			Code:    errcode.EventsDisabled,
+			Message: "event publishing is not configured",
+		}
+	}
+	
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return 0, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "from must be before to",
+		}
+	}
+	
+	details := map[string]interface{}{
+		"pull_request_id": filter.PullRequestID,
+		"from":            filter.From,
+		"to":              filter.To,
+	}
+	if err := s.audit(actorID, AuditEventReplay, filter.PullRequestID, details); err != nil {
+		return 0, err
+	}
+	
+	published := 0
+	var afterID int64
+	for {
+		events, err := s.storage.ListPREvents(filter, afterID, replayBatchSize)
+		if err != nil {
+			return published, err
+		}
+	
+		for i := range events {
+			if ctx.Err() != nil {
+				return published, ctx.Err()
+			}
+			if err := s.events.Publish(ctx, eventbus.NewEnvelope(&events[i], true)); err != nil {
+				return published, err
+			}
+			published++
+			afterID = events[i].ID
+		}
+	
+		if len(events) < replayBatchSize {
+			return published, nil
+		}
+	}
+}
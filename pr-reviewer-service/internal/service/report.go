@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+const reportBottleneckLimit = 3
+
+// parseISOWeek parses "2026-W41" into the week's Monday 00:00 UTC
+func parseISOWeek(week string) (time.Time, error) {
+	var year, num int
+	if _, err := fmt.Sscanf(week, "%d-W%d", &year, &num); err != nil || num < 1 || num > 53 {
+		return time.Time{}, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "week must look like 2026-W41",
+		}
+	}
+	
+	// week 1 is the one containing January 4th
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	monday := jan4.AddDate(0, 0, -offset+(num-1)*7)
+	
+	if y, w := monday.ISOWeek(); y != year || w != num {
+		return time.Time{}, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("year %d has no week %d", year, num),
+		}
+	}
+	return monday, nil
+}
+
+func isoWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// GetTeamReport builds report for the ISO week, empty week means the previous one
+func (s *Service) GetTeamReport(teamName, week string) (*models.TeamReport, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	if week == "" {
+		week = isoWeek(time.Now().UTC().AddDate(0, 0, -7))
+	}
+	
+	from, err := parseISOWeek(week)
+	if err != nil {
+		return nil, err
+	}
+	
+	report := &models.TeamReport{
+		TeamName: teamName,
+		Week:     isoWeek(from),
+		From:     from,
+		To:       from.AddDate(0, 0, 7),
+	}
+	
+	if err := s.storage.FillTeamReport(report); err != nil {
+		return nil, err
+	}
+	
+	report.Bottlenecks, err = s.storage.GetTeamBottlenecks(teamName, reportBottleneckLimit)
+	if err != nil {
+		return nil, err
+	}
+	
+	return report, nil
+}
+
+// SendWeeklyReports delivers last week's report to each team once, run by the scheduler
+func (s *Service) SendWeeklyReports(ctx context.Context) error {
+	teams, err := s.storage.GetTeamNames()
+	if err != nil {
+		return err
+	}
+	
+	week := isoWeek(time.Now().UTC().AddDate(0, 0, -7))
+	for _, teamName := range teams {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	
+		if err := s.sendWeeklyReport(teamName, week); err != nil {
+			log.Printf("Weekly report for %s failed: %v", teamName, err)
+		}
+	}
+	
+	return nil
+}
+
+func (s *Service) sendWeeklyReport(teamName, week string) error {
+	report, err := s.GetTeamReport(teamName, week)
+	if err != nil {
+		return err
+	}
+	
+	isNew, err := s.storage.RecordTeamReport(teamName, week)
+	if err != nil || !isNew {
+		return err
+	}
+	
+	recipients, err := s.storage.GetTeamLeads(teamName)
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		if recipients, err = s.storage.GetActiveTeamMembers(teamName, ""); err != nil {
+			return err
+		}
+	}
+	
+	render := s.notificationRenderer(teamName, NotificationWeeklyReport, report)
+	for _, user := range recipients {
+		message, err := render(s.localeOf(&user))
+		if err != nil {
+			return err
+		}
+		if err := s.notify(user.UserID, NotificationWeeklyReport, "", message); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+// Webhook PR actions
+const (
+	WebhookOpened          = "opened"
+	WebhookReopened        = "reopened"
+	WebhookClosed          = "closed"
+	WebhookReviewRequested = "review_requested"
+)
+
+func (s *Service) ListRepositories(teamName string) ([]models.Repository, error) {
+	if teamName != "" {
+		if err := s.ensureTeam(teamName); err != nil {
+			return nil, err
+		}
+	}
+	return s.storage.ListRepositories(teamName)
+}
+
+// SetRepositoryOwner registers repo for the team or moves it, lead of both teams or admin only.
+// PRs created before the move stay with the old team.
+func (s *Service) SetRepositoryOwner(repositoryID, teamName, actorID string) (*models.Repository, error) {
+	repositoryID = strings.TrimSpace(repositoryID)
+	if repositoryID == "" {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "repository_id is required",
+		}
+	}
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	if _, err := s.authorizeTeam(actorID, teamName); err != nil {
+		return nil, err
+	}
+	
+	current, err := s.storage.GetRepository(repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	previousTeam := ""
+	if current != nil && current.TeamName != teamName {
+		if _, err := s.authorizeTeam(actorID, current.TeamName); err != nil {
+			return nil, err
+		}
+		previousTeam = current.TeamName
+	}
+	
+	repo := &models.Repository{RepositoryID: repositoryID, TeamName: teamName}
+	if err := s.storage.SaveRepository(repo); err != nil {
+		return nil, err
+	}
+	
+	details := map[string]interface{}{
+		"repository_id": repositoryID,
+		"team_name":     teamName,
+	}
+	if previousTeam != "" {
+		details["previous_team_name"] = previousTeam
+	}
+	if err := s.audit(actorID, AuditRepositoryOwner, "", details); err != nil {
+		return nil, err
+	}
+	
+	return repo, nil
+}
+
+// DeleteRepository unregisters repo, lead of its team or admin only
+func (s *Service) DeleteRepository(repositoryID, actorID string) error {
+	repo, err := s.ensureRepository(repositoryID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.authorizeTeam(actorID, repo.TeamName); err != nil {
+		return err
+	}
+	
+	if err := s.storage.DeleteRepository(repositoryID); err != nil {
+		return err
+	}
+	
+	details := map[string]interface{}{
+		"repository_id":      repositoryID,
+		"previous_team_name": repo.TeamName,
+	}
+	return s.audit(actorID, AuditRepositoryOwner, "", details)
+}
+
+// webhookPRID - id of a webhook-ingested PR, unique across repositories
+func webhookPRID(repositoryID string, number int) string {
+	return fmt.Sprintf("%s#%d", repositoryID, number)
+}
+
+// IngestPullRequestWebhook creates or merges PR of a registered repository.
+// Redelivered events are no-ops, returns nil PR for ignored actions.
+func (s *Service) IngestPullRequestWebhook(event *models.PullRequestWebhook) (*models.PullRequest, error) {
+	if event.RepositoryID == "" || event.Number <= 0 {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "repository and PR number are required",
+		}
+	}
+	prID := webhookPRID(event.RepositoryID, event.Number)
+	// the PR may have been created under another id, e.g. before the repository was renamed
+	existingID, err := s.externalPRID(event)
+	if err != nil {
+		return nil, err
+	}
+	if existingID != "" {
+		prID = existingID
+	}
+	
+	switch event.Action {
+	case WebhookOpened, WebhookReopened:
+		exists, err := s.storage.PRExists(prID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return s.reopenWebhookPR(prID, event)
+		}
+		authorID, err := s.webhookUser(event.Provider, event.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		pr, err := s.CreatePullRequest(&models.CreatePullRequestRequest{
+			PullRequestID:   prID,
+			PullRequestName: event.Title,
+			AuthorID:        authorID,
+			RepositoryID:    event.RepositoryID,
+			ExternalID:      event.ExternalID,
+			ExternalURL:     event.ExternalURL,
+			Labels:          event.Labels,
+		})
+		if err != nil {
+			// a concurrent redelivery stored it first
+			if existingID, lookupErr := s.externalPRID(event); lookupErr == nil && existingID != "" {
+				return s.storage.GetPullRequest(existingID)
+			}
+			return nil, err
+		}
+		return pr, nil
+	
+	case WebhookClosed:
+		if !event.Merged {
+			return s.closeWebhookPR(prID, event)
+		}
+		mergedBy, err := s.webhookUser(event.Provider, event.Merge.MergedBy)
+		if err != nil {
+			return nil, err
+		}
+		merge := event.Merge
+		merge.MergedBy = mergedBy
+		return s.mergeWebhookPR(prID, event, merge)
+	
+	case WebhookReviewRequested:
+		pr, err := s.storage.GetPullRequest(prID)
+		if err != nil {
+			return nil, nil
+		}
+		// a team request has no reviewer and resumes everyone
+		reviewerID, err := s.webhookUser(event.Provider, event.RequestedReviewer)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.resumeReview(prID, pr.AuthorID, reviewerID); err != nil {
+			return nil, err
+		}
+		return pr, nil
+	}
+	
+	return nil, nil
+}
+
+// mergeWebhookPR merges the PR, the provider wins over a local close: a closed PR is reopened
+// first so its timeline shows both steps
+func (s *Service) mergeWebhookPR(prID string, event *models.PullRequestWebhook, merge models.MergeInfo) (*models.PullRequest, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err == nil && pr.Status == PRStatusClosed {
+		actorID, err := s.webhookActor(pr, event)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.transitionPullRequest(prID, actorID, PRStatusOpen, EventPRReopened); err != nil {
+			return nil, err
+		}
+	}
+	return s.MergePullRequest(prID, merge)
+}
+
+// closeWebhookPR closes the open PR, redelivered and unknown PRs are left as they are
+func (s *Service) closeWebhookPR(prID string, event *models.PullRequestWebhook) (*models.PullRequest, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, nil
+	}
+	if pr.Status != PRStatusOpen {
+		return pr, nil
+	}
+	actorID, err := s.webhookActor(pr, event)
+	if err != nil {
+		return nil, err
+	}
+	return s.transitionPullRequest(prID, actorID, PRStatusClosed, EventPRClosed)
+}
+
+// reopenWebhookPR reopens the closed PR, other PRs are returned unchanged
+func (s *Service) reopenWebhookPR(prID string, event *models.PullRequestWebhook) (*models.PullRequest, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Action != WebhookReopened || pr.Status != PRStatusClosed {
+		return pr, nil
+	}
+	actorID, err := s.webhookActor(pr, event)
+	if err != nil {
+		return nil, err
+	}
+	return s.transitionPullRequest(prID, actorID, PRStatusOpen, EventPRReopened)
+}
+
+// webhookActor returns the user who triggered the event, the PR author when the provider
+// didn't say
+func (s *Service) webhookActor(pr *models.PullRequest, event *models.PullRequestWebhook) (string, error) {
+	actorID, err := s.webhookUser(event.Provider, event.Sender)
+	if err != nil {
+		return "", err
+	}
+	if actorID == "" {
+		actorID = pr.AuthorID
+	}
+	return actorID, nil
+}
+
+// externalPRID returns ID of the PR stored with the event's external keys, empty if there is none
+func (s *Service) externalPRID(event *models.PullRequestWebhook) (string, error) {
+	if event.ExternalID == "" && event.ExternalURL == "" {
+		return "", nil
+	}
+	return s.storage.GetPRIDByExternalKey(event.ExternalID, event.ExternalURL)
+}
+
+// webhookUser returns the user linked to provider account, unlinked accounts are taken for
+// user ids
+func (s *Service) webhookUser(provider, externalID string) (string, error) {
+	if provider == "" || externalID == "" {
+		return externalID, nil
+	}
+	userID, err := s.linkedUser(provider, externalID)
+	if err != nil || userID == "" {
+		return externalID, err
+	}
+	return userID, nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// EventPRRevertMarked - PR was marked as revert of a merged PR
+const EventPRRevertMarked = "PR_REVERT_MARKED"
+
+// MarkRevert links the PR as revert of a merged one, archived ones included. Marking it again
+// with the same PR is a no-op.
+func (s *Service) MarkRevert(prID, revertedID, markedBy string) (*models.PullRequest, *models.PRRevert, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	revert, err := s.markRevert(prID, revertedID, markedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pr, revert, nil
+}
+
+// markRevert validates and saves the link, the reviewers it keeps are preferred from now on
+func (s *Service) markRevert(prID, revertedID, markedBy string) (*models.PRRevert, error) {
+	revert, linked, err := s.checkRevert(prID, revertedID, markedBy)
+	if err != nil || linked {
+		return revert, err
+	}
+	return s.saveRevert(revert)
+}
+
+// checkRevert validates the link and builds it, linked is true when the PR already reverts
+// the same PR and the stored link is returned
+func (s *Service) checkRevert(prID, revertedID, markedBy string) (*models.PRRevert, bool, error) {
+	if revertedID == "" {
+		return nil, false, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "reverted_id is required",
+		}
+	}
+	if revertedID == prID {
+		return nil, false, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "pull request can't revert itself",
+		}
+	}
+	
+	existing, err := s.storage.GetPRRevert(prID)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		if existing.RevertedID != revertedID {
+			return nil, false, &ServiceError{
+				Code:    errcode.RevertLinked,
+				Message: "pull request already reverts " + existing.RevertedID,
+			}
+		}
+		return existing, true, nil
+	}
+	
+	reverted, err := s.revertedPR(revertedID)
+	if err != nil {
+		return nil, false, err
+	}
+	
+	return &models.PRRevert{
+		PullRequestID:    prID,
+		RevertedID:       revertedID,
+		TeamName:         reverted.TeamName,
+		RevertedMergedAt: *reverted.MergedAt,
+		Reviewers:        append([]string{}, reverted.AssignedReviewers...),
+		MarkedBy:         markedBy,
+	}, false, nil
+}
+
+// saveRevert stores a checked link and records it on the timeline
+func (s *Service) saveRevert(revert *models.PRRevert) (*models.PRRevert, error) {
+	saved, err := s.storage.SavePRRevert(revert)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		// marked concurrently, the stored link wins
+		return s.markRevert(revert.PullRequestID, revert.RevertedID, revert.MarkedBy)
+	}
+	
+	payload := map[string]interface{}{
+		"reverted_id": revert.RevertedID,
+		"team_name":   revert.TeamName,
+	}
+	if err := s.recordEvent(revert.PullRequestID, EventPRRevertMarked, revert.MarkedBy, payload); err != nil {
+		return nil, err
+	}
+	return revert, nil
+}
+
+// revertedPR looks the PR up in the hot table, then in the archive; it must be merged
+func (s *Service) revertedPR(prID string) (*models.PullRequest, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		if pr, err = s.storage.GetArchivedPullRequest(prID); err != nil {
+			return nil, err
+		}
+	}
+	if pr == nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "reverted pull request not found",
+		}
+	}
+	if pr.Status != PRStatusMerged || pr.MergedAt == nil {
+		return nil, &ServiceError{
+			Code:    errcode.PRNotMerged,
+			Message: "only a merged pull request can be reverted",
+		}
+	}
+	return pr, nil
+}
+
+// preferredReviewers returns reviewers the assigner picks first when free: those of the stack
+// base for a stacked PR, else those of the PR it reverts
+func (s *Service) preferredReviewers(prID string) ([]string, error) {
+	reviewers, err := s.stackBaseReviewers(prID)
+	if err != nil || len(reviewers) > 0 {
+		return reviewers, err
+	}
+	
+	revert, err := s.storage.GetPRRevert(prID)
+	if err != nil || revert == nil {
+		return nil, err
+	}
+	return revert.Reviewers, nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/storage"
+)
+
+// Reviewer actions
+const (
+	ReviewActionAccept  = "ACCEPT"
+	ReviewActionApprove = "APPROVE"
+	ReviewActionComment = "COMMENT"
+	// ReviewActionRequestChanges is a decision but keeps the assignment status, like a comment
+	ReviewActionRequestChanges = "REQUEST_CHANGES"
+)
+
+// Review decisions, stored as immutable rows
+const (
+	DecisionApproved         = "APPROVED"
+	DecisionChangesRequested = "CHANGES_REQUESTED"
+)
+
+// Reviewer assignment statuses
+const (
+	ReviewerPending  = "PENDING"
+	ReviewerAccepted = "ACCEPTED"
+	ReviewerApproved = "APPROVED"
+)
+
+// ReviewAction records reviewer's action on PR, the first one stops the time-to-first-review clock.
+// Approvals and change requests are also kept as decisions on the given revision.
+func (s *Service) ReviewAction(prID, userID, action, revision string) (*models.PullRequest, error) {
+	var status, decision string
+	switch action {
+	case ReviewActionAccept:
+		status = ReviewerAccepted
+	case ReviewActionApprove:
+		status = ReviewerApproved
+		decision = DecisionApproved
+	case ReviewActionRequestChanges:
+		decision = DecisionChangesRequested
+	case ReviewActionComment:
+	default:
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown review action " + action,
+		}
+	}
+	
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	if err := ensurePROpen(pr, "cannot review merged PR"); err != nil {
+		return nil, err
+	}
+	
+	isAssigned, err := s.storage.IsReviewerAssigned(prID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAssigned {
+		return nil, &ServiceError{
+			Code:    errcode.NotAssigned,
+			Message: "user is not assigned as reviewer to this PR",
+		}
+	}
+	
+	err = s.storage.InTx(func(repos storage.Repos) error {
+		if err := repos.RecordReviewAction(prID, userID, status); err != nil {
+			return err
+		}
+		if decision == "" {
+			return nil
+		}
+		return repos.AddReviewDecision(&models.ReviewDecision{
+			PullRequestID: prID,
+			UserID:        userID,
+			Decision:      decision,
+			Revision:      revision,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	payload := map[string]interface{}{"action": action}
+	if revision != "" {
+		payload["revision"] = revision
+	}
+	if err := s.recordEvent(prID, EventReviewAction, userID, payload); err != nil {
+		return nil, err
+	}
+	
+	return pr, nil
+}
+
+// GetReviewDecisions returns the PR decision history, oldest first
+func (s *Service) GetReviewDecisions(prID string) ([]models.ReviewDecision, error) {
+	exists, err := s.storage.PRExists(prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	return s.storage.GetReviewDecisions(prID)
+}
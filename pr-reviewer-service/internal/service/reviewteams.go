@@ -0,0 +1,278 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/i18n"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/strategy"
+	"slices"
+	"strings"
+)
+
+// EventReviewTeamAdded - another team joined the PR review with its approval requirement
+const EventReviewTeamAdded = "REVIEW_TEAM_ADDED"
+
+// reviewTeamSet - validated reviewer set of another team
+type reviewTeamSet struct {
+	models.ReviewTeam
+	Reviewers int
+}
+
+// parseReviewTeam validates a reviewer set, reviewers default to the required approvals and
+// can't be fewer since the PR could never be merged then
+func (s *Service) parseReviewTeam(prID, owningTeam string, req models.ReviewTeamRequest) (reviewTeamSet, error) {
+	set := reviewTeamSet{
+		ReviewTeam: models.ReviewTeam{
+			PullRequestID:     prID,
+			TeamName:          strings.TrimSpace(req.TeamName),
+			RequiredApprovals: 1,
+		},
+		Reviewers: req.Reviewers,
+	}
+	if req.RequiredApprovals != nil {
+		set.RequiredApprovals = *req.RequiredApprovals
+	}
+	
+	switch {
+	case set.TeamName == "":
+		return set, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "team_name of review team is required",
+		}
+	case set.TeamName == owningTeam:
+		return set, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("%s owns the pull request and can't be added as review team", set.TeamName),
+		}
+	case set.RequiredApprovals < 0 || set.Reviewers < 0:
+		return set, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "reviewers and required_approvals can't be negative",
+		}
+	}
+	if set.Reviewers == 0 {
+		set.Reviewers = set.RequiredApprovals
+	}
+	if set.Reviewers < set.RequiredApprovals {
+		return set, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("review team %s gets fewer reviewers than required approvals", set.TeamName),
+		}
+	}
+	
+	if err := s.ensureTeam(set.TeamName); err != nil {
+		return set, err
+	}
+	return set, nil
+}
+
+// parseReviewTeams validates reviewer sets of a new PR, each team may appear once
+func (s *Service) parseReviewTeams(prID, owningTeam string, reqs []models.ReviewTeamRequest) ([]reviewTeamSet, error) {
+	sets := make([]reviewTeamSet, 0, len(reqs))
+	for _, req := range reqs {
+		set, err := s.parseReviewTeam(prID, owningTeam, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range sets {
+			if other.TeamName == set.TeamName {
+				return nil, &ServiceError{
+					Code:    errcode.InvalidRequest,
+					Message: "duplicate review team " + set.TeamName,
+				}
+			}
+		}
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// assignReviewTeam saves the set and tops its reviewers up to the requested count, reviewers
+// nobody can take now are queued. Caller holds the team lock.
+func (s *Service) assignReviewTeam(rng *rand.Rand, pr *models.PullRequest, labels []string, set reviewTeamSet, trace *skipTrace) ([]string, error) {
+	if err := s.storage.SaveReviewTeam(&set.ReviewTeam); err != nil {
+		return nil, err
+	}
+	payload := map[string]interface{}{
+		"team_name":          set.TeamName,
+		"required_approvals": set.RequiredApprovals,
+	}
+	if err := s.recordEvent(pr.PullRequestID, EventReviewTeamAdded, "", payload); err != nil {
+		return nil, err
+	}
+	
+	current, err := s.storage.GetReviewerAssignments(pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	assigned := make([]string, 0, len(current))
+	missing := set.Reviewers
+	for _, r := range current {
+		assigned = append(assigned, r.UserID)
+		if r.TeamName == set.TeamName {
+			missing--
+		}
+	}
+	if missing <= 0 {
+		return nil, nil
+	}
+	
+	selected, err := s.assignReviewers(rng, strategy.Request{
+		TeamName:        set.TeamName,
+		PullRequestID:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		AuthorID:        pr.AuthorID,
+		Priority:        pr.Priority,
+		Size:            pr.Size,
+		RepositoryID:    pr.RepositoryID,
+		Labels:          labels,
+		Count:           missing,
+		Assigned:        assigned,
+	}, trace)
+	if err != nil {
+		return nil, err
+	}
+	
+	for _, reviewerID := range selected {
+		if err := s.addReviewer(pr.PullRequestID, reviewerID, set.TeamName, AssignmentAuto); err != nil {
+			return nil, err
+		}
+		assigned := map[string]interface{}{
+			"user_id":         reviewerID,
+			"assignment_type": AssignmentAuto,
+			"team_name":       set.TeamName,
+		}
+		if err := s.recordEvent(pr.PullRequestID, EventReviewerAssigned, "", assigned); err != nil {
+			return nil, err
+		}
+	}
+	
+	if missing -= len(selected); missing > 0 {
+		if err := s.queueAssignment(pr.PullRequestID, set.TeamName, missing); err != nil {
+			return nil, err
+		}
+	}
+	return selected, nil
+}
+
+// AddReviewTeam brings another team's reviewers to an open PR. Adding a team again changes
+// its required approvals and assigns more reviewers if the set grew.
+func (s *Service) AddReviewTeam(prID string, req models.ReviewTeamRequest) (*models.PullRequest, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	if err := ensurePROpen(pr, "cannot add review team to merged PR"); err != nil {
+		return nil, err
+	}
+	
+	set, err := s.parseReviewTeam(prID, pr.TeamName, req)
+	if err != nil {
+		return nil, err
+	}
+	
+	var added []string
+	err = s.storage.WithTeamLock(set.TeamName, func() error {
+		added, err = s.assignReviewTeam(s.rand, pr, nil, set, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	for _, reviewerID := range added {
+		message := i18n.Sprintf(s.UserLocale(reviewerID), "You are assigned to review %q (%s) for team %s",
+			pr.PullRequestName, pr.PullRequestID, set.TeamName)
+		if err := s.notify(reviewerID, NotificationAssignment, pr.PullRequestID, message); err != nil {
+			return nil, err
+		}
+	}
+	
+	return s.storage.GetPullRequest(prID)
+}
+
+// GetReviewTeams returns approval progress of every team reviewing the PR beside the owning team
+func (s *Service) GetReviewTeams(prID string) ([]models.ReviewTeamStatus, error) {
+	exists, err := s.storage.PRExists(prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	return s.reviewTeamStatuses(prID)
+}
+
+func (s *Service) reviewTeamStatuses(prID string) ([]models.ReviewTeamStatus, error) {
+	teams, err := s.storage.GetReviewTeams(prID)
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return []models.ReviewTeamStatus{}, nil
+	}
+	reviewers, err := s.storage.GetReviewerAssignments(prID)
+	if err != nil {
+		return nil, err
+	}
+	
+	statuses := make([]models.ReviewTeamStatus, 0, len(teams))
+	for _, team := range teams {
+		status := models.ReviewTeamStatus{
+			TeamName:          team.TeamName,
+			RequiredApprovals: team.RequiredApprovals,
+			Reviewers:         []string{},
+		}
+		for _, r := range reviewers {
+			if r.TeamName != team.TeamName {
+				continue
+			}
+			status.Reviewers = append(status.Reviewers, r.UserID)
+			if r.Status == ReviewerApproved {
+				status.Approvals++
+			}
+		}
+		status.Approved = status.Approvals >= status.RequiredApprovals
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// checkMergeApprovals blocks the merge until every review team has its required approvals
+func (s *Service) checkMergeApprovals(prID string) error {
+	statuses, err := s.reviewTeamStatuses(prID)
+	if err != nil {
+		return err
+	}
+	
+	var missing []string
+	for _, status := range statuses {
+		if !status.Approved {
+			missing = append(missing, fmt.Sprintf("%s %d/%d", status.TeamName, status.Approvals, status.RequiredApprovals))
+		}
+	}
+	if len(missing) > 0 {
+		return &ServiceError{
+			Code:    errcode.ApprovalsMissing,
+			Message: "review teams lack required approvals: " + strings.Join(missing, ", "),
+		}
+	}
+	return nil
+}
+
+// withoutReviewTeams drops path-routed teams that review the PR as a reviewer set instead
+func withoutReviewTeams(teams []string, sets []reviewTeamSet) []string {
+	return slices.DeleteFunc(teams, func(team string) bool {
+		return slices.ContainsFunc(sets, func(set reviewTeamSet) bool {
+			return set.TeamName == team
+		})
+	})
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+// Risk signal kinds
+const (
+	RiskSignalLabel = "LABEL"
+	RiskSignalPath  = "PATH"
+)
+
+// WarningSeniorsMissing - the risk rule asked for more senior reviewers than were free
+const WarningSeniorsMissing = "RISK_SENIORS_MISSING"
+
+const (
+	maxRiskScore   = 100
+	maxRiskRules   = 20
+	maxRiskSignals = 100
+
+	// PR whose paths are owned by several teams is riskier than its size tells
+	crossTeamRisk = 15
+)
+
+// sizeRisk - base risk score by PR size, unknown size counts as medium
+var sizeRisk = map[string]int{
+	SizeXS: 0,
+	SizeS:  10,
+	SizeM:  25,
+	SizeL:  40,
+	SizeXL: 60,
+}
+
+func (s *Service) GetTeamRiskRules(teamName string) (*models.TeamRiskRules, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	return s.storage.GetRiskRules(teamName)
+}
+
+// SetTeamRiskRules replaces team thresholds and signals, each threshold score may appear once
+func (s *Service) SetTeamRiskRules(teamRules *models.TeamRiskRules) (*models.TeamRiskRules, error) {
+	if err := s.ensureTeam(teamRules.TeamName); err != nil {
+		return nil, err
+	}
+	if len(teamRules.Rules) > maxRiskRules || len(teamRules.Signals) > maxRiskSignals {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("at most %d risk rules and %d signals allowed", maxRiskRules, maxRiskSignals),
+		}
+	}
+	
+	scores := make(map[int]bool, len(teamRules.Rules))
+	for _, rule := range teamRules.Rules {
+		switch {
+		case rule.MinScore < 0 || rule.MinScore > maxRiskScore:
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: fmt.Sprintf("min_score must be between 0 and %d", maxRiskScore),
+			}
+		case scores[rule.MinScore]:
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: fmt.Sprintf("duplicate risk rule for min_score %d", rule.MinScore),
+			}
+		case rule.Reviewers < 1 || rule.Reviewers > maxReviewerCount:
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: fmt.Sprintf("reviewers must be between 1 and %d", maxReviewerCount),
+			}
+		case rule.SeniorReviewers < 0 || rule.SeniorReviewers > rule.Reviewers:
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "senior_reviewers must be between 0 and reviewers",
+			}
+		}
+		scores[rule.MinScore] = true
+	}
+	
+	seen := make(map[string]bool, len(teamRules.Signals))
+	for i := range teamRules.Signals {
+		signal := &teamRules.Signals[i]
+		signal.Kind = strings.ToUpper(strings.TrimSpace(signal.Kind))
+		switch signal.Kind {
+		case RiskSignalLabel:
+			signal.Value = strings.ToLower(strings.TrimSpace(signal.Value))
+		case RiskSignalPath:
+			signal.Value = normalizePath(signal.Value)
+		default:
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "unknown risk signal kind " + signal.Kind,
+			}
+		}
+		if signal.Value == "" {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "risk signal value is required",
+			}
+		}
+		if signal.Weight < 1 || signal.Weight > maxRiskScore {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: fmt.Sprintf("weight must be between 1 and %d", maxRiskScore),
+			}
+		}
+		key := signal.Kind + ":" + signal.Value
+		if seen[key] {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "duplicate risk signal " + key,
+			}
+		}
+		seen[key] = true
+	}
+	
+	if err := s.storage.ReplaceRiskRules(teamRules); err != nil {
+		return nil, err
+	}
+	return s.storage.GetRiskRules(teamRules.TeamName)
+}
+
+// riskScore computes the score of a new PR: base score of its size, cross-team paths and the
+// weights of team signals it matches, capped at 100
+func riskScore(size string, paths, labels []string, teams int, signals []models.RiskSignal) int {
+	score, ok := sizeRisk[size]
+	if !ok {
+		score = sizeRisk[SizeM]
+	}
+	if teams > 1 {
+		score += crossTeamRisk
+	}
+	
+	prLabels := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		prLabels[strings.ToLower(strings.TrimSpace(label))] = true
+	}
+	for _, signal := range signals {
+		switch signal.Kind {
+		case RiskSignalLabel:
+			if prLabels[signal.Value] {
+				score += signal.Weight
+			}
+		case RiskSignalPath:
+			for _, path := range paths {
+				path = normalizePath(path)
+				if path == signal.Value || strings.HasPrefix(path, signal.Value+"/") {
+					score += signal.Weight
+					break
+				}
+			}
+		}
+	}
+	return min(score, maxRiskScore)
+}
+
+// riskRule resolves the PR risk score and the team rule it falls under, the given score wins
+// over the computed one. Both are nil for teams without risk rules and no given score.
+func (s *Service) riskRule(teamName, size string, paths, labels []string, teams int, given *int) (*int, *models.RiskRule, error) {
+	if given != nil && (*given < 0 || *given > maxRiskScore) {
+		return nil, nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("risk_score must be between 0 and %d", maxRiskScore),
+		}
+	}
+	
+	teamRules, err := s.storage.GetRiskRules(teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(teamRules.Rules) == 0 {
+		return given, nil, nil
+	}
+	
+	score := given
+	if score == nil {
+		computed := riskScore(size, paths, labels, teams, teamRules.Signals)
+		score = &computed
+	}
+	// rules come from the highest threshold
+	for i := range teamRules.Rules {
+		if *score >= teamRules.Rules[i].MinScore {
+			return score, &teamRules.Rules[i], nil
+		}
+	}
+	return score, nil, nil
+}
+
+// pickReviewers takes count candidates in order, non-juniors first up to seniors of them
+func pickReviewers(candidates []models.User, count, seniors int) []string {
+	selected := make([]string, 0, count)
+	taken := make(map[string]bool, count)
+	for _, candidate := range candidates {
+		if len(selected) >= min(seniors, count) {
+			break
+		}
+		if !candidate.IsJunior {
+			selected = append(selected, candidate.UserID)
+			taken[candidate.UserID] = true
+		}
+	}
+	for _, candidate := range candidates {
+		if len(selected) >= count {
+			break
+		}
+		if !taken[candidate.UserID] {
+			selected = append(selected, candidate.UserID)
+		}
+	}
+	return selected
+}
+
+// seniorsMissing counts how many more non-junior reviewers the risk rule wanted
+func (s *Service) seniorsMissing(reviewers []string, seniors int) (int, error) {
+	for _, reviewerID := range reviewers {
+		if seniors <= 0 {
+			break
+		}
+		user, err := s.storage.GetUser(reviewerID)
+		if err != nil {
+			return 0, err
+		}
+		if !user.IsJunior {
+			seniors--
+		}
+	}
+	return max(seniors, 0), nil
+}
+
+func seniorsWarning(missing int) models.Warning {
+	return models.Warning{
+		Code:    WarningSeniorsMissing,
+		Message: fmt.Sprintf("risk rule wanted %d more senior reviewers", missing),
+	}
+}
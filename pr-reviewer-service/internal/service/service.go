@@ -1,15 +1,25 @@
 package service
 
 import (
+	"log"
 	"math/rand"
-	"time"
+	"pr-reviewer-service/internal/alerting"
+	"pr-reviewer-service/internal/cache"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/eventbus"
+	"pr-reviewer-service/internal/i18n"
+	"pr-reviewer-service/internal/idgen"
 	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/notify"
 	"pr-reviewer-service/internal/storage"
+	"pr-reviewer-service/internal/strategy"
+	"strings"
+	"time"
 )
 
-// ServiceError - custom Error
+// ServiceError - custom Error, the code must be registered in errcode
 type ServiceError struct {
-	Code    string
+	Code    errcode.Code
 	Message string
 }
 
@@ -17,19 +27,139 @@ func (e *ServiceError) Error() string {
 	return e.Message
 }
 
+// errorDetail reports the error of one item of a bulk operation, other than service errors
+// are internal ones with the fallback message
+func errorDetail(err error, fallback string) *models.ErrorDetail {
+	if serviceErr, ok := err.(*ServiceError); ok {
+		return &models.ErrorDetail{Code: serviceErr.Code, Number: serviceErr.Code.Number(), Message: serviceErr.Message}
+	}
+	return &models.ErrorDetail{Code: errcode.Internal, Number: errcode.Internal.Number(), Message: fallback}
+}
+
+// User roles
+const (
+	RoleMember = "member"
+	RoleLead   = "lead"
+	RoleAdmin  = "admin"
+)
+
+func isValidRole(role string) bool {
+	return role == RoleMember || role == RoleLead || role == RoleAdmin
+}
+
+// Reviewer assignment types
+const (
+	AssignmentAuto      = "AUTO"
+	AssignmentManual    = "MANUAL"
+	AssignmentVolunteer = "VOLUNTEER"
+	// AssignmentEscalation - team lead brought in on a PR left without approvals
+	AssignmentEscalation = "ESCALATION"
+)
+
 type Service struct {
-	storage storage.Storage
-	rand    *rand.Rand // for selecting reviewers
+	storage  storage.Storage
+	rand     *rand.Rand // for selecting reviewers
+	calendar CalendarProvider
+	alerter  alerting.Sender
+	events   eventbus.Publisher
+	notifier *notify.Dispatcher
+	ranker   strategy.Ranker
+	rules    []CandidateRule
+	prIDs    idgen.Generator // IDs of PRs created without one
+
+	eventSourced bool // PR rows are rebuilt from the timeline after each state change
+	queueJobs    bool // side effects go through the persistent job queue
+	requestSeeds bool // requests may pin reviewer selection with a seed
+
+	notifySlots chan struct{} // background deliveries in flight without the job queue
+
+	linkBaseURL  string        // public URL used in notification links
+	archiveAfter time.Duration // merged PRs older than this are archived, zero keeps them
+
+	pseudonymKey    []byte // HMAC key of user pseudonyms in analytics, pseudonyms are off without it
+	anonymizeAlways bool   // analytics use pseudonyms for every caller
+
+	defaultLocale string // locale of users who haven't chosen one
+
+	version     string                              // build shown on the status page
+	startedAt   time.Time                           // uptime on the status page counts from here
+	statusCache *cache.Cache[*models.ServiceStatus] // checks shared by status requests within statusCacheTTL
+
+	statsCacheTTL time.Duration // how long aggregate statistics are reused
+	caches        statsCaches
 }
 
-func NewService(storage storage.Storage) *Service {
-	source := rand.NewSource(time.Now().UnixNano())
-	return &Service{
-		storage: storage,
-		rand:    rand.New(source),
+// Option configures optional Service dependencies
+type Option func(*Service)
+
+// WithCalendar enables out-of-office sync with external calendar
+func WithCalendar(calendar CalendarProvider) Option {
+	return func(s *Service) {
+		s.calendar = calendar
 	}
 }
 
+// WithAlerter sends SLA breach alerts to an external channel
+func WithAlerter(alerter alerting.Sender) Option {
+	return func(s *Service) {
+		s.alerter = alerter
+	}
+}
+
+// WithEventPublisher forwards PR timeline events to downstream consumers
+func WithEventPublisher(events eventbus.Publisher) Option {
+	return func(s *Service) {
+		s.events = events
+	}
+}
+
+// WithNotifier sends delivered notifications to external channels as well
+func WithNotifier(notifier *notify.Dispatcher) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
+}
+
+// WithEventSourcing makes PR timeline the source of truth for PR and reviewer rows
+func WithEventSourcing() Option {
+	return func(s *Service) {
+		s.eventSourced = true
+	}
+}
+
+// WithLinkBaseURL sets public service URL for links in notifications
+func WithLinkBaseURL(baseURL string) Option {
+	return func(s *Service) {
+		s.linkBaseURL = baseURL
+	}
+}
+
+// WithArchiveAfter makes the archival job move PRs merged more than days ago to the archive
+func WithArchiveAfter(days int) Option {
+	return func(s *Service) {
+		s.archiveAfter = time.Duration(days) * 24 * time.Hour
+	}
+}
+
+func NewService(storage storage.Storage, opts ...Option) *Service {
+	s := &Service{
+		storage:       storage,
+		rand:          newRand(rand.NewSource(time.Now().UnixNano())),
+		prIDs:         idgen.UUIDv7,
+		defaultLocale: i18n.Default,
+		version:       buildVersion(),
+		startedAt:     time.Now().UTC(),
+		statusCache:   cache.New[*models.ServiceStatus](statusCacheTTL, observeCache("status")),
+		notifySlots:   make(chan struct{}, maxNotifyDispatches),
+		statsCacheTTL: defaultStatsCacheTTL,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.caches = newStatsCaches(s.statsCacheTTL)
+	return s
+}
+
 // TEAMS
 
 func (s *Service) CreateTeam(req *models.TeamResponse) error {
@@ -39,35 +169,79 @@ func (s *Service) CreateTeam(req *models.TeamResponse) error {
 	}
 	if exists {
 		return &ServiceError{
-			Code:    "TEAM_EXISTS",
+			Code:    errcode.TeamExists,
 			Message: "team already exists",
 		}
 	}
 	
+	if err := normalizeMembers(req.Members); err != nil {
+		return err
+	}
+	
 	if err := s.storage.CreateTeam(req.TeamName); err != nil {
 		return err
 	}
 	
-	for _, member := range req.Members {
+	return createMembers(s.storage, req.TeamName, req.Members)
+}
+
+// normalizeMembers defaults member roles and rejects unknown ones
+func normalizeMembers(members []models.TeamMember) error {
+	for i := range members {
+		if members[i].Role == "" {
+			members[i].Role = RoleMember
+		}
+		if !isValidRole(members[i].Role) {
+			return &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "unknown role " + members[i].Role,
+			}
+		}
+	}
+	return nil
+}
+
+// createMembers creates members of the team, existing users move to it
+func createMembers(repos storage.Repos, teamName string, members []models.TeamMember) error {
+	for _, member := range members {
 		user := &models.User{
 			UserID:   member.UserID,
 			Username: member.Username,
-			TeamName: req.TeamName,
+			TeamName: teamName,
 			IsActive: member.IsActive,
+			Role:     member.Role,
+			Region:   member.Region,
 		}
-		if err := s.storage.CreateOrUpdateUser(user); err != nil {
+		if err := repos.CreateOrUpdateUser(user); err != nil {
 			return err
 		}
 	}
-	
 	return nil
 }
 
+// ListTeams returns a page of teams in creation order
+func (s *Service) ListTeams(cursor string, limit int) ([]models.TeamSummary, string, error) {
+	page, err := newPage(cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	teams, err := s.storage.ListTeams(page)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	teams, next := trimPage(teams, page, func(team models.TeamSummary) models.Cursor {
+		return models.Cursor{CreatedAt: team.CreatedAt, ID: team.TeamName}
+	})
+	return teams, next, nil
+}
+
 func (s *Service) GetTeam(teamName string) (*models.TeamResponse, error) {
 	team, err := s.storage.GetTeam(teamName)
 	if err != nil {
 		return nil, &ServiceError{
-			Code:    "NOT_FOUND",
+			Code:    errcode.NotFound,
 			Message: "team not found",
 		}
 	}
@@ -80,7 +254,7 @@ func (s *Service) SetUserActive(userID string, isActive bool) (*models.User, err
 	user, err := s.storage.GetUser(userID)
 	if err != nil {
 		return nil, &ServiceError{
-			Code:    "NOT_FOUND",
+			Code:    errcode.NotFound,
 			Message: "user not found",
 		}
 	}
@@ -93,125 +267,743 @@ func (s *Service) SetUserActive(userID string, isActive bool) (*models.User, err
 	return user, nil
 }
 
-func (s *Service) GetPRsByReviewer(userID string) ([]models.PullRequestShort, error) {
-	_, err := s.storage.GetUser(userID)
+func (s *Service) SetUserJunior(userID string, isJunior bool) (*models.User, error) {
+	user, err := s.storage.GetUser(userID)
 	if err != nil {
 		return nil, &ServiceError{
-			Code:    "NOT_FOUND",
+			Code:    errcode.NotFound,
 			Message: "user not found",
 		}
 	}
 	
-	prs, err := s.storage.GetPRsByReviewer(userID)
-	if err != nil {
+	if err := s.storage.SetUserJunior(userID, isJunior); err != nil {
 		return nil, err
 	}
 	
-	return prs, nil
+	user.IsJunior = isJunior
+	return user, nil
+}
+
+// PR listing sort fields
+const (
+	PRSortCreatedAt = "created_at"
+	PRSortPriority  = "priority"
+	PRSortDeadline  = "deadline"
+	PRSortName      = "name"
+)
+
+// prSortDefaultDesc - default order per field: newest, most urgent, soonest due, alphabetical
+var prSortDefaultDesc = map[string]bool{
+	PRSortCreatedAt: true,
+	PRSortPriority:  true,
+	PRSortDeadline:  false,
+	PRSortName:      false,
+}
+
+func parsePRSort(field, order string) (models.PRSort, error) {
+	if field == "" {
+		field = PRSortCreatedAt
+	}
+	desc, ok := prSortDefaultDesc[field]
+	if !ok {
+		return models.PRSort{}, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "sort must be one of created_at, priority, deadline, name",
+		}
+	}
+	
+	switch order {
+	case "":
+	case "asc":
+		desc = false
+	case "desc":
+		desc = true
+	default:
+		return models.PRSort{}, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "order must be asc or desc",
+		}
+	}
+	
+	return models.PRSort{Field: field, Desc: desc}, nil
+}
+
+// GetPRsByReviewer returns a page of PRs reviewed by user, newest first unless sort is given
+func (s *Service) GetPRsByReviewer(userID, sortField, order, cursor string, limit int) ([]models.PullRequestShort, string, error) {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, "", &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	sort, err := parsePRSort(sortField, order)
+	if err != nil {
+		return nil, "", err
+	}
+	sortKey := sort.Field + ":" + order
+	
+	page, err := newPage(cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if page.After != nil && page.After.Sort != sortKey {
+		return nil, "", &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "cursor belongs to a different sort order",
+		}
+	}
+	
+	settings, err := s.teamSettings(user.TeamName)
+	if err != nil {
+		return nil, "", err
+	}
+	calendar, err := s.teamHolidays(user.TeamName)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	prs, err := s.storage.GetPRsByReviewer(userID, sort, page)
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now().UTC()
+	for i := range prs {
+		deadline := reviewDeadline(prs[i].AssignedAt, settings, calendar, user.Region)
+		prs[i].Deadline = pausedDeadline(deadline, prs[i].SLAPausedAt, prs[i].SLAPausedSeconds, now)
+	}
+	if err := s.attachDependencies(prs); err != nil {
+		return nil, "", err
+	}
+	
+	prs, next := trimPage(prs, page, func(pr models.PullRequestShort) models.Cursor {
+		cursor := models.Cursor{ID: pr.PullRequestID, Sort: sortKey}
+		switch sort.Field {
+		case PRSortPriority:
+			cursor.Value = pr.Priority
+		case PRSortName:
+			cursor.Value = pr.PullRequestName
+		case PRSortDeadline:
+			cursor.CreatedAt = pr.AssignedAt
+		default:
+			cursor.CreatedAt = pr.CreatedAt
+		}
+		return cursor
+	})
+	return prs, next, nil
 }
 
 // PULL REQUESTS
 
-// CreatePullRequest creates PR and automatically assigns up to 2 reviewers
-func (s *Service) CreatePullRequest(prID, prName, authorID string) (*models.PullRequest, error) {
-	exists, err := s.storage.PRExists(prID)
+// CreatePullRequest creates PR and automatically assigns reviewers, 2 by default or as team
+// size rules say, one per team when changed paths route it to several teams
+func (s *Service) CreatePullRequest(req *models.CreatePullRequestRequest) (*models.PullRequest, error) {
+	timer := newStageTimer()
+	pr, err := s.createPullRequest(req, timer)
+	timer.observe(err)
+	return pr, err
+}
+
+func (s *Service) createPullRequest(req *models.CreatePullRequestRequest, timer *stageTimer) (*models.PullRequest, error) {
+	prID := req.PullRequestID
+	authorID, err := s.resolveAuthor(req.AuthorID)
 	if err != nil {
 		return nil, err
 	}
-	if exists {
+	
+	priority := req.Priority
+	if priority != "" && !isValidPriority(priority) {
 		return nil, &ServiceError{
-			Code:    "PR_EXISTS",
-			Message: "pull request already exists",
+			Code:    errcode.InvalidRequest,
+			Message: "unknown priority " + priority,
+		}
+	}
+	
+	rng, err := s.randFor(req.Seed)
+	if err != nil {
+		return nil, err
+	}
+	
+	if prID == "" {
+		if prID, err = s.newPRID(); err != nil {
+			return nil, err
+		}
+	} else {
+		exists, err := s.storage.PRExists(prID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, &ServiceError{
+				Code:    errcode.PRExists,
+				Message: "pull request already exists",
+			}
+		}
+	}
+	if req.ExternalID != "" || req.ExternalURL != "" {
+		existingID, err := s.storage.GetPRIDByExternalKey(req.ExternalID, req.ExternalURL)
+		if err != nil {
+			return nil, err
+		}
+		if existingID != "" {
+			return nil, &ServiceError{
+				Code:    errcode.PRExists,
+				Message: "pull request already exists as " + existingID,
+			}
 		}
 	}
 	
 	author, err := s.storage.GetUser(authorID)
 	if err != nil {
 		return nil, &ServiceError{
-			Code:    "NOT_FOUND",
+			Code:    errcode.NotFound,
 			Message: "author not found",
 		}
 	}
 	
+	// PRs of a registered repository belong to its team, the author may come from elsewhere
+	teamName := author.TeamName
+	if req.RepositoryID != "" {
+		repo, err := s.ensureRepository(req.RepositoryID)
+		if err != nil {
+			return nil, err
+		}
+		teamName = repo.TeamName
+	}
+	
+	teams := []string{teamName}
+	if len(req.ChangedPaths) > 0 {
+		if req.RepositoryID == "" {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "changed_paths require repository_id",
+			}
+		}
+		if teams, err = s.routeTeams(req.RepositoryID, teamName, req.ChangedPaths); err != nil {
+			return nil, err
+		}
+	}
+	
+	reviewTeams, err := s.parseReviewTeams(prID, teamName, req.ReviewTeams)
+	if err != nil {
+		return nil, err
+	}
+	teams = withoutReviewTeams(teams, reviewTeams)
+	locked := append([]string(nil), teams...)
+	for _, set := range reviewTeams {
+		locked = append(locked, set.TeamName)
+	}
+	
+	if req.ReviewerPool != "" {
+		if err := s.ensurePool(teamName, req.ReviewerPool); err != nil {
+			return nil, err
+		}
+	}
+	
+	// explicit priority and pool win over the template, a template pool deleted since falls back to the team
+	poolName := req.ReviewerPool
+	template, err := s.matchPRTemplate(teamName, req.PullRequestName, req.Labels)
+	if err != nil {
+		return nil, err
+	}
+	if template != nil {
+		if priority == "" {
+			priority = template.Priority
+		}
+		if poolName == "" {
+			poolName = template.ReviewerPool
+		}
+	}
+	if priority == "" {
+		priority = PriorityNormal
+	}
+	
+	size, count, err := s.reviewerCount(teamName, req.Size, req.LinesChanged)
+	if err != nil {
+		return nil, err
+	}
+	
+	// a matching risk rule overrides the size count and may require seniors
+	score, risk, err := s.riskRule(teamName, size, req.ChangedPaths, req.Labels, len(teams), req.RiskScore)
+	if err != nil {
+		return nil, err
+	}
+	seniors := 0
+	if risk != nil {
+		count = risk.Reviewers
+		seniors = risk.SeniorReviewers
+	}
+	
+	if req.StackedOn != "" {
+		parentExists, err := s.storage.PRExists(req.StackedOn)
+		if err != nil {
+			return nil, err
+		}
+		if !parentExists {
+			return nil, &ServiceError{
+				Code:    errcode.NotFound,
+				Message: "stacked_on pull request not found",
+			}
+		}
+	}
+	// the link is checked before the PR is stored so a rejected one leaves no PR behind
+	var revert *models.PRRevert
+	revertLinked := false
+	if req.RevertOf != "" {
+		if revert, revertLinked, err = s.checkRevert(prID, req.RevertOf, authorID); err != nil {
+			return nil, err
+		}
+	}
+	
+	// checked before the PR is stored so it doesn't match itself
+	duplicates, err := s.findDuplicates(authorID, req.RepositoryID, req.PullRequestName, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	timer.since(stageChecks, timer.started)
+	
 	pr := &models.PullRequest{
 		PullRequestID:   prID,
-		PullRequestName: prName,
+		PullRequestName: req.PullRequestName,
 		AuthorID:        authorID,
-		Status:          "OPEN",
+		TeamName:        teamName,
+		RepositoryID:    req.RepositoryID,
+		Status:          PRStatusOpen,
+		Priority:        priority,
+		Size:            size,
+		ReviewerPool:    poolName,
 		CreatedAt:       time.Now(),
+		ExternalID:      req.ExternalID,
+		ExternalURL:     req.ExternalURL,
 	}
+	if template != nil {
+		pr.Template = template.Name
+	}
+	pr.RiskScore = score
 	
+	start := time.Now()
 	if err := s.storage.CreatePullRequest(pr); err != nil {
 		return nil, err
 	}
+	timer.since(stageInsert, start)
 	
-	reviewers, err := s.assignReviewers(author.TeamName, authorID, 2)
+	created := map[string]interface{}{
+		"pull_request_name": pr.PullRequestName,
+		"priority":          pr.Priority,
+		"size":              pr.Size,
+		"team_name":         pr.TeamName,
+		"repository_id":     pr.RepositoryID,
+		"reviewer_pool":     pr.ReviewerPool,
+	}
+	if pr.Template != "" {
+		created["template"] = pr.Template
+	}
+	if pr.RiskScore != nil {
+		created["risk_score"] = *pr.RiskScore
+	}
+	if pr.ExternalID != "" {
+		created["external_id"] = pr.ExternalID
+	}
+	if pr.ExternalURL != "" {
+		created["external_url"] = pr.ExternalURL
+	}
+	start = time.Now()
+	if err := s.recordEvent(prID, EventPRCreated, authorID, created); err != nil {
+		return nil, err
+	}
+	timer.since(stageNotify, start)
+	if req.StackedOn != "" {
+		if err := s.stackPR(prID, req.StackedOn); err != nil {
+			return nil, err
+		}
+	}
+	if revert != nil && !revertLinked {
+		if _, err := s.saveRevert(revert); err != nil {
+			return nil, err
+		}
+	}
+	
+	// a PR touching several teams' paths gets one reviewer from each of them
+	perTeam := count
+	if len(teams) > 1 {
+		perTeam = 1
+	}
+	
+	settings, err := s.teamSettings(teamName)
 	if err != nil {
 		return nil, err
 	}
 	
-	for _, reviewerID := range reviewers {
-		if err := s.storage.AddReviewer(prID, reviewerID); err != nil {
-			return nil, err
+	// in two-phase teams the rest of reviewers wait for the first-pass approval
+	secondPhase := 0
+	if len(teams) == 1 && perTeam > 1 {
+		if settings.TwoPhaseReview {
+			secondPhase = perTeam - 1
+			perTeam = 1
 		}
 	}
 	
+	// loads are read and updated under team lock so concurrent PRs can't overfill a reviewer
+	trace := newSkipTrace(req.Debug)
+	reviewers := []string{}
+	start = time.Now()
+	err = s.withTeamLocks(locked, func() error {
+		timer.since(stageLockWait, start)
+		for _, reviewTeam := range teams {
+			poolName := ""
+			teamSeniors := 0
+			if reviewTeam == teamName {
+				poolName = pr.ReviewerPool
+				teamSeniors = min(seniors, perTeam)
+			}
+			start := time.Now()
+			selected, reason, err := s.selectReviewers(rng, strategy.Request{
+				TeamName:        reviewTeam,
+				PullRequestID:   prID,
+				PullRequestName: pr.PullRequestName,
+				AuthorID:        authorID,
+				Priority:        pr.Priority,
+				Size:            pr.Size,
+				RepositoryID:    pr.RepositoryID,
+				Labels:          req.Labels,
+				ReviewerPool:    poolName,
+				Count:           perTeam,
+				Seniors:         teamSeniors,
+			}, trace)
+			if err != nil {
+				return err
+			}
+			timer.since(stageCandidates, start)
+			if teamSeniors > 0 {
+				missing, err := s.seniorsMissing(selected, teamSeniors)
+				if err != nil {
+					return err
+				}
+				if missing > 0 {
+					pr.Warnings = append(pr.Warnings, seniorsWarning(missing))
+				}
+			}
+	
+			for _, reviewerID := range selected {
+				start := time.Now()
+				if err := s.addReviewer(prID, reviewerID, reviewTeam, AssignmentAuto); err != nil {
+					return err
+				}
+				timer.since(stageInsert, start)
+	
+				assigned := map[string]interface{}{
+					"user_id":         reviewerID,
+					"assignment_type": AssignmentAuto,
+				}
+				if reviewTeam != teamName {
+					assigned["team_name"] = reviewTeam
+				}
+				start = time.Now()
+				if err := s.recordEvent(prID, EventReviewerAssigned, "", assigned); err != nil {
+					return err
+				}
+				timer.since(stageNotify, start)
+			}
+			reviewers = append(reviewers, selected...)
+	
+			// reviewers nobody could take now are assigned by the scheduler once capacity frees up,
+			// without an owning-team reviewer there is no first pass, so the whole count waits
+			missing := perTeam - len(selected)
+			if reviewTeam == teamName && len(selected) == 0 {
+				missing += secondPhase
+			}
+			if missing > 0 {
+				pr.AssignmentIncomplete = true
+				pr.Warnings = append(pr.Warnings, incompleteWarning(reviewTeam, reason, len(selected), len(selected)+missing))
+				start := time.Now()
+				if err := s.queueAssignment(prID, reviewTeam, missing); err != nil {
+					return err
+				}
+				timer.since(stageInsert, start)
+			}
+		}
+	
+		// review teams come after routing so their sets don't take reviewers the paths need
+		for _, set := range reviewTeams {
+			selected, err := s.assignReviewTeam(rng, pr, req.Labels, set, trace)
+			if err != nil {
+				return err
+			}
+			reviewers = append(reviewers, selected...)
+		}
+	
+		added, warnings, err := s.applyAssignmentRules(rng, pr, req.Labels, reviewers, trace)
+		if err != nil {
+			return err
+		}
+		reviewers = append(reviewers, added...)
+		pr.Warnings = append(pr.Warnings, warnings...)
+	
+		shadows, err := s.assignShadows(rng, pr, reviewers, settings.ShadowReviewers)
+		if err != nil {
+			return err
+		}
+		pr.ShadowReviewers = shadows
+	
+		if secondPhase > 0 && len(reviewers) > 0 {
+			pr.ReviewPhase = 1
+			return s.storage.CreateReviewPhase(prID, secondPhase)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	
 	pr.AssignedReviewers = reviewers
+	pr.AssignmentDebug = trace.result()
+	if len(duplicates) > 0 {
+		pr.Warnings = append(pr.Warnings, duplicateWarning(duplicates))
+	}
 	return pr, nil
 }
 
-// assignReviewers selects random active team members
-func (s *Service) assignReviewers(teamName, excludeUserID string, maxCount int) ([]string, error) {
-	candidates, err := s.storage.GetActiveTeamMembers(teamName, excludeUserID)
+// assignReviewers selects active team members below their review cap except the author,
+// random unless team policy or a ranking strategy orders them, reviewers of the stack base go first.
+// Non-juniors are picked ahead of the order when the request needs seniors.
+func (s *Service) assignReviewers(rng *rand.Rand, req strategy.Request, trace *skipTrace) ([]string, error) {
+	selected, _, err := s.selectReviewers(rng, req, trace)
+	return selected, err
+}
+
+// selectReviewers picks reviewers like assignReviewers, when fewer than requested are
+// picked it also names the filter that left too few candidates
+func (s *Service) selectReviewers(rng *rand.Rand, req strategy.Request, trace *skipTrace) ([]string, string, error) {
+	candidates, err := s.storage.GetActiveTeamMembers(req.TeamName, req.AuthorID)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	if err := s.traceUnavailable(trace, req.TeamName, req.AuthorID, candidates); err != nil {
+		return nil, "", err
+	}
+	short := &shortfall{need: req.Count}
+	short.check(IncompleteNoMembers, candidates)
+	
+	if req.PullRequestID != "" && req.Preferred == nil {
+		if req.Preferred, err = s.preferredReviewers(req.PullRequestID); err != nil {
+			return nil, "", err
+		}
+	}
+	
+	if len(req.Assigned) > 0 {
+		before := trace.ids(candidates)
+		assigned := make(map[string]bool, len(req.Assigned))
+		for _, userID := range req.Assigned {
+			assigned[userID] = true
+		}
+		available := candidates[:0]
+		for _, candidate := range candidates {
+			if !assigned[candidate.UserID] {
+				available = append(available, candidate)
+			}
+		}
+		candidates = available
+		trace.dropped(req.TeamName, SkipAlreadyAssigned, before, candidates)
+		short.check(SkipAlreadyAssigned, candidates)
+	}
+	
+	before := trace.ids(candidates)
+	candidates, err = s.filterByPool(req.TeamName, req.ReviewerPool, candidates)
+	if err != nil {
+		return nil, "", err
+	}
+	trace.dropped(req.TeamName, SkipNotInPool, before, candidates)
+	short.check(SkipNotInPool, candidates)
+	
+	before = trace.ids(candidates)
+	candidates, err = s.filterByCapacity(req.TeamName, candidates)
+	if err != nil {
+		return nil, "", err
+	}
+	trace.dropped(req.TeamName, SkipAtCap, before, candidates)
+	short.check(SkipAtCap, candidates)
+	
+	before = trace.ids(candidates)
+	candidates, err = s.filterByCooldown(req.TeamName, candidates)
+	if err != nil {
+		return nil, "", err
+	}
+	trace.dropped(req.TeamName, SkipCooldown, before, candidates)
+	short.check(SkipCooldown, candidates)
+	
+	before = trace.ids(candidates)
+	candidates, err = s.filterByAbsence(req.TeamName, candidates)
+	if err != nil {
+		return nil, "", err
+	}
+	trace.dropped(req.TeamName, SkipAbsenceSoon, before, candidates)
+	short.check(SkipAbsenceSoon, candidates)
+	
+	allowed, err := s.filterByRules(req.AuthorID, candidates)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.traceExcluded(trace, req.TeamName, req.AuthorID, candidates, allowed); err != nil {
+		return nil, "", err
 	}
+	candidates = allowed
+	short.check(SkipExcluded, candidates)
 	
-	count := maxCount
+	count := req.Count
 	if len(candidates) < count {
 		count = len(candidates)
 	}
 	
 	selected := make([]string, 0, count)
 	
-	s.rand.Shuffle(len(candidates), func(i, j int) {
+	rng.Shuffle(len(candidates), func(i, j int) {
 		candidates[i], candidates[j] = candidates[j], candidates[i]
 	})
-	
-	for i := 0; i < count; i++ {
-		selected = append(selected, candidates[i].UserID)
+	ranked := false
+	if len(candidates) > count {
+		if candidates, ranked, err = s.rankByPolicy(req, candidates); err != nil {
+			return nil, "", err
+		}
 	}
+	if s.ranker != nil && !ranked && len(candidates) > count {
+		candidates = s.rankCandidates(req, candidates)
+	}
+	if len(req.Preferred) > 0 {
+		preferred, rest := splitPreferred(candidates, req.Preferred)
+		candidates = append(preferred, rest...)
+	}
+	
+	selected = append(selected, pickReviewers(candidates, count, req.Seniors)...)
+	trace.notSelected(req.TeamName, candidates, selected)
 	
-	return selected, nil
+	return selected, short.reason, nil
 }
 
-func (s *Service) MergePullRequest(prID string) (*models.PullRequest, error) {
-	if err := s.storage.MergePullRequest(prID); err != nil {
+// addReviewer assigns reviewer on behalf of the team with a fresh copy of its checklist
+func (s *Service) addReviewer(prID, userID, teamName, assignmentType string) error {
+	if err := s.storage.AddTeamReviewer(prID, userID, teamName, assignmentType); err != nil {
+		return err
+	}
+	return s.createChecklist(prID, userID, teamName)
+}
+
+func (s *Service) createChecklist(prID, userID, teamName string) error {
+	items, err := s.storage.GetChecklistTemplate(teamName)
+	if err != nil {
+		return err
+	}
+	return s.storage.CreateReviewChecklist(prID, userID, items)
+}
+
+// MergePullRequest is idempotent, metadata of repeated calls is ignored
+func (s *Service) MergePullRequest(prID string, merge models.MergeInfo) (*models.PullRequest, error) {
+	merge.MergedBy = strings.TrimSpace(merge.MergedBy)
+	merge.MergeCommit = strings.TrimSpace(merge.MergeCommit)
+	merge.MergeURL = strings.TrimSpace(merge.MergeURL)
+	
+	wasOpen := false
+	current, err := s.storage.GetPullRequest(prID)
+	if err == nil && current.Status != PRStatusMerged {
+		if err := checkTransition(current, PRStatusMerged); err != nil {
+			return nil, err
+		}
+		wasOpen = true
+	}
+	
+	var openDependencies []string
+	if wasOpen {
+		if err := s.checkMergeChecklists(prID); err != nil {
+			return nil, err
+		}
+		if err := s.checkMergeApprovals(prID); err != nil {
+			return nil, err
+		}
+		if openDependencies, err = s.checkMergeDependencies(current); err != nil {
+			return nil, err
+		}
+	}
+	
+	// the merge, the queue cleanup and its history are stored together, repeated merge calls
+	// don't add timeline entries
+	var event *models.PREvent
+	err = s.storage.InTx(func(repos storage.Repos) error {
+		if err := repos.MergePullRequest(prID, merge); err != nil {
+			return err
+		}
+		if !wasOpen {
+			return nil
+		}
+	
+		if err := repos.DeletePendingAssignments(prID); err != nil {
+			return err
+		}
+		details := map[string]interface{}{
+			"merged_by":    merge.MergedBy,
+			"merge_commit": merge.MergeCommit,
+			"merge_url":    merge.MergeURL,
+		}
+		if len(openDependencies) > 0 {
+			details["open_dependencies"] = openDependencies
+		}
+		event = &models.PREvent{
+			PullRequestID: prID,
+			EventType:     EventPRMerged,
+			ActorID:       merge.MergedBy,
+			Payload:       details,
+		}
+		if err := repos.AddPREvent(event); err != nil {
+			return err
+		}
+		return repos.AddAuditEntry(&models.AuditEntry{
+			ActorID:       merge.MergedBy,
+			Action:        AuditMerge,
+			PullRequestID: prID,
+			Details:       details,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 	
+	// the merge is committed, so delivery failures don't fail it
+	if event != nil {
+		if err := s.eventStored(event); err != nil {
+			log.Printf("Merge event of %s is stored, delivery failed: %v", prID, err)
+		}
+	}
+	
 	pr, err := s.storage.GetPullRequest(prID)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.withDependencies(pr); err != nil {
+		return nil, err
+	}
+	if wasOpen {
+		pr.OpenDependencies = openDependencies
+	}
 	
 	return pr, nil
 }
 
-func (s *Service) ReassignReviewer(prID, oldReviewerID string) (*models.PullRequest, string, error) {
+// ReassignReviewer replaces the reviewer, with debug the PR lists why other members weren't picked
+func (s *Service) ReassignReviewer(prID, oldReviewerID string, debug bool) (*models.PullRequest, string, error) {
+	return s.reassignReviewer(prID, oldReviewerID, nil, newSkipTrace(debug))
+}
+
+// reassignReviewer replaces the reviewer, extra fields are added to the reassignment event
+func (s *Service) reassignReviewer(prID, oldReviewerID string, extra map[string]interface{}, trace *skipTrace) (*models.PullRequest, string, error) {
 	pr, err := s.storage.GetPullRequest(prID)
 	if err != nil {
 		return nil, "", &ServiceError{
-			Code:    "NOT_FOUND",
+			Code:    errcode.NotFound,
 			Message: "pull request not found",
 		}
 	}
 	
-	if pr.Status == "MERGED" {
-		return nil, "", &ServiceError{
-			Code:    "PR_MERGED",
-			Message: "cannot reassign on merged PR",
-		}
+	if err := ensurePROpen(pr, "cannot reassign on merged PR"); err != nil {
+		return nil, "", err
 	}
 	
 	isAssigned, err := s.storage.IsReviewerAssigned(prID, oldReviewerID)
@@ -220,7 +1012,7 @@ func (s *Service) ReassignReviewer(prID, oldReviewerID string) (*models.PullRequ
 	}
 	if !isAssigned {
 		return nil, "", &ServiceError{
-			Code:    "NOT_ASSIGNED",
+			Code:    errcode.NotAssigned,
 			Message: "user is not assigned as reviewer to this PR",
 		}
 	}
@@ -228,52 +1020,202 @@ func (s *Service) ReassignReviewer(prID, oldReviewerID string) (*models.PullRequ
 	oldReviewer, err := s.storage.GetUser(oldReviewerID)
 	if err != nil {
 		return nil, "", &ServiceError{
-			Code:    "NOT_FOUND",
+			Code:    errcode.NotFound,
 			Message: "reviewer not found",
 		}
 	}
 	
-	candidates, err := s.storage.GetActiveTeamMembers(oldReviewer.TeamName, oldReviewerID)
+	// replacement comes from the team the reviewer represents, path routing and review teams bring
+	// other teams, assignments made before teams were tracked fall back to the reviewer's own team
+	assignment, err := s.storage.GetReviewerAssignment(prID, oldReviewerID)
+	if err != nil {
+		return nil, "", err
+	}
+	teamName := assignment.TeamName
+	if teamName == "" {
+		teamName = oldReviewer.TeamName
+	}
+	settings, err := s.teamSettings(teamName)
+	if err != nil {
+		return nil, "", err
+	}
+	fallback := settings.NoCandidateFallback
+	
+	var newReviewerID string
+	applied := ""
+	err = s.storage.WithTeamLock(teamName, func() error {
+		replacementID, err := s.replaceReviewer(pr, oldReviewerID, teamName, extra, trace)
+		if !isNoCandidate(err) || fallback == NoCandidateFail || fallback == NoCandidateParentTeam {
+			newReviewerID = replacementID
+			return err
+		}
+		applied = fallback
+		newReviewerID, err = s.applyNoCandidateFallback(pr, oldReviewerID, teamName, fallback, extra)
+		return err
+	})
+	// parent team is locked on its own, holding both locks could deadlock with a reassignment going the other way
+	if isNoCandidate(err) && fallback == NoCandidateParentTeam {
+		applied = fallback
+		err = s.storage.WithTeamLock(settings.ParentTeam, func() error {
+			payload := map[string]interface{}{"fallback": fallback, "team_name": settings.ParentTeam}
+			for key, value := range extra {
+				payload[key] = value
+			}
+			replacementID, err := s.replaceReviewer(pr, oldReviewerID, settings.ParentTeam, payload, trace)
+			newReviewerID = replacementID
+			return err
+		})
+	}
 	if err != nil {
 		return nil, "", err
 	}
 	
+	pr, err = s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, "", err
+	}
+	pr.AssignmentDebug = trace.result()
+	pr.AssignmentFallback = applied
+	
+	return pr, newReviewerID, nil
+}
+
+// replaceReviewer swaps the reviewer for a random replacement from the team, caller holds the team lock
+func (s *Service) replaceReviewer(pr *models.PullRequest, oldReviewerID, teamName string, extra map[string]interface{}, trace *skipTrace) (string, error) {
+	availableCandidates, err := s.replacementCandidates(pr, oldReviewerID, teamName, trace)
+	if err != nil {
+		return "", err
+	}
+	
+	// Select random candidate
+	newReviewerID := availableCandidates[s.rand.Intn(len(availableCandidates))].UserID
+	trace.notSelected(teamName, availableCandidates, []string{newReviewerID})
+	
+	return newReviewerID, s.swapReviewer(pr.PullRequestID, oldReviewerID, newReviewerID, teamName, AssignmentAuto, extra)
+}
+
+// swapReviewer moves the assignment to newReviewerID, empty newReviewerID only drops the old reviewer.
+// The replacement represents the same team as the old reviewer, teamName only picks the checklist.
+func (s *Service) swapReviewer(prID, oldReviewerID, newReviewerID, teamName, assignmentType string, extra map[string]interface{}) error {
+	err := s.storage.InTx(func(repos storage.Repos) error {
+		old, err := repos.GetReviewerAssignment(prID, oldReviewerID)
+		if err != nil {
+			return err
+		}
+		if err := repos.RemoveReviewer(prID, oldReviewerID); err != nil {
+			return err
+		}
+		if newReviewerID == "" {
+			return nil
+		}
+		return repos.AddTeamReviewer(prID, newReviewerID, old.TeamName, assignmentType)
+	})
+	if err != nil {
+		return err
+	}
+	if newReviewerID != "" {
+		if err := s.createChecklist(prID, newReviewerID, teamName); err != nil {
+			return err
+		}
+	}
+	
+	payload := map[string]interface{}{
+		"old_user_id":     oldReviewerID,
+		"new_user_id":     newReviewerID,
+		"assignment_type": assignmentType,
+	}
+	for key, value := range extra {
+		payload[key] = value
+	}
+	return s.recordEvent(prID, EventReviewerReassigned, "", payload)
+}
+
+// replacementCandidates returns who can take over oldReviewerID's review of the PR
+func (s *Service) replacementCandidates(pr *models.PullRequest, oldReviewerID, teamName string, trace *skipTrace) ([]models.User, error) {
+	prID := pr.PullRequestID
+	poolName := ""
+	if teamName == pr.TeamName {
+		poolName = pr.ReviewerPool
+	}
+	
+	candidates, err := s.storage.GetActiveTeamMembers(teamName, oldReviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.traceUnavailable(trace, teamName, pr.AuthorID, candidates, oldReviewerID); err != nil {
+		return nil, err
+	}
+	
 	// Exclude current reviewers and author from candidates
 	var availableCandidates []models.User
 	for _, candidate := range candidates {
 		if candidate.UserID == pr.AuthorID {
+			trace.skip(teamName, candidate.UserID, SkipAuthor, "")
 			continue
 		}
 		isAlreadyAssigned, err := s.storage.IsReviewerAssigned(prID, candidate.UserID)
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
-		if !isAlreadyAssigned {
-			availableCandidates = append(availableCandidates, candidate)
+		if isAlreadyAssigned {
+			trace.skip(teamName, candidate.UserID, SkipAlreadyAssigned, "")
+			continue
 		}
+		availableCandidates = append(availableCandidates, candidate)
 	}
 	
-	if len(availableCandidates) == 0 {
-		return nil, "", &ServiceError{
-			Code:    "NO_CANDIDATE",
-			Message: "no active replacement candidate available in team",
-		}
+	before := trace.ids(availableCandidates)
+	availableCandidates, err = s.filterByPool(teamName, poolName, availableCandidates)
+	if err != nil {
+		return nil, err
 	}
+	trace.dropped(teamName, SkipNotInPool, before, availableCandidates)
 	
-	// Select random candidate
-	newReviewerID := availableCandidates[s.rand.Intn(len(availableCandidates))].UserID
+	before = trace.ids(availableCandidates)
+	availableCandidates, err = s.filterByCapacity(teamName, availableCandidates)
+	if err != nil {
+		return nil, err
+	}
+	trace.dropped(teamName, SkipAtCap, before, availableCandidates)
 	
-	if err := s.storage.RemoveReviewer(prID, oldReviewerID); err != nil {
-		return nil, "", err
+	before = trace.ids(availableCandidates)
+	availableCandidates, err = s.filterByCooldown(teamName, availableCandidates)
+	if err != nil {
+		return nil, err
 	}
-	if err := s.storage.AddReviewer(prID, newReviewerID); err != nil {
-		return nil, "", err
+	trace.dropped(teamName, SkipCooldown, before, availableCandidates)
+	
+	before = trace.ids(availableCandidates)
+	availableCandidates, err = s.filterByAbsence(teamName, availableCandidates)
+	if err != nil {
+		return nil, err
 	}
+	trace.dropped(teamName, SkipAbsenceSoon, before, availableCandidates)
 	
-	pr, err = s.storage.GetPullRequest(prID)
+	allowed, err := s.filterByRules(pr.AuthorID, availableCandidates)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
+	if err := s.traceExcluded(trace, teamName, pr.AuthorID, availableCandidates, allowed); err != nil {
+		return nil, err
+	}
+	availableCandidates = allowed
 	
-	return pr, newReviewerID, nil
+	if len(availableCandidates) == 0 {
+		return nil, &ServiceError{
+			Code:    errcode.NoCandidate,
+			Message: "no active replacement candidate available in team",
+		}
+	}
+	
+	// stacked PR keeps reviewers of its base, a revert those of the reverted PR, when one of them is free
+	preferredIDs, err := s.preferredReviewers(prID)
+	if err != nil {
+		return nil, err
+	}
+	if preferred, rest := splitPreferred(availableCandidates, preferredIDs); len(preferred) > 0 {
+		trace.notSelected(teamName, rest, nil)
+		availableCandidates = preferred
+	}
+	return availableCandidates, nil
 }
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// maxReviewSession caps a single session so a forgotten finish doesn't inflate stats
+const maxReviewSession = 8 * time.Hour
+
+// StartReview opens a time tracking session of the reviewer, repeated calls return the running one
+func (s *Service) StartReview(prID, userID string) (*models.ReviewSession, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	if err := ensurePROpen(pr, "cannot review merged PR"); err != nil {
+		return nil, err
+	}
+	
+	if !isReviewing(pr, userID) {
+		return nil, &ServiceError{
+			Code:    errcode.NotAssigned,
+			Message: "user is not assigned as reviewer to this PR",
+		}
+	}
+	
+	return s.storage.StartReviewSession(prID, userID, time.Now().UTC())
+}
+
+// FinishReview closes the running session, allowed after merge so time isn't lost
+func (s *Service) FinishReview(prID, userID string) (*models.ReviewSession, error) {
+	session, err := s.storage.GetOpenReviewSession(prID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, &ServiceError{
+			Code:    errcode.NoReviewSession,
+			Message: "no review in progress for this user and PR",
+		}
+	}
+	
+	finishedAt := time.Now().UTC()
+	if finishedAt.Sub(session.StartedAt) > maxReviewSession {
+		finishedAt = session.StartedAt.Add(maxReviewSession)
+	}
+	
+	finished, err := s.storage.FinishReviewSession(session.SessionID, finishedAt)
+	if err != nil {
+		return nil, err
+	}
+	if !finished {
+		return nil, &ServiceError{
+			Code:    errcode.NoReviewSession,
+			Message: "review session is already finished",
+		}
+	}
+	
+	session.FinishedAt = &finishedAt
+	return session, nil
+}
+
+// isReviewing - reviewers and shadows both spend time on PR
+func isReviewing(pr *models.PullRequest, userID string) bool {
+	for _, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == userID {
+			return true
+		}
+	}
+	for _, shadowID := range pr.ShadowReviewers {
+		if shadowID == userID {
+			return true
+		}
+	}
+	return false
+}
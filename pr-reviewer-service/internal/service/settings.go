@@ -0,0 +1,222 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+const defaultReviewSLAHours = 24
+
+// teamSettings returns stored settings or defaults, no cap by default
+func (s *Service) teamSettings(teamName string) (*models.TeamSettings, error) {
+	settings, err := s.storage.GetTeamSettings(teamName)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = &models.TeamSettings{
+			TeamName:            teamName,
+			ReviewSLAHours:      defaultReviewSLAHours,
+			DependencyPolicy:    DependencyPolicyNone,
+			TransferReviews:     TransferReviewsKeep,
+			NoCandidateFallback: NoCandidateFail,
+		}
+	}
+	if settings.ReviewerCount == 0 {
+		settings.ReviewerCount = defaultReviewerCount
+	}
+	return settings, nil
+}
+
+// reviewDeadline - when the assigned review is due, reviewer's holidays don't count
+func reviewDeadline(assignedAt time.Time, settings *models.TeamSettings, calendar holidays, region string) time.Time {
+	deadline := assignedAt.Add(time.Duration(settings.ReviewSLAHours) * time.Hour)
+	if len(calendar) == 0 {
+		return deadline
+	}
+	
+	// a holiday inside the window pushes the deadline by the part of the day it covers
+	for day := assignedAt.UTC().Truncate(24 * time.Hour); day.Before(deadline); day = day.Add(24 * time.Hour) {
+		if !calendar.observes(day, region) {
+			continue
+		}
+		from := day
+		if from.Before(assignedAt) {
+			from = assignedAt
+		}
+		deadline = deadline.Add(day.Add(24 * time.Hour).Sub(from))
+	}
+	return deadline
+}
+
+func (s *Service) GetTeamSettings(teamName string) (*models.TeamSettings, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	return s.teamSettings(teamName)
+}
+
+// UpdateTeamSettings applies the fields present in the JSON patch to the current settings,
+// omitted fields keep their values and null clears the optional ones
+func (s *Service) UpdateTeamSettings(teamName string, patch json.RawMessage) (*models.TeamSettings, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	var settings *models.TeamSettings
+	err := s.storage.WithTeamLock(teamName, func() error {
+		current, err := s.teamSettings(teamName)
+		if err != nil {
+			return err
+		}
+		if err := applySettingsPatch(current, patch); err != nil {
+			return &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "invalid settings: " + err.Error(),
+			}
+		}
+		current.TeamName = teamName
+		if err := s.validateTeamSettings(current); err != nil {
+			return err
+		}
+	
+		if err := s.storage.SaveTeamSettings(current); err != nil {
+			return err
+		}
+		settings = current
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// applySettingsPatch overwrites the settings present in the JSON object patch. Unmarshal merges
+// into an existing map, so a map present in the patch is dropped first to be replaced whole.
+func applySettingsPatch(settings *models.TeamSettings, patch json.RawMessage) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		return err
+	}
+	if _, ok := fields["notification_routes"]; ok {
+		settings.NotificationRoutes = nil
+	}
+	return json.Unmarshal(patch, settings)
+}
+
+// validateTeamSettings fills defaults of omitted settings and validates the rest
+func (s *Service) validateTeamSettings(settings *models.TeamSettings) error {
+	if settings.ReviewSLAHours == 0 {
+		settings.ReviewSLAHours = defaultReviewSLAHours
+	}
+	if settings.ReviewSLAHours < 0 {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "review_sla_hours must be positive",
+		}
+	}
+	if settings.ReviewerCount == 0 {
+		settings.ReviewerCount = defaultReviewerCount
+	}
+	if settings.ReviewerCount < 0 || settings.ReviewerCount > maxReviewerCount {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("reviewer_count must be between 1 and %d", maxReviewerCount),
+		}
+	}
+	if settings.MaxOpenReviews != nil && *settings.MaxOpenReviews < 0 {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "max_open_reviews must not be negative",
+		}
+	}
+	if settings.MaxDailyAssignments != nil && *settings.MaxDailyAssignments < 0 {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "max_daily_assignments must not be negative",
+		}
+	}
+	if settings.LeadEscalationHours != nil && *settings.LeadEscalationHours <= 0 {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "lead_escalation_hours must be positive",
+		}
+	}
+	if settings.DependencyPolicy == "" {
+		settings.DependencyPolicy = DependencyPolicyNone
+	}
+	if !isValidDependencyPolicy(settings.DependencyPolicy) {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown dependency_policy " + settings.DependencyPolicy,
+		}
+	}
+	if settings.TransferReviews == "" {
+		settings.TransferReviews = TransferReviewsKeep
+	}
+	if !isValidTransferReviews(settings.TransferReviews) {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown transfer_reviews " + settings.TransferReviews,
+		}
+	}
+	if err := s.validateNotificationRoutes(settings.NotificationRoutes); err != nil {
+		return err
+	}
+	if err := s.validateNoCandidateFallback(settings); err != nil {
+		return err
+	}
+	if settings.AbsenceReserveDays < 0 || settings.AbsenceReserveDays > maxAbsenceReserveDays {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("absence_reserve_days must be between 0 and %d", maxAbsenceReserveDays),
+		}
+	}
+	if settings.ShadowReviewers < 0 || settings.ShadowReviewers > maxReviewerCount {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("shadow_reviewers must be between 0 and %d", maxReviewerCount),
+		}
+	}
+	return nil
+}
+
+// validateNotificationRoutes accepts known notification kinds and, when channels are
+// configured, only their names
+func (s *Service) validateNotificationRoutes(routes map[string][]string) error {
+	for kind, channels := range routes {
+		if !isNotificationKind(kind) {
+			return &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "unknown notification kind " + kind,
+			}
+		}
+		for _, channel := range channels {
+			if channel == "" || (s.notifier != nil && !s.notifier.HasChannel(channel)) {
+				return &ServiceError{
+					Code:    errcode.InvalidRequest,
+					Message: fmt.Sprintf("unknown notification channel %q", channel),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) ensureTeam(teamName string) error {
+	exists, err := s.storage.TeamExists(teamName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "team not found",
+		}
+	}
+	return nil
+}
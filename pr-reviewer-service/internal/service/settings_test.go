@@ -0,0 +1,44 @@
+package service
+
+import (
+	"encoding/json"
+	"pr-reviewer-service/internal/models"
+	"reflect"
+	"testing"
+)
+
+func TestApplySettingsPatch(t *testing.T) {
+	routes := func() map[string][]string {
+		return map[string][]string{"ASSIGNMENT": {"slack"}, "DIGEST": {"email"}}
+	}
+	
+	tests := []struct {
+		name   string
+		patch  string
+		routes map[string][]string
+		count  int
+	}{
+		{"route removed", `{"notification_routes": {"ASSIGNMENT": ["slack"]}}`, map[string][]string{"ASSIGNMENT": {"slack"}}, 2},
+		{"routes cleared", `{"notification_routes": null}`, nil, 2},
+		{"routes kept", `{"reviewer_count": 3}`, routes(), 3},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &models.TeamSettings{TeamName: "backend", ReviewerCount: 2, NotificationRoutes: routes()}
+			if err := applySettingsPatch(settings, json.RawMessage(tt.patch)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(settings.NotificationRoutes, tt.routes) {
+				t.Fatalf("expected routes %v, got %v", tt.routes, settings.NotificationRoutes)
+			}
+			if settings.ReviewerCount != tt.count {
+				t.Fatalf("expected reviewer_count %d, got %d", tt.count, settings.ReviewerCount)
+			}
+		})
+	}
+	
+	if err := applySettingsPatch(&models.TeamSettings{}, json.RawMessage(`[1]`)); err == nil {
+		t.Fatal("patch that isn't an object must fail")
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"math/rand"
+	"pr-reviewer-service/internal/models"
+)
+
+// assignShadows adds junior observers of the owning team next to real reviewers.
+// Shadows skip capacity checks and never block merge, their load isn't counted.
+func (s *Service) assignShadows(rng *rand.Rand, pr *models.PullRequest, reviewers []string, count int) ([]string, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+	
+	members, err := s.storage.GetActiveTeamMembers(pr.TeamName, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	
+	reviewing := make(map[string]bool, len(reviewers))
+	for _, userID := range reviewers {
+		reviewing[userID] = true
+	}
+	
+	var candidates []string
+	for _, member := range members {
+		if member.IsJunior && !reviewing[member.UserID] {
+			candidates = append(candidates, member.UserID)
+		}
+	}
+	
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > count {
+		candidates = candidates[:count]
+	}
+	
+	for _, userID := range candidates {
+		if err := s.storage.AddShadowReviewer(pr.PullRequestID, userID); err != nil {
+			return nil, err
+		}
+		if err := s.recordEvent(pr.PullRequestID, EventShadowAssigned, "", map[string]interface{}{"user_id": userID}); err != nil {
+			return nil, err
+		}
+	}
+	
+	return candidates, nil
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/policy"
+	"pr-reviewer-service/internal/strategy"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Strategies a simulation can replay
+const (
+	SimulateRandom      = "RANDOM"
+	SimulateLeastLoaded = "LEAST_LOADED"
+	SimulatePolicy      = "POLICY"
+)
+
+const (
+	defaultSimulationDays = 30
+	maxSimulationDays     = 90
+
+	// simulations are repeatable by default, the seed only picks another random draw
+	defaultSimulationSeed = 1
+)
+
+// SimulateAssignments replays team's PR creations of the last days with the given strategy and
+// config against today's active members and reports the load each would have got. Reviews stay
+// open until the PR actually merged or closed. Nothing is persisted.
+func (s *Service) SimulateAssignments(req models.SimulationRequest) (*models.SimulationResult, error) {
+	if err := s.ensureTeam(req.TeamName); err != nil {
+		return nil, err
+	}
+	if req.Days == 0 {
+		req.Days = defaultSimulationDays
+	}
+	if req.Days < 1 || req.Days > maxSimulationDays {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("days must be between 1 and %d", maxSimulationDays),
+		}
+	}
+	if req.Reviewers < 0 || req.Reviewers > maxReviewerCount {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("reviewers must be between 0 and %d", maxReviewerCount),
+		}
+	}
+	if req.MaxOpenReviews != nil && *req.MaxOpenReviews < 0 {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "max_open_reviews must not be negative",
+		}
+	}
+	
+	program, err := s.simulationProgram(&req)
+	if err != nil {
+		return nil, err
+	}
+	
+	settings, err := s.teamSettings(req.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if req.MaxOpenReviews != nil {
+		override := *settings
+		override.MaxOpenReviews = req.MaxOpenReviews
+		settings = &override
+	}
+	
+	members, err := s.storage.GetActiveTeamMembers(req.TeamName, "")
+	if err != nil {
+		return nil, err
+	}
+	prs, err := s.storage.GetPRCreations(req.TeamName, time.Now().AddDate(0, 0, -req.Days))
+	if err != nil {
+		return nil, err
+	}
+	
+	seed := int64(defaultSimulationSeed)
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+	rng := rand.New(rand.NewSource(seed))
+	
+	result := &models.SimulationResult{
+		TeamName:     req.TeamName,
+		Days:         req.Days,
+		Strategy:     req.Strategy,
+		PullRequests: len(prs),
+	}
+	loads := make(map[string]*models.SimulatedLoad, len(members))
+	for _, member := range members {
+		loads[member.UserID] = &models.SimulatedLoad{UserID: member.UserID}
+	}
+	
+	// open simulated reviews, released when their PR actually merged or closed
+	open := make(map[string]int, len(members))
+	type review struct {
+		releasedAt time.Time
+		reviewers  []string
+	}
+	var pending []review
+	for _, pr := range prs {
+		for _, reviewerID := range pr.Reviewers {
+			if load, ok := loads[reviewerID]; ok {
+				load.ActualAssignments++
+			}
+		}
+	
+		kept := pending[:0]
+		for _, r := range pending {
+			if r.releasedAt.After(pr.CreatedAt) {
+				kept = append(kept, r)
+				continue
+			}
+			for _, reviewerID := range r.reviewers {
+				open[reviewerID]--
+			}
+		}
+		pending = kept
+	
+		candidates := make([]models.User, 0, len(members))
+		for _, member := range members {
+			if member.UserID == pr.AuthorID {
+				continue
+			}
+			if limit := reviewCap(&member, settings); limit != nil && open[member.UserID] >= *limit {
+				continue
+			}
+			candidates = append(candidates, member)
+		}
+		rng.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+	
+		switch req.Strategy {
+		case SimulateLeastLoaded:
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return open[candidates[i].UserID] < open[candidates[j].UserID]
+			})
+		case SimulatePolicy:
+			policyReq := strategy.Request{
+				TeamName: req.TeamName,
+				AuthorID: pr.AuthorID,
+				Priority: pr.Priority,
+				Size:     pr.Size,
+			}
+			scores, err := policyScores(program, policyReq, candidates, open)
+			if err != nil {
+				return nil, &ServiceError{
+					Code:    errcode.InvalidRequest,
+					Message: "policy failed: " + err.Error(),
+				}
+			}
+			sort.SliceStable(candidates, func(i, j int) bool {
+				return scores[candidates[i].UserID] > scores[candidates[j].UserID]
+			})
+		}
+	
+		count := req.Reviewers
+		if count == 0 {
+			count = max(len(pr.Reviewers), 1)
+		}
+		picked := make([]string, 0, count)
+		for _, candidate := range candidates[:min(count, len(candidates))] {
+			picked = append(picked, candidate.UserID)
+			open[candidate.UserID]++
+			load := loads[candidate.UserID]
+			load.Assignments++
+			load.PeakOpenReviews = max(load.PeakOpenReviews, open[candidate.UserID])
+		}
+		result.Unassigned += count - len(picked)
+	
+		releasedAt := pr.MergedAt
+		if releasedAt == nil {
+			releasedAt = pr.ClosedAt
+		}
+		if releasedAt != nil {
+			pending = append(pending, review{releasedAt: *releasedAt, reviewers: picked})
+		}
+	}
+	
+	simulated := make([]int, 0, len(members))
+	actual := make([]int, 0, len(members))
+	result.Load = make([]models.SimulatedLoad, 0, len(members))
+	for _, member := range members {
+		load := loads[member.UserID]
+		simulated = append(simulated, load.Assignments)
+		actual = append(actual, load.ActualAssignments)
+		result.Load = append(result.Load, *load)
+	}
+	sort.Slice(result.Load, func(i, j int) bool {
+		if result.Load[i].Assignments != result.Load[j].Assignments {
+			return result.Load[i].Assignments > result.Load[j].Assignments
+		}
+		return result.Load[i].UserID < result.Load[j].UserID
+	})
+	result.AssignmentsStdDev = stdDev(simulated)
+	result.ActualStdDev = stdDev(actual)
+	
+	return result, nil
+}
+
+// simulationProgram resolves the strategy, team's policy when it has one, and compiles the
+// policy to replay. The program is nil for other strategies.
+func (s *Service) simulationProgram(req *models.SimulationRequest) (*policy.Program, error) {
+	req.Strategy = strings.ToUpper(strings.TrimSpace(req.Strategy))
+	req.Policy = strings.TrimSpace(req.Policy)
+	
+	if req.Policy == "" && (req.Strategy == "" || req.Strategy == SimulatePolicy) {
+		teamPolicy, err := s.storage.GetTeamPolicy(req.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if teamPolicy != nil {
+			req.Policy = teamPolicy.Source
+		}
+	}
+	if req.Strategy == "" {
+		req.Strategy = SimulateRandom
+		if req.Policy != "" {
+			req.Strategy = SimulatePolicy
+		}
+	}
+	
+	switch req.Strategy {
+	case SimulateRandom, SimulateLeastLoaded:
+		return nil, nil
+	case SimulatePolicy:
+	default:
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown strategy " + req.Strategy,
+		}
+	}
+	
+	if req.Policy == "" {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "policy is required for POLICY strategy",
+		}
+	}
+	program, err := policy.Compile(req.Policy, policyVars)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "invalid policy: " + err.Error(),
+		}
+	}
+	return program, nil
+}
+
+// stdDev - population standard deviation, 0 for no values
+func stdDev(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += float64(v)
+	}
+	mean := sum / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (float64(v) - mean) * (float64(v) - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// PR sizes, smallest first
+const (
+	SizeXS = "XS"
+	SizeS  = "S"
+	SizeM  = "M"
+	SizeL  = "L"
+	SizeXL = "XL"
+)
+
+var sizeOrder = []string{SizeXS, SizeS, SizeM, SizeL, SizeXL}
+
+const (
+	defaultReviewerCount = 2
+	maxReviewerCount     = 10
+)
+
+func sizeIndex(size string) int {
+	for i, s := range sizeOrder {
+		if s == size {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Service) GetTeamSizeRules(teamName string) (*models.TeamSizeRules, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	rules, err := s.storage.GetSizeRules(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &models.TeamSizeRules{TeamName: teamName, Rules: rules}, nil
+}
+
+// SetTeamSizeRules replaces team rules, thresholds must grow with size and only the largest size may be unbounded
+func (s *Service) SetTeamSizeRules(teamRules *models.TeamSizeRules) (*models.TeamSizeRules, error) {
+	if err := s.ensureTeam(teamRules.TeamName); err != nil {
+		return nil, err
+	}
+	
+	bySize := make([]*models.SizeRule, len(sizeOrder))
+	for i := range teamRules.Rules {
+		rule := &teamRules.Rules[i]
+		idx := sizeIndex(rule.Size)
+		if idx < 0 {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "unknown size " + rule.Size,
+			}
+		}
+		if bySize[idx] != nil {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "duplicate rule for size " + rule.Size,
+			}
+		}
+		if rule.Reviewers < 1 || rule.Reviewers > maxReviewerCount {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: fmt.Sprintf("reviewers must be between 1 and %d", maxReviewerCount),
+			}
+		}
+		if rule.MaxLines != nil && *rule.MaxLines < 0 {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "max_lines must not be negative",
+			}
+		}
+		bySize[idx] = rule
+	}
+	
+	rules := make([]models.SizeRule, 0, len(teamRules.Rules))
+	for _, rule := range bySize {
+		if rule == nil {
+			continue
+		}
+		if len(rules) > 0 {
+			prev := rules[len(rules)-1]
+			if prev.MaxLines == nil || (rule.MaxLines != nil && *rule.MaxLines <= *prev.MaxLines) {
+				return nil, &ServiceError{
+					Code:    errcode.InvalidRequest,
+					Message: "max_lines must grow with size, only the largest size may omit it",
+				}
+			}
+		}
+		rules = append(rules, *rule)
+	}
+	
+	if err := s.storage.ReplaceSizeRules(teamRules.TeamName, rules); err != nil {
+		return nil, err
+	}
+	
+	teamRules.Rules = rules
+	return teamRules, nil
+}
+
+// reviewerCount resolves PR size from the request and the team's reviewer count for it.
+// Lines above every threshold get the largest size, sizes without a rule get team's reviewer_count.
+func (s *Service) reviewerCount(teamName, size string, linesChanged *int) (string, int, error) {
+	if size != "" && sizeIndex(size) < 0 {
+		return "", 0, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown size " + size,
+		}
+	}
+	if linesChanged != nil && *linesChanged < 0 {
+		return "", 0, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "lines_changed must not be negative",
+		}
+	}
+	
+	rules, err := s.storage.GetSizeRules(teamName)
+	if err != nil {
+		return "", 0, err
+	}
+	
+	if size == "" && linesChanged != nil && len(rules) > 0 {
+		size = rules[len(rules)-1].Size
+		for _, rule := range rules {
+			if rule.MaxLines == nil || *linesChanged <= *rule.MaxLines {
+				size = rule.Size
+				break
+			}
+		}
+	}
+	
+	for _, rule := range rules {
+		if rule.Size == size {
+			return size, rule.Reviewers, nil
+		}
+	}
+	settings, err := s.teamSettings(teamName)
+	if err != nil {
+		return "", 0, err
+	}
+	return size, settings.ReviewerCount, nil
+}
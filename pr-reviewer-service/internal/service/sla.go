@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/alerting"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// ProcessSLABreaches alerts once per assignment that crossed its deadline, run by the scheduler
+func (s *Service) ProcessSLABreaches(ctx context.Context) error {
+	assignments, err := s.storage.GetOpenAssignments()
+	if err != nil {
+		return err
+	}
+	
+	now := time.Now().UTC()
+	settingsByTeam := make(map[string]*models.TeamSettings)
+	holidaysByTeam := make(map[string]holidays)
+	
+	for _, a := range assignments {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	
+		settings, ok := settingsByTeam[a.TeamName]
+		if !ok {
+			if settings, err = s.teamSettings(a.TeamName); err != nil {
+				return err
+			}
+			settingsByTeam[a.TeamName] = settings
+		}
+	
+		calendar, err := s.cachedHolidays(holidaysByTeam, a.ReviewerTeam)
+		if err != nil {
+			return err
+		}
+	
+		if a.SLAPausedAt != nil {
+			continue
+		}
+		deadline := assignmentDeadline(a, settings, calendar, now)
+		if now.Before(deadline) {
+			continue
+		}
+	
+		isNew, err := s.storage.RecordSLABreach(a.PullRequestID, a.ReviewerID, a.AssignedAt)
+		if err != nil {
+			return err
+		}
+		if !isNew {
+			continue
+		}
+	
+		if err := s.alertSLABreach(ctx, a, deadline); err != nil {
+			log.Printf("SLA breach alert for %s/%s failed: %v", a.PullRequestID, a.ReviewerID, err)
+			if err := s.storage.DeleteSLABreach(a.PullRequestID, a.ReviewerID, a.AssignedAt); err != nil {
+				return err
+			}
+		}
+	}
+	
+	return nil
+}
+
+func (s *Service) alertSLABreach(ctx context.Context, a models.ReviewAssignment, deadline time.Time) error {
+	alert := alerting.Alert{
+		DedupKey:        fmt.Sprintf("sla:%s:%s:%d", a.PullRequestID, a.ReviewerID, a.AssignedAt.Unix()),
+		PullRequestID:   a.PullRequestID,
+		PullRequestName: a.PullRequestName,
+		ReviewerID:      a.ReviewerID,
+		TeamName:        a.TeamName,
+		AssignedAt:      a.AssignedAt,
+		Deadline:        deadline,
+	}
+	
+	if s.alerter != nil {
+		send := s.alerter.Send
+		if s.queueJobs {
+			send = func(_ context.Context, alert alerting.Alert) error {
+				return s.enqueue(JobSendAlert, alert)
+			}
+		}
+		if err := send(ctx, alert); err != nil {
+			return err
+		}
+	}
+	
+	message, err := s.renderNotification(a.TeamName, NotificationSLABreach, s.UserLocale(a.ReviewerID),
+		s.prNotificationData(a, deadline))
+	if err != nil {
+		return err
+	}
+	return s.notify(a.ReviewerID, NotificationSLABreach, a.PullRequestID, message)
+}
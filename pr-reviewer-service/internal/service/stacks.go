@@ -0,0 +1,156 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// RegisterStack chains existing PRs base first, each one is stacked on the previous.
+// The first PR may already be part of a stack, the chain then grows from it.
+func (s *Service) RegisterStack(prIDs []string) ([]models.StackMember, error) {
+	if len(prIDs) < 2 {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "stack needs at least 2 pull requests",
+		}
+	}
+	
+	seen := make(map[string]bool, len(prIDs))
+	for i, prID := range prIDs {
+		if seen[prID] {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "duplicate pull request " + prID,
+			}
+		}
+		seen[prID] = true
+	
+		exists, err := s.storage.PRExists(prID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, &ServiceError{
+				Code:    errcode.NotFound,
+				Message: "pull request " + prID + " not found",
+			}
+		}
+		if i == 0 {
+			continue
+		}
+		member, err := s.storage.GetStackMember(prID)
+		if err != nil {
+			return nil, err
+		}
+		if member != nil {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "pull request " + prID + " is already stacked",
+			}
+		}
+	}
+	
+	for i := 1; i < len(prIDs); i++ {
+		if err := s.stackPR(prIDs[i], prIDs[i-1]); err != nil {
+			return nil, err
+		}
+	}
+	
+	base, err := s.storage.GetStackMember(prIDs[0])
+	if err != nil {
+		return nil, err
+	}
+	return s.storage.GetStack(base.BaseID)
+}
+
+// GetStack returns the stack the PR belongs to
+func (s *Service) GetStack(prID string) ([]models.StackMember, error) {
+	exists, err := s.storage.PRExists(prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	member, err := s.storage.GetStackMember(prID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request is not stacked",
+		}
+	}
+	return s.storage.GetStack(member.BaseID)
+}
+
+// stackPR puts PR on top of parent, the parent also becomes its merge dependency
+func (s *Service) stackPR(prID, parentID string) error {
+	cycle, err := s.storage.DependencyPathExists(parentID, prID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: parentID + " already depends on " + prID,
+		}
+	}
+	
+	if err := s.storage.AddStackedPR(prID, parentID); err != nil {
+		return err
+	}
+	if err := s.storage.AddPRDependency(prID, parentID); err != nil {
+		return err
+	}
+	
+	member, err := s.storage.GetStackMember(prID)
+	if err != nil {
+		return err
+	}
+	payload := map[string]interface{}{
+		"parent_id": parentID,
+		"base_id":   member.BaseID,
+		"position":  member.Position,
+	}
+	return s.recordEvent(prID, EventPRStacked, "", payload)
+}
+
+// stackBaseReviewers returns reviewers of the stack base for the rest of the stack
+func (s *Service) stackBaseReviewers(prID string) ([]string, error) {
+	member, err := s.storage.GetStackMember(prID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.BaseID == prID {
+		return nil, nil
+	}
+	return s.storage.GetReviewers(member.BaseID)
+}
+
+// splitPreferred separates preferred candidates keeping the order of both parts
+func splitPreferred(candidates []models.User, preferred []string) ([]models.User, []models.User) {
+	if len(preferred) == 0 {
+		return nil, candidates
+	}
+	
+	wanted := make(map[string]bool, len(preferred))
+	for _, userID := range preferred {
+		wanted[userID] = true
+	}
+	
+	var picked, rest []models.User
+	for _, candidate := range candidates {
+		if wanted[candidate.UserID] {
+			picked = append(picked, candidate)
+		} else {
+			rest = append(rest, candidate)
+		}
+	}
+	return picked, rest
+}
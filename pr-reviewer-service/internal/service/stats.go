@@ -0,0 +1,152 @@
+package service
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+const (
+	defaultStatsPeriodDays = 30
+	maxStatsPeriodDays     = 365
+)
+
+func statsSince(periodDays int) (time.Time, error) {
+	if periodDays <= 0 || periodDays > maxStatsPeriodDays {
+		return time.Time{}, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "days must be between 1 and 365",
+		}
+	}
+	return time.Now().UTC().AddDate(0, 0, -periodDays), nil
+}
+
+// GetTeamStats returns review statistics of team members acting as reviewers and how often
+// the team's merged PRs were reverted. Results are shared for the stats cache TTL.
+func (s *Service) GetTeamStats(teamName string, periodDays int) (*models.ReviewStats, error) {
+	key := fmt.Sprintf("%s/%d", teamName, periodDays)
+	cached, err := s.caches.teamStats.Get(key, func() (*models.ReviewStats, error) {
+		return s.teamStats(teamName, periodDays)
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	// callers anonymize the result in place
+	stats := *cached
+	if cached.Reverts != nil {
+		reverts := *cached.Reverts
+		stats.Reverts = &reverts
+	}
+	return &stats, nil
+}
+
+func (s *Service) teamStats(teamName string, periodDays int) (*models.ReviewStats, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	since, err := statsSince(periodDays)
+	if err != nil {
+		return nil, err
+	}
+	
+	firstReview, err := s.storage.GetFirstReviewStatsByTeam(teamName, since)
+	if err != nil {
+		return nil, err
+	}
+	
+	stats := &models.ReviewStats{
+		TeamName:   teamName,
+		PeriodDays: periodDays,
+	}
+	if len(firstReview) > 0 {
+		stats.TimeToFirstReview = firstReview[0]
+	}
+	stats.TimeToFirstReview.TeamName = teamName
+	
+	reviewTime, err := s.storage.GetReviewTimeStatsByTeam(teamName, since)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviewTime) > 0 {
+		stats.ReviewTime = reviewTime[0]
+	}
+	stats.ReviewTime.TeamName = teamName
+	
+	if stats.Reverts, err = s.storage.GetRevertStats(teamName, since); err != nil {
+		return nil, err
+	}
+	
+	return stats, nil
+}
+
+// GetUserStats returns review statistics of a single reviewer
+func (s *Service) GetUserStats(userID string, periodDays int) (*models.ReviewStats, error) {
+	user, err := s.storage.GetUser(userID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	since, err := statsSince(periodDays)
+	if err != nil {
+		return nil, err
+	}
+	
+	firstReview, err := s.storage.GetFirstReviewStatsByUser(userID, since)
+	if err != nil {
+		return nil, err
+	}
+	
+	stats := &models.ReviewStats{
+		TeamName:   user.TeamName,
+		UserID:     userID,
+		PeriodDays: periodDays,
+	}
+	if len(firstReview) > 0 {
+		stats.TimeToFirstReview = firstReview[0]
+	}
+	stats.TimeToFirstReview.TeamName = user.TeamName
+	stats.TimeToFirstReview.UserID = userID
+	
+	reviewTime, err := s.storage.GetReviewTimeStatsByUser(userID, since)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviewTime) > 0 {
+		stats.ReviewTime = reviewTime[0]
+	}
+	stats.ReviewTime.TeamName = user.TeamName
+	stats.ReviewTime.UserID = userID
+	
+	return stats, nil
+}
+
+// FirstReviewStats returns per-team and per-user percentiles for the default period, used by metrics.
+// With anonymized analytics users are labeled by pseudonyms.
+func (s *Service) FirstReviewStats() ([]models.FirstReviewStats, []models.FirstReviewStats, error) {
+	since := time.Now().UTC().AddDate(0, 0, -defaultStatsPeriodDays)
+	
+	byTeam, err := s.storage.GetFirstReviewStatsByTeam("", since)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	byUser, err := s.storage.GetFirstReviewStatsByUser("", since)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.anonymizeAlways {
+		for i := range byUser {
+			if byUser[i].UserID, err = s.Pseudonym(byUser[i].UserID); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	
+	return byTeam, byUser, nil
+}
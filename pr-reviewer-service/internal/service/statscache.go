@@ -0,0 +1,42 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/cache"
+	"pr-reviewer-service/internal/metrics"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// defaultStatsCacheTTL - how long a computed aggregate is served to other callers, short enough
+// that dashboards still look live
+const defaultStatsCacheTTL = 15 * time.Second
+
+// statsCaches - expensive aggregates by team and period, a refresh from many dashboards at once
+// runs each query once
+type statsCaches struct {
+	teamStats    *cache.Cache[*models.ReviewStats]
+	declineStats *cache.Cache[*models.DeclineStats]
+	leaderboard  *cache.Cache[[]models.LeaderboardEntry]
+}
+
+func newStatsCaches(ttl time.Duration) statsCaches {
+	return statsCaches{
+		teamStats:    cache.New[*models.ReviewStats](ttl, observeCache("team_stats")),
+		declineStats: cache.New[*models.DeclineStats](ttl, observeCache("decline_stats")),
+		leaderboard:  cache.New[[]models.LeaderboardEntry](ttl, observeCache("leaderboard")),
+	}
+}
+
+func observeCache(name string) func(result string) {
+	return func(result string) {
+		metrics.CacheRequest(name, result)
+	}
+}
+
+// WithStatsCacheTTL changes how long aggregate statistics are reused, zero only merges
+// concurrent identical requests
+func WithStatsCacheTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.statsCacheTTL = ttl
+	}
+}
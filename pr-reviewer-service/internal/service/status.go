@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"runtime/debug"
+	"time"
+)
+
+// Status page states
+const (
+	StatusOK       = "OK"
+	StatusDegraded = "DEGRADED"
+)
+
+// statusPingTimeout - how long the status page waits for the database
+const statusPingTimeout = 2 * time.Second
+
+// statusCollectTimeout - how long a shared collection may run once no caller bounds it
+const statusCollectTimeout = 10 * time.Second
+
+// statusCacheTTL - how long collected internals are served to other status requests, a
+// monitoring probe or a refreshing dashboard runs the checks once per interval
+const statusCacheTTL = 5 * time.Second
+
+// webhookQueue - name of the outbound delivery backlog on the status page
+const webhookQueue = "webhooks"
+
+// webhookJobs - job kinds delivering events and notifications to outside endpoints
+var webhookJobs = map[string]bool{
+	JobPublishEvent:         true,
+	JobDispatchNotification: true,
+}
+
+// WithVersion sets the version shown on the status page, by default it's taken from build info
+func WithVersion(version string) Option {
+	return func(s *Service) {
+		s.version = version
+	}
+}
+
+// buildVersion - module version of the binary, or its VCS revision for development builds
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return "devel"
+}
+
+// GetPublicStatus reports only whether the service is OK or DEGRADED, for unauthenticated probes
+func (s *Service) GetPublicStatus(ctx context.Context) *models.PublicStatus {
+	return &models.PublicStatus{Status: s.GetStatus(ctx).Status}
+}
+
+// GetStatus reports service internals for ops dashboards, collected at most once per
+// statusCacheTTL. Failed checks make the status DEGRADED, the reason is only logged.
+// The collection is shared, so it outlives the caller that started it; every caller
+// waits only as long as its own ctx allows and gets DEGRADED when that runs out.
+func (s *Service) GetStatus(ctx context.Context) *models.ServiceStatus {
+	collected := make(chan *models.ServiceStatus, 1)
+	go func() {
+		collectCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statusCollectTimeout)
+		defer cancel()
+		cached, err := s.statusCache.Get("", func() (*models.ServiceStatus, error) {
+			return s.collectStatus(collectCtx)
+		})
+		if err != nil {
+			log.Printf("Status check: %v", err)
+		}
+		collected <- cached
+	}()
+	
+	var status models.ServiceStatus
+	select {
+	case cached := <-collected:
+		if cached == nil {
+			cached = s.degradedStatus()
+		}
+		status = *cached
+	case <-ctx.Done():
+		status = *s.degradedStatus()
+	}
+	status.UptimeSeconds = int64(time.Since(s.startedAt).Seconds())
+	return &status
+}
+
+// degradedStatus - status page without any checks done
+func (s *Service) degradedStatus() *models.ServiceStatus {
+	status := s.newStatus()
+	status.Status = StatusDegraded
+	return status
+}
+
+func (s *Service) newStatus() *models.ServiceStatus {
+	return &models.ServiceStatus{
+		Status:         StatusOK,
+		Version:        s.version,
+		StartedAt:      s.startedAt,
+		UptimeSeconds:  int64(time.Since(s.startedAt).Seconds()),
+		Queues:         []models.QueueDepth{},
+		Scheduler:      []models.SchedulerRun{},
+		WebhookBacklog: models.QueueDepth{Name: webhookQueue},
+	}
+}
+
+// collectStatus runs the checks, a status cut short by a timeout comes with the context error
+// so it's not served to other requests
+func (s *Service) collectStatus(ctx context.Context) (*models.ServiceStatus, error) {
+	status := s.newStatus()
+	
+	pingCtx, cancel := context.WithTimeout(ctx, statusPingTimeout)
+	defer cancel()
+	started := time.Now()
+	if err := s.storage.Ping(pingCtx); err != nil {
+		status.Status = StatusDegraded
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return status, err
+		}
+		log.Printf("Status check: %v", err)
+		return status, nil
+	}
+	status.Database = models.DatabaseStatus{
+		Connected: true,
+		LatencyMs: float64(time.Since(started).Microseconds()) / 1000,
+	}
+	
+	queues, err := s.storage.GetQueueDepths()
+	if err != nil {
+		log.Printf("Status check: %v", err)
+		status.Status = StatusDegraded
+	} else {
+		status.Queues = queues
+	}
+	for _, queue := range status.Queues {
+		if !webhookJobs[queue.Name] {
+			continue
+		}
+		backlog := &status.WebhookBacklog
+		backlog.Pending += queue.Pending
+		backlog.Running += queue.Running
+		backlog.Dead += queue.Dead
+		if queue.OldestAt != nil && (backlog.OldestAt == nil || queue.OldestAt.Before(*backlog.OldestAt)) {
+			backlog.OldestAt = queue.OldestAt
+		}
+	}
+	
+	runs, err := s.storage.GetSchedulerRuns()
+	if err != nil {
+		log.Printf("Status check: %v", err)
+		status.Status = StatusDegraded
+	} else {
+		status.Scheduler = runs
+	}
+	
+	return status, ctx.Err()
+}
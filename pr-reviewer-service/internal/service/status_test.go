@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/storage"
+	"testing"
+	"time"
+)
+
+// statusStorage answers only the status checks, ping replies come from pings
+type statusStorage struct {
+	storage.Storage
+	pings chan error
+}
+
+func (s *statusStorage) Ping(ctx context.Context) error {
+	select {
+	case err := <-s.pings:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *statusStorage) GetQueueDepths() ([]models.QueueDepth, error) {
+	return []models.QueueDepth{}, nil
+}
+
+func (s *statusStorage) GetSchedulerRuns() ([]models.SchedulerRun, error) {
+	return []models.SchedulerRun{}, nil
+}
+
+func TestGetStatusOutlivesCaller(t *testing.T) {
+	st := &statusStorage{pings: make(chan error)}
+	s := NewService(st)
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if status := s.GetStatus(ctx); status.Status != StatusDegraded {
+		t.Fatalf("cancelled caller got %s, want %s", status.Status, StatusDegraded)
+	}
+	
+	// the collection started by the cancelled caller still waits for the database
+	select {
+	case st.pings <- nil:
+	case <-time.After(time.Second):
+		t.Fatal("collection stopped with its caller")
+	}
+	if status := s.GetStatus(context.Background()); status.Status != StatusOK {
+		t.Fatalf("next caller got %s, want %s", status.Status, StatusOK)
+	}
+}
+
+func TestGetStatusTimeoutNotCached(t *testing.T) {
+	st := &statusStorage{pings: make(chan error, 1)}
+	s := NewService(st)
+	
+	st.pings <- context.DeadlineExceeded
+	if status := s.GetStatus(context.Background()); status.Status != StatusDegraded {
+		t.Fatalf("timed out check got %s, want %s", status.Status, StatusDegraded)
+	}
+	
+	st.pings <- nil
+	if status := s.GetStatus(context.Background()); status.Status != StatusOK {
+		t.Fatalf("check after a timeout got %s, want %s", status.Status, StatusOK)
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/strategy"
+)
+
+// WithRanker delegates reviewer choice to an external decision service,
+// random selection is kept as fallback when it fails
+func WithRanker(ranker strategy.Ranker) Option {
+	return func(s *Service) {
+		s.ranker = ranker
+	}
+}
+
+// rankCandidates puts candidates in ranker's order, unranked ones keep their random order after them
+func (s *Service) rankCandidates(req strategy.Request, candidates []models.User) []models.User {
+	byID := make(map[string]models.User, len(candidates))
+	req.Candidates = make([]strategy.Candidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		byID[candidate.UserID] = candidate
+		req.Candidates = append(req.Candidates, strategy.Candidate{
+			UserID:   candidate.UserID,
+			Username: candidate.Username,
+		})
+	}
+	
+	ranking, err := s.ranker.Rank(context.Background(), req)
+	if err != nil {
+		log.Printf("Reviewer strategy failed for team %s, using random selection: %v", req.TeamName, err)
+		return candidates
+	}
+	
+	ranked := make([]models.User, 0, len(candidates))
+	seen := make(map[string]bool, len(candidates))
+	// ids outside of the candidate list are ignored
+	for _, userID := range ranking {
+		if candidate, ok := byID[userID]; ok && !seen[userID] {
+			ranked = append(ranked, candidate)
+			seen[userID] = true
+		}
+	}
+	for _, candidate := range candidates {
+		if !seen[candidate.UserID] {
+			ranked = append(ranked, candidate)
+		}
+	}
+	
+	return ranked
+}
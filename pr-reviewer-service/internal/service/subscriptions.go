@@ -0,0 +1,125 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+)
+
+// MutePR silences user's notifications about one PR, assignments and workload are unaffected
+func (s *Service) MutePR(prID, userID string, muted bool) error {
+	exists, err := s.storage.PRExists(prID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	if _, err := s.storage.GetUser(userID); err != nil {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	if !muted {
+		return s.storage.UnmutePR(prID, userID)
+	}
+	return s.storage.MutePR(prID, userID)
+}
+
+// WatchPR subscribes user to PR lifecycle events, watching=false unsubscribes
+func (s *Service) WatchPR(prID, userID string, watching bool) error {
+	exists, err := s.storage.PRExists(prID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	if _, err := s.storage.GetUser(userID); err != nil {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "user not found",
+		}
+	}
+	
+	if !watching {
+		return s.storage.RemovePRWatcher(prID, userID)
+	}
+	return s.storage.AddPRWatcher(prID, userID)
+}
+
+// GetPRWatchers returns users subscribed to the PR
+func (s *Service) GetPRWatchers(prID string) ([]string, error) {
+	exists, err := s.storage.PRExists(prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "pull request not found",
+		}
+	}
+	
+	return s.storage.GetPRWatchers(prID)
+}
+
+// notifyWatchers sends PR event to its watchers except the actor
+func (s *Service) notifyWatchers(event *models.PREvent) error {
+	watchers, err := s.storage.GetPRWatchers(event.PullRequestID)
+	if err != nil {
+		return err
+	}
+	if len(watchers) == 0 {
+		return nil
+	}
+	
+	pr, err := s.storage.GetPullRequest(event.PullRequestID)
+	if err != nil {
+		return err
+	}
+	data := PREventData{
+		TeamName:  pr.TeamName,
+		PRID:      pr.PullRequestID,
+		PRName:    pr.PullRequestName,
+		Author:    pr.AuthorID,
+		EventType: event.EventType,
+		Actor:     event.ActorID,
+		Payload:   event.Payload,
+		Link:      s.prLink(pr.PullRequestID),
+	}
+	render := s.notificationRenderer(pr.TeamName, NotificationPREvent, data)
+	
+	for _, userID := range watchers {
+		if userID == event.ActorID {
+			continue
+		}
+		if blindEvent(event.EventType) {
+			hidden, err := s.reviewersHidden(pr, userID)
+			if err != nil {
+				return err
+			}
+			if hidden {
+				continue
+			}
+		}
+		message, err := render(s.UserLocale(userID))
+		if err != nil {
+			return err
+		}
+		if err := s.notify(userID, NotificationPREvent, pr.PullRequestID, message); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"log"
+	"net/url"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/i18n"
+	"pr-reviewer-service/internal/models"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// PRNotificationData - variables of PR-related notification templates
+type PRNotificationData struct {
+	TeamName     string
+	PRID         string
+	PRName       string
+	Author       string
+	Reviewer     string
+	Deadline     time.Time
+	OverdueHours int
+	Link         string
+}
+
+// PREventData - variables of the PR event template sent to watchers
+type PREventData struct {
+	TeamName  string
+	PRID      string
+	PRName    string
+	Author    string
+	EventType string
+	Actor     string
+	Payload   map[string]interface{}
+	Link      string
+}
+
+// MentionData - variables of the @mention template
+type MentionData struct {
+	TeamName  string
+	PRID      string
+	PRName    string
+	Author    string
+	Commenter string
+	Comment   string
+	Link      string
+}
+
+// DigestData - variables of the daily digest template
+type DigestData struct {
+	UserID      string
+	OpenReviews int
+	New         []PRNotificationData
+	DueSoon     []PRNotificationData
+	Pending     []PRNotificationData
+}
+
+var defaultTemplates = map[string]string{
+	NotificationEscalation: `Review of {{printf "%q" .PRName}} by {{.Reviewer}} is overdue by {{.OverdueHours}}h` +
+		`{{if .Link}}: {{.Link}}{{end}}`,
+	NotificationSLABreach: `Review of {{printf "%q" .PRName}} from {{.Author}} is past its deadline ` +
+		`{{.Deadline.Format "Jan 2 15:04 MST"}}{{if .Link}}: {{.Link}}{{end}}`,
+	NotificationDigest: `Daily review digest: {{.OpenReviews}} open reviews
+{{- define "items"}}{{range .}}
+- {{printf "%q" .PRName}} ({{.PRID}}), due {{.Deadline.Format "Jan 2 15:04"}}{{end}}{{end}}
+{{- with .New}}
+
+Newly assigned:{{template "items" .}}{{end}}
+{{- with .DueSoon}}
+
+Deadline approaching:{{template "items" .}}{{end}}
+{{- with .Pending}}
+
+Pending:{{template "items" .}}{{end}}`,
+	NotificationWeeklyReport: `Weekly report of {{.TeamName}} for {{.Week}}
+PRs created: {{.PRsCreated}}, merged: {{.PRsMerged}}, reviewer assignments: {{.Assignments}}
+{{- with .AvgTurnaroundHours}}
+Average turnaround: {{printf "%.1f" (deref .)}}h{{end}}
+{{- with .Bottlenecks}}
+Bottlenecks:{{range .}}
+- {{.UserID}}: {{.OpenReviews}} open reviews, waiting since {{.OldestAssignedAt.Format "Jan 2"}}{{end}}{{end}}`,
+	NotificationPREvent: `{{.EventType}} on {{printf "%q" .PRName}} ({{.PRID}}){{with .Actor}} by {{.}}{{end}}` +
+		`{{if .Link}}: {{.Link}}{{end}}`,
+	NotificationMention: `{{.Commenter}} mentioned you on {{printf "%q" .PRName}}: {{.Comment}}` +
+		`{{if .Link}}
+{{.Link}}{{end}}`,
+}
+
+// localizedTemplates - default templates in other locales, English ones are used for the rest
+var localizedTemplates = map[string]map[string]string{
+	i18n.Russian: {
+		NotificationEscalation: `Ревью {{printf "%q" .PRName}} ревьюером {{.Reviewer}} просрочено на {{.OverdueHours}} ч` +
+			`{{if .Link}}: {{.Link}}{{end}}`,
+		NotificationSLABreach: `Истёк срок ревью {{printf "%q" .PRName}} от {{.Author}}: ` +
+			`{{.Deadline.Format "02.01.2006 15:04 MST"}}{{if .Link}}, {{.Link}}{{end}}`,
+		NotificationDigest: `Ежедневная сводка ревью, открытых ревью: {{.OpenReviews}}
+{{- define "items"}}{{range .}}
+- {{printf "%q" .PRName}} ({{.PRID}}), срок {{.Deadline.Format "02.01 15:04"}}{{end}}{{end}}
+{{- with .New}}
+
+Новые назначения:{{template "items" .}}{{end}}
+{{- with .DueSoon}}
+
+Скоро срок:{{template "items" .}}{{end}}
+{{- with .Pending}}
+
+Ожидают:{{template "items" .}}{{end}}`,
+		NotificationWeeklyReport: `Недельный отчёт {{.TeamName}} за {{.Week}}
+PR создано: {{.PRsCreated}}, смёржено: {{.PRsMerged}}, назначений ревьюеров: {{.Assignments}}
+{{- with .AvgTurnaroundHours}}
+Среднее время ревью: {{printf "%.1f" (deref .)}} ч{{end}}
+{{- with .Bottlenecks}}
+Узкие места:{{range .}}
+- {{.UserID}}: открытых ревью {{.OpenReviews}}, ждут с {{.OldestAssignedAt.Format "02.01"}}{{end}}{{end}}`,
+		NotificationPREvent: `{{.EventType}} в {{printf "%q" .PRName}} ({{.PRID}}){{with .Actor}}, инициатор {{.}}{{end}}` +
+			`{{if .Link}}: {{.Link}}{{end}}`,
+		NotificationMention: `{{.Commenter}} упоминает вас в {{printf "%q" .PRName}}: {{.Comment}}` +
+			`{{if .Link}}
+{{.Link}}{{end}}`,
+	},
+}
+
+// defaultTemplate returns the built-in template of the kind in the locale
+func defaultTemplate(kind, locale string) string {
+	if body, ok := localizedTemplates[locale][kind]; ok {
+		return body
+	}
+	return defaultTemplates[kind]
+}
+
+// templateSamples - data used to validate custom templates before saving
+var templateSamples = map[string]interface{}{
+	NotificationEscalation:   samplePRData(),
+	NotificationSLABreach:    samplePRData(),
+	NotificationDigest:       DigestData{UserID: "u1", OpenReviews: 1, New: []PRNotificationData{samplePRData()}},
+	NotificationWeeklyReport: &models.TeamReport{TeamName: "backend", Week: "2026-W01"},
+	NotificationPREvent: PREventData{
+		TeamName:  "backend",
+		PRID:      "pr-1001",
+		PRName:    "Add search",
+		Author:    "u1",
+		EventType: EventPRMerged,
+		Actor:     "u2",
+		Payload:   map[string]interface{}{"user_id": "u2"},
+		Link:      "https://example.com/pr-1001",
+	},
+	NotificationMention: MentionData{
+		TeamName:  "backend",
+		PRID:      "pr-1001",
+		PRName:    "Add search",
+		Author:    "u1",
+		Commenter: "u2",
+		Comment:   "@u3 could you check the query plan?",
+		Link:      "https://example.com/pr-1001#comment-1",
+	},
+}
+
+func samplePRData() PRNotificationData {
+	return PRNotificationData{
+		TeamName:     "backend",
+		PRID:         "pr-1001",
+		PRName:       "Add search",
+		Author:       "u1",
+		Reviewer:     "u2",
+		Deadline:     time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC),
+		OverdueHours: 48,
+		Link:         "https://example.com/pr-1001",
+	}
+}
+
+func (s *Service) prNotificationData(a models.ReviewAssignment, deadline time.Time) PRNotificationData {
+	return PRNotificationData{
+		TeamName: a.TeamName,
+		PRID:     a.PullRequestID,
+		PRName:   a.PullRequestName,
+		Author:   a.AuthorID,
+		Reviewer: a.ReviewerID,
+		Deadline: deadline,
+		Link:     s.prLink(a.PullRequestID),
+	}
+}
+
+// prLink returns PR timeline URL or empty string if base URL isn't configured
+func (s *Service) prLink(prID string) string {
+	if s.linkBaseURL == "" {
+		return ""
+	}
+	return strings.TrimRight(s.linkBaseURL, "/") + "/pullRequest/timeline?pull_request_id=" + url.QueryEscape(prID)
+}
+
+// renderNotification executes team template of the kind, broken custom templates fall back to
+// the default one in the recipient's locale. Custom templates are used for every locale.
+func (s *Service) renderNotification(teamName, kind, locale string, data interface{}) (string, error) {
+	custom, err := s.storage.GetNotificationTemplate(teamName, kind)
+	if err != nil {
+		return "", err
+	}
+	
+	if custom != "" {
+		message, err := executeTemplate(kind, custom, data)
+		if err == nil {
+			return message, nil
+		}
+		log.Printf("Template %s of team %s failed, using default: %v", kind, teamName, err)
+	}
+	
+	return executeTemplate(kind, defaultTemplate(kind, locale), data)
+}
+
+// notificationRenderer renders the notification once per locale, for messages sent to many users
+func (s *Service) notificationRenderer(teamName, kind string, data interface{}) func(locale string) (string, error) {
+	messages := make(map[string]string)
+	return func(locale string) (string, error) {
+		if message, ok := messages[locale]; ok {
+			return message, nil
+		}
+		message, err := s.renderNotification(teamName, kind, locale, data)
+		if err != nil {
+			return "", err
+		}
+		messages[locale] = message
+		return message, nil
+	}
+}
+
+var templateFuncs = template.FuncMap{
+	"deref": func(v *float64) float64 { return *v },
+}
+
+func executeTemplate(kind, body string, data interface{}) (string, error) {
+	tmpl, err := template.New(kind).Funcs(templateFuncs).Option("missingkey=error").Parse(body)
+	if err != nil {
+		return "", err
+	}
+	
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// GetNotificationTemplates returns effective templates of the team
+func (s *Service) GetNotificationTemplates(teamName string) ([]models.NotificationTemplate, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	custom, err := s.storage.GetNotificationTemplates(teamName)
+	if err != nil {
+		return nil, err
+	}
+	
+	templates := make([]models.NotificationTemplate, 0, len(defaultTemplates))
+	for _, kind := range []string{NotificationEscalation, NotificationSLABreach, NotificationDigest, NotificationWeeklyReport, NotificationPREvent,
+		NotificationMention} {
+		tmpl := models.NotificationTemplate{
+			TeamName:  teamName,
+			Kind:      kind,
+			Body:      defaultTemplates[kind],
+			IsDefault: true,
+		}
+		if body, ok := custom[kind]; ok {
+			tmpl.Body = body
+			tmpl.IsDefault = false
+		}
+		templates = append(templates, tmpl)
+	}
+	
+	return templates, nil
+}
+
+// SetNotificationTemplate validates and stores team template, empty body restores the default
+func (s *Service) SetNotificationTemplate(teamName, kind, body string) (*models.NotificationTemplate, error) {
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	
+	sample, ok := templateSamples[kind]
+	if !ok {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "unknown notification kind " + kind,
+		}
+	}
+	
+	if body == "" {
+		if err := s.storage.DeleteNotificationTemplate(teamName, kind); err != nil {
+			return nil, err
+		}
+		return &models.NotificationTemplate{
+			TeamName:  teamName,
+			Kind:      kind,
+			Body:      defaultTemplates[kind],
+			IsDefault: true,
+		}, nil
+	}
+	
+	if _, err := executeTemplate(kind, body, sample); err != nil {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "invalid template: " + err.Error(),
+		}
+	}
+	
+	if err := s.storage.SaveNotificationTemplate(teamName, kind, body); err != nil {
+		return nil, err
+	}
+	
+	return &models.NotificationTemplate{
+		TeamName: teamName,
+		Kind:     kind,
+		Body:     body,
+	}, nil
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"slices"
+	"strings"
+	"time"
+)
+
+// API token scopes, each one includes the ones before it
+const (
+	ScopeAnalytics     = "analytics" // stats and reports with pseudonyms instead of user ids
+	ScopeRead          = "read"
+	ScopeReviewActions = "review-actions"
+	ScopeAdmin         = "admin"
+)
+
+// Token audit actions
+const (
+	AuditTokenMint   = "TOKEN_MINT"
+	AuditTokenRevoke = "TOKEN_REVOKE"
+	AuditTokenUse    = "TOKEN_USE"
+)
+
+const (
+	tokenPrefix           = "prs_"
+	defaultTokenTTLDays   = 90
+	maxTokenTTLDays       = 365
+	maxTokenNameLength    = 255
+	tokenTouchGranularity = time.Minute
+)
+
+var scopeLevel = map[string]int{
+	ScopeAnalytics:     1,
+	ScopeRead:          2,
+	ScopeReviewActions: 3,
+	ScopeAdmin:         4,
+}
+
+// tokenGrants reports whether token scopes include the required one
+func tokenGrants(scopes []string, required string) bool {
+	for _, scope := range scopes {
+		if scopeLevel[scope] >= scopeLevel[required] {
+			return true
+		}
+	}
+	return false
+}
+
+// AnalyticsOnly reports whether the token may see analytics only with pseudonyms
+func AnalyticsOnly(token *models.APIToken) bool {
+	return !tokenGrants(token.Scopes, ScopeRead)
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// authorizeTokenOwner lets the caller manage own tokens, admin-scoped callers manage anyone's
+func authorizeTokenOwner(caller *models.APIToken, userID string) error {
+	if caller != nil && (caller.UserID == userID || tokenGrants(caller.Scopes, ScopeAdmin)) {
+		return nil
+	}
+	return &ServiceError{
+		Code:    errcode.Forbidden,
+		Message: "only the token owner or an admin can manage tokens",
+	}
+}
+
+// MintToken creates a personal token for the caller, or for anyone when the caller holds admin
+// scope. A caller can't grant any token more than it has, admin scope needs admin role.
+func (s *Service) MintToken(caller *models.APIToken, userID, name string, scopes []string, expiresInDays int) (*models.APIToken, error) {
+	if err := authorizeTokenOwner(caller, userID); err != nil {
+		return nil, err
+	}
+	for _, scope := range scopes {
+		if !tokenGrants(caller.Scopes, scope) {
+			return nil, &ServiceError{
+				Code:    errcode.Forbidden,
+				Message: "caller lacks scope " + scope,
+			}
+		}
+	}
+	return s.mintToken(caller.UserID, userID, name, scopes, expiresInDays, false)
+}
+
+// MintBootstrapToken creates the first token of a user holding no live ones, the caller proved
+// the bootstrap secret instead of a token
+func (s *Service) MintBootstrapToken(userID, name string, scopes []string, expiresInDays int) (*models.APIToken, error) {
+	if _, err := s.GetUser(userID); err != nil {
+		return nil, err
+	}
+	tokens, err := s.storage.ListAPITokens(userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for _, token := range tokens {
+		if token.RevokedAt == nil && now.Before(token.ExpiresAt) {
+			return nil, &ServiceError{
+				Code:    errcode.Forbidden,
+				Message: "user already has a token, bootstrap is only for the first one",
+			}
+		}
+	}
+	return s.mintToken(userID, userID, name, scopes, expiresInDays, true)
+}
+
+// mintToken creates the token, the secret is returned only here. Expiry defaults to 90 days.
+func (s *Service) mintToken(actorID, userID, name string, scopes []string, expiresInDays int, bootstrap bool) (*models.APIToken, error) {
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxTokenNameLength {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("name is required and must be at most %d characters", maxTokenNameLength),
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "at least one scope is required",
+		}
+	}
+	for _, scope := range scopes {
+		if _, ok := scopeLevel[scope]; !ok {
+			return nil, &ServiceError{
+				Code:    errcode.InvalidRequest,
+				Message: "unknown scope " + scope,
+			}
+		}
+	}
+	if slices.Contains(scopes, ScopeAdmin) && user.Role != RoleAdmin {
+		return nil, &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "only admin can mint admin tokens",
+		}
+	}
+	if expiresInDays == 0 {
+		expiresInDays = defaultTokenTTLDays
+	}
+	if expiresInDays < 0 || expiresInDays > maxTokenTTLDays {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: fmt.Sprintf("expires_in_days must be between 1 and %d", maxTokenTTLDays),
+		}
+	}
+	
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	
+	now := time.Now().UTC().Truncate(time.Second)
+	token := &models.APIToken{
+		UserID:    userID,
+		Name:      name,
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(scopes))),
+		ExpiresAt: now.AddDate(0, 0, expiresInDays),
+		CreatedAt: now,
+		Token:     tokenPrefix + hex.EncodeToString(secret),
+	}
+	if err := s.storage.CreateAPIToken(token, hashToken(token.Token)); err != nil {
+		return nil, err
+	}
+	
+	details := map[string]interface{}{"token_id": token.TokenID, "user_id": userID, "name": name, "scopes": token.Scopes}
+	if bootstrap {
+		details["bootstrap"] = true
+	}
+	if err := s.audit(actorID, AuditTokenMint, "", details); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// ListTokens returns user's tokens without secrets, to the user or an admin-scoped caller
+func (s *Service) ListTokens(caller *models.APIToken, userID string) ([]models.APIToken, error) {
+	if err := authorizeTokenOwner(caller, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetUser(userID); err != nil {
+		return nil, err
+	}
+	return s.storage.ListAPITokens(userID)
+}
+
+// RevokeToken disables user's token, revoked tokens stay listed. Same callers as ListTokens.
+func (s *Service) RevokeToken(caller *models.APIToken, userID string, tokenID int64) error {
+	if err := authorizeTokenOwner(caller, userID); err != nil {
+		return err
+	}
+	revoked, err := s.storage.RevokeAPIToken(userID, tokenID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return &ServiceError{
+			Code:    errcode.NotFound,
+			Message: "active token not found",
+		}
+	}
+	details := map[string]interface{}{"token_id": tokenID, "user_id": userID}
+	return s.audit(caller.UserID, AuditTokenRevoke, "", details)
+}
+
+// AuthenticateToken resolves the secret to a live token that grants the scope
+func (s *Service) AuthenticateToken(raw, scope string) (*models.APIToken, error) {
+	if !strings.HasPrefix(raw, tokenPrefix) {
+		return nil, &ServiceError{
+			Code:    errcode.Unauthorized,
+			Message: "invalid token",
+		}
+	}
+	
+	token, err := s.storage.GetAPITokenByHash(hashToken(raw))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if token == nil || token.RevokedAt != nil || !now.Before(token.ExpiresAt) {
+		return nil, &ServiceError{
+			Code:    errcode.Unauthorized,
+			Message: "invalid, revoked or expired token",
+		}
+	}
+	if !tokenGrants(token.Scopes, scope) {
+		return nil, &ServiceError{
+			Code:    errcode.Forbidden,
+			Message: "token lacks scope " + scope,
+		}
+	}
+	
+	// last use is informational, a write per request isn't worth it
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenTouchGranularity {
+		if err := s.storage.TouchAPIToken(token.TokenID, now); err != nil {
+			return nil, err
+		}
+	}
+	return token, nil
+}
+
+// AuthenticateCertificate maps the first client certificate name linked to a user to
+// a principal with the scopes the user could mint: admin for admins, review-actions for others
+func (s *Service) AuthenticateCertificate(names []string, scope string) (*models.APIToken, error) {
+	for _, name := range names {
+		userID, err := s.linkedUser(IdentityCertificate, name)
+		if err != nil {
+			return nil, err
+		}
+		if userID == "" {
+			continue
+		}
+	
+		user, err := s.storage.GetUser(userID)
+		if err != nil || !user.IsActive {
+			return nil, &ServiceError{
+				Code:    errcode.Unauthorized,
+				Message: "certificate principal is missing or inactive",
+			}
+		}
+		scopes := []string{ScopeReviewActions}
+		if user.Role == RoleAdmin {
+			scopes = []string{ScopeAdmin}
+		}
+		if !tokenGrants(scopes, scope) {
+			return nil, &ServiceError{
+				Code:    errcode.Forbidden,
+				Message: "certificate principal lacks scope " + scope,
+			}
+		}
+		return &models.APIToken{UserID: userID, Name: name, Scopes: scopes, Certificate: name}, nil
+	}
+	return nil, &ServiceError{
+		Code:    errcode.Unauthorized,
+		Message: "client certificate is not linked to a user",
+	}
+}
+
+// AuditTokenUse attributes a state-changing request to the token and its owner
+func (s *Service) AuditTokenUse(token *models.APIToken, method, path string) error {
+	details := map[string]interface{}{"token_id": token.TokenID, "method": method, "path": path}
+	if token.Certificate != "" {
+		delete(details, "token_id")
+		details["certificate"] = token.Certificate
+	}
+	return s.audit(token.UserID, AuditTokenUse, "", details)
+}
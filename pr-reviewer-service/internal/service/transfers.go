@@ -0,0 +1,151 @@
+package service
+
+import (
+	"pr-reviewer-service/internal/errcode"
+	"pr-reviewer-service/internal/models"
+	"strings"
+)
+
+// What happens to open reviews of a user moving to another team
+const (
+	TransferReviewsKeep     = "KEEP"
+	TransferReviewsReassign = "REASSIGN"
+)
+
+// AuditTeamTransfer - user moved between teams
+const AuditTeamTransfer = "TEAM_TRANSFER"
+
+func isValidTransferReviews(policy string) bool {
+	return policy == TransferReviewsKeep || policy == TransferReviewsReassign
+}
+
+// TransferUser moves user to another team, done by admin or lead of the current team.
+// Open reviews follow openReviews or the current team's transfer_reviews setting;
+// reviews nobody can take over are kept. Dry run reports the same without changing anything.
+func (s *Service) TransferUser(actorID, userID, teamName, openReviews string, dryRun bool) (*models.TeamTransfer, error) {
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.authorizeTeam(actorID, user.TeamName); err != nil {
+		return nil, err
+	}
+	
+	teamName = strings.TrimSpace(teamName)
+	if err := s.ensureTeam(teamName); err != nil {
+		return nil, err
+	}
+	if teamName == user.TeamName {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "user is already in team " + teamName,
+		}
+	}
+	
+	if openReviews == "" {
+		settings, err := s.teamSettings(user.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		openReviews = settings.TransferReviews
+	}
+	if !isValidTransferReviews(openReviews) {
+		return nil, &ServiceError{
+			Code:    errcode.InvalidRequest,
+			Message: "open_reviews must be KEEP or REASSIGN",
+		}
+	}
+	
+	transfer := &models.TeamTransfer{
+		UserID:      userID,
+		FromTeam:    user.TeamName,
+		ToTeam:      teamName,
+		OpenReviews: openReviews,
+		DryRun:      dryRun,
+		Reassigned:  []models.ReviewerChange{},
+		Kept:        []string{},
+	}
+	
+	assignments, err := s.storage.GetOpenAssignmentsByReviewer(userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// replacements come from the reviewer's team, so reviews move before the user does
+	for _, a := range assignments {
+		if openReviews == TransferReviewsKeep {
+			transfer.Kept = append(transfer.Kept, a.PullRequestID)
+			continue
+		}
+		if dryRun {
+			change, err := s.planReassignment(a.PullRequestID, userID, user.TeamName)
+			if err != nil {
+				return nil, err
+			}
+			if change == nil {
+				transfer.Kept = append(transfer.Kept, a.PullRequestID)
+			} else {
+				transfer.Reassigned = append(transfer.Reassigned, *change)
+			}
+			continue
+		}
+		pr, newReviewerID, err := s.ReassignReviewer(a.PullRequestID, userID, false)
+		if isNoCandidate(err) {
+			transfer.Kept = append(transfer.Kept, a.PullRequestID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if pr.AssignmentFallback == NoCandidateKeep {
+			transfer.Kept = append(transfer.Kept, a.PullRequestID)
+			continue
+		}
+		transfer.Reassigned = append(transfer.Reassigned, models.ReviewerChange{
+			PullRequestID: a.PullRequestID,
+			NewReviewerID: newReviewerID,
+		})
+	}
+	
+	if dryRun {
+		return transfer, nil
+	}
+	if err := s.storage.TransferUser(userID, transfer.FromTeam, teamName); err != nil {
+		return nil, err
+	}
+	
+	details := map[string]interface{}{
+		"user_id":      userID,
+		"from_team":    transfer.FromTeam,
+		"to_team":      teamName,
+		"open_reviews": openReviews,
+		"reassigned":   len(transfer.Reassigned),
+		"kept":         transfer.Kept,
+	}
+	if err := s.audit(actorID, AuditTeamTransfer, "", details); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// planReassignment lists who could replace the reviewer, nil when nobody can
+func (s *Service) planReassignment(prID, reviewerID, teamName string) (*models.ReviewerChange, error) {
+	pr, err := s.storage.GetPullRequest(prID)
+	if err != nil {
+		return nil, err
+	}
+	
+	candidates, err := s.replacementCandidates(pr, reviewerID, teamName, nil)
+	if isNoCandidate(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	change := &models.ReviewerChange{PullRequestID: prID}
+	for _, candidate := range candidates {
+		change.Candidates = append(change.Candidates, candidate.UserID)
+	}
+	return change, nil
+}
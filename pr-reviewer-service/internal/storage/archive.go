@@ -0,0 +1,304 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ARCHIVE
+
+// createArchivePartitions adds the month partitions of both archive tables if missing
+func createArchivePartitions(tx *sql.Tx, month time.Time) error {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	
+	for _, table := range []string{"pull_requests_archive", "pr_reviewers_archive"} {
+		partition := pq.QuoteIdentifier(fmt.Sprintf("%s_%s", table, from.Format("2006_01")))
+		query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+			partition, table, from.Format("2006-01-02"), to.Format("2006-01-02"))
+		if _, err := tx.Exec(query); err != nil {
+			return fmt.Errorf("failed to create archive partition: %w", err)
+		}
+	}
+	
+	return nil
+}
+
+// archiveDependentRows copies every row referencing the PRs into pr_history_archive before the
+// delete cascades to it. Referencing tables are read from the catalog, so tables added later
+// are archived too.
+func archiveDependentRows(tx *sql.Tx, prIDs []string) error {
+	query := `
+		SELECT c.conrelid::regclass::text, array_agg(a.attname::text ORDER BY a.attname)
+		FROM pg_constraint c
+		INNER JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = ANY(c.conkey)
+		WHERE c.contype = 'f' AND c.confrelid = 'pull_requests'::regclass
+		GROUP BY c.conrelid
+		ORDER BY 1
+	`
+	
+	rows, err := tx.Query(query)
+	if err != nil {
+		return fmt.Errorf("failed to read PR dependent tables: %w", err)
+	}
+	dependents := make(map[string][]string)
+	var tables []string
+	for rows.Next() {
+		var table string
+		var columns []string
+		if err := rows.Scan(&table, pq.Array(&columns)); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan PR dependent table: %w", err)
+		}
+		tables = append(tables, table)
+		dependents[table] = columns
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("failed to close rows: %w", err)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating PR dependent tables: %w", err)
+	}
+	
+	for _, table := range tables {
+		// a row linking two PRs, e.g. a dependency, is kept under the first archived one
+		var owner, match []string
+		for _, column := range dependents[table] {
+			column = pq.QuoteIdentifier(column)
+			owner = append(owner, fmt.Sprintf("WHEN t.%s = ANY($1) THEN t.%s", column, column))
+			match = append(match, fmt.Sprintf("t.%s = ANY($1)", column))
+		}
+		copyRows := fmt.Sprintf(`
+			INSERT INTO pr_history_archive (pull_request_id, source_table, row_data)
+			SELECT CASE %s END, $2, to_jsonb(t)
+			FROM %s t
+			WHERE %s
+		`, strings.Join(owner, " "), table, strings.Join(match, " OR "))
+		if _, err := tx.Exec(copyRows, pq.Array(prIDs), table); err != nil {
+			return fmt.Errorf("failed to archive %s rows: %w", table, err)
+		}
+	}
+	
+	return nil
+}
+
+// ArchiveMergedPRs moves up to limit PRs merged before the cutoff and their reviewers into
+// monthly archive partitions, every other row referencing them is kept in pr_history_archive
+// before the PR is deleted. PRs an open PR depends on or is stacked on stay until it's merged,
+// PRs with an open follow-up review until it's done.
+func (s *PostgresStorage) ArchiveMergedPRs(mergedBefore time.Time, limit int) ([]string, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	query := `
+		SELECT pr.pull_request_id, pr.merged_at
+		FROM pull_requests pr
+		WHERE pr.status = 'MERGED' AND pr.merged_at < $1
+			AND NOT EXISTS (
+				SELECT 1 FROM pr_dependencies d
+				INNER JOIN pull_requests o ON o.pull_request_id = d.pull_request_id
+				WHERE d.depends_on_id = pr.pull_request_id AND o.status = 'OPEN'
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM pr_stacks st
+				INNER JOIN pull_requests o ON o.pull_request_id = st.pull_request_id
+				WHERE (st.base_id = pr.pull_request_id OR st.parent_id = pr.pull_request_id) AND o.status = 'OPEN'
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM pr_followups f
+				WHERE f.pull_request_id = pr.pull_request_id AND f.completed_at IS NULL
+			)
+		ORDER BY pr.merged_at
+		LIMIT $2
+		FOR UPDATE OF pr SKIP LOCKED
+	`
+	
+	rows, err := tx.Query(query, mergedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select PRs to archive: %w", err)
+	}
+	
+	var prIDs []string
+	months := make(map[time.Time]bool)
+	for rows.Next() {
+		var prID string
+		var mergedAt time.Time
+		if err := rows.Scan(&prID, &mergedAt); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan PR to archive: %w", err)
+		}
+		prIDs = append(prIDs, prID)
+		months[time.Date(mergedAt.Year(), mergedAt.Month(), 1, 0, 0, 0, 0, time.UTC)] = true
+	}
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating PRs to archive: %w", err)
+	}
+	if len(prIDs) == 0 {
+		return nil, nil
+	}
+	
+	for month := range months {
+		if err := createArchivePartitions(tx, month); err != nil {
+			return nil, err
+		}
+	}
+	
+	archivePRs := `
+		INSERT INTO pull_requests_archive (pull_request_id, pull_request_name, author_id, team_name, repository_id,
+			priority, size, reviewer_pool, created_at, merged_at, merged_by, merge_commit, merge_url, external_id, external_url)
+		SELECT pull_request_id, pull_request_name, author_id, team_name, repository_id,
+			priority, size, reviewer_pool, created_at, merged_at, merged_by, merge_commit, merge_url, external_id, external_url
+		FROM pull_requests
+		WHERE pull_request_id = ANY($1)
+	`
+	if _, err := tx.Exec(archivePRs, pq.Array(prIDs)); err != nil {
+		return nil, fmt.Errorf("failed to archive PRs: %w", err)
+	}
+	
+	archiveReviewers := `
+		INSERT INTO pr_reviewers_archive (pull_request_id, user_id, merged_at, assigned_at, status, first_action_at,
+			assignment_type, team_name, first_action_paused_seconds)
+		SELECT r.pull_request_id, r.user_id, pr.merged_at, r.assigned_at, r.status, r.first_action_at,
+			r.assignment_type, r.team_name, r.first_action_paused_seconds
+		FROM pr_reviewers r
+		INNER JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		WHERE r.pull_request_id = ANY($1)
+	`
+	if _, err := tx.Exec(archiveReviewers, pq.Array(prIDs)); err != nil {
+		return nil, fmt.Errorf("failed to archive reviewers: %w", err)
+	}
+	
+	if err := archiveDependentRows(tx, prIDs); err != nil {
+		return nil, err
+	}
+	
+	if _, err := tx.Exec("DELETE FROM pull_requests WHERE pull_request_id = ANY($1)", pq.Array(prIDs)); err != nil {
+		return nil, fmt.Errorf("failed to delete archived PRs: %w", err)
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit archival: %w", err)
+	}
+	
+	return prIDs, nil
+}
+
+// GetArchivedPullRequest returns the latest archived PR with the id, nil if there is none
+func (s *PostgresStorage) GetArchivedPullRequest(prID string) (*models.PullRequest, error) {
+	query := `
+		SELECT pull_request_id, pull_request_name, author_id, team_name, repository_id, priority, size,
+			reviewer_pool, created_at, merged_at, merged_by, merge_commit, merge_url, external_id, external_url
+		FROM pull_requests_archive
+		WHERE pull_request_id = $1
+		ORDER BY merged_at DESC
+		LIMIT 1
+	`
+	
+	pr := models.PullRequest{Status: "MERGED"}
+	err := s.db.QueryRow(query, prID).Scan(
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.AuthorID,
+		&pr.TeamName,
+		&pr.RepositoryID,
+		&pr.Priority,
+		&pr.Size,
+		&pr.ReviewerPool,
+		&pr.CreatedAt,
+		&pr.MergedAt,
+		&pr.MergedBy,
+		&pr.MergeCommit,
+		&pr.MergeURL,
+		&pr.ExternalID,
+		&pr.ExternalURL,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived pull request: %w", err)
+	}
+	
+	reviewersQuery := `
+		SELECT user_id
+		FROM pr_reviewers_archive
+		WHERE pull_request_id = $1 AND merged_at = $2
+		ORDER BY assigned_at, user_id
+	`
+	
+	rows, err := s.db.Query(reviewersQuery, prID, pr.MergedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived reviewers: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	pr.AssignedReviewers = []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan archived reviewer: %w", err)
+		}
+		pr.AssignedReviewers = append(pr.AssignedReviewers, userID)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating archived reviewers: %w", err)
+	}
+	
+	return &pr, nil
+}
+
+// GetArchivedPRHistory returns rows of other tables archived with the PR, oldest first
+func (s *PostgresStorage) GetArchivedPRHistory(prID string) ([]models.ArchivedRow, error) {
+	query := `
+		SELECT source_table, row_data, archived_at
+		FROM pr_history_archive
+		WHERE pull_request_id = $1
+		ORDER BY archived_at, id
+	`
+	
+	rows, err := s.db.Query(query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived PR history: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	history := []models.ArchivedRow{}
+	for rows.Next() {
+		var row models.ArchivedRow
+		if err := rows.Scan(&row.SourceTable, &row.Row, &row.ArchivedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan archived row: %w", err)
+		}
+		history = append(history, row)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating archived rows: %w", err)
+	}
+	
+	return history, nil
+}
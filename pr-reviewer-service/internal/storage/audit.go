@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// AUDIT
+
+func (s *pgRepos) AddAuditEntry(entry *models.AuditEntry) error {
+	details, err := json.Marshal(entry.Details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit details: %w", err)
+	}
+	if entry.Details == nil {
+		details = []byte("{}")
+	}
+	
+	query := `
+		INSERT INTO audit_log (actor_id, action, pull_request_id, details)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		RETURNING id, created_at
+	`
+	
+	err = s.db.QueryRow(query, entry.ActorID, entry.Action, entry.PullRequestID, details).
+		Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add audit entry: %w", err)
+	}
+	
+	return nil
+}
+
+// ListAuditEntries returns audit log, newest first
+func (s *PostgresStorage) ListAuditEntries(page models.Page) ([]models.AuditEntry, error) {
+	query := `
+		SELECT id, actor_id, action, pull_request_id, details, created_at
+		FROM audit_log
+		WHERE $1::timestamp IS NULL OR (created_at, id) < ($1, NULLIF($2, '')::bigint)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+	
+	after, afterID := cursorArgs(page)
+	rows, err := s.db.Query(query, after, afterID, page.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var entries []models.AuditEntry
+	for rows.Next() {
+		var entry models.AuditEntry
+		var prID sql.NullString
+		var details []byte
+		err := rows.Scan(&entry.ID, &entry.ActorID, &entry.Action, &prID, &details, &entry.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entry.PullRequestID = prID.String
+		if err := json.Unmarshal(details, &entry.Details); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal audit details: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit entries: %w", err)
+	}
+	
+	return entries, nil
+}
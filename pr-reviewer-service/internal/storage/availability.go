@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// AVAILABILITY
+
+// GetTeamMembers returns all team members, inactive ones included
+func (s *PostgresStorage) GetTeamMembers(teamName string) ([]models.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE team_name = $1
+		ORDER BY user_id
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		if err := s.scanUser(rows, &user); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating team members: %w", err)
+	}
+	
+	return users, nil
+}
+
+// GetTeamVacations returns end of the vacation each team member is on at the time,
+// the latest one if vacations overlap
+func (s *PostgresStorage) GetTeamVacations(teamName string, at time.Time) (map[string]time.Time, error) {
+	query := `
+		SELECT v.user_id, MAX(v.ends_at)
+		FROM user_vacations v
+		INNER JOIN users u ON u.user_id = v.user_id
+		WHERE u.team_name = $1
+		AND v.starts_at <= $2
+		AND v.ends_at > $2
+		GROUP BY v.user_id
+	`
+	
+	rows, err := s.db.Query(query, teamName, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team vacations: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	vacations := make(map[string]time.Time)
+	for rows.Next() {
+		var userID string
+		var endsAt time.Time
+		if err := rows.Scan(&userID, &endsAt); err != nil {
+			return nil, fmt.Errorf("failed to scan vacation: %w", err)
+		}
+		vacations[userID] = endsAt
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating team vacations: %w", err)
+	}
+	
+	return vacations, nil
+}
+
+// GetUpcomingVacations returns start of the earliest vacation of each team member
+// starting after from and no later than to
+func (s *PostgresStorage) GetUpcomingVacations(teamName string, from, to time.Time) (map[string]time.Time, error) {
+	query := `
+		SELECT v.user_id, MIN(v.starts_at)
+		FROM user_vacations v
+		INNER JOIN users u ON u.user_id = v.user_id
+		WHERE u.team_name = $1
+		AND v.starts_at > $2
+		AND v.starts_at <= $3
+		GROUP BY v.user_id
+	`
+	
+	rows, err := s.db.Query(query, teamName, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upcoming vacations: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	vacations := make(map[string]time.Time)
+	for rows.Next() {
+		var userID string
+		var startsAt time.Time
+		if err := rows.Scan(&userID, &startsAt); err != nil {
+			return nil, fmt.Errorf("failed to scan vacation: %w", err)
+		}
+		vacations[userID] = startsAt
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating upcoming vacations: %w", err)
+	}
+	
+	return vacations, nil
+}
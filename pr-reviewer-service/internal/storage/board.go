@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// BOARDS
+
+// GetTeamOpenPRs returns OPEN PRs owned by the team, oldest first, without reviewers
+func (s *PostgresStorage) GetTeamOpenPRs(teamName string) ([]models.PullRequest, error) {
+	query := `
+		SELECT pull_request_id, pull_request_name, author_id, team_name, status, priority, created_at
+		FROM pull_requests
+		WHERE team_name = $1 AND status = 'OPEN'
+		ORDER BY created_at, pull_request_id
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team open PRs: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var prs []models.PullRequest
+	for rows.Next() {
+		var pr models.PullRequest
+		err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.TeamName, &pr.Status,
+			&pr.Priority, &pr.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan PR: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating PRs: %w", err)
+	}
+	
+	return prs, nil
+}
+
+// GetTeamOpenAssignments returns reviewers of OPEN PRs owned by the team
+func (s *PostgresStorage) GetTeamOpenAssignments(teamName string) ([]models.ReviewAssignment, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, r.user_id, pr.team_name, r.assigned_at,
+			u.team_name, u.region, r.sla_paused_at, r.sla_paused_seconds
+		FROM pr_reviewers r
+		INNER JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		INNER JOIN users u ON u.user_id = r.user_id
+		WHERE pr.status = 'OPEN' AND pr.team_name = $1
+		ORDER BY r.assigned_at, r.user_id
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team assignments: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var assignments []models.ReviewAssignment
+	for rows.Next() {
+		var a models.ReviewAssignment
+		err := rows.Scan(&a.PullRequestID, &a.PullRequestName, &a.AuthorID, &a.ReviewerID, &a.TeamName, &a.AssignedAt,
+			&a.ReviewerTeam, &a.ReviewerRegion, &a.SLAPausedAt, &a.SLAPausedSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating assignments: %w", err)
+	}
+	
+	return assignments, nil
+}
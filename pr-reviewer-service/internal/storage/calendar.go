@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// VACATIONS
+
+// ReplaceVacations overwrites user's vacations of the given source that end after from
+func (s *PostgresStorage) ReplaceVacations(userID, source string, from time.Time, vacations []models.Vacation) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	_, err = tx.Exec(
+		"DELETE FROM user_vacations WHERE user_id = $1 AND source = $2 AND ends_at > $3",
+		userID, source, from,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete vacations: %w", err)
+	}
+	
+	query := `
+		INSERT INTO user_vacations (user_id, starts_at, ends_at, source, external_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	for _, v := range vacations {
+		_, err := tx.Exec(query, userID, v.StartsAt, v.EndsAt, source, v.ExternalID)
+		if err != nil {
+			return fmt.Errorf("failed to insert vacation: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit vacations: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) AddVacation(vacation *models.Vacation) error {
+	query := `
+		INSERT INTO user_vacations (user_id, starts_at, ends_at, source, external_id)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING id
+	`
+	
+	err := s.db.QueryRow(query, vacation.UserID, vacation.StartsAt, vacation.EndsAt, vacation.Source,
+		vacation.ExternalID).Scan(&vacation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to add vacation: %w", err)
+	}
+	
+	return nil
+}
+
+// GetUserVacations returns user's vacations of all sources that end after from, earliest first
+func (s *PostgresStorage) GetUserVacations(userID string, from time.Time) ([]models.Vacation, error) {
+	query := `
+		SELECT id, user_id, starts_at, ends_at, source, COALESCE(external_id, '')
+		FROM user_vacations
+		WHERE user_id = $1 AND ends_at > $2
+		ORDER BY starts_at, id
+	`
+	
+	rows, err := s.db.Query(query, userID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user vacations: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	vacations := []models.Vacation{}
+	for rows.Next() {
+		var v models.Vacation
+		if err := rows.Scan(&v.ID, &v.UserID, &v.StartsAt, &v.EndsAt, &v.Source, &v.ExternalID); err != nil {
+			return nil, fmt.Errorf("failed to scan vacation: %w", err)
+		}
+		vacations = append(vacations, v)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user vacations: %w", err)
+	}
+	
+	return vacations, nil
+}
+
+// DeleteVacation removes user's vacation of the given source, false if there is none
+func (s *PostgresStorage) DeleteVacation(userID string, id int64, source string) (bool, error) {
+	result, err := s.db.Exec("DELETE FROM user_vacations WHERE id = $1 AND user_id = $2 AND source = $3", id, userID, source)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete vacation: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+// CALENDAR
+
+func (s *PostgresStorage) SaveCalendarToken(token *models.CalendarToken) error {
+	query := `
+		INSERT INTO user_calendar_tokens (user_id, refresh_token, access_token, expires_at, last_synced_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			refresh_token = EXCLUDED.refresh_token,
+			access_token = EXCLUDED.access_token,
+			expires_at = EXCLUDED.expires_at,
+			last_synced_at = EXCLUDED.last_synced_at
+	`
+	
+	_, err := s.db.Exec(query,
+		token.UserID,
+		token.RefreshToken,
+		token.AccessToken,
+		token.ExpiresAt,
+		token.LastSyncedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save calendar token: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) GetCalendarTokens() ([]models.CalendarToken, error) {
+	query := `
+		SELECT user_id, refresh_token, access_token, expires_at, last_synced_at
+		FROM user_calendar_tokens
+		ORDER BY user_id
+	`
+	
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar tokens: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var tokens []models.CalendarToken
+	for rows.Next() {
+		var token models.CalendarToken
+		err := rows.Scan(
+			&token.UserID,
+			&token.RefreshToken,
+			&token.AccessToken,
+			&token.ExpiresAt,
+			&token.LastSyncedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan calendar token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating calendar tokens: %w", err)
+	}
+	
+	return tokens, nil
+}
+
+func (s *PostgresStorage) DeleteCalendarToken(userID string) error {
+	query := "DELETE FROM user_calendar_tokens WHERE user_id = $1"
+	
+	_, err := s.db.Exec(query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar token: %w", err)
+	}
+	
+	return nil
+}
+
+// RecordCalendarState remembers the OAuth state nonce for ttl, returns false if it was used
+// already. Expired nonces are dropped on the way.
+func (s *PostgresStorage) RecordCalendarState(nonce string, ttl time.Duration) (bool, error) {
+	query := `
+		INSERT INTO calendar_oauth_states (nonce, expires_at)
+		VALUES ($1, NOW() AT TIME ZONE 'UTC' + $2 * INTERVAL '1 second')
+		ON CONFLICT (nonce) DO UPDATE
+		SET expires_at = EXCLUDED.expires_at
+		WHERE calendar_oauth_states.expires_at <= NOW() AT TIME ZONE 'UTC'
+	`
+	
+	result, err := s.db.Exec(query, nonce, ttl.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to record calendar state: %w", err)
+	}
+	
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	
+	cleanup := `DELETE FROM calendar_oauth_states WHERE expires_at <= NOW() AT TIME ZONE 'UTC'`
+	if _, err := s.db.Exec(cleanup); err != nil {
+		return false, fmt.Errorf("failed to delete expired calendar states: %w", err)
+	}
+	
+	return affected > 0, nil
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// CAPACITY
+
+// GetOpenReviewLoads returns number of OPEN PRs each team member reviews
+func (s *PostgresStorage) GetOpenReviewLoads(teamName string) (map[string]int, error) {
+	query := `
+		SELECT u.user_id, COALESCE(l.open_reviews, 0)
+		FROM users u
+		LEFT JOIN stats_user_load l ON l.user_id = u.user_id
+		WHERE u.team_name = $1
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review loads: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	loads := make(map[string]int)
+	for rows.Next() {
+		var userID string
+		var load int
+		if err := rows.Scan(&userID, &load); err != nil {
+			return nil, fmt.Errorf("failed to scan review load: %w", err)
+		}
+		loads[userID] = load
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review loads: %w", err)
+	}
+	
+	return loads, nil
+}
+
+// SetUserMaxOpenReviews sets personal cap, nil falls back to the team cap
+func (s *PostgresStorage) SetUserMaxOpenReviews(userID string, maxOpenReviews *int) error {
+	query := "UPDATE users SET max_open_reviews = $1 WHERE user_id = $2"
+	
+	result, err := s.db.Exec(query, maxOpenReviews, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set max open reviews: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+	
+	return nil
+}
+
+// SetUserMaxDailyAssignments sets personal cooldown limit, nil falls back to the team limit
+func (s *PostgresStorage) SetUserMaxDailyAssignments(userID string, maxDailyAssignments *int) error {
+	query := "UPDATE users SET max_daily_assignments = $1 WHERE user_id = $2"
+	
+	result, err := s.db.Exec(query, maxDailyAssignments, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set max daily assignments: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+	
+	return nil
+}
+
+// CountRecentAssignments returns assignments of each team member made since the given time,
+// on any PR and of any assignment type
+func (s *PostgresStorage) CountRecentAssignments(teamName string, since time.Time) (map[string]int, error) {
+	query := `
+		SELECT r.user_id, COUNT(*)
+		FROM pr_reviewers r
+		INNER JOIN users u ON u.user_id = r.user_id
+		WHERE u.team_name = $1 AND r.assigned_at >= $2
+		GROUP BY r.user_id
+	`
+	
+	rows, err := s.db.Query(query, teamName, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent assignments: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	counts := make(map[string]int)
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan recent assignments: %w", err)
+		}
+		counts[userID] = count
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recent assignments: %w", err)
+	}
+	
+	return counts, nil
+}
+
+// CountTeamAssignmentsSince counts reviewer assignments on PRs owned by the team
+func (s *PostgresStorage) CountTeamAssignmentsSince(teamName string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM pr_reviewers r
+		INNER JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		WHERE pr.team_name = $1 AND r.assigned_at >= $2
+	`
+	
+	var count int
+	err := s.db.QueryRow(query, teamName, since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count team assignments: %w", err)
+	}
+	
+	return count, nil
+}
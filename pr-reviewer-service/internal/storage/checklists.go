@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// CHECKLISTS
+
+func (s *PostgresStorage) GetChecklistTemplate(teamName string) ([]string, error) {
+	query := "SELECT title FROM checklist_templates WHERE team_name = $1 ORDER BY position"
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checklist template: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	items := []string{}
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return nil, fmt.Errorf("failed to scan checklist item: %w", err)
+		}
+		items = append(items, title)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating checklist template: %w", err)
+	}
+	
+	return items, nil
+}
+
+// ReplaceChecklistTemplate overwrites team checklist, existing assignments keep their copies
+func (s *PostgresStorage) ReplaceChecklistTemplate(teamName string, items []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("DELETE FROM checklist_templates WHERE team_name = $1", teamName); err != nil {
+		return fmt.Errorf("failed to delete checklist template: %w", err)
+	}
+	
+	query := "INSERT INTO checklist_templates (team_name, position, title) VALUES ($1, $2, $3)"
+	for i, title := range items {
+		if _, err := tx.Exec(query, teamName, i, title); err != nil {
+			return fmt.Errorf("failed to insert checklist item: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit checklist template: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) CreateReviewChecklist(prID, userID string, items []string) error {
+	query := `
+		INSERT INTO review_checklist_items (pull_request_id, user_id, position, title)
+		VALUES ($1, $2, $3, $4)
+	`
+	for i, title := range items {
+		if _, err := s.db.Exec(query, prID, userID, i, title); err != nil {
+			return fmt.Errorf("failed to create checklist item: %w", err)
+		}
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) GetReviewChecklist(prID, userID string) ([]models.ChecklistItem, error) {
+	query := `
+		SELECT id, title, checked_at
+		FROM review_checklist_items
+		WHERE pull_request_id = $1 AND user_id = $2
+		ORDER BY position
+	`
+	
+	rows, err := s.db.Query(query, prID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review checklist: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	items := []models.ChecklistItem{}
+	for rows.Next() {
+		var item models.ChecklistItem
+		if err := rows.Scan(&item.ID, &item.Title, &item.CheckedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan checklist item: %w", err)
+		}
+		item.Checked = item.CheckedAt != nil
+		items = append(items, item)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review checklist: %w", err)
+	}
+	
+	return items, nil
+}
+
+func (s *PostgresStorage) SetChecklistItemChecked(prID, userID string, itemID int64, checked bool) error {
+	query := `
+		UPDATE review_checklist_items
+		SET checked_at = CASE WHEN $4 THEN COALESCE(checked_at, CURRENT_TIMESTAMP) END
+		WHERE id = $1 AND pull_request_id = $2 AND user_id = $3
+	`
+	
+	result, err := s.db.Exec(query, itemID, prID, userID, checked)
+	if err != nil {
+		return fmt.Errorf("failed to update checklist item: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return fmt.Errorf("checklist item not found")
+	}
+	
+	return nil
+}
+
+// CountUncheckedItems counts open checklist items of all current PR reviewers
+func (s *PostgresStorage) CountUncheckedItems(prID string) (int, error) {
+	query := "SELECT COUNT(*) FROM review_checklist_items WHERE pull_request_id = $1 AND checked_at IS NULL"
+	
+	var count int
+	if err := s.db.QueryRow(query, prID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unchecked items: %w", err)
+	}
+	
+	return count, nil
+}
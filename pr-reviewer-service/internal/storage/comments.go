@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"fmt"
+	"pr-reviewer-service/internal/models"
+)
+
+// COMMENTS
+
+func (s *PostgresStorage) AddPRComment(comment *models.PRComment) error {
+	query := `
+		INSERT INTO pr_comments (pull_request_id, author_id, body)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	
+	err := s.db.QueryRow(query, comment.PullRequestID, comment.AuthorID, comment.Body).
+		Scan(&comment.ID, &comment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add PR comment: %w", err)
+	}
+	
+	return nil
+}
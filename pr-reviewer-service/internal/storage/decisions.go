@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// REVIEW DECISIONS
+
+// AddReviewDecision appends a decision, rows are never updated or deleted
+func (s *pgRepos) AddReviewDecision(decision *models.ReviewDecision) error {
+	query := `
+		INSERT INTO review_decisions (pull_request_id, user_id, decision, revision)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, decided_at
+	`
+	
+	err := s.db.QueryRow(query, decision.PullRequestID, decision.UserID, decision.Decision, decision.Revision).
+		Scan(&decision.ID, &decision.DecidedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add review decision: %w", err)
+	}
+	
+	return nil
+}
+
+// GetReviewDecisions returns PR decisions in the order they were made
+func (s *PostgresStorage) GetReviewDecisions(prID string) ([]models.ReviewDecision, error) {
+	query := `
+		SELECT id, pull_request_id, user_id, decision, revision, decided_at
+		FROM review_decisions
+		WHERE pull_request_id = $1
+		ORDER BY decided_at, id
+	`
+	
+	rows, err := s.db.Query(query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review decisions: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	decisions := []models.ReviewDecision{}
+	for rows.Next() {
+		var d models.ReviewDecision
+		if err := rows.Scan(&d.ID, &d.PullRequestID, &d.UserID, &d.Decision, &d.Revision, &d.DecidedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review decision: %w", err)
+		}
+		decisions = append(decisions, d)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review decisions: %w", err)
+	}
+	
+	return decisions, nil
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// ASSIGNMENT DECLINES
+
+func (s *PostgresStorage) AddAssignmentDecline(decline *models.AssignmentDecline) error {
+	query := `
+		INSERT INTO assignment_declines (pull_request_id, user_id, team_name, reason, comment, replaced_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	
+	err := s.db.QueryRow(query, decline.PullRequestID, decline.UserID, decline.TeamName,
+		decline.Reason, decline.Comment, decline.ReplacedBy).Scan(&decline.ID, &decline.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add assignment decline: %w", err)
+	}
+	
+	return nil
+}
+
+// GetDeclineCounts counts team's declines since the time by reviewer and reason
+func (s *PostgresStorage) GetDeclineCounts(teamName string, since time.Time) ([]models.DeclineCount, error) {
+	query := `
+		SELECT user_id, reason, COUNT(*)
+		FROM assignment_declines
+		WHERE team_name = $1 AND created_at >= $2
+		GROUP BY user_id, reason
+		ORDER BY user_id, reason
+	`
+	
+	rows, err := s.db.Query(query, teamName, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decline counts: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var counts []models.DeclineCount
+	for rows.Next() {
+		var count models.DeclineCount
+		if err := rows.Scan(&count.UserID, &count.Reason, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan decline count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating decline counts: %w", err)
+	}
+	
+	return counts, nil
+}
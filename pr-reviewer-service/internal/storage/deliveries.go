@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// WEBHOOK DELIVERIES
+
+// RecordWebhookDelivery remembers the source's delivery for ttl, returns false if it is
+// remembered already. Expired deliveries are dropped on the way.
+func (s *PostgresStorage) RecordWebhookDelivery(source, deliveryID string, ttl time.Duration) (bool, error) {
+	query := `
+		INSERT INTO webhook_deliveries (source, delivery_id, expires_at)
+		VALUES ($1, $2, NOW() AT TIME ZONE 'UTC' + $3 * INTERVAL '1 second')
+		ON CONFLICT (source, delivery_id) DO UPDATE
+		SET expires_at = EXCLUDED.expires_at
+		WHERE webhook_deliveries.expires_at <= NOW() AT TIME ZONE 'UTC'
+	`
+	
+	result, err := s.db.Exec(query, source, deliveryID, ttl.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+	
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	
+	cleanup := `DELETE FROM webhook_deliveries WHERE expires_at <= NOW() AT TIME ZONE 'UTC'`
+	if _, err := s.db.Exec(cleanup); err != nil {
+		return false, fmt.Errorf("failed to delete expired webhook deliveries: %w", err)
+	}
+	
+	return affected > 0, nil
+}
+
+// ForgetWebhookDelivery drops the delivery so the source may send it again
+func (s *PostgresStorage) ForgetWebhookDelivery(source, deliveryID string) error {
+	query := `DELETE FROM webhook_deliveries WHERE source = $1 AND delivery_id = $2`
+	
+	if _, err := s.db.Exec(query, source, deliveryID); err != nil {
+		return fmt.Errorf("failed to forget webhook delivery: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// PR DEPENDENCIES
+
+// AddPRDependency links PR to the one it depends on, repeated links are ignored
+func (s *PostgresStorage) AddPRDependency(prID, dependsOnID string) error {
+	query := `
+		INSERT INTO pr_dependencies (pull_request_id, depends_on_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	
+	if _, err := s.db.Exec(query, prID, dependsOnID); err != nil {
+		return fmt.Errorf("failed to add PR dependency: %w", err)
+	}
+	
+	return nil
+}
+
+// RemovePRDependency returns false if there was no such link
+func (s *PostgresStorage) RemovePRDependency(prID, dependsOnID string) (bool, error) {
+	query := "DELETE FROM pr_dependencies WHERE pull_request_id = $1 AND depends_on_id = $2"
+	
+	result, err := s.db.Exec(query, prID, dependsOnID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove PR dependency: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+// GetPRDependencies returns links in both directions of the given PRs
+func (s *PostgresStorage) GetPRDependencies(prIDs []string) ([]models.PRDependency, error) {
+	if len(prIDs) == 0 {
+		return nil, nil
+	}
+	
+	query := `
+		SELECT pull_request_id, depends_on_id, created_at
+		FROM pr_dependencies
+		WHERE pull_request_id = ANY($1) OR depends_on_id = ANY($1)
+		ORDER BY pull_request_id, depends_on_id
+	`
+	
+	rows, err := s.db.Query(query, pq.Array(prIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR dependencies: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var dependencies []models.PRDependency
+	for rows.Next() {
+		var dependency models.PRDependency
+		if err := rows.Scan(&dependency.PullRequestID, &dependency.DependsOnID, &dependency.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan PR dependency: %w", err)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating PR dependencies: %w", err)
+	}
+	
+	return dependencies, nil
+}
+
+// GetOpenDependencies returns PRs the given one depends on that aren't merged yet
+func (s *PostgresStorage) GetOpenDependencies(prID string) ([]string, error) {
+	query := `
+		SELECT d.depends_on_id
+		FROM pr_dependencies d
+		INNER JOIN pull_requests pr ON pr.pull_request_id = d.depends_on_id
+		WHERE d.pull_request_id = $1 AND pr.status = 'OPEN'
+		ORDER BY d.depends_on_id
+	`
+	
+	rows, err := s.db.Query(query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open dependencies: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var open []string
+	for rows.Next() {
+		var dependsOnID string
+		if err := rows.Scan(&dependsOnID); err != nil {
+			return nil, fmt.Errorf("failed to scan open dependency: %w", err)
+		}
+		open = append(open, dependsOnID)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open dependencies: %w", err)
+	}
+	
+	return open, nil
+}
+
+// DependencyPathExists reports whether fromID depends on toID directly or transitively
+func (s *PostgresStorage) DependencyPathExists(fromID, toID string) (bool, error) {
+	query := `
+		WITH RECURSIVE reachable(pull_request_id) AS (
+			SELECT depends_on_id FROM pr_dependencies WHERE pull_request_id = $1
+			UNION
+			SELECT d.depends_on_id
+			FROM pr_dependencies d
+			INNER JOIN reachable r ON d.pull_request_id = r.pull_request_id
+		)
+		SELECT EXISTS(SELECT 1 FROM reachable WHERE pull_request_id = $2)
+	`
+	
+	var exists bool
+	if err := s.db.QueryRow(query, fromID, toID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check dependency path: %w", err)
+	}
+	
+	return exists, nil
+}
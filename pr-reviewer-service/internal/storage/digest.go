@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// DIGESTS
+
+// SetUserDigest sets local delivery hour of the daily digest, nil hour disables it
+func (s *PostgresStorage) SetUserDigest(userID string, digestHour *int, timezone string) error {
+	query := "UPDATE users SET digest_hour = $1, timezone = $2 WHERE user_id = $3"
+	
+	result, err := s.db.Exec(query, digestHour, timezone, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set user digest: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+	
+	return nil
+}
+
+// GetDigestSubscribers returns active users with digest enabled
+func (s *PostgresStorage) GetDigestSubscribers() ([]models.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE digest_hour IS NOT NULL AND is_active = true
+		ORDER BY user_id
+	`
+	
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest subscribers: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		if err := s.scanUser(rows, &user); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating digest subscribers: %w", err)
+	}
+	
+	return users, nil
+}
+
+// GetOpenAssignmentsByReviewer returns user's reviews on OPEN PRs with the team they represent
+func (s *PostgresStorage) GetOpenAssignmentsByReviewer(userID string) ([]models.ReviewAssignment, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, r.user_id,
+			COALESCE(NULLIF(r.team_name, ''), pr.team_name), r.assigned_at, u.team_name, u.region, r.sla_paused_at, r.sla_paused_seconds
+		FROM pr_reviewers r
+		INNER JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		INNER JOIN users u ON u.user_id = r.user_id
+		WHERE pr.status = 'OPEN' AND r.user_id = $1
+		ORDER BY r.assigned_at
+	`
+	
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer assignments: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var assignments []models.ReviewAssignment
+	for rows.Next() {
+		var a models.ReviewAssignment
+		err := rows.Scan(&a.PullRequestID, &a.PullRequestName, &a.AuthorID, &a.ReviewerID, &a.TeamName, &a.AssignedAt,
+			&a.ReviewerTeam, &a.ReviewerRegion, &a.SLAPausedAt, &a.SLAPausedSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating assignments: %w", err)
+	}
+	
+	return assignments, nil
+}
+
+func (s *PostgresStorage) MarkDigestSent(userID string, sentAt time.Time) error {
+	query := "UPDATE users SET last_digest_at = $1 WHERE user_id = $2"
+	
+	_, err := s.db.Exec(query, sentAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+	
+	return nil
+}
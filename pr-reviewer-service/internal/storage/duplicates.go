@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// DUPLICATE DETECTION
+
+// GetRecentPRsByAuthor returns PRs the author created since the given time, newest first
+func (s *PostgresStorage) GetRecentPRsByAuthor(authorID string, since time.Time) ([]models.PRMatch, error) {
+	query := `
+		SELECT pull_request_id, pull_request_name, repository_id, status, created_at
+		FROM pull_requests
+		WHERE author_id = $1 AND created_at >= $2
+		ORDER BY created_at DESC, pull_request_id
+	`
+	
+	rows, err := s.db.Query(query, authorID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent PRs: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var prs []models.PRMatch
+	for rows.Next() {
+		var pr models.PRMatch
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.RepositoryID, &pr.Status, &pr.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan recent PR: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recent PRs: %w", err)
+	}
+	
+	return prs, nil
+}
@@ -0,0 +1,323 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/fieldcrypt"
+	"time"
+)
+
+// PERSONAL DATA ENCRYPTION
+
+// Encrypted columns, the name is authenticated together with the value
+const (
+	columnUsername            = "users.username"
+	columnNotificationAddress = "users.notification_address"
+	columnExternalID          = "user_identities.external_id"
+)
+
+// Data key purposes
+const (
+	keyPurposeData  = "data"
+	keyPurposeIndex = "index"
+)
+
+// dataKeyMaxAge - a newer data key is created for writes, rows are re-encrypted with it on boot
+const dataKeyMaxAge = 90 * 24 * time.Hour
+
+// fieldCipher holds unwrapped data keys, it's read-only once loaded
+type fieldCipher struct {
+	activeID int64
+	keys     map[int64][]byte
+	indexKey []byte // blind index key, never rotated so lookups keep matching
+}
+
+// EnableEncryption turns on envelope encryption of usernames, notification addresses and
+// external identities. Data keys are loaded or created, keys wrapped by an older master key
+// are rewrapped by the active one, then existing rows are encrypted with the active data key.
+// Call it once before serving requests.
+func (s *PostgresStorage) EnableEncryption(keyring *fieldcrypt.Keyring) error {
+	crypt, err := s.loadDataKeys(keyring, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	s.pgRepos.crypt = crypt
+	
+	users, identities, err := s.reencryptPersonalData()
+	if err != nil {
+		return err
+	}
+	if users > 0 || identities > 0 {
+		log.Printf("Encrypted personal data of %d users and %d identities with data key %d", users, identities, crypt.activeID)
+	}
+	return nil
+}
+
+// loadDataKeys reads data keys under a table lock, so instances booting together agree on
+// the index key and the active data key
+func (s *PostgresStorage) loadDataKeys(keyring *fieldcrypt.Keyring, now time.Time) (*fieldCipher, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("LOCK TABLE data_keys IN EXCLUSIVE MODE"); err != nil {
+		return nil, fmt.Errorf("failed to lock data keys: %w", err)
+	}
+	
+	type dataKey struct {
+		id          int64
+		purpose     string
+		masterKeyID string
+		wrapped     []byte
+		createdAt   time.Time
+	}
+	rows, err := tx.Query("SELECT key_id, purpose, master_key_id, wrapped_key, created_at FROM data_keys ORDER BY key_id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data keys: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var stored []dataKey
+	for rows.Next() {
+		var key dataKey
+		if err := rows.Scan(&key.id, &key.purpose, &key.masterKeyID, &key.wrapped, &key.createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan data key: %w", err)
+		}
+		stored = append(stored, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating data keys: %w", err)
+	}
+	
+	crypt := &fieldCipher{keys: make(map[int64][]byte, len(stored))}
+	var activeCreatedAt time.Time
+	for _, key := range stored {
+		plain, err := keyring.Unwrap(key.masterKeyID, key.wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unwrap data key %d: %w", key.id, err)
+		}
+		if key.masterKeyID != keyring.ActiveID() {
+			wrapped, err := keyring.Wrap(plain)
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewrap data key %d: %w", key.id, err)
+			}
+			if _, err := tx.Exec("UPDATE data_keys SET master_key_id = $1, wrapped_key = $2 WHERE key_id = $3",
+				keyring.ActiveID(), wrapped, key.id); err != nil {
+				return nil, fmt.Errorf("failed to rewrap data key %d: %w", key.id, err)
+			}
+		}
+	
+		if key.purpose == keyPurposeIndex {
+			crypt.indexKey = plain
+			continue
+		}
+		crypt.keys[key.id] = plain
+		crypt.activeID = key.id
+		activeCreatedAt = key.createdAt
+	}
+	
+	if crypt.indexKey == nil {
+		if _, crypt.indexKey, err = createDataKey(tx, keyring, keyPurposeIndex); err != nil {
+			return nil, err
+		}
+	}
+	if crypt.activeID == 0 || now.Sub(activeCreatedAt) >= dataKeyMaxAge {
+		id, key, err := createDataKey(tx, keyring, keyPurposeData)
+		if err != nil {
+			return nil, err
+		}
+		crypt.keys[id] = key
+		crypt.activeID = id
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit data keys: %w", err)
+	}
+	return crypt, nil
+}
+
+func createDataKey(tx *sql.Tx, keyring *fieldcrypt.Keyring, purpose string) (int64, []byte, error) {
+	key, err := fieldcrypt.NewDataKey()
+	if err != nil {
+		return 0, nil, err
+	}
+	wrapped, err := keyring.Wrap(key)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	
+	var id int64
+	err = tx.QueryRow("INSERT INTO data_keys (purpose, master_key_id, wrapped_key) VALUES ($1, $2, $3) RETURNING key_id",
+		purpose, keyring.ActiveID(), wrapped).Scan(&id)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create data key: %w", err)
+	}
+	return id, key, nil
+}
+
+// sealPII encrypts the column value with the active data key, plaintext without encryption
+func (s *pgRepos) sealPII(column, value string) (string, error) {
+	if s.crypt == nil {
+		return value, nil
+	}
+	sealed, err := fieldcrypt.Encrypt(s.crypt.activeID, s.crypt.keys[s.crypt.activeID], column, value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt %s: %w", column, err)
+	}
+	return sealed, nil
+}
+
+// openPII decrypts the stored column value, plaintext left from before encryption passes as is
+func (s *pgRepos) openPII(column, stored string) (string, error) {
+	keyID, encrypted := fieldcrypt.KeyID(stored)
+	if !encrypted {
+		return stored, nil
+	}
+	if s.crypt == nil {
+		return "", fmt.Errorf("%s is encrypted but encryption isn't enabled", column)
+	}
+	key, ok := s.crypt.keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("%s is encrypted with %w %d", column, fieldcrypt.ErrUnknownKey, keyID)
+	}
+	plain, err := fieldcrypt.Decrypt(key, column, stored)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s: %w", column, err)
+	}
+	return plain, nil
+}
+
+// identityKey - what external_id holds: the blind index with encryption, the id itself without
+func (s *pgRepos) identityKey(provider, externalID string) string {
+	if s.crypt == nil {
+		return externalID
+	}
+	return fieldcrypt.BlindIndex(s.crypt.indexKey, provider+":"+externalID)
+}
+
+// stale reports whether the stored value is plaintext or encrypted with an older data key
+func (c *fieldCipher) stale(stored string) bool {
+	keyID, encrypted := fieldcrypt.KeyID(stored)
+	return !encrypted || keyID != c.activeID
+}
+
+// reencryptPersonalData rewrites plaintext and older-key values with the active data key,
+// each row is updated only if it didn't change meanwhile
+func (s *PostgresStorage) reencryptPersonalData() (int, int, error) {
+	type userRow struct {
+		userID, username, address string
+	}
+	var users []userRow
+	err := s.eachRow("SELECT user_id, username, notification_address FROM users", func(rows *sql.Rows) error {
+		var row userRow
+		if err := rows.Scan(&row.userID, &row.username, &row.address); err != nil {
+			return err
+		}
+		if s.crypt.stale(row.username) || (row.address != "" && s.crypt.stale(row.address)) {
+			users = append(users, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read users for encryption: %w", err)
+	}
+	for _, row := range users {
+		username, err := s.reseal(columnUsername, row.username)
+		if err != nil {
+			return 0, 0, err
+		}
+		address := row.address
+		if address != "" {
+			if address, err = s.reseal(columnNotificationAddress, row.address); err != nil {
+				return 0, 0, err
+			}
+		}
+		_, err = s.db.Exec(`
+			UPDATE users SET username = $1, notification_address = $2
+			WHERE user_id = $3 AND username = $4 AND notification_address = $5
+		`, username, address, row.userID, row.username, row.address)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to encrypt user: %w", err)
+		}
+	}
+	
+	type identityRow struct {
+		provider, externalID string
+		encrypted            sql.NullString
+	}
+	var identities []identityRow
+	err = s.eachRow("SELECT provider, external_id, external_id_enc FROM user_identities", func(rows *sql.Rows) error {
+		var row identityRow
+		if err := rows.Scan(&row.provider, &row.externalID, &row.encrypted); err != nil {
+			return err
+		}
+		if !row.encrypted.Valid || s.crypt.stale(row.encrypted.String) {
+			identities = append(identities, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read identities for encryption: %w", err)
+	}
+	for _, row := range identities {
+		plain := row.externalID
+		if row.encrypted.Valid {
+			if plain, err = s.openPII(columnExternalID, row.encrypted.String); err != nil {
+				return 0, 0, err
+			}
+		}
+		sealed, err := s.sealPII(columnExternalID, plain)
+		if err != nil {
+			return 0, 0, err
+		}
+		_, err = s.db.Exec(`
+			UPDATE user_identities SET external_id = $1, external_id_enc = $2
+			WHERE provider = $3 AND external_id = $4
+		`, s.identityKey(row.provider, plain), sealed, row.provider, row.externalID)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to encrypt identity: %w", err)
+		}
+	}
+	
+	return len(users), len(identities), nil
+}
+
+// reseal decrypts the value if needed and encrypts it with the active data key
+func (s *PostgresStorage) reseal(column, stored string) (string, error) {
+	plain, err := s.openPII(column, stored)
+	if err != nil {
+		return "", err
+	}
+	return s.sealPII(column, plain)
+}
+
+// eachRow runs the query and calls scan for every row
+func (s *PostgresStorage) eachRow(query string, scan func(rows *sql.Rows) error) error {
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,298 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// TEAM LEADS
+
+// GetTeamLeads returns active members with the lead role
+func (s *PostgresStorage) GetTeamLeads(teamName string) ([]models.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE team_name = $1
+		AND role = 'lead'
+		AND is_active = true
+		ORDER BY user_id
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team leads: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		err := s.scanUser(rows, &user)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating team leads: %w", err)
+	}
+	
+	return users, nil
+}
+
+// ESCALATIONS
+
+func (s *PostgresStorage) GetEscalationRules(teamName string) ([]models.EscalationRule, error) {
+	query := `
+		SELECT id, team_name, overdue_hours, action
+		FROM escalation_rules
+		WHERE team_name = $1
+		ORDER BY overdue_hours, action
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escalation rules: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var rules []models.EscalationRule
+	for rows.Next() {
+		var rule models.EscalationRule
+		err := rows.Scan(&rule.ID, &rule.TeamName, &rule.OverdueHours, &rule.Action)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan escalation rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating escalation rules: %w", err)
+	}
+	
+	return rules, nil
+}
+
+// ReplaceEscalationRules overwrites all rules of the team
+func (s *PostgresStorage) ReplaceEscalationRules(teamName string, rules []models.EscalationRule) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("DELETE FROM escalation_rules WHERE team_name = $1", teamName); err != nil {
+		return fmt.Errorf("failed to delete escalation rules: %w", err)
+	}
+	
+	query := `
+		INSERT INTO escalation_rules (team_name, overdue_hours, action)
+		VALUES ($1, $2, $3)
+	`
+	for _, rule := range rules {
+		if _, err := tx.Exec(query, teamName, rule.OverdueHours, rule.Action); err != nil {
+			return fmt.Errorf("failed to insert escalation rule: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit escalation rules: %w", err)
+	}
+	
+	return nil
+}
+
+// GetOpenAssignments returns reviewers of all OPEN PRs with the team each of them represents
+func (s *PostgresStorage) GetOpenAssignments() ([]models.ReviewAssignment, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, r.user_id,
+			COALESCE(NULLIF(r.team_name, ''), pr.team_name), r.assigned_at, u.team_name, u.region, r.sla_paused_at, r.sla_paused_seconds
+		FROM pr_reviewers r
+		INNER JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		INNER JOIN users u ON u.user_id = r.user_id
+		WHERE pr.status = 'OPEN'
+		ORDER BY r.assigned_at
+	`
+	
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open assignments: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var assignments []models.ReviewAssignment
+	for rows.Next() {
+		var a models.ReviewAssignment
+		err := rows.Scan(&a.PullRequestID, &a.PullRequestName, &a.AuthorID, &a.ReviewerID, &a.TeamName, &a.AssignedAt,
+			&a.ReviewerTeam, &a.ReviewerRegion, &a.SLAPausedAt, &a.SLAPausedSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating assignments: %w", err)
+	}
+	
+	return assignments, nil
+}
+
+// EscalationApplied reports whether the rule was already applied to this assignment
+func (s *PostgresStorage) EscalationApplied(prID, userID string, ruleID int64) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM pr_escalations
+			WHERE pull_request_id = $1 AND user_id = $2 AND rule_id = $3
+		)
+	`
+	
+	var applied bool
+	if err := s.db.QueryRow(query, prID, userID, ruleID).Scan(&applied); err != nil {
+		return false, fmt.Errorf("failed to check escalation: %w", err)
+	}
+	
+	return applied, nil
+}
+
+// RecordEscalation returns false if the rule was already applied to this assignment
+func (s *PostgresStorage) RecordEscalation(prID, userID string, ruleID int64) (bool, error) {
+	query := `
+		INSERT INTO pr_escalations (pull_request_id, user_id, rule_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+	
+	result, err := s.db.Exec(query, prID, userID, ruleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to record escalation: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+// GetStalledPRs returns OPEN PRs without approvals older than their team's lead escalation
+// threshold that weren't escalated to the lead yet
+func (s *PostgresStorage) GetStalledPRs(now time.Time) ([]models.StalledPR, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.team_name, pr.created_at,
+			ts.lead_escalation_hours
+		FROM pull_requests pr
+		INNER JOIN team_settings ts ON ts.team_name = pr.team_name
+		WHERE pr.status = 'OPEN'
+		AND ts.lead_escalation_hours IS NOT NULL
+		AND pr.created_at + make_interval(hours => ts.lead_escalation_hours) <= $1
+		AND NOT EXISTS (
+			SELECT 1 FROM pr_reviewers r
+			WHERE r.pull_request_id = pr.pull_request_id AND r.status = 'APPROVED'
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM pr_lead_escalations e WHERE e.pull_request_id = pr.pull_request_id
+		)
+		ORDER BY pr.created_at
+	`
+	
+	rows, err := s.db.Query(query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stalled PRs: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var prs []models.StalledPR
+	for rows.Next() {
+		var pr models.StalledPR
+		err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.TeamName, &pr.CreatedAt,
+			&pr.ThresholdHours)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stalled PR: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stalled PRs: %w", err)
+	}
+	
+	return prs, nil
+}
+
+// RecordLeadEscalation returns false if PR was already escalated to the lead
+func (s *PostgresStorage) RecordLeadEscalation(prID string) (bool, error) {
+	query := "INSERT INTO pr_lead_escalations (pull_request_id) VALUES ($1) ON CONFLICT DO NOTHING"
+	
+	result, err := s.db.Exec(query, prID)
+	if err != nil {
+		return false, fmt.Errorf("failed to record lead escalation: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+// SLA BREACHES
+
+// RecordSLABreach returns false if the breach of this assignment was already alerted
+func (s *PostgresStorage) RecordSLABreach(prID, userID string, assignedAt time.Time) (bool, error) {
+	query := `
+		INSERT INTO sla_breaches (pull_request_id, user_id, assigned_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+	
+	result, err := s.db.Exec(query, prID, userID, assignedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to record SLA breach: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+// DeleteSLABreach forgets the breach so the alert is retried
+func (s *PostgresStorage) DeleteSLABreach(prID, userID string, assignedAt time.Time) error {
+	query := "DELETE FROM sla_breaches WHERE pull_request_id = $1 AND user_id = $2 AND assigned_at = $3"
+	
+	_, err := s.db.Exec(query, prID, userID, assignedAt)
+	if err != nil {
+		return fmt.Errorf("failed to delete SLA breach: %w", err)
+	}
+	
+	return nil
+}
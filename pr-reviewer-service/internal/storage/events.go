@@ -0,0 +1,360 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// TIMELINE
+
+func (s *pgRepos) AddPREvent(event *models.PREvent) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+	if event.Payload == nil {
+		payload = []byte("{}")
+	}
+	
+	query := `
+		INSERT INTO pr_events (pull_request_id, event_type, actor_id, payload)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		RETURNING id, created_at
+	`
+	
+	err = s.db.QueryRow(query, event.PullRequestID, event.EventType, event.ActorID, payload).
+		Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add PR event: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) GetPREvents(prID string) ([]models.PREvent, error) {
+	query := `
+		SELECT id, pull_request_id, event_type, actor_id, payload, created_at
+		FROM pr_events
+		WHERE pull_request_id = $1
+		ORDER BY created_at, id
+	`
+	
+	rows, err := s.db.Query(query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR events: %w", err)
+	}
+	return scanPREvents(rows)
+}
+
+// GetPRTimeline returns a page of PR events in chronological order
+func (s *PostgresStorage) GetPRTimeline(prID string, page models.Page) ([]models.PREvent, error) {
+	query := `
+		SELECT id, pull_request_id, event_type, actor_id, payload, created_at
+		FROM pr_events
+		WHERE pull_request_id = $1
+			AND ($2::timestamp IS NULL OR (created_at, id) > ($2, NULLIF($3, '')::bigint))
+		ORDER BY created_at, id
+		LIMIT $4
+	`
+	
+	after, afterID := cursorArgs(page)
+	rows, err := s.db.Query(query, prID, after, afterID, page.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR timeline: %w", err)
+	}
+	return scanPREvents(rows)
+}
+
+// ListPREvents pages through events matching the filter in id order
+func (s *PostgresStorage) ListPREvents(filter models.PREventFilter, afterID int64, limit int) ([]models.PREvent, error) {
+	query := `
+		SELECT id, pull_request_id, event_type, actor_id, payload, created_at
+		FROM pr_events
+		WHERE id > $1
+			AND ($2 = '' OR pull_request_id = $2)
+			AND ($3::timestamp IS NULL OR created_at >= $3)
+			AND ($4::timestamp IS NULL OR created_at < $4)
+		ORDER BY id
+		LIMIT $5
+	`
+	
+	var from, to *time.Time
+	if !filter.From.IsZero() {
+		from = &filter.From
+	}
+	if !filter.To.IsZero() {
+		to = &filter.To
+	}
+	
+	rows, err := s.db.Query(query, afterID, filter.PullRequestID, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PR events: %w", err)
+	}
+	return scanPREvents(rows)
+}
+
+func scanPREvents(rows *sql.Rows) ([]models.PREvent, error) {
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var events []models.PREvent
+	for rows.Next() {
+		var event models.PREvent
+		var actorID sql.NullString
+		var payload []byte
+		err := rows.Scan(&event.ID, &event.PullRequestID, &event.EventType, &actorID, &payload, &event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan PR event: %w", err)
+		}
+		event.ActorID = actorID.String
+		if err := json.Unmarshal(payload, &event.Payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event payload: %w", err)
+		}
+		events = append(events, event)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating PR events: %w", err)
+	}
+	
+	return events, nil
+}
+
+// NOTIFICATIONS
+
+func (s *PostgresStorage) CreateNotification(notification *models.Notification) error {
+	query := `
+		INSERT INTO notifications (user_id, kind, pull_request_id, message, deliver_after, delivered_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)
+		RETURNING id, created_at
+	`
+	
+	err := s.db.QueryRow(query,
+		notification.UserID,
+		notification.Kind,
+		notification.PullRequestID,
+		notification.Message,
+		notification.DeliverAfter,
+		notification.DeliveredAt,
+	).Scan(&notification.ID, &notification.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	
+	return nil
+}
+
+// GetNotifications returns delivered notifications, cursor is (delivered_at, id)
+func (s *PostgresStorage) GetNotifications(userID string, page models.Page) ([]models.Notification, error) {
+	query := `
+		SELECT id, user_id, kind, pull_request_id, message, created_at, delivered_at, read_at
+		FROM notifications
+		WHERE user_id = $1 AND delivered_at IS NOT NULL
+			AND ($2::timestamp IS NULL OR (delivered_at, id) < ($2, NULLIF($3, '')::bigint))
+		ORDER BY delivered_at DESC, id DESC
+		LIMIT $4
+	`
+	
+	after, afterID := cursorArgs(page)
+	rows, err := s.db.Query(query, userID, after, afterID, page.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var notifications []models.Notification
+	for rows.Next() {
+		var n models.Notification
+		var prID sql.NullString
+		err := rows.Scan(&n.ID, &n.UserID, &n.Kind, &prID, &n.Message, &n.CreatedAt, &n.DeliveredAt, &n.ReadAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		n.PullRequestID = prID.String
+		notifications = append(notifications, n)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notifications: %w", err)
+	}
+	
+	return notifications, nil
+}
+
+// ReleaseDeferredNotifications delivers notifications held back by quiet hours and returns them
+func (s *PostgresStorage) ReleaseDeferredNotifications(now time.Time) ([]models.Notification, error) {
+	query := `
+		UPDATE notifications
+		SET delivered_at = $1
+		WHERE delivered_at IS NULL AND deliver_after <= $1
+		RETURNING id, user_id, kind, pull_request_id, message, created_at, delivered_at, read_at
+	`
+	
+	rows, err := s.db.Query(query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release deferred notifications: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var released []models.Notification
+	for rows.Next() {
+		var n models.Notification
+		var prID sql.NullString
+		err := rows.Scan(&n.ID, &n.UserID, &n.Kind, &prID, &n.Message, &n.CreatedAt, &n.DeliveredAt, &n.ReadAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		n.PullRequestID = prID.String
+		released = append(released, n)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating released notifications: %w", err)
+	}
+	
+	return released, nil
+}
+
+func (s *PostgresStorage) SetUserQuietHours(userID string, start, end *int) error {
+	query := "UPDATE users SET quiet_hours_start = $1, quiet_hours_end = $2 WHERE user_id = $3"
+	
+	result, err := s.db.Exec(query, start, end, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set quiet hours: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+	
+	return nil
+}
+
+// ReplacePRProjection overwrites PR row and its reviewers with the folded state
+func (s *PostgresStorage) ReplacePRProjection(state *models.PRState) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	pr := state.PullRequest
+	// PR_CREATED events without team_name predate repositories, such PRs belong to the author's team
+	query := `
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, team_name, repository_id,
+			status, priority, size, reviewer_pool, created_at, merged_at, merged_by, merge_commit, merge_url, external_id, external_url)
+		VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), (SELECT team_name FROM users WHERE user_id = $3)), $5, $6, $7, $8, $9, $10, $11,
+			$12, $13, $14, $15, $16)
+		ON CONFLICT (pull_request_id)
+		DO UPDATE SET
+			pull_request_name = EXCLUDED.pull_request_name,
+			team_name = EXCLUDED.team_name,
+			repository_id = EXCLUDED.repository_id,
+			status = EXCLUDED.status,
+			priority = EXCLUDED.priority,
+			size = EXCLUDED.size,
+			reviewer_pool = EXCLUDED.reviewer_pool,
+			created_at = EXCLUDED.created_at,
+			merged_at = EXCLUDED.merged_at,
+			merged_by = EXCLUDED.merged_by,
+			merge_commit = EXCLUDED.merge_commit,
+			merge_url = EXCLUDED.merge_url,
+			external_id = EXCLUDED.external_id,
+			external_url = EXCLUDED.external_url
+	`
+	_, err = tx.Exec(query, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.TeamName, pr.RepositoryID,
+		pr.Status, pr.Priority, pr.Size, pr.ReviewerPool, pr.CreatedAt, pr.MergedAt, pr.MergedBy, pr.MergeCommit, pr.MergeURL,
+		pr.ExternalID, pr.ExternalURL)
+	if err != nil {
+		return fmt.Errorf("failed to save PR projection: %w", err)
+	}
+	
+	keep := make(map[string]bool, len(state.Reviewers))
+	for _, r := range state.Reviewers {
+		keep[r.UserID] = true
+	}
+	
+	rows, err := tx.Query("SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1", pr.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("failed to get reviewers: %w", err)
+	}
+	var stale []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			if closeErr := rows.Close(); closeErr != nil {
+				log.Printf("Failed to close rows: %v", closeErr)
+			}
+			return fmt.Errorf("failed to scan reviewer: %w", err)
+		}
+		if !keep[userID] {
+			stale = append(stale, userID)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("failed to close reviewer rows: %w", err)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating reviewers: %w", err)
+	}
+	
+	for _, userID := range stale {
+		_, err := tx.Exec("DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2", pr.PullRequestID, userID)
+		if err != nil {
+			return fmt.Errorf("failed to remove reviewer: %w", err)
+		}
+	}
+	
+	query = `
+		INSERT INTO pr_reviewers (pull_request_id, user_id, status, assignment_type, team_name, assigned_at, first_action_at,
+			sla_paused_at, sla_paused_seconds, first_action_paused_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (pull_request_id, user_id)
+		DO UPDATE SET
+			status = EXCLUDED.status,
+			assignment_type = EXCLUDED.assignment_type,
+			team_name = EXCLUDED.team_name,
+			assigned_at = EXCLUDED.assigned_at,
+			first_action_at = EXCLUDED.first_action_at,
+			sla_paused_at = EXCLUDED.sla_paused_at,
+			sla_paused_seconds = EXCLUDED.sla_paused_seconds,
+			first_action_paused_seconds = EXCLUDED.first_action_paused_seconds
+	`
+	for _, r := range state.Reviewers {
+		_, err := tx.Exec(query, pr.PullRequestID, r.UserID, r.Status, r.AssignmentType, r.TeamName, r.AssignedAt, r.FirstActionAt,
+			r.SLAPausedAt, r.SLAPausedSeconds, r.FirstActionPausedSeconds)
+		if err != nil {
+			return fmt.Errorf("failed to save reviewer projection: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit PR projection: %w", err)
+	}
+	
+	return nil
+}
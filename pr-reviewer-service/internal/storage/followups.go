@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// FOLLOW-UP REVIEWS
+
+const followUpColumns = "id, pull_request_id, user_id, reason, requested_by, sla_hours, created_at, due_at, completed_at"
+
+func scanFollowUp(row rowScanner, followUp *models.FollowUp) error {
+	return row.Scan(
+		&followUp.ID,
+		&followUp.PullRequestID,
+		&followUp.UserID,
+		&followUp.Reason,
+		&followUp.RequestedBy,
+		&followUp.SLAHours,
+		&followUp.CreatedAt,
+		&followUp.DueAt,
+		&followUp.CompletedAt,
+	)
+}
+
+// CreateFollowUp fills id and created_at, false if the PR already has an open follow-up
+func (s *PostgresStorage) CreateFollowUp(followUp *models.FollowUp) (bool, error) {
+	query := `
+		INSERT INTO pr_followups (pull_request_id, user_id, reason, requested_by, sla_hours, created_at, due_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (pull_request_id) WHERE completed_at IS NULL DO NOTHING
+		RETURNING id
+	`
+	
+	err := s.db.QueryRow(query, followUp.PullRequestID, followUp.UserID, followUp.Reason, followUp.RequestedBy,
+		followUp.SLAHours, followUp.CreatedAt, followUp.DueAt).Scan(&followUp.ID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create follow-up: %w", err)
+	}
+	
+	return true, nil
+}
+
+// CompleteFollowUp closes the open follow-up of the reviewer, nil if there is none
+func (s *PostgresStorage) CompleteFollowUp(prID, userID string, at time.Time) (*models.FollowUp, error) {
+	query := `
+		UPDATE pr_followups
+		SET completed_at = $3
+		WHERE pull_request_id = $1 AND user_id = $2 AND completed_at IS NULL
+		RETURNING ` + followUpColumns
+	
+	var followUp models.FollowUp
+	err := scanFollowUp(s.db.QueryRow(query, prID, userID, at), &followUp)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete follow-up: %w", err)
+	}
+	
+	return &followUp, nil
+}
+
+// GetUserFollowUps returns follow-ups of the reviewer, soonest due first
+func (s *PostgresStorage) GetUserFollowUps(userID string, includeCompleted bool) ([]models.FollowUp, error) {
+	query := `
+		SELECT ` + followUpColumns + `
+		FROM pr_followups
+		WHERE user_id = $1 AND ($2 OR completed_at IS NULL)
+		ORDER BY due_at, id
+	`
+	
+	return s.queryFollowUps(query, userID, includeCompleted)
+}
+
+// GetOverdueFollowUps returns open follow-ups past their deadline whose reviewer wasn't alerted yet
+func (s *PostgresStorage) GetOverdueFollowUps(now time.Time) ([]models.FollowUp, error) {
+	query := `
+		SELECT ` + followUpColumns + `
+		FROM pr_followups
+		WHERE completed_at IS NULL AND breach_notified_at IS NULL AND due_at <= $1
+		ORDER BY due_at, id
+	`
+	
+	return s.queryFollowUps(query, now)
+}
+
+func (s *PostgresStorage) queryFollowUps(query string, args ...interface{}) ([]models.FollowUp, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow-ups: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var followUps []models.FollowUp
+	for rows.Next() {
+		var followUp models.FollowUp
+		if err := scanFollowUp(rows, &followUp); err != nil {
+			return nil, fmt.Errorf("failed to scan follow-up: %w", err)
+		}
+		followUps = append(followUps, followUp)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating follow-ups: %w", err)
+	}
+	
+	return followUps, nil
+}
+
+// MarkFollowUpBreached returns false if the breach was already recorded, e.g. by another instance
+func (s *PostgresStorage) MarkFollowUpBreached(id int64, at time.Time) (bool, error) {
+	query := "UPDATE pr_followups SET breach_notified_at = $2 WHERE id = $1 AND breach_notified_at IS NULL"
+	
+	result, err := s.db.Exec(query, id, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark follow-up breach: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+// UnmarkFollowUpBreached lets the next run alert again after a failed notification
+func (s *PostgresStorage) UnmarkFollowUpBreached(id int64) error {
+	if _, err := s.db.Exec("UPDATE pr_followups SET breach_notified_at = NULL WHERE id = $1", id); err != nil {
+		return fmt.Errorf("failed to unmark follow-up breach: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// HANDOFFS
+
+// CreateHandoff returns false if reviewer already has a pending handoff on the PR
+func (s *PostgresStorage) CreateHandoff(handoff *models.ReviewerHandoff) (bool, error) {
+	query := `
+		INSERT INTO reviewer_handoffs (pull_request_id, from_user_id, to_user_id, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (pull_request_id, from_user_id) WHERE status = 'PENDING' DO NOTHING
+		RETURNING id, status, created_at
+	`
+	
+	err := s.db.QueryRow(query, handoff.PullRequestID, handoff.FromUserID, handoff.ToUserID, handoff.ExpiresAt).
+		Scan(&handoff.ID, &handoff.Status, &handoff.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create handoff: %w", err)
+	}
+	
+	return true, nil
+}
+
+func (s *PostgresStorage) GetHandoff(id int64) (*models.ReviewerHandoff, error) {
+	query := `
+		SELECT id, pull_request_id, from_user_id, to_user_id, status, created_at, expires_at, resolved_at
+		FROM reviewer_handoffs
+		WHERE id = $1
+	`
+	
+	var handoff models.ReviewerHandoff
+	err := s.db.QueryRow(query, id).Scan(
+		&handoff.ID,
+		&handoff.PullRequestID,
+		&handoff.FromUserID,
+		&handoff.ToUserID,
+		&handoff.Status,
+		&handoff.CreatedAt,
+		&handoff.ExpiresAt,
+		&handoff.ResolvedAt,
+	)
+	
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("handoff not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get handoff: %w", err)
+	}
+	
+	return &handoff, nil
+}
+
+// CompleteHandoff accepts pending handoff and swaps reviewers in one transaction
+func (s *PostgresStorage) CompleteHandoff(id int64, now time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	query := `
+		UPDATE reviewer_handoffs
+		SET status = 'ACCEPTED', resolved_at = $2
+		WHERE id = $1 AND status = 'PENDING' AND expires_at > $2
+		RETURNING pull_request_id, from_user_id, to_user_id
+	`
+	
+	var prID, fromUserID, toUserID string
+	err = tx.QueryRow(query, id, now).Scan(&prID, &fromUserID, &toUserID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("handoff is not pending")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to accept handoff: %w", err)
+	}
+	
+	// the new reviewer represents the same team as the one handing off
+	var teamName string
+	err = tx.QueryRow(
+		"DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2 RETURNING team_name",
+		prID, fromUserID,
+	).Scan(&teamName)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("reviewer not assigned")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove reviewer: %w", err)
+	}
+	
+	_, err = tx.Exec(
+		"INSERT INTO pr_reviewers (pull_request_id, user_id, team_name, assignment_type) VALUES ($1, $2, $3, 'MANUAL')",
+		prID, toUserID, teamName,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add reviewer: %w", err)
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit handoff: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) ResolveHandoff(id int64, status string, now time.Time) error {
+	query := `
+		UPDATE reviewer_handoffs
+		SET status = $2, resolved_at = $3
+		WHERE id = $1 AND status = 'PENDING'
+	`
+	
+	result, err := s.db.Exec(query, id, status, now)
+	if err != nil {
+		return fmt.Errorf("failed to resolve handoff: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return fmt.Errorf("handoff is not pending")
+	}
+	
+	return nil
+}
+
+// ExpireHandoffs closes pending handoffs past their expiry, returns their count
+func (s *PostgresStorage) ExpireHandoffs(now time.Time) (int64, error) {
+	query := `
+		UPDATE reviewer_handoffs
+		SET status = 'EXPIRED', resolved_at = $1
+		WHERE status = 'PENDING' AND expires_at <= $1
+	`
+	
+	result, err := s.db.Exec(query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire handoffs: %w", err)
+	}
+	
+	expired, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return expired, nil
+}
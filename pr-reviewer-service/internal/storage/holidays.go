@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// HOLIDAYS
+
+// GetTeamHolidays returns team calendar ordered by date and region
+func (s *PostgresStorage) GetTeamHolidays(teamName string) ([]models.Holiday, error) {
+	query := `
+		SELECT holiday_date, region, name
+		FROM team_holidays
+		WHERE team_name = $1
+		ORDER BY holiday_date, region
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team holidays: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	holidays := []models.Holiday{}
+	for rows.Next() {
+		var holiday models.Holiday
+		var date time.Time
+		if err := rows.Scan(&date, &holiday.Region, &holiday.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan team holiday: %w", err)
+		}
+		holiday.Date = date.Format("2006-01-02")
+		holidays = append(holidays, holiday)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating team holidays: %w", err)
+	}
+	
+	return holidays, nil
+}
+
+// ReplaceTeamHolidays overwrites the whole team calendar
+func (s *PostgresStorage) ReplaceTeamHolidays(teamName string, holidays []models.Holiday) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("DELETE FROM team_holidays WHERE team_name = $1", teamName); err != nil {
+		return fmt.Errorf("failed to delete team holidays: %w", err)
+	}
+	
+	query := "INSERT INTO team_holidays (team_name, holiday_date, region, name) VALUES ($1, $2, $3, $4)"
+	for _, holiday := range holidays {
+		if _, err := tx.Exec(query, teamName, holiday.Date, holiday.Region, holiday.Name); err != nil {
+			return fmt.Errorf("failed to insert team holiday: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit team holidays: %w", err)
+	}
+	
+	return nil
+}
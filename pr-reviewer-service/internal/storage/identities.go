@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"cmp"
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"slices"
+)
+
+// EXTERNAL IDENTITIES
+// With encryption external_id holds a blind index for lookups and external_id_enc the id itself
+
+// LinkUserIdentity links external account to the user, false if it already belongs to someone
+func (s *PostgresStorage) LinkUserIdentity(identity *models.UserIdentity) (bool, error) {
+	var encrypted sql.NullString
+	if s.crypt != nil {
+		sealed, err := s.sealPII(columnExternalID, identity.ExternalID)
+		if err != nil {
+			return false, err
+		}
+		encrypted = sql.NullString{String: sealed, Valid: true}
+	}
+	
+	query := `
+		INSERT INTO user_identities (provider, external_id, external_id_enc, user_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, external_id) DO NOTHING
+		RETURNING created_at
+	`
+	
+	key := s.identityKey(identity.Provider, identity.ExternalID)
+	err := s.db.QueryRow(query, identity.Provider, key, encrypted, identity.UserID).Scan(&identity.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to link user identity: %w", err)
+	}
+	
+	return true, nil
+}
+
+// UnlinkUserIdentity removes user's external account, false if the user doesn't have it
+func (s *PostgresStorage) UnlinkUserIdentity(userID, provider, externalID string) (bool, error) {
+	query := "DELETE FROM user_identities WHERE provider = $1 AND external_id = $2 AND user_id = $3"
+	
+	result, err := s.db.Exec(query, provider, s.identityKey(provider, externalID), userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unlink user identity: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+// ResolveIdentity returns id of the user owning external account, empty if it isn't linked
+func (s *PostgresStorage) ResolveIdentity(provider, externalID string) (string, error) {
+	query := "SELECT user_id FROM user_identities WHERE provider = $1 AND external_id = $2"
+	
+	var userID string
+	err := s.db.QueryRow(query, provider, s.identityKey(provider, externalID)).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve identity: %w", err)
+	}
+	
+	return userID, nil
+}
+
+// GetUserIdentities returns external accounts linked to the user
+func (s *PostgresStorage) GetUserIdentities(userID string) ([]models.UserIdentity, error) {
+	query := `
+		SELECT user_id, provider, external_id, external_id_enc, created_at
+		FROM user_identities
+		WHERE user_id = $1
+	`
+	
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user identities: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	identities := []models.UserIdentity{}
+	for rows.Next() {
+		var identity models.UserIdentity
+		var encrypted sql.NullString
+		if err := rows.Scan(&identity.UserID, &identity.Provider, &identity.ExternalID, &encrypted, &identity.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan user identity: %w", err)
+		}
+		if encrypted.Valid {
+			if identity.ExternalID, err = s.openPII(columnExternalID, encrypted.String); err != nil {
+				return nil, err
+			}
+		}
+		identities = append(identities, identity)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user identities: %w", err)
+	}
+	
+	// sorted here, blind indexes don't keep the order of external ids
+	slices.SortFunc(identities, func(a, b models.UserIdentity) int {
+		return cmp.Or(cmp.Compare(a.Provider, b.Provider), cmp.Compare(a.ExternalID, b.ExternalID))
+	})
+	
+	return identities, nil
+}
@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// JOB QUEUE
+
+const jobColumns = "id, kind, payload, status, attempts, max_attempts, run_at, locked_by, locked_until, " +
+	"last_error, created_at, updated_at"
+
+func (s *PostgresStorage) EnqueueJob(job *models.Job) error {
+	payload := []byte(job.Payload)
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now().UTC()
+	}
+	if job.MaxAttempts == 0 {
+		job.MaxAttempts = 5
+	}
+	
+	query := `
+		INSERT INTO jobs (kind, payload, max_attempts, run_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, status, created_at, updated_at
+	`
+	
+	err := s.db.QueryRow(query, job.Kind, payload, job.MaxAttempts, job.RunAt).
+		Scan(&job.ID, &job.Status, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+	
+	return nil
+}
+
+// ClaimJobs locks due jobs for the worker, RUNNING jobs whose lock expired are claimed again
+func (s *PostgresStorage) ClaimJobs(workerID string, limit int, visibility time.Duration) ([]models.Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'RUNNING',
+			attempts = attempts + 1,
+			locked_by = $1,
+			locked_until = NOW() AT TIME ZONE 'UTC' + $3 * INTERVAL '1 second',
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE (status = 'QUEUED' AND run_at <= NOW() AT TIME ZONE 'UTC')
+				OR (status = 'RUNNING' AND locked_until <= NOW() AT TIME ZONE 'UTC')
+			ORDER BY run_at, id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+	
+	rows, err := s.db.Query(query, workerID, limit, visibility.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim jobs: %w", err)
+	}
+	return scanJobs(rows)
+}
+
+func (s *PostgresStorage) CompleteJob(id int64) error {
+	query := `
+		UPDATE jobs
+		SET status = 'DONE', locked_by = NULL, locked_until = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+	
+	if _, err := s.db.Exec(query, id); err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	
+	return nil
+}
+
+// FailJob schedules retry at retryAt, nil retryAt moves the job to DEAD
+func (s *PostgresStorage) FailJob(id int64, lastError string, retryAt *time.Time) error {
+	query := `
+		UPDATE jobs
+		SET status = CASE WHEN $3::timestamp IS NULL THEN 'DEAD' ELSE 'QUEUED' END,
+			run_at = COALESCE($3, run_at),
+			last_error = $2,
+			locked_by = NULL,
+			locked_until = NULL,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+	
+	if _, err := s.db.Exec(query, id, lastError, retryAt); err != nil {
+		return fmt.Errorf("failed to fail job: %w", err)
+	}
+	
+	return nil
+}
+
+// ListJobs returns newest jobs, empty status matches all
+func (s *PostgresStorage) ListJobs(status string, page models.Page) ([]models.Job, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE ($1 = '' OR status = $1)
+			AND ($2::timestamp IS NULL OR (created_at, id) < ($2, NULLIF($3, '')::bigint))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4
+	`
+	
+	after, afterID := cursorArgs(page)
+	rows, err := s.db.Query(query, status, after, afterID, page.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	return scanJobs(rows)
+}
+
+// RetryJob requeues a DEAD job with a fresh attempt budget
+func (s *PostgresStorage) RetryJob(id int64) error {
+	query := `
+		UPDATE jobs
+		SET status = 'QUEUED', attempts = 0, run_at = NOW() AT TIME ZONE 'UTC', updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'DEAD'
+	`
+	
+	result, err := s.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to retry job: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return fmt.Errorf("dead job not found")
+	}
+	
+	return nil
+}
+
+func scanJobs(rows *sql.Rows) ([]models.Job, error) {
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	jobs := []models.Job{}
+	for rows.Next() {
+		var job models.Job
+		var payload []byte
+		var lockedBy, lastError sql.NullString
+		err := rows.Scan(
+			&job.ID,
+			&job.Kind,
+			&payload,
+			&job.Status,
+			&job.Attempts,
+			&job.MaxAttempts,
+			&job.RunAt,
+			&lockedBy,
+			&job.LockedUntil,
+			&lastError,
+			&job.CreatedAt,
+			&job.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		job.Payload = payload
+		job.LockedBy = lockedBy.String
+		job.LastError = lastError.String
+		jobs = append(jobs, job)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating jobs: %w", err)
+	}
+	
+	return jobs, nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// LEADERBOARD
+
+// GetLeaderboardEntries aggregates assignments since the time of team members who had any,
+// archived PRs included. Rank and username are left to the caller.
+func (s *PostgresStorage) GetLeaderboardEntries(teamName string, since time.Time) ([]models.LeaderboardEntry, error) {
+	query := `
+		WITH reviews AS (
+			SELECT pull_request_id, user_id, assigned_at, status, first_action_at, first_action_paused_seconds
+			FROM pr_reviewers
+			WHERE assigned_at >= $2
+			UNION ALL
+			SELECT pull_request_id, user_id, assigned_at, status, first_action_at, first_action_paused_seconds
+			FROM pr_reviewers_archive
+			WHERE assigned_at >= $2
+		),
+		reverted AS (
+			SELECT DISTINCT reverted_id AS pull_request_id, unnest(reviewers) AS user_id
+			FROM pr_reverts
+		)
+		SELECT u.user_id,
+			COUNT(*) FILTER (WHERE r.status = 'APPROVED'),
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY ` + firstReviewSeconds + `),
+			COUNT(*) FILTER (WHERE r.status = 'APPROVED' AND x.pull_request_id IS NOT NULL)
+		FROM users u
+		INNER JOIN reviews r ON r.user_id = u.user_id
+		LEFT JOIN reverted x ON x.pull_request_id = r.pull_request_id AND x.user_id = r.user_id
+		WHERE u.team_name = $1
+		GROUP BY u.user_id
+		ORDER BY u.user_id
+	`
+	
+	rows, err := s.db.Query(query, teamName, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	entries := []models.LeaderboardEntry{}
+	for rows.Next() {
+		var (
+			entry  models.LeaderboardEntry
+			median sql.NullFloat64
+		)
+		if err := rows.Scan(&entry.UserID, &entry.CompletedReviews, &median, &entry.RevertedApprovals); err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard entry: %w", err)
+		}
+		if median.Valid {
+			entry.MedianTurnaroundSeconds = &median.Float64
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating leaderboard: %w", err)
+	}
+	
+	return entries, nil
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// LEASES
+
+// AcquireLease takes or renews the named lease, returns false while another holder owns an unexpired one
+func (s *PostgresStorage) AcquireLease(name, holder string, ttl time.Duration) (bool, error) {
+	query := `
+		INSERT INTO scheduler_leases (name, holder, expires_at)
+		VALUES ($1, $2, NOW() AT TIME ZONE 'UTC' + $3 * INTERVAL '1 second')
+		ON CONFLICT (name) DO UPDATE
+		SET holder = EXCLUDED.holder, expires_at = EXCLUDED.expires_at
+		WHERE scheduler_leases.holder = EXCLUDED.holder
+			OR scheduler_leases.expires_at <= NOW() AT TIME ZONE 'UTC'
+	`
+	
+	result, err := s.db.Exec(query, name, holder, ttl.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease: %w", err)
+	}
+	
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	
+	return affected > 0, nil
+}
+
+// ReleaseLease drops the lease if it is still owned by holder
+func (s *PostgresStorage) ReleaseLease(name, holder string) error {
+	query := `DELETE FROM scheduler_leases WHERE name = $1 AND holder = $2`
+	
+	if _, err := s.db.Exec(query, name, holder); err != nil {
+		return fmt.Errorf("failed to release lease: %w", err)
+	}
+	
+	return nil
+}
+
+// RecordJobRun keeps the latest run of each scheduler job
+func (s *PostgresStorage) RecordJobRun(name, holder string, startedAt, finishedAt time.Time, runErr string) error {
+	query := `
+		INSERT INTO scheduler_runs (job_name, holder, started_at, finished_at, last_error)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (job_name) DO UPDATE
+		SET holder = EXCLUDED.holder,
+			started_at = EXCLUDED.started_at,
+			finished_at = EXCLUDED.finished_at,
+			last_error = EXCLUDED.last_error
+	`
+	
+	if _, err := s.db.Exec(query, name, holder, startedAt, finishedAt, runErr); err != nil {
+		return fmt.Errorf("failed to record job run: %w", err)
+	}
+	
+	return nil
+}
+
+// GetSchedulerRuns returns the latest run of every job that ran, by job name
+func (s *PostgresStorage) GetSchedulerRuns() ([]models.SchedulerRun, error) {
+	query := `
+		SELECT job_name, holder, started_at, finished_at, last_error
+		FROM scheduler_runs
+		ORDER BY job_name
+	`
+	
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler runs: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	runs := []models.SchedulerRun{}
+	for rows.Next() {
+		var run models.SchedulerRun
+		if err := rows.Scan(&run.JobName, &run.Holder, &run.StartedAt, &run.FinishedAt, &run.LastError); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduler run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating scheduler runs: %w", err)
+	}
+	
+	return runs, nil
+}
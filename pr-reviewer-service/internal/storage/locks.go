@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"log"
+)
+
+// LOCKS
+
+// advisory lock namespace, first key of pg_advisory_lock(int, int)
+const lockClassTeam = 1
+
+// WithTeamLock runs fn holding a session advisory lock for the team,
+// serializing reviewer selection across goroutines and service instances
+func (s *PostgresStorage) WithTeamLock(teamName string, fn func() error) error {
+	ctx := context.Background()
+	
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get lock connection: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Printf("Failed to close lock connection: %v", err)
+		}
+	}()
+	
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1, hashtext($2))`, lockClassTeam, teamName); err != nil {
+		return fmt.Errorf("failed to lock team: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1, hashtext($2))`, lockClassTeam, teamName); err != nil {
+			log.Printf("Failed to unlock team %s: %v", teamName, err)
+			// drop the connection so the lock is not kept by the pool
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+	
+	return fn()
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// ORG STRUCTURE
+
+// ImportUserManagers sets managers of the listed users in one transaction, other users keep theirs
+func (s *PostgresStorage) ImportUserManagers(managers []models.UserManager) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	for _, entry := range managers {
+		result, err := tx.Exec("UPDATE users SET manager_id = $1 WHERE user_id = $2", entry.ManagerID, entry.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to set user manager: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("user %s not found", entry.UserID)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user managers: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+)
+
+// SLA PAUSES
+
+// PauseAssignmentSLA stops the reviewer's SLA clock, returns false if it is already paused
+func (s *PostgresStorage) PauseAssignmentSLA(prID, userID string) (bool, error) {
+	query := `
+		UPDATE pr_reviewers
+		SET sla_paused_at = CURRENT_TIMESTAMP
+		WHERE pull_request_id = $1 AND user_id = $2 AND sla_paused_at IS NULL
+	`
+	
+	result, err := s.db.Exec(query, prID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to pause assignment SLA: %w", err)
+	}
+	
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	
+	return affected > 0, nil
+}
+
+// ResumeAssignmentSLA restarts paused SLA clocks of the PR, adding the paused time to the assignment.
+// Empty userID resumes every reviewer, returns the resumed ones.
+func (s *PostgresStorage) ResumeAssignmentSLA(prID, userID string) ([]string, error) {
+	query := `
+		UPDATE pr_reviewers
+		SET sla_paused_seconds = sla_paused_seconds
+				+ GREATEST(EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - sla_paused_at)), 0)::BIGINT,
+			sla_paused_at = NULL
+		WHERE pull_request_id = $1 AND ($2 = '' OR user_id = $2) AND sla_paused_at IS NOT NULL
+		RETURNING user_id
+	`
+	
+	rows, err := s.db.Query(query, prID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume assignment SLA: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	resumed := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan resumed reviewer: %w", err)
+		}
+		resumed = append(resumed, id)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating resumed reviewers: %w", err)
+	}
+	
+	return resumed, nil
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// PENDING ASSIGNMENTS
+
+// QueuePendingAssignment adds reviewers to the PR's queue entry for the team, keeping its place in line
+func (s *PostgresStorage) QueuePendingAssignment(prID, teamName string, reviewers int) error {
+	query := `
+		INSERT INTO pending_assignments (pull_request_id, team_name, reviewers)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (pull_request_id, team_name)
+		DO UPDATE SET reviewers = pending_assignments.reviewers + EXCLUDED.reviewers
+	`
+	
+	if _, err := s.db.Exec(query, prID, teamName, reviewers); err != nil {
+		return fmt.Errorf("failed to queue pending assignment: %w", err)
+	}
+	
+	return nil
+}
+
+// GetPendingAssignments returns queue entries of OPEN PRs, most urgent and oldest first.
+// Empty teamName returns all teams.
+func (s *PostgresStorage) GetPendingAssignments(teamName string) ([]models.PendingAssignment, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pa.team_name, pr.priority,
+			pa.reviewers, pa.queued_at
+		FROM pending_assignments pa
+		INNER JOIN pull_requests pr ON pr.pull_request_id = pa.pull_request_id
+		WHERE pr.status = 'OPEN'
+		AND ($1 = '' OR pa.team_name = $1)
+		ORDER BY pr.priority_rank DESC, pa.queued_at, pa.pull_request_id
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending assignments: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	pending := []models.PendingAssignment{}
+	for rows.Next() {
+		var p models.PendingAssignment
+		err := rows.Scan(&p.PullRequestID, &p.PullRequestName, &p.AuthorID, &p.TeamName, &p.Priority,
+			&p.Reviewers, &p.QueuedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending assignment: %w", err)
+		}
+		pending = append(pending, p)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending assignments: %w", err)
+	}
+	
+	return pending, nil
+}
+
+// ResolvePendingAssignment takes assigned reviewers off the entry, a fully served entry is removed
+func (s *PostgresStorage) ResolvePendingAssignment(prID, teamName string, assigned int) error {
+	query := `
+		UPDATE pending_assignments SET reviewers = reviewers - $3
+		WHERE pull_request_id = $1 AND team_name = $2 AND reviewers > $3
+	`
+	
+	result, err := s.db.Exec(query, prID, teamName, assigned)
+	if err != nil {
+		return fmt.Errorf("failed to resolve pending assignment: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+	
+	_, err = s.db.Exec("DELETE FROM pending_assignments WHERE pull_request_id = $1 AND team_name = $2", prID, teamName)
+	if err != nil {
+		return fmt.Errorf("failed to delete pending assignment: %w", err)
+	}
+	
+	return nil
+}
+
+// DeletePendingAssignments drops the whole PR from the queue
+func (s *pgRepos) DeletePendingAssignments(prID string) error {
+	if _, err := s.db.Exec("DELETE FROM pending_assignments WHERE pull_request_id = $1", prID); err != nil {
+		return fmt.Errorf("failed to delete pending assignments: %w", err)
+	}
+	
+	return nil
+}
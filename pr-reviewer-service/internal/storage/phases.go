@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// REVIEW PHASES
+
+// CreateReviewPhase puts PR into the first pass, secondPhaseReviewers are assigned after an approval
+func (s *PostgresStorage) CreateReviewPhase(prID string, secondPhaseReviewers int) error {
+	query := "INSERT INTO review_phases (pull_request_id, second_phase_reviewers) VALUES ($1, $2)"
+	
+	if _, err := s.db.Exec(query, prID, secondPhaseReviewers); err != nil {
+		return fmt.Errorf("failed to create review phase: %w", err)
+	}
+	
+	return nil
+}
+
+// GetPendingPhases returns OPEN PRs in the first pass with at least one approval
+func (s *PostgresStorage) GetPendingPhases() ([]models.PendingPhase, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.team_name, pr.priority, ph.second_phase_reviewers
+		FROM review_phases ph
+		INNER JOIN pull_requests pr ON pr.pull_request_id = ph.pull_request_id
+		WHERE ph.phase = 1 AND pr.status = 'OPEN'
+		AND EXISTS (
+			SELECT 1 FROM pr_reviewers r
+			WHERE r.pull_request_id = ph.pull_request_id AND r.status = 'APPROVED'
+		)
+		ORDER BY ph.created_at
+	`
+	
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending phases: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var phases []models.PendingPhase
+	for rows.Next() {
+		var p models.PendingPhase
+		if err := rows.Scan(&p.PullRequestID, &p.PullRequestName, &p.AuthorID, &p.TeamName, &p.Priority, &p.SecondPhaseReviewers); err != nil {
+			return nil, fmt.Errorf("failed to scan pending phase: %w", err)
+		}
+		phases = append(phases, p)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending phases: %w", err)
+	}
+	
+	return phases, nil
+}
+
+// AdvanceReviewPhase moves PR to the second pass, false if another run already did it
+func (s *PostgresStorage) AdvanceReviewPhase(prID string, now time.Time) (bool, error) {
+	query := "UPDATE review_phases SET phase = 2, advanced_at = $2 WHERE pull_request_id = $1 AND phase = 1"
+	
+	result, err := s.db.Exec(query, prID, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to advance review phase: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// ASSIGNMENT RULES
+
+// GetAssignmentRules returns team rules in the order they are applied
+func (s *PostgresStorage) GetAssignmentRules(teamName string) ([]models.AssignmentRule, error) {
+	query := `
+		SELECT name, conditions, action, reviewer_pool, user_id, count
+		FROM assignment_rules
+		WHERE team_name = $1
+		ORDER BY position
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment rules: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	rules := []models.AssignmentRule{}
+	for rows.Next() {
+		var rule models.AssignmentRule
+		var conditions []byte
+		if err := rows.Scan(&rule.Name, &conditions, &rule.Action, &rule.ReviewerPool, &rule.UserID, &rule.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment rule: %w", err)
+		}
+		if err := json.Unmarshal(conditions, &rule.Conditions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rule conditions: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating assignment rules: %w", err)
+	}
+	
+	return rules, nil
+}
+
+func (s *PostgresStorage) ReplaceAssignmentRules(teamName string, rules []models.AssignmentRule) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("DELETE FROM assignment_rules WHERE team_name = $1", teamName); err != nil {
+		return fmt.Errorf("failed to delete assignment rules: %w", err)
+	}
+	
+	query := `
+		INSERT INTO assignment_rules (team_name, name, position, conditions, action, reviewer_pool, user_id, count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	for i, rule := range rules {
+		conditions, err := json.Marshal(rule.Conditions)
+		if err != nil {
+			return fmt.Errorf("failed to marshal rule conditions: %w", err)
+		}
+		if _, err := tx.Exec(query, teamName, rule.Name, i, conditions, rule.Action, rule.ReviewerPool,
+			rule.UserID, rule.Count); err != nil {
+			return fmt.Errorf("failed to insert assignment rule: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit assignment rules: %w", err)
+	}
+	
+	return nil
+}
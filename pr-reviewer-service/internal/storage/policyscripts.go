@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// POLICY SCRIPTS
+
+// GetTeamPolicy returns nil if team has no policy
+func (s *PostgresStorage) GetTeamPolicy(teamName string) (*models.TeamPolicy, error) {
+	query := `
+		SELECT team_name, source, updated_by, updated_at
+		FROM team_policies
+		WHERE team_name = $1
+	`
+	
+	var policy models.TeamPolicy
+	var updatedAt time.Time
+	err := s.db.QueryRow(query, teamName).Scan(&policy.TeamName, &policy.Source, &policy.UpdatedBy, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team policy: %w", err)
+	}
+	policy.UpdatedAt = &updatedAt
+	
+	return &policy, nil
+}
+
+func (s *pgRepos) SaveTeamPolicy(policy *models.TeamPolicy) error {
+	query := `
+		INSERT INTO team_policies (team_name, source, updated_by, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		ON CONFLICT (team_name)
+		DO UPDATE SET
+			source = EXCLUDED.source,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+	
+	var updatedAt time.Time
+	if err := s.db.QueryRow(query, policy.TeamName, policy.Source, policy.UpdatedBy).Scan(&updatedAt); err != nil {
+		return fmt.Errorf("failed to save team policy: %w", err)
+	}
+	policy.UpdatedAt = &updatedAt
+	
+	return nil
+}
+
+func (s *PostgresStorage) DeleteTeamPolicy(teamName string) error {
+	if _, err := s.db.Exec("DELETE FROM team_policies WHERE team_name = $1", teamName); err != nil {
+		return fmt.Errorf("failed to delete team policy: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// REVIEWER POOLS
+
+// GetReviewerPools returns team pools ordered by name, members ordered by id
+func (s *PostgresStorage) GetReviewerPools(teamName string) ([]models.ReviewerPool, error) {
+	query := `
+		SELECT pool_name, user_id
+		FROM reviewer_pools
+		WHERE team_name = $1
+		ORDER BY pool_name, user_id
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer pools: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	pools := []models.ReviewerPool{}
+	for rows.Next() {
+		var poolName, userID string
+		if err := rows.Scan(&poolName, &userID); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer pool member: %w", err)
+		}
+		if len(pools) == 0 || pools[len(pools)-1].PoolName != poolName {
+			pools = append(pools, models.ReviewerPool{
+				TeamName: teamName,
+				PoolName: poolName,
+				Members:  []string{},
+			})
+		}
+		last := &pools[len(pools)-1]
+		last.Members = append(last.Members, userID)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviewer pools: %w", err)
+	}
+	
+	return pools, nil
+}
+
+// GetReviewerPoolMembers returns empty list for unknown pool
+func (s *PostgresStorage) GetReviewerPoolMembers(teamName, poolName string) ([]string, error) {
+	query := "SELECT user_id FROM reviewer_pools WHERE team_name = $1 AND pool_name = $2 ORDER BY user_id"
+	
+	rows, err := s.db.Query(query, teamName, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer pool members: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	members := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer pool member: %w", err)
+		}
+		members = append(members, userID)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviewer pool members: %w", err)
+	}
+	
+	return members, nil
+}
+
+// ReplaceReviewerPool overwrites pool members, empty list deletes the pool
+func (s *PostgresStorage) ReplaceReviewerPool(teamName, poolName string, userIDs []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("DELETE FROM reviewer_pools WHERE team_name = $1 AND pool_name = $2", teamName, poolName); err != nil {
+		return fmt.Errorf("failed to delete reviewer pool: %w", err)
+	}
+	
+	query := "INSERT INTO reviewer_pools (team_name, pool_name, user_id) VALUES ($1, $2, $3)"
+	for _, userID := range userIDs {
+		if _, err := tx.Exec(query, teamName, poolName, userID); err != nil {
+			return fmt.Errorf("failed to insert reviewer pool member: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reviewer pool: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,92 @@
+package storage_test
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"pr-reviewer-service/internal/storage"
+	"pr-reviewer-service/internal/storage/storagetest"
+	"pr-reviewer-service/migrations"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	_ "github.com/lib/pq"
+)
+
+// testDSNVar - database the conformance suite runs against, the test is skipped without it
+const testDSNVar = "TEST_DATABASE_URL"
+
+// TestPostgres runs the storage conformance suite, every subtest gets an empty schema of its own
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv(testDSNVar)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNVar)
+	}
+	
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := admin.Close(); err != nil {
+			t.Logf("Failed to close database: %v", err)
+		}
+	})
+	
+	var schemas atomic.Int64
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		schema := fmt.Sprintf("storagetest_%d_%d", os.Getpid(), schemas.Add(1))
+		if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+			t.Fatalf("failed to create schema: %v", err)
+		}
+		t.Cleanup(func() {
+			if _, err := admin.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+				t.Logf("Failed to drop schema %s: %v", schema, err)
+			}
+		})
+	
+		schemaDSN, err := withSearchPath(dsn, schema)
+		if err != nil {
+			t.Fatalf("invalid %s: %v", testDSNVar, err)
+		}
+		db, err := sql.Open("postgres", schemaDSN)
+		if err != nil {
+			t.Fatalf("failed to open database: %v", err)
+		}
+		_, err = db.Exec(migrations.InitSQL)
+		if closeErr := db.Close(); closeErr != nil {
+			t.Logf("Failed to close database: %v", closeErr)
+		}
+		if err != nil {
+			t.Fatalf("failed to apply schema: %v", err)
+		}
+	
+		s, err := storage.NewPostgresStorage(schemaDSN)
+		if err != nil {
+			t.Fatalf("failed to connect: %v", err)
+		}
+		t.Cleanup(func() {
+			if err := s.Close(); err != nil {
+				t.Logf("Failed to close storage: %v", err)
+			}
+		})
+		return s
+	})
+}
+
+// withSearchPath makes connections of the DSN, URL or key=value form, use the schema
+func withSearchPath(dsn, schema string) (string, error) {
+	if !strings.HasPrefix(dsn, "postgres://") && !strings.HasPrefix(dsn, "postgresql://") {
+		return dsn + " search_path=" + schema, nil
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// USER PROFILES AND ONBOARDING
+
+// UpdateUserProfile overwrites all profile fields, callers merge partial updates first
+func (s *PostgresStorage) UpdateUserProfile(userID string, profile *models.UserProfile) error {
+	username, err := s.sealPII(columnUsername, profile.Username)
+	if err != nil {
+		return err
+	}
+	address := profile.NotificationAddress
+	if address != "" {
+		if address, err = s.sealPII(columnNotificationAddress, address); err != nil {
+			return err
+		}
+	}
+	
+	query := `
+		UPDATE users
+		SET username = $2, tags = $3, timezone = $4, role = $5, notification_address = $6, locale = $7
+		WHERE user_id = $1
+	`
+	
+	tags := profile.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	result, err := s.db.Exec(query, userID, username, pq.Array(tags), profile.Timezone,
+		profile.Role, address, profile.Locale)
+	if err != nil {
+		return fmt.Errorf("failed to update user profile: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+	
+	return nil
+}
+
+// AddTeamMember creates a new user together with the event announcing it, false if the
+// user id is taken
+func (s *PostgresStorage) AddTeamMember(user *models.User, event *models.UserEvent) (bool, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+	if event.Payload == nil {
+		payload = []byte("{}")
+	}
+	username, err := s.sealPII(columnUsername, user.Username)
+	if err != nil {
+		return false, err
+	}
+	
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	result, err := tx.Exec(`
+		INSERT INTO users (user_id, username, team_name, is_active, role, region)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO NOTHING
+	`, user.UserID, username, user.TeamName, user.IsActive, user.Role, user.Region)
+	if err != nil {
+		return false, fmt.Errorf("failed to add team member: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+	
+	err = tx.QueryRow(`
+		INSERT INTO user_events (user_id, event_type, actor_id, payload)
+		VALUES ($1, $2, NULLIF($3, ''), $4)
+		RETURNING id, created_at
+	`, event.UserID, event.EventType, event.ActorID, payload).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to add user event: %w", err)
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit team member: %w", err)
+	}
+	
+	return true, nil
+}
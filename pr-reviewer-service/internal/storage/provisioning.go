@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// PROVISIONING
+
+// ListUsers returns users ordered by id, SCIM clients page by offset
+func (s *PostgresStorage) ListUsers(offset, limit int) ([]models.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		ORDER BY user_id
+		OFFSET $1 LIMIT $2
+	`
+	
+	rows, err := s.db.Query(query, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		if err := s.scanUser(rows, &user); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+	
+	return users, nil
+}
+
+func (s *PostgresStorage) CountUsers() (int, error) {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
+
+// ListTeamNames returns team names in alphabetical order
+func (s *PostgresStorage) ListTeamNames(offset, limit int) ([]string, error) {
+	rows, err := s.db.Query("SELECT team_name FROM teams ORDER BY team_name OFFSET $1 LIMIT $2", offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team names: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan team name: %w", err)
+		}
+		names = append(names, name)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating team names: %w", err)
+	}
+	
+	return names, nil
+}
+
+func (s *PostgresStorage) CountTeams() (int, error) {
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM teams").Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count teams: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// PR TEMPLATES
+
+// GetPRTemplates returns team templates in the order they are matched
+func (s *PostgresStorage) GetPRTemplates(teamName string) ([]models.PRTemplate, error) {
+	query := `
+		SELECT name, name_prefix, labels, priority, reviewer_pool
+		FROM pr_templates
+		WHERE team_name = $1
+		ORDER BY position
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR templates: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	templates := []models.PRTemplate{}
+	for rows.Next() {
+		var template models.PRTemplate
+		if err := rows.Scan(&template.Name, &template.NamePrefix, pq.Array(&template.Labels),
+			&template.Priority, &template.ReviewerPool); err != nil {
+			return nil, fmt.Errorf("failed to scan PR template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating PR templates: %w", err)
+	}
+	
+	return templates, nil
+}
+
+func (s *PostgresStorage) ReplacePRTemplates(teamName string, templates []models.PRTemplate) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("DELETE FROM pr_templates WHERE team_name = $1", teamName); err != nil {
+		return fmt.Errorf("failed to delete PR templates: %w", err)
+	}
+	
+	query := `
+		INSERT INTO pr_templates (team_name, name, position, name_prefix, labels, priority, reviewer_pool)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	for i, template := range templates {
+		labels := template.Labels
+		if labels == nil {
+			labels = []string{}
+		}
+		if _, err := tx.Exec(query, teamName, template.Name, i, template.NamePrefix, pq.Array(labels),
+			template.Priority, template.ReviewerPool); err != nil {
+			return fmt.Errorf("failed to insert PR template: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit PR templates: %w", err)
+	}
+	
+	return nil
+}
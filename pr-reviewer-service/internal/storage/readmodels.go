@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// READ MODELS
+//
+// stats_user_load and stats_team_weekly are denormalized from the PR event stream
+// so capacity checks and reports don't aggregate OLTP tables on every request.
+
+// ApplyStatsEvent updates read models with one PR event
+func (s *PostgresStorage) ApplyStatsEvent(event *models.PREvent) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	userID, _ := event.Payload["user_id"].(string)
+	oldUserID, _ := event.Payload["old_user_id"].(string)
+	newUserID, _ := event.Payload["new_user_id"].(string)
+	
+	switch event.EventType {
+	case "PR_CREATED":
+		err = bumpTeamWeekly(tx, event.PullRequestID, event.CreatedAt, 1, 0, 0, false)
+	case "REVIEWER_ASSIGNED":
+		if err = bumpUserLoad(tx, userID, 1); err == nil {
+			err = bumpTeamWeekly(tx, event.PullRequestID, event.CreatedAt, 0, 0, 1, false)
+		}
+	case "REVIEWER_REASSIGNED":
+		if err = bumpUserLoad(tx, oldUserID, -1); err == nil && newUserID != "" {
+			if err = bumpUserLoad(tx, newUserID, 1); err == nil {
+				err = bumpTeamWeekly(tx, event.PullRequestID, event.CreatedAt, 0, 0, 1, false)
+			}
+		}
+	case "PR_MERGED":
+		query := `
+			UPDATE stats_user_load
+			SET open_reviews = GREATEST(open_reviews - 1, 0)
+			WHERE user_id IN (SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1)
+		`
+		if _, err = tx.Exec(query, event.PullRequestID); err == nil {
+			err = bumpTeamWeekly(tx, event.PullRequestID, event.CreatedAt, 0, 1, 0, true)
+		}
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply stats event: %w", err)
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit stats event: %w", err)
+	}
+	
+	return nil
+}
+
+func bumpUserLoad(tx *sql.Tx, userID string, delta int) error {
+	query := `
+		INSERT INTO stats_user_load (user_id, open_reviews)
+		VALUES ($1, GREATEST($2, 0))
+		ON CONFLICT (user_id)
+		DO UPDATE SET open_reviews = GREATEST(stats_user_load.open_reviews + $2, 0)
+	`
+	_, err := tx.Exec(query, userID, delta)
+	return err
+}
+
+// bumpTeamWeekly adds counters to the week of at for the PR's owning team, merged adds turnaround
+func bumpTeamWeekly(tx *sql.Tx, prID string, at time.Time, created, merged, assignments int, withTurnaround bool) error {
+	query := `
+		INSERT INTO stats_team_weekly (team_name, week_start, prs_created, prs_merged, assignments, turnaround_hours_sum)
+		SELECT pr.team_name, date_trunc('week', $2::timestamp)::date, $3, $4, $5,
+			CASE WHEN $6 THEN EXTRACT(EPOCH FROM ($2::timestamp - pr.created_at)) / 3600 ELSE 0 END
+		FROM pull_requests pr
+		WHERE pr.pull_request_id = $1
+		ON CONFLICT (team_name, week_start)
+		DO UPDATE SET
+			prs_created = stats_team_weekly.prs_created + EXCLUDED.prs_created,
+			prs_merged = stats_team_weekly.prs_merged + EXCLUDED.prs_merged,
+			assignments = stats_team_weekly.assignments + EXCLUDED.assignments,
+			turnaround_hours_sum = stats_team_weekly.turnaround_hours_sum + EXCLUDED.turnaround_hours_sum
+	`
+	_, err := tx.Exec(query, prID, at, created, merged, assignments, withTurnaround)
+	return err
+}
+
+// RebuildReadModels recomputes read models from scratch, assignments are counted from events
+func (s *PostgresStorage) RebuildReadModels() error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("DELETE FROM stats_user_load"); err != nil {
+		return fmt.Errorf("failed to clear user load: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM stats_team_weekly"); err != nil {
+		return fmt.Errorf("failed to clear team weekly stats: %w", err)
+	}
+	
+	query := `
+		INSERT INTO stats_user_load (user_id, open_reviews)
+		SELECT r.user_id, COUNT(*)
+		FROM pr_reviewers r
+		INNER JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		WHERE pr.status = 'OPEN'
+		GROUP BY r.user_id
+	`
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("failed to rebuild user load: %w", err)
+	}
+	
+	query = `
+		INSERT INTO stats_team_weekly (team_name, week_start, prs_created, prs_merged, assignments, turnaround_hours_sum)
+		SELECT team_name, week_start, SUM(created), SUM(merged), SUM(assigned), SUM(hours)
+		FROM (
+			SELECT pr.team_name, date_trunc('week', pr.created_at)::date AS week_start,
+				1 AS created, 0 AS merged, 0 AS assigned, 0::double precision AS hours
+			FROM pull_requests pr
+			UNION ALL
+			SELECT pr.team_name, date_trunc('week', pr.merged_at)::date,
+				0, 1, 0, EXTRACT(EPOCH FROM (pr.merged_at - pr.created_at)) / 3600
+			FROM pull_requests pr
+			WHERE pr.merged_at IS NOT NULL
+			UNION ALL
+			SELECT pr.team_name, date_trunc('week', e.created_at)::date, 0, 0, 1, 0
+			FROM pr_events e
+			INNER JOIN pull_requests pr ON pr.pull_request_id = e.pull_request_id
+			WHERE e.event_type IN ('REVIEWER_ASSIGNED', 'REVIEWER_REASSIGNED')
+		) facts
+		GROUP BY team_name, week_start
+	`
+	if _, err := tx.Exec(query); err != nil {
+		return fmt.Errorf("failed to rebuild team weekly stats: %w", err)
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit read models: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// REBALANCING
+
+// GetMovableAssignments returns reviews of OPEN PRs the service assigned for the team and nobody
+// started yet, the latest first
+func (s *PostgresStorage) GetMovableAssignments(teamName string) ([]models.ReviewAssignment, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, r.user_id,
+			COALESCE(NULLIF(r.team_name, ''), pr.team_name), r.assigned_at,
+			u.team_name, u.region
+		FROM pr_reviewers r
+		INNER JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		INNER JOIN users u ON u.user_id = r.user_id
+		WHERE pr.status = 'OPEN'
+		AND COALESCE(NULLIF(r.team_name, ''), pr.team_name) = $1
+		AND r.status = 'PENDING' AND r.first_action_at IS NULL AND r.assignment_type = 'AUTO'
+		ORDER BY r.assigned_at DESC, pr.pull_request_id
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movable assignments: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var assignments []models.ReviewAssignment
+	for rows.Next() {
+		var a models.ReviewAssignment
+		err := rows.Scan(&a.PullRequestID, &a.PullRequestName, &a.AuthorID, &a.ReviewerID, &a.TeamName, &a.AssignedAt,
+			&a.ReviewerTeam, &a.ReviewerRegion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan assignment: %w", err)
+		}
+		assignments = append(assignments, a)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating assignments: %w", err)
+	}
+	
+	return assignments, nil
+}
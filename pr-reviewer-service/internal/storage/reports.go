@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// REPORTS
+
+func (s *PostgresStorage) GetTeamNames() ([]string, error) {
+	rows, err := s.db.Query("SELECT team_name FROM teams ORDER BY team_name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		names = append(names, name)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating teams: %w", err)
+	}
+	
+	return names, nil
+}
+
+// FillTeamReport sums weekly read model of the team for weeks starting within [report.From, report.To)
+func (s *PostgresStorage) FillTeamReport(report *models.TeamReport) error {
+	query := `
+		SELECT
+			COALESCE(SUM(prs_created), 0),
+			COALESCE(SUM(prs_merged), 0),
+			COALESCE(SUM(assignments), 0),
+			SUM(turnaround_hours_sum) / NULLIF(SUM(prs_merged), 0)
+		FROM stats_team_weekly
+		WHERE team_name = $1 AND week_start >= $2::date AND week_start < $3::date
+	`
+	
+	err := s.db.QueryRow(query, report.TeamName, report.From, report.To).Scan(
+		&report.PRsCreated,
+		&report.PRsMerged,
+		&report.Assignments,
+		&report.AvgTurnaroundHours,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get team report: %w", err)
+	}
+	
+	return nil
+}
+
+// GetTeamBottlenecks returns team members with the most open reviews, oldest first on ties
+func (s *PostgresStorage) GetTeamBottlenecks(teamName string, limit int) ([]models.ReviewerBottleneck, error) {
+	query := `
+		SELECT r.user_id, COUNT(*), MIN(r.assigned_at)
+		FROM pr_reviewers r
+		INNER JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		INNER JOIN users u ON u.user_id = r.user_id
+		WHERE pr.status = 'OPEN' AND u.team_name = $1
+		GROUP BY r.user_id
+		ORDER BY COUNT(*) DESC, MIN(r.assigned_at)
+		LIMIT $2
+	`
+	
+	rows, err := s.db.Query(query, teamName, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bottlenecks: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var bottlenecks []models.ReviewerBottleneck
+	for rows.Next() {
+		var b models.ReviewerBottleneck
+		if err := rows.Scan(&b.UserID, &b.OpenReviews, &b.OldestAssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan bottleneck: %w", err)
+		}
+		bottlenecks = append(bottlenecks, b)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bottlenecks: %w", err)
+	}
+	
+	return bottlenecks, nil
+}
+
+// RecordTeamReport returns false if the weekly report was already delivered
+func (s *PostgresStorage) RecordTeamReport(teamName, week string) (bool, error) {
+	query := `
+		INSERT INTO team_reports (team_name, week)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	
+	result, err := s.db.Exec(query, teamName, week)
+	if err != nil {
+		return false, fmt.Errorf("failed to record team report: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
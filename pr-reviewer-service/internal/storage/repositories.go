@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// REPOSITORIES
+
+// SaveRepository registers repo or moves it to another team
+func (s *PostgresStorage) SaveRepository(repo *models.Repository) error {
+	query := `
+		INSERT INTO repositories (repository_id, team_name)
+		VALUES ($1, $2)
+		ON CONFLICT (repository_id)
+		DO UPDATE SET team_name = EXCLUDED.team_name
+		RETURNING created_at
+	`
+	
+	if err := s.db.QueryRow(query, repo.RepositoryID, repo.TeamName).Scan(&repo.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save repository: %w", err)
+	}
+	
+	return nil
+}
+
+// GetRepository returns nil for unknown repo
+func (s *PostgresStorage) GetRepository(repositoryID string) (*models.Repository, error) {
+	query := "SELECT repository_id, team_name, created_at FROM repositories WHERE repository_id = $1"
+	
+	var repo models.Repository
+	err := s.db.QueryRow(query, repositoryID).Scan(&repo.RepositoryID, &repo.TeamName, &repo.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+	
+	return &repo, nil
+}
+
+// ListRepositories returns repos of the team, empty teamName returns all
+func (s *PostgresStorage) ListRepositories(teamName string) ([]models.Repository, error) {
+	query := `
+		SELECT repository_id, team_name, created_at
+		FROM repositories
+		WHERE $1 = '' OR team_name = $1
+		ORDER BY repository_id
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	repos := []models.Repository{}
+	for rows.Next() {
+		var repo models.Repository
+		if err := rows.Scan(&repo.RepositoryID, &repo.TeamName, &repo.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan repository: %w", err)
+		}
+		repos = append(repos, repo)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating repositories: %w", err)
+	}
+	
+	return repos, nil
+}
+
+// DeleteRepository unregisters repo, existing PRs keep their team
+func (s *PostgresStorage) DeleteRepository(repositoryID string) error {
+	result, err := s.db.Exec("DELETE FROM repositories WHERE repository_id = $1", repositoryID)
+	if err != nil {
+		return fmt.Errorf("failed to delete repository: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("repository not found")
+	}
+	
+	return nil
+}
+
+// GetPathOwners returns prefixes grouped by team, ordered by team name
+func (s *PostgresStorage) GetPathOwners(repositoryID string) ([]models.PathOwners, error) {
+	query := `
+		SELECT team_name, path_prefix
+		FROM path_owners
+		WHERE repository_id = $1
+		ORDER BY team_name, path_prefix
+	`
+	
+	rows, err := s.db.Query(query, repositoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get path owners: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	owners := []models.PathOwners{}
+	for rows.Next() {
+		var teamName, prefix string
+		if err := rows.Scan(&teamName, &prefix); err != nil {
+			return nil, fmt.Errorf("failed to scan path owner: %w", err)
+		}
+		if len(owners) == 0 || owners[len(owners)-1].TeamName != teamName {
+			owners = append(owners, models.PathOwners{
+				RepositoryID: repositoryID,
+				TeamName:     teamName,
+				Prefixes:     []string{},
+			})
+		}
+		last := &owners[len(owners)-1]
+		last.Prefixes = append(last.Prefixes, prefix)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating path owners: %w", err)
+	}
+	
+	return owners, nil
+}
+
+// ReplacePathOwners overwrites prefixes the team owns in the repository
+func (s *PostgresStorage) ReplacePathOwners(repositoryID, teamName string, prefixes []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("DELETE FROM path_owners WHERE repository_id = $1 AND team_name = $2", repositoryID, teamName); err != nil {
+		return fmt.Errorf("failed to delete path owners: %w", err)
+	}
+	
+	query := "INSERT INTO path_owners (repository_id, path_prefix, team_name) VALUES ($1, $2, $3)"
+	for _, prefix := range prefixes {
+		if _, err := tx.Exec(query, repositoryID, prefix, teamName); err != nil {
+			return fmt.Errorf("failed to insert path owner: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit path owners: %w", err)
+	}
+	
+	return nil
+}
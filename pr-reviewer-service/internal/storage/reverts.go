@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"pr-reviewer-service/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// REVERTS
+
+// SavePRRevert fills created_at, false if the PR is already marked as a revert
+func (s *PostgresStorage) SavePRRevert(revert *models.PRRevert) (bool, error) {
+	query := `
+		INSERT INTO pr_reverts (pull_request_id, reverted_id, team_name, reverted_merged_at, reviewers, marked_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (pull_request_id) DO NOTHING
+		RETURNING created_at
+	`
+	
+	err := s.db.QueryRow(query, revert.PullRequestID, revert.RevertedID, revert.TeamName, revert.RevertedMergedAt,
+		pq.Array(revert.Reviewers), revert.MarkedBy).Scan(&revert.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to save revert: %w", err)
+	}
+	
+	return true, nil
+}
+
+// GetPRRevert returns nil if the PR isn't a revert
+func (s *PostgresStorage) GetPRRevert(prID string) (*models.PRRevert, error) {
+	query := `
+		SELECT pull_request_id, reverted_id, team_name, reverted_merged_at, reviewers, marked_by, created_at
+		FROM pr_reverts
+		WHERE pull_request_id = $1
+	`
+	
+	var revert models.PRRevert
+	err := s.db.QueryRow(query, prID).Scan(&revert.PullRequestID, &revert.RevertedID, &revert.TeamName,
+		&revert.RevertedMergedAt, pq.Array(&revert.Reviewers), &revert.MarkedBy, &revert.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revert: %w", err)
+	}
+	
+	return &revert, nil
+}
+
+// GetRevertStats counts team's PRs merged since, archived ones included, and how many of them
+// were reverted. A PR reverted several times counts once.
+func (s *PostgresStorage) GetRevertStats(teamName string, since time.Time) (*models.RevertStats, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM pull_requests WHERE team_name = $1 AND status = 'MERGED' AND merged_at >= $2)
+			+ (SELECT COUNT(*) FROM pull_requests_archive WHERE team_name = $1 AND merged_at >= $2),
+			(SELECT COUNT(DISTINCT reverted_id) FROM pr_reverts WHERE team_name = $1 AND reverted_merged_at >= $2)
+	`
+	
+	var stats models.RevertStats
+	if err := s.db.QueryRow(query, teamName, since).Scan(&stats.Merged, &stats.Reverted); err != nil {
+		return nil, fmt.Errorf("failed to get revert stats: %w", err)
+	}
+	if stats.Merged > 0 {
+		stats.RevertRate = float64(stats.Reverted) / float64(stats.Merged)
+	}
+	
+	return &stats, nil
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// REVIEW TEAMS
+
+// SaveReviewTeam adds the team to the PR reviewers, for a team already there only the required approvals change
+func (s *PostgresStorage) SaveReviewTeam(team *models.ReviewTeam) error {
+	query := `
+		INSERT INTO pr_review_teams (pull_request_id, team_name, required_approvals)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (pull_request_id, team_name)
+		DO UPDATE SET required_approvals = EXCLUDED.required_approvals
+		RETURNING created_at
+	`
+	
+	err := s.db.QueryRow(query, team.PullRequestID, team.TeamName, team.RequiredApprovals).Scan(&team.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save review team: %w", err)
+	}
+	
+	return nil
+}
+
+// GetReviewTeams returns teams reviewing the PR beside the owning team, in the order they were added
+func (s *PostgresStorage) GetReviewTeams(prID string) ([]models.ReviewTeam, error) {
+	query := `
+		SELECT pull_request_id, team_name, required_approvals, created_at
+		FROM pr_review_teams
+		WHERE pull_request_id = $1
+		ORDER BY created_at, team_name
+	`
+	
+	rows, err := s.db.Query(query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review teams: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var teams []models.ReviewTeam
+	for rows.Next() {
+		var team models.ReviewTeam
+		if err := rows.Scan(&team.PullRequestID, &team.TeamName, &team.RequiredApprovals, &team.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review team: %w", err)
+		}
+		teams = append(teams, team)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review teams: %w", err)
+	}
+	
+	return teams, nil
+}
+
+// GetReviewerAssignments returns the PR reviewers with the team each of them represents
+func (s *PostgresStorage) GetReviewerAssignments(prID string) ([]models.PRReviewer, error) {
+	query := `
+		SELECT r.pull_request_id, r.user_id, r.status, r.assignment_type,
+			COALESCE(NULLIF(r.team_name, ''), pr.team_name), r.assigned_at, r.first_action_at
+		FROM pr_reviewers r
+		INNER JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		WHERE r.pull_request_id = $1
+		ORDER BY r.assigned_at, r.user_id
+	`
+	
+	rows, err := s.db.Query(query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer assignments: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var reviewers []models.PRReviewer
+	for rows.Next() {
+		var r models.PRReviewer
+		err := rows.Scan(&r.PullRequestID, &r.UserID, &r.Status, &r.AssignmentType, &r.TeamName, &r.AssignedAt, &r.FirstActionAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer assignment: %w", err)
+		}
+		reviewers = append(reviewers, r)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviewer assignments: %w", err)
+	}
+	
+	return reviewers, nil
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// RISK RULES
+
+// GetRiskRules returns team rules from the highest threshold and its signals
+func (s *PostgresStorage) GetRiskRules(teamName string) (*models.TeamRiskRules, error) {
+	teamRules := &models.TeamRiskRules{
+		TeamName: teamName,
+		Rules:    []models.RiskRule{},
+		Signals:  []models.RiskSignal{},
+	}
+	
+	query := `
+		SELECT min_score, reviewers, senior_reviewers
+		FROM team_risk_rules
+		WHERE team_name = $1
+		ORDER BY min_score DESC
+	`
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get risk rules: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	for rows.Next() {
+		var rule models.RiskRule
+		if err := rows.Scan(&rule.MinScore, &rule.Reviewers, &rule.SeniorReviewers); err != nil {
+			return nil, fmt.Errorf("failed to scan risk rule: %w", err)
+		}
+		teamRules.Rules = append(teamRules.Rules, rule)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating risk rules: %w", err)
+	}
+	
+	query = `
+		SELECT kind, value, weight
+		FROM team_risk_signals
+		WHERE team_name = $1
+		ORDER BY kind, value
+	`
+	signalRows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get risk signals: %w", err)
+	}
+	defer func() {
+		if err := signalRows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	for signalRows.Next() {
+		var signal models.RiskSignal
+		if err := signalRows.Scan(&signal.Kind, &signal.Value, &signal.Weight); err != nil {
+			return nil, fmt.Errorf("failed to scan risk signal: %w", err)
+		}
+		teamRules.Signals = append(teamRules.Signals, signal)
+	}
+	if err = signalRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating risk signals: %w", err)
+	}
+	
+	return teamRules, nil
+}
+
+// ReplaceRiskRules replaces both rules and signals of the team
+func (s *PostgresStorage) ReplaceRiskRules(teamRules *models.TeamRiskRules) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("DELETE FROM team_risk_rules WHERE team_name = $1", teamRules.TeamName); err != nil {
+		return fmt.Errorf("failed to delete risk rules: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM team_risk_signals WHERE team_name = $1", teamRules.TeamName); err != nil {
+		return fmt.Errorf("failed to delete risk signals: %w", err)
+	}
+	
+	query := "INSERT INTO team_risk_rules (team_name, min_score, reviewers, senior_reviewers) VALUES ($1, $2, $3, $4)"
+	for _, rule := range teamRules.Rules {
+		if _, err := tx.Exec(query, teamRules.TeamName, rule.MinScore, rule.Reviewers, rule.SeniorReviewers); err != nil {
+			return fmt.Errorf("failed to insert risk rule: %w", err)
+		}
+	}
+	
+	query = "INSERT INTO team_risk_signals (team_name, kind, value, weight) VALUES ($1, $2, $3, $4)"
+	for _, signal := range teamRules.Signals {
+		if _, err := tx.Exec(query, teamRules.TeamName, signal.Kind, signal.Value, signal.Weight); err != nil {
+			return fmt.Errorf("failed to insert risk signal: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit risk rules: %w", err)
+	}
+	
+	return nil
+}
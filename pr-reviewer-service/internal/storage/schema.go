@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/migrations"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// SCHEMA VALIDATION
+
+var (
+	createTablePattern = regexp.MustCompile(`^CREATE TABLE (\w+) \(`)
+	columnPattern      = regexp.MustCompile(`^\t([a-z_][a-z0-9_]*) `)
+	createIndexPattern = regexp.MustCompile(`^CREATE (?:UNIQUE )?INDEX (\w+) ON`)
+)
+
+// expectedSchema - tables with their columns and index names declared in init.sql
+type expectedSchema struct {
+	tables  map[string][]string
+	indexes []string
+}
+
+// parseSchema reads tables, columns and indexes from the schema file, column lines are
+// the ones indented once inside CREATE TABLE, constraints are upper case
+func parseSchema(schema string) expectedSchema {
+	expected := expectedSchema{tables: make(map[string][]string)}
+	table := ""
+	for _, line := range strings.Split(schema, "\n") {
+		if match := createTablePattern.FindStringSubmatch(line); match != nil {
+			table = match[1]
+			expected.tables[table] = nil
+			continue
+		}
+		if strings.HasPrefix(line, ")") {
+			table = ""
+			continue
+		}
+		if table != "" {
+			if match := columnPattern.FindStringSubmatch(line); match != nil {
+				expected.tables[table] = append(expected.tables[table], match[1])
+			}
+			continue
+		}
+		if match := createIndexPattern.FindStringSubmatch(line); match != nil {
+			expected.indexes = append(expected.indexes, match[1])
+		}
+	}
+	return expected
+}
+
+// ValidateSchema checks that the database has every table, column and index of the
+// embedded schema and the same schema version, all problems are reported at once
+func (s *PostgresStorage) ValidateSchema() error {
+	expected := parseSchema(migrations.InitSQL)
+	
+	rows, err := s.db.Query(`
+		SELECT table_name, column_name
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to read schema columns: %w", err)
+	}
+	actual := make(map[string]map[string]bool)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan schema column: %w", err)
+		}
+		if actual[table] == nil {
+			actual[table] = make(map[string]bool)
+		}
+		actual[table][column] = true
+	}
+	if err := rows.Close(); err != nil {
+		log.Printf("Failed to close rows: %v", err)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating schema columns: %w", err)
+	}
+	
+	indexRows, err := s.db.Query("SELECT indexname FROM pg_indexes WHERE schemaname = current_schema()")
+	if err != nil {
+		return fmt.Errorf("failed to read schema indexes: %w", err)
+	}
+	indexes := make(map[string]bool)
+	for indexRows.Next() {
+		var index string
+		if err := indexRows.Scan(&index); err != nil {
+			_ = indexRows.Close()
+			return fmt.Errorf("failed to scan schema index: %w", err)
+		}
+		indexes[index] = true
+	}
+	if err := indexRows.Close(); err != nil {
+		log.Printf("Failed to close rows: %v", err)
+	}
+	if err = indexRows.Err(); err != nil {
+		return fmt.Errorf("error iterating schema indexes: %w", err)
+	}
+	
+	var problems []string
+	tables := make([]string, 0, len(expected.tables))
+	for table := range expected.tables {
+		tables = append(tables, table)
+	}
+	slices.Sort(tables)
+	for _, table := range tables {
+		if actual[table] == nil {
+			problems = append(problems, "missing table "+table)
+			continue
+		}
+		for _, column := range expected.tables[table] {
+			if !actual[table][column] {
+				problems = append(problems, fmt.Sprintf("missing column %s.%s", table, column))
+			}
+		}
+	}
+	for _, index := range expected.indexes {
+		if !indexes[index] {
+			problems = append(problems, "missing index "+index)
+		}
+	}
+	
+	// the version is only meaningful once the tables are there
+	if len(problems) == 0 {
+		var version int
+		if err := s.db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version); err != nil {
+			return fmt.Errorf("failed to read schema version: %w", err)
+		}
+		if version != migrations.Version {
+			problems = append(problems, fmt.Sprintf("schema version is %d, binary expects %d", version, migrations.Version))
+		}
+	}
+	
+	if len(problems) > 0 {
+		return fmt.Errorf("database schema doesn't match migrations/init.sql: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
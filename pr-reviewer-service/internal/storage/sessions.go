@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// REVIEW TIME TRACKING
+
+// StartReviewSession opens a session, a running one is returned as is
+func (s *PostgresStorage) StartReviewSession(prID, userID string, startedAt time.Time) (*models.ReviewSession, error) {
+	query := `
+		INSERT INTO review_sessions (pull_request_id, user_id, started_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (pull_request_id, user_id) WHERE finished_at IS NULL DO NOTHING
+	`
+	
+	if _, err := s.db.Exec(query, prID, userID, startedAt); err != nil {
+		return nil, fmt.Errorf("failed to start review session: %w", err)
+	}
+	
+	return s.GetOpenReviewSession(prID, userID)
+}
+
+// GetOpenReviewSession returns nil if the reviewer has no running session on PR
+func (s *PostgresStorage) GetOpenReviewSession(prID, userID string) (*models.ReviewSession, error) {
+	query := `
+		SELECT session_id, pull_request_id, user_id, started_at
+		FROM review_sessions
+		WHERE pull_request_id = $1 AND user_id = $2 AND finished_at IS NULL
+	`
+	
+	var session models.ReviewSession
+	err := s.db.QueryRow(query, prID, userID).Scan(
+		&session.SessionID,
+		&session.PullRequestID,
+		&session.UserID,
+		&session.StartedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review session: %w", err)
+	}
+	
+	return &session, nil
+}
+
+// FinishReviewSession reports false if the session was already finished
+func (s *PostgresStorage) FinishReviewSession(sessionID int64, finishedAt time.Time) (bool, error) {
+	query := "UPDATE review_sessions SET finished_at = $2 WHERE session_id = $1 AND finished_at IS NULL"
+	
+	result, err := s.db.Exec(query, sessionID, finishedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to finish review session: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
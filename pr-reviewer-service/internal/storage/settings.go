@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"pr-reviewer-service/internal/models"
+)
+
+// TEAM SETTINGS
+
+// GetTeamSettings returns nil if team has no custom settings
+func (s *PostgresStorage) GetTeamSettings(teamName string) (*models.TeamSettings, error) {
+	query := `
+		SELECT team_name, review_sla_hours, max_open_reviews, strict_merge, two_phase_review, shadow_reviewers,
+			max_daily_assignments, lead_escalation_hours, dependency_policy, transfer_reviews, notification_routes,
+			no_candidate_fallback, parent_team, absence_reserve_days, blind_review, reviewer_count, leaderboard
+		FROM team_settings
+		WHERE team_name = $1
+	`
+	
+	var settings models.TeamSettings
+	var routes []byte
+	err := s.db.QueryRow(query, teamName).Scan(
+		&settings.TeamName,
+		&settings.ReviewSLAHours,
+		&settings.MaxOpenReviews,
+		&settings.StrictMerge,
+		&settings.TwoPhaseReview,
+		&settings.ShadowReviewers,
+		&settings.MaxDailyAssignments,
+		&settings.LeadEscalationHours,
+		&settings.DependencyPolicy,
+		&settings.TransferReviews,
+		&routes,
+		&settings.NoCandidateFallback,
+		&settings.ParentTeam,
+		&settings.AbsenceReserveDays,
+		&settings.BlindReview,
+		&settings.ReviewerCount,
+		&settings.Leaderboard,
+	)
+	
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
+	}
+	if err := json.Unmarshal(routes, &settings.NotificationRoutes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification routes: %w", err)
+	}
+	if len(settings.NotificationRoutes) == 0 {
+		settings.NotificationRoutes = nil
+	}
+	
+	return &settings, nil
+}
+
+func (s *pgRepos) SaveTeamSettings(settings *models.TeamSettings) error {
+	routes := []byte("{}")
+	if len(settings.NotificationRoutes) > 0 {
+		var err error
+		if routes, err = json.Marshal(settings.NotificationRoutes); err != nil {
+			return fmt.Errorf("failed to marshal notification routes: %w", err)
+		}
+	}
+	
+	query := `
+		INSERT INTO team_settings (team_name, review_sla_hours, max_open_reviews, strict_merge, two_phase_review, shadow_reviewers,
+			max_daily_assignments, lead_escalation_hours, dependency_policy, transfer_reviews, notification_routes,
+			no_candidate_fallback, parent_team, absence_reserve_days, blind_review, reviewer_count, leaderboard)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		ON CONFLICT (team_name)
+		DO UPDATE SET
+			review_sla_hours = EXCLUDED.review_sla_hours,
+			max_open_reviews = EXCLUDED.max_open_reviews,
+			strict_merge = EXCLUDED.strict_merge,
+			two_phase_review = EXCLUDED.two_phase_review,
+			shadow_reviewers = EXCLUDED.shadow_reviewers,
+			max_daily_assignments = EXCLUDED.max_daily_assignments,
+			lead_escalation_hours = EXCLUDED.lead_escalation_hours,
+			dependency_policy = EXCLUDED.dependency_policy,
+			transfer_reviews = EXCLUDED.transfer_reviews,
+			notification_routes = EXCLUDED.notification_routes,
+			no_candidate_fallback = EXCLUDED.no_candidate_fallback,
+			parent_team = EXCLUDED.parent_team,
+			absence_reserve_days = EXCLUDED.absence_reserve_days,
+			blind_review = EXCLUDED.blind_review,
+			reviewer_count = EXCLUDED.reviewer_count,
+			leaderboard = EXCLUDED.leaderboard
+	`
+	
+	_, err := s.db.Exec(query, settings.TeamName, settings.ReviewSLAHours, settings.MaxOpenReviews,
+		settings.StrictMerge, settings.TwoPhaseReview, settings.ShadowReviewers,
+		settings.MaxDailyAssignments, settings.LeadEscalationHours, settings.DependencyPolicy, settings.TransferReviews, routes,
+		settings.NoCandidateFallback, settings.ParentTeam, settings.AbsenceReserveDays, settings.BlindReview,
+		settings.ReviewerCount, settings.Leaderboard)
+	if err != nil {
+		return fmt.Errorf("failed to save team settings: %w", err)
+	}
+	
+	return nil
+}
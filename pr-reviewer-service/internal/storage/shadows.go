@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+)
+
+// SHADOW REVIEWERS
+
+// AddShadowReviewer adds an observer, shadows are kept out of pr_reviewers so caps and loads ignore them
+func (s *pgRepos) AddShadowReviewer(prID, userID string) error {
+	query := `
+		INSERT INTO pr_shadow_reviewers (pull_request_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	
+	if _, err := s.db.Exec(query, prID, userID); err != nil {
+		return fmt.Errorf("failed to add shadow reviewer: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *pgRepos) GetShadowReviewers(prID string) ([]string, error) {
+	query := "SELECT user_id FROM pr_shadow_reviewers WHERE pull_request_id = $1 ORDER BY user_id"
+	
+	rows, err := s.db.Query(query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shadow reviewers: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var shadows []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan shadow reviewer: %w", err)
+		}
+		shadows = append(shadows, userID)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shadow reviewers: %w", err)
+	}
+	
+	return shadows, nil
+}
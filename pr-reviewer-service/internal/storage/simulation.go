@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ASSIGNMENT SIMULATION
+
+// GetPRCreations returns team's PRs created since, archived ones included, oldest first
+// with the reviewers the owning team gave them. Closed PRs carry the time they were last closed.
+func (s *PostgresStorage) GetPRCreations(teamName string, since time.Time) ([]models.SimulatedPR, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.author_id, pr.priority, pr.size, pr.created_at, pr.merged_at,
+			CASE WHEN pr.status = 'CLOSED' THEN COALESCE((
+				SELECT MAX(e.created_at) FROM pr_events e
+				WHERE e.pull_request_id = pr.pull_request_id AND e.event_type = 'PR_CLOSED'
+			), pr.created_at) END,
+			ARRAY(
+				SELECT r.user_id FROM pr_reviewers r
+				WHERE r.pull_request_id = pr.pull_request_id
+				AND COALESCE(NULLIF(r.team_name, ''), pr.team_name) = pr.team_name
+				ORDER BY r.assigned_at, r.user_id
+			)
+		FROM pull_requests pr
+		WHERE pr.team_name = $1 AND pr.created_at >= $2
+		UNION ALL
+		SELECT a.pull_request_id, a.author_id, a.priority, a.size, a.created_at, a.merged_at, NULL::TIMESTAMP,
+			ARRAY(
+				SELECT r.user_id FROM pr_reviewers_archive r
+				WHERE r.pull_request_id = a.pull_request_id AND r.merged_at = a.merged_at
+				AND COALESCE(NULLIF(r.team_name, ''), a.team_name) = a.team_name
+				ORDER BY r.assigned_at, r.user_id
+			)
+		FROM pull_requests_archive a
+		WHERE a.team_name = $1 AND a.created_at >= $2
+		ORDER BY 5, 1
+	`
+	
+	rows, err := s.db.Query(query, teamName, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR creations: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	prs := []models.SimulatedPR{}
+	for rows.Next() {
+		var pr models.SimulatedPR
+		if err := rows.Scan(&pr.PullRequestID, &pr.AuthorID, &pr.Priority, &pr.Size, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt, pq.Array(&pr.Reviewers)); err != nil {
+			return nil, fmt.Errorf("failed to scan PR creation: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating PR creations: %w", err)
+	}
+	
+	return prs, nil
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// SIZE RULES
+
+// GetSizeRules returns team rules from the smallest threshold, unbounded rule last
+func (s *PostgresStorage) GetSizeRules(teamName string) ([]models.SizeRule, error) {
+	query := `
+		SELECT size, max_lines, reviewers
+		FROM team_size_rules
+		WHERE team_name = $1
+		ORDER BY max_lines NULLS LAST
+	`
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get size rules: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	rules := []models.SizeRule{}
+	for rows.Next() {
+		var rule models.SizeRule
+		if err := rows.Scan(&rule.Size, &rule.MaxLines, &rule.Reviewers); err != nil {
+			return nil, fmt.Errorf("failed to scan size rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating size rules: %w", err)
+	}
+	
+	return rules, nil
+}
+
+func (s *PostgresStorage) ReplaceSizeRules(teamName string, rules []models.SizeRule) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if _, err := tx.Exec("DELETE FROM team_size_rules WHERE team_name = $1", teamName); err != nil {
+		return fmt.Errorf("failed to delete size rules: %w", err)
+	}
+	
+	query := "INSERT INTO team_size_rules (team_name, size, max_lines, reviewers) VALUES ($1, $2, $3, $4)"
+	for _, rule := range rules {
+		if _, err := tx.Exec(query, teamName, rule.Size, rule.MaxLines, rule.Reviewers); err != nil {
+			return fmt.Errorf("failed to insert size rule: %w", err)
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit size rules: %w", err)
+	}
+	
+	return nil
+}
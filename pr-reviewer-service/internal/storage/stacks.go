@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// STACKED PRS
+
+// AddStackedPR puts PR on top of parent, parent outside of any stack becomes a base
+func (s *PostgresStorage) AddStackedPR(prID, parentID string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	base := `
+		INSERT INTO pr_stacks (pull_request_id, base_id, position)
+		VALUES ($1, $1, 0)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(base, parentID); err != nil {
+		return fmt.Errorf("failed to add stack base: %w", err)
+	}
+	
+	query := `
+		INSERT INTO pr_stacks (pull_request_id, base_id, parent_id, position)
+		SELECT $1, base_id, pull_request_id, position + 1
+		FROM pr_stacks
+		WHERE pull_request_id = $2
+	`
+	if _, err := tx.Exec(query, prID, parentID); err != nil {
+		return fmt.Errorf("failed to add stacked PR: %w", err)
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit stacked PR: %w", err)
+	}
+	
+	return nil
+}
+
+// GetStackMember returns nil if PR isn't stacked
+func (s *PostgresStorage) GetStackMember(prID string) (*models.StackMember, error) {
+	query := `
+		SELECT pull_request_id, base_id, COALESCE(parent_id, ''), position
+		FROM pr_stacks
+		WHERE pull_request_id = $1
+	`
+	
+	var member models.StackMember
+	err := s.db.QueryRow(query, prID).Scan(&member.PullRequestID, &member.BaseID, &member.ParentID, &member.Position)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stack member: %w", err)
+	}
+	
+	return &member, nil
+}
+
+// GetStack returns the whole stack from the base up
+func (s *PostgresStorage) GetStack(baseID string) ([]models.StackMember, error) {
+	query := `
+		SELECT pull_request_id, base_id, COALESCE(parent_id, ''), position
+		FROM pr_stacks
+		WHERE base_id = $1
+		ORDER BY position, pull_request_id
+	`
+	
+	rows, err := s.db.Query(query, baseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stack: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var members []models.StackMember
+	for rows.Next() {
+		var member models.StackMember
+		if err := rows.Scan(&member.PullRequestID, &member.BaseID, &member.ParentID, &member.Position); err != nil {
+			return nil, fmt.Errorf("failed to scan stack member: %w", err)
+		}
+		members = append(members, member)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stack: %w", err)
+	}
+	
+	return members, nil
+}
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+)
+
+// REVIEW ACTIONS
+
+// RecordReviewAction stamps the first action time with the SLA pause taken before it, empty status keeps
+// the current one and approval is final
+func (s *pgRepos) RecordReviewAction(prID, userID, status string) error {
+	query := `
+		UPDATE pr_reviewers
+		SET first_action_at = COALESCE(first_action_at, CURRENT_TIMESTAMP),
+			first_action_paused_seconds = CASE
+				WHEN first_action_at IS NOT NULL THEN first_action_paused_seconds
+				ELSE sla_paused_seconds
+					+ COALESCE(GREATEST(EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - sla_paused_at)), 0)::BIGINT, 0)
+			END,
+			status = CASE
+				WHEN $3 = '' OR status = 'APPROVED' THEN status
+				ELSE $3
+			END
+		WHERE pull_request_id = $1 AND user_id = $2
+	`
+	
+	result, err := s.db.Exec(query, prID, userID, status)
+	if err != nil {
+		return fmt.Errorf("failed to record review action: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return fmt.Errorf("reviewer not assigned")
+	}
+	
+	return nil
+}
+
+// STATISTICS
+
+// GetFirstReviewStatsByTeam groups by reviewer's team, empty teamName returns all teams
+func (s *PostgresStorage) GetFirstReviewStatsByTeam(teamName string, since time.Time) ([]models.FirstReviewStats, error) {
+	query := `
+		SELECT u.team_name, '', ` + firstReviewAggregates + `
+		FROM pr_reviewers r
+		INNER JOIN users u ON u.user_id = r.user_id
+		WHERE r.first_action_at IS NOT NULL
+		AND r.assigned_at >= $2
+		AND ($1 = '' OR u.team_name = $1)
+		GROUP BY u.team_name
+		ORDER BY u.team_name
+	`
+	
+	return s.queryFirstReviewStats(query, teamName, since)
+}
+
+// GetFirstReviewStatsByUser groups by reviewer, empty userID returns all users
+func (s *PostgresStorage) GetFirstReviewStatsByUser(userID string, since time.Time) ([]models.FirstReviewStats, error) {
+	query := `
+		SELECT u.team_name, u.user_id, ` + firstReviewAggregates + `
+		FROM pr_reviewers r
+		INNER JOIN users u ON u.user_id = r.user_id
+		WHERE r.first_action_at IS NOT NULL
+		AND r.assigned_at >= $2
+		AND ($1 = '' OR u.user_id = $1)
+		GROUP BY u.team_name, u.user_id
+		ORDER BY u.user_id
+	`
+	
+	return s.queryFirstReviewStats(query, userID, since)
+}
+
+// firstReviewAggregates - time to first review without the time its SLA was paused
+const firstReviewAggregates = `
+	COUNT(*),
+	percentile_cont(0.5) WITHIN GROUP (ORDER BY ` + firstReviewSeconds + `),
+	percentile_cont(0.9) WITHIN GROUP (ORDER BY ` + firstReviewSeconds + `)
+`
+
+const firstReviewSeconds = `GREATEST(EXTRACT(EPOCH FROM (r.first_action_at - r.assigned_at)) - r.first_action_paused_seconds, 0)`
+
+func (s *PostgresStorage) queryFirstReviewStats(query, filter string, since time.Time) ([]models.FirstReviewStats, error) {
+	rows, err := s.db.Query(query, filter, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get first review stats: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var stats []models.FirstReviewStats
+	for rows.Next() {
+		var st models.FirstReviewStats
+		err := rows.Scan(&st.TeamName, &st.UserID, &st.Samples, &st.P50Seconds, &st.P90Seconds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan first review stats: %w", err)
+		}
+		stats = append(stats, st)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating first review stats: %w", err)
+	}
+	
+	return stats, nil
+}
+
+// GetReviewTimeStatsByTeam groups finished sessions by reviewer's team, empty teamName returns all teams
+func (s *PostgresStorage) GetReviewTimeStatsByTeam(teamName string, since time.Time) ([]models.ReviewTimeStats, error) {
+	query := `
+		SELECT u.team_name, '', ` + reviewTimeAggregates + `
+		FROM review_sessions rs
+		INNER JOIN users u ON u.user_id = rs.user_id
+		WHERE rs.finished_at IS NOT NULL
+		AND rs.started_at >= $2
+		AND ($1 = '' OR u.team_name = $1)
+		GROUP BY u.team_name
+		ORDER BY u.team_name
+	`
+	
+	return s.queryReviewTimeStats(query, teamName, since)
+}
+
+// GetReviewTimeStatsByUser groups finished sessions by reviewer, empty userID returns all users
+func (s *PostgresStorage) GetReviewTimeStatsByUser(userID string, since time.Time) ([]models.ReviewTimeStats, error) {
+	query := `
+		SELECT u.team_name, u.user_id, ` + reviewTimeAggregates + `
+		FROM review_sessions rs
+		INNER JOIN users u ON u.user_id = rs.user_id
+		WHERE rs.finished_at IS NOT NULL
+		AND rs.started_at >= $2
+		AND ($1 = '' OR u.user_id = $1)
+		GROUP BY u.team_name, u.user_id
+		ORDER BY u.user_id
+	`
+	
+	return s.queryReviewTimeStats(query, userID, since)
+}
+
+// reviews are distinct PRs, one review may span several sessions
+const reviewTimeAggregates = `
+	COUNT(*),
+	COUNT(DISTINCT rs.pull_request_id),
+	SUM(EXTRACT(EPOCH FROM (rs.finished_at - rs.started_at)))
+`
+
+func (s *PostgresStorage) queryReviewTimeStats(query, filter string, since time.Time) ([]models.ReviewTimeStats, error) {
+	rows, err := s.db.Query(query, filter, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review time stats: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var stats []models.ReviewTimeStats
+	for rows.Next() {
+		var st models.ReviewTimeStats
+		err := rows.Scan(&st.TeamName, &st.UserID, &st.Sessions, &st.Reviews, &st.TotalSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review time stats: %w", err)
+		}
+		st.AvgSecondsPerReview = st.TotalSeconds / float64(st.Reviews)
+		stats = append(stats, st)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review time stats: %w", err)
+	}
+	
+	return stats, nil
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+)
+
+// STATUS
+
+// Queues reported besides the job queue
+const (
+	QueuePendingAssignments    = "pending_assignments"
+	QueueDeferredNotifications = "deferred_notifications"
+)
+
+// Ping checks that the database answers
+func (s *PostgresStorage) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
+// GetQueueDepths returns unfinished jobs by kind, then PRs waiting for reviewers and
+// notifications deferred by quiet hours
+func (s *PostgresStorage) GetQueueDepths() ([]models.QueueDepth, error) {
+	query := `
+		SELECT kind,
+			COUNT(*) FILTER (WHERE status = 'QUEUED'),
+			COUNT(*) FILTER (WHERE status = 'RUNNING'),
+			COUNT(*) FILTER (WHERE status = 'DEAD'),
+			MIN(run_at) FILTER (WHERE status IN ('QUEUED', 'RUNNING'))
+		FROM jobs
+		WHERE status <> 'DONE'
+		GROUP BY kind
+		ORDER BY kind
+	`
+	
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job queue depths: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	depths := []models.QueueDepth{}
+	for rows.Next() {
+		var (
+			depth    models.QueueDepth
+			oldestAt sql.NullTime
+		)
+		if err := rows.Scan(&depth.Name, &depth.Pending, &depth.Running, &depth.Dead, &oldestAt); err != nil {
+			return nil, fmt.Errorf("failed to scan job queue depth: %w", err)
+		}
+		if oldestAt.Valid {
+			depth.OldestAt = &oldestAt.Time
+		}
+		depths = append(depths, depth)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job queue depths: %w", err)
+	}
+	
+	others := []struct {
+		name  string
+		query string
+	}{
+		{QueuePendingAssignments, "SELECT COUNT(*), MIN(queued_at) FROM pending_assignments"},
+		{QueueDeferredNotifications, "SELECT COUNT(*), MIN(deliver_after) FROM notifications WHERE delivered_at IS NULL"},
+	}
+	for _, other := range others {
+		var oldestAt sql.NullTime
+		depth := models.QueueDepth{Name: other.name}
+		if err := s.db.QueryRow(other.query).Scan(&depth.Pending, &oldestAt); err != nil {
+			return nil, fmt.Errorf("failed to get %s depth: %w", other.name, err)
+		}
+		if oldestAt.Valid {
+			depth.OldestAt = &oldestAt.Time
+		}
+		depths = append(depths, depth)
+	}
+	
+	return depths, nil
+}
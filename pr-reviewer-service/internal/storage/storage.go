@@ -1,67 +1,479 @@
 package storage
 
 import (
+	"cmp"
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log"
 	"pr-reviewer-service/internal/models"
+	"slices"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
-// Storage - iface for db
-type Storage interface {
-	// Teams
+// TeamRepo - teams
+type TeamRepo interface {
 	CreateTeam(teamName string) error
 	GetTeam(teamName string) (*models.TeamResponse, error)
 	TeamExists(teamName string) (bool, error)
+	ListTeams(page models.Page) ([]models.TeamSummary, error)
+}
 
-	// Users
+// UserRepo - users and team membership
+type UserRepo interface {
 	CreateOrUpdateUser(user *models.User) error
 	GetUser(userID string) (*models.User, error)
 	SetUserActive(userID string, isActive bool) error
+	SetUserJunior(userID string, isJunior bool) error
 	GetActiveTeamMembers(teamName string, excludeUserID string) ([]models.User, error)
+}
 
-	// Pull Requests
+// PRRepo - pull requests
+type PRRepo interface {
 	CreatePullRequest(pr *models.PullRequest) error
 	GetPullRequest(prID string) (*models.PullRequest, error)
-	MergePullRequest(prID string) error
+	MergePullRequest(prID string, merge models.MergeInfo) error
+	SetPullRequestStatus(prID, from, to string) (bool, error)
 	PRExists(prID string) (bool, error)
+	GetPRIDByExternalKey(externalID, externalURL string) (string, error)
+}
 
-	// Reviewers
-	AddReviewer(prID, userID string) error
+// ReviewerRepo - reviewer assignments
+type ReviewerRepo interface {
+	AddReviewer(prID, userID, assignmentType string) error
+	AddTeamReviewer(prID, userID, teamName, assignmentType string) error
 	RemoveReviewer(prID, userID string) error
 	GetReviewers(prID string) ([]string, error)
 	IsReviewerAssigned(prID, userID string) (bool, error)
-	GetPRsByReviewer(userID string) ([]models.PullRequestShort, error)
+	GetReviewerAssignment(prID, userID string) (*models.PRReviewer, error)
+	GetPRsByReviewer(userID string, sort models.PRSort, page models.Page) ([]models.PullRequestShort, error)
+	RecordReviewAction(prID, userID, status string) error
+	AddShadowReviewer(prID, userID string) error
+	GetShadowReviewers(prID string) ([]string, error)
+	AddReviewDecision(decision *models.ReviewDecision) error
+	DeletePendingAssignments(prID string) error
+}
+
+// TeamConfigRepo - team configuration written together with the team on bootstrap
+type TeamConfigRepo interface {
+	SaveTeamSettings(settings *models.TeamSettings) error
+	SaveTeamPolicy(policy *models.TeamPolicy) error
+}
+
+// HistoryRepo - PR timeline and audit log, written with the change they record
+type HistoryRepo interface {
+	AddPREvent(event *models.PREvent) error
+	AddAuditEntry(entry *models.AuditEntry) error
+}
+
+// Repos - core repositories, also the view of storage inside a transaction
+type Repos interface {
+	TeamRepo
+	UserRepo
+	PRRepo
+	ReviewerRepo
+	TeamConfigRepo
+	HistoryRepo
+}
+
+// UnitOfWork runs fn in one transaction, it is rolled back if fn returns an error
+type UnitOfWork interface {
+	InTx(fn func(repos Repos) error) error
+}
+
+// Storage - iface for db
+type Storage interface {
+	Repos
+	UnitOfWork
+
+	// Statistics
+	GetFirstReviewStatsByTeam(teamName string, since time.Time) ([]models.FirstReviewStats, error)
+	GetFirstReviewStatsByUser(userID string, since time.Time) ([]models.FirstReviewStats, error)
+	GetReviewTimeStatsByTeam(teamName string, since time.Time) ([]models.ReviewTimeStats, error)
+	GetReviewTimeStatsByUser(userID string, since time.Time) ([]models.ReviewTimeStats, error)
+	GetLeaderboardEntries(teamName string, since time.Time) ([]models.LeaderboardEntry, error)
+
+	// PR dependencies
+	AddPRDependency(prID, dependsOnID string) error
+	RemovePRDependency(prID, dependsOnID string) (bool, error)
+	GetPRDependencies(prIDs []string) ([]models.PRDependency, error)
+	GetOpenDependencies(prID string) ([]string, error)
+	DependencyPathExists(fromID, toID string) (bool, error)
+
+	// API tokens
+	CreateAPIToken(token *models.APIToken, tokenHash string) error
+	ListAPITokens(userID string) ([]models.APIToken, error)
+	GetAPITokenByHash(tokenHash string) (*models.APIToken, error)
+	RevokeAPIToken(userID string, tokenID int64, at time.Time) (bool, error)
+	TouchAPIToken(tokenID int64, at time.Time) error
+
+	// Provisioning
+	ListUsers(offset, limit int) ([]models.User, error)
+	CountUsers() (int, error)
+	ListTeamNames(offset, limit int) ([]string, error)
+	CountTeams() (int, error)
+
+	// Org structure
+	ImportUserManagers(managers []models.UserManager) error
+
+	// Duplicate detection
+	GetRecentPRsByAuthor(authorID string, since time.Time) ([]models.PRMatch, error)
+
+	// Stacked PRs
+	AddStackedPR(prID, parentID string) error
+	GetStackMember(prID string) (*models.StackMember, error)
+	GetStack(baseID string) ([]models.StackMember, error)
+
+	// Reverts
+	SavePRRevert(revert *models.PRRevert) (bool, error)
+	GetPRRevert(prID string) (*models.PRRevert, error)
+	GetRevertStats(teamName string, since time.Time) (*models.RevertStats, error)
+
+	// Review decisions
+	GetReviewDecisions(prID string) ([]models.ReviewDecision, error)
+
+	// Review time tracking
+	StartReviewSession(prID, userID string, startedAt time.Time) (*models.ReviewSession, error)
+	GetOpenReviewSession(prID, userID string) (*models.ReviewSession, error)
+	FinishReviewSession(sessionID int64, finishedAt time.Time) (bool, error)
+
+	// Vacations
+	ReplaceVacations(userID, source string, from time.Time, vacations []models.Vacation) error
+	AddVacation(vacation *models.Vacation) error
+	GetUserVacations(userID string, from time.Time) ([]models.Vacation, error)
+	DeleteVacation(userID string, id int64, source string) (bool, error)
+
+	// User profiles
+	UpdateUserProfile(userID string, profile *models.UserProfile) error
+	AddTeamMember(user *models.User, event *models.UserEvent) (bool, error)
+
+	// External identities
+	LinkUserIdentity(identity *models.UserIdentity) (bool, error)
+	UnlinkUserIdentity(userID, provider, externalID string) (bool, error)
+	ResolveIdentity(provider, externalID string) (string, error)
+	GetUserIdentities(userID string) ([]models.UserIdentity, error)
+
+	// Availability
+	GetTeamMembers(teamName string) ([]models.User, error)
+	GetTeamVacations(teamName string, at time.Time) (map[string]time.Time, error)
+	GetUpcomingVacations(teamName string, from, to time.Time) (map[string]time.Time, error)
+
+	// Calendar
+	SaveCalendarToken(token *models.CalendarToken) error
+	GetCalendarTokens() ([]models.CalendarToken, error)
+	DeleteCalendarToken(userID string) error
+	RecordCalendarState(nonce string, ttl time.Duration) (bool, error)
+
+	// Holidays
+	GetTeamHolidays(teamName string) ([]models.Holiday, error)
+	ReplaceTeamHolidays(teamName string, holidays []models.Holiday) error
+
+	// Assignment declines
+	AddAssignmentDecline(decline *models.AssignmentDecline) error
+	GetDeclineCounts(teamName string, since time.Time) ([]models.DeclineCount, error)
+
+	// Team settings
+	GetTeamSettings(teamName string) (*models.TeamSettings, error)
+
+	// Repositories
+	SaveRepository(repo *models.Repository) error
+	GetRepository(repositoryID string) (*models.Repository, error)
+	ListRepositories(teamName string) ([]models.Repository, error)
+	DeleteRepository(repositoryID string) error
+	GetPathOwners(repositoryID string) ([]models.PathOwners, error)
+	ReplacePathOwners(repositoryID, teamName string, prefixes []string) error
+
+	// Reviewer pools
+	GetReviewerPools(teamName string) ([]models.ReviewerPool, error)
+	GetReviewerPoolMembers(teamName, poolName string) ([]string, error)
+	ReplaceReviewerPool(teamName, poolName string, userIDs []string) error
+
+	// Size rules
+	GetSizeRules(teamName string) ([]models.SizeRule, error)
+	ReplaceSizeRules(teamName string, rules []models.SizeRule) error
+
+	// Risk rules
+	GetRiskRules(teamName string) (*models.TeamRiskRules, error)
+	ReplaceRiskRules(teamRules *models.TeamRiskRules) error
+
+	// Assignment simulation
+	GetPRCreations(teamName string, since time.Time) ([]models.SimulatedPR, error)
+
+	// Rebalancing
+	GetMovableAssignments(teamName string) ([]models.ReviewAssignment, error)
+
+	// PR templates
+	GetPRTemplates(teamName string) ([]models.PRTemplate, error)
+	ReplacePRTemplates(teamName string, templates []models.PRTemplate) error
+
+	// Assignment rules
+	GetAssignmentRules(teamName string) ([]models.AssignmentRule, error)
+	ReplaceAssignmentRules(teamName string, rules []models.AssignmentRule) error
+
+	// Policy scripts
+	GetTeamPolicy(teamName string) (*models.TeamPolicy, error)
+	DeleteTeamPolicy(teamName string) error
+
+	// Review phases
+	CreateReviewPhase(prID string, secondPhaseReviewers int) error
+	GetPendingPhases() ([]models.PendingPhase, error)
+	AdvanceReviewPhase(prID string, now time.Time) (bool, error)
+
+	// Review teams
+	SaveReviewTeam(team *models.ReviewTeam) error
+	GetReviewTeams(prID string) ([]models.ReviewTeam, error)
+	GetReviewerAssignments(prID string) ([]models.PRReviewer, error)
+
+	// Pending assignments
+	QueuePendingAssignment(prID, teamName string, reviewers int) error
+	GetPendingAssignments(teamName string) ([]models.PendingAssignment, error)
+	ResolvePendingAssignment(prID, teamName string, assigned int) error
+
+	// Checklists
+	GetChecklistTemplate(teamName string) ([]string, error)
+	ReplaceChecklistTemplate(teamName string, items []string) error
+	CreateReviewChecklist(prID, userID string, items []string) error
+	GetReviewChecklist(prID, userID string) ([]models.ChecklistItem, error)
+	SetChecklistItemChecked(prID, userID string, itemID int64, checked bool) error
+	CountUncheckedItems(prID string) (int, error)
+
+	// Capacity
+	GetOpenReviewLoads(teamName string) (map[string]int, error)
+	SetUserMaxOpenReviews(userID string, maxOpenReviews *int) error
+	SetUserMaxDailyAssignments(userID string, maxDailyAssignments *int) error
+	CountRecentAssignments(teamName string, since time.Time) (map[string]int, error)
+	CountTeamAssignmentsSince(teamName string, since time.Time) (int, error)
+
+	// Escalations
+	GetTeamLeads(teamName string) ([]models.User, error)
+	GetStalledPRs(now time.Time) ([]models.StalledPR, error)
+	RecordLeadEscalation(prID string) (bool, error)
+	GetEscalationRules(teamName string) ([]models.EscalationRule, error)
+	ReplaceEscalationRules(teamName string, rules []models.EscalationRule) error
+	GetOpenAssignments() ([]models.ReviewAssignment, error)
+	EscalationApplied(prID, userID string, ruleID int64) (bool, error)
+	RecordEscalation(prID, userID string, ruleID int64) (bool, error)
+
+	// SLA pauses
+	PauseAssignmentSLA(prID, userID string) (bool, error)
+	ResumeAssignmentSLA(prID, userID string) ([]string, error)
+
+	// SLA breaches
+	RecordSLABreach(prID, userID string, assignedAt time.Time) (bool, error)
+	DeleteSLABreach(prID, userID string, assignedAt time.Time) error
+
+	// Follow-up reviews
+	CreateFollowUp(followUp *models.FollowUp) (bool, error)
+	CompleteFollowUp(prID, userID string, at time.Time) (*models.FollowUp, error)
+	GetUserFollowUps(userID string, includeCompleted bool) ([]models.FollowUp, error)
+	GetOverdueFollowUps(now time.Time) ([]models.FollowUp, error)
+	MarkFollowUpBreached(id int64, at time.Time) (bool, error)
+	UnmarkFollowUpBreached(id int64) error
+
+	// Digests
+	SetUserDigest(userID string, digestHour *int, timezone string) error
+	GetDigestSubscribers() ([]models.User, error)
+	GetOpenAssignmentsByReviewer(userID string) ([]models.ReviewAssignment, error)
+	MarkDigestSent(userID string, sentAt time.Time) error
+
+	// Team transfers
+	TransferUser(userID, fromTeam, toTeam string) error
+
+	// Archive
+	ArchiveMergedPRs(mergedBefore time.Time, limit int) ([]string, error)
+	GetArchivedPullRequest(prID string) (*models.PullRequest, error)
+	GetArchivedPRHistory(prID string) ([]models.ArchivedRow, error)
+
+	// Boards
+	GetTeamOpenPRs(teamName string) ([]models.PullRequest, error)
+	GetTeamOpenAssignments(teamName string) ([]models.ReviewAssignment, error)
+
+	// Read models
+	ApplyStatsEvent(event *models.PREvent) error
+	RebuildReadModels() error
+
+	// Reports
+	GetTeamNames() ([]string, error)
+	FillTeamReport(report *models.TeamReport) error
+	GetTeamBottlenecks(teamName string, limit int) ([]models.ReviewerBottleneck, error)
+	RecordTeamReport(teamName, week string) (bool, error)
+
+	// Notification templates
+	GetNotificationTemplate(teamName, kind string) (string, error)
+	GetNotificationTemplates(teamName string) (map[string]string, error)
+	SaveNotificationTemplate(teamName, kind, body string) error
+	DeleteNotificationTemplate(teamName, kind string) error
+
+	// Subscriptions
+	MutePR(prID, userID string) error
+	UnmutePR(prID, userID string) error
+	IsPRMuted(prID, userID string) (bool, error)
+	AddPRWatcher(prID, userID string) error
+	RemovePRWatcher(prID, userID string) error
+	GetPRWatchers(prID string) ([]string, error)
+
+	// Comments
+	AddPRComment(comment *models.PRComment) error
+
+	// Handoffs
+	CreateHandoff(handoff *models.ReviewerHandoff) (bool, error)
+	GetHandoff(id int64) (*models.ReviewerHandoff, error)
+	CompleteHandoff(id int64, now time.Time) error
+	ResolveHandoff(id int64, status string, now time.Time) error
+	ExpireHandoffs(now time.Time) (int64, error)
+
+	// Job queue
+	EnqueueJob(job *models.Job) error
+	ClaimJobs(workerID string, limit int, visibility time.Duration) ([]models.Job, error)
+	CompleteJob(id int64) error
+	FailJob(id int64, lastError string, retryAt *time.Time) error
+	ListJobs(status string, page models.Page) ([]models.Job, error)
+	RetryJob(id int64) error
+
+	// Locks
+	WithTeamLock(teamName string, fn func() error) error
+	AcquireLease(name, holder string, ttl time.Duration) (bool, error)
+	ReleaseLease(name, holder string) error
+	RecordJobRun(name, holder string, startedAt, finishedAt time.Time, runErr string) error
+	GetSchedulerRuns() ([]models.SchedulerRun, error)
+
+	// Webhook deliveries
+	RecordWebhookDelivery(source, deliveryID string, ttl time.Duration) (bool, error)
+	ForgetWebhookDelivery(source, deliveryID string) error
+
+	// Status
+	Ping(ctx context.Context) error
+	GetQueueDepths() ([]models.QueueDepth, error)
+
+	// Audit
+	ListAuditEntries(page models.Page) ([]models.AuditEntry, error)
+
+	// Timeline & notifications
+	GetPREvents(prID string) ([]models.PREvent, error)
+	GetPRTimeline(prID string, page models.Page) ([]models.PREvent, error)
+	ListPREvents(filter models.PREventFilter, afterID int64, limit int) ([]models.PREvent, error)
+	ReplacePRProjection(state *models.PRState) error
+	CreateNotification(notification *models.Notification) error
+	GetNotifications(userID string, page models.Page) ([]models.Notification, error)
+	ReleaseDeferredNotifications(now time.Time) ([]models.Notification, error)
+	SetUserQuietHours(userID string, start, end *int) error
+}
+
+// defaultMaxIdleConns - database/sql default, restored after idle connections are dropped
+const defaultMaxIdleConns = 2
+
+// querier - common part of *sql.DB and *sql.Tx
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// pgRepos implements Repos on top of a connection pool or a transaction
+type pgRepos struct {
+	db    querier
+	crypt *fieldCipher // nil unless personal data encryption is enabled
 }
 
 type PostgresStorage struct {
+	*pgRepos
 	db *sql.DB
 }
 
 // NewPostgresStorage create new connection
 func NewPostgresStorage(connStr string) (*PostgresStorage, error) {
-	
-	db, err := sql.Open("postgres", connStr)
+	return NewPostgresStorageFromSource(func() string { return connStr })
+}
+
+// dsnConnector opens every connection with the current DSN, so rotated credentials are
+// used by new connections without reopening the pool
+type dsnConnector struct {
+	dsn func() string
+}
+
+func (c *dsnConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	connector, err := pq.NewConnector(c.dsn())
 	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// NewPostgresStorageFromSource connects with the DSN returned by dsn, it's called for every
+// new connection, e.g. to read credentials a secret manager rotates
+func NewPostgresStorageFromSource(dsn func() string) (*PostgresStorage, error) {
+	if _, err := pq.NewConnector(dsn()); err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	db := sql.OpenDB(&dsnConnector{dsn: dsn})
 	
 	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 	
-	return &PostgresStorage{db: db}, nil
+	// fail on boot rather than with SQL errors on the first request
+	s := &PostgresStorage{pgRepos: &pgRepos{db: db}, db: db}
+	if err := s.ValidateSchema(); err != nil {
+		if closeErr := db.Close(); closeErr != nil {
+			log.Printf("Failed to close database: %v", closeErr)
+		}
+		return nil, err
+	}
+	
+	return s, nil
 }
 
 func (s *PostgresStorage) Close() error {
 	return s.db.Close()
 }
 
+// CloseIdleConnections drops idle connections, after credential rotation the pool then
+// reconnects with the new DSN. Connections in use close when they are returned.
+func (s *PostgresStorage) CloseIdleConnections() {
+	s.db.SetMaxIdleConns(0)
+	s.db.SetMaxIdleConns(defaultMaxIdleConns)
+}
+
+func (s *PostgresStorage) InTx(fn func(repos Repos) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	if err := fn(&pgRepos{db: tx, crypt: s.crypt}); err != nil {
+		return err
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	
+	return nil
+}
+
+// cursorArgs returns keyset bounds for queries, nil time means the first page
+func cursorArgs(page models.Page) (*time.Time, string) {
+	if page.After == nil {
+		return nil, ""
+	}
+	return &page.After.CreatedAt, page.After.ID
+}
+
 // TEAMS
 
-func (s *PostgresStorage) CreateTeam(teamName string) error {
+func (s *pgRepos) CreateTeam(teamName string) error {
 	query := "INSERT INTO teams (team_name) VALUES ($1)"
 	
 	_, err := s.db.Exec(query, teamName)
@@ -72,7 +484,7 @@ func (s *PostgresStorage) CreateTeam(teamName string) error {
 	return nil
 }
 
-func (s *PostgresStorage) TeamExists(teamName string) (bool, error) {
+func (s *pgRepos) TeamExists(teamName string) (bool, error) {
 	query := "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)"
 	
 	var exists bool
@@ -84,8 +496,47 @@ func (s *PostgresStorage) TeamExists(teamName string) (bool, error) {
 	return exists, nil
 }
 
+// ListTeams returns teams in creation order
+func (s *pgRepos) ListTeams(page models.Page) ([]models.TeamSummary, error) {
+	query := `
+		SELECT t.team_name, t.created_at, COUNT(u.user_id)
+		FROM teams t
+		LEFT JOIN users u ON u.team_name = t.team_name
+		WHERE $1::timestamp IS NULL OR (t.created_at, t.team_name) > ($1, $2)
+		GROUP BY t.team_name, t.created_at
+		ORDER BY t.created_at, t.team_name
+		LIMIT $3
+	`
+	
+	after, afterID := cursorArgs(page)
+	rows, err := s.db.Query(query, after, afterID, page.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var teams []models.TeamSummary
+	for rows.Next() {
+		var team models.TeamSummary
+		if err := rows.Scan(&team.TeamName, &team.CreatedAt, &team.MemberCount); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams = append(teams, team)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating teams: %w", err)
+	}
+	
+	return teams, nil
+}
+
 // GetTeam return all team members
-func (s *PostgresStorage) GetTeam(teamName string) (*models.TeamResponse, error) {
+func (s *pgRepos) GetTeam(teamName string) (*models.TeamResponse, error) {
 	exists, err := s.TeamExists(teamName)
 	if err != nil {
 		return nil, err
@@ -95,10 +546,9 @@ func (s *PostgresStorage) GetTeam(teamName string) (*models.TeamResponse, error)
 	}
 	
 	query := `
-		SELECT user_id, username, is_active 
+		SELECT user_id, username, is_active, role, region
 		FROM users 
 		WHERE team_name = $1
-		ORDER BY username
 	`
 	
 	rows, err := s.db.Query(query, teamName)
@@ -114,10 +564,13 @@ func (s *PostgresStorage) GetTeam(teamName string) (*models.TeamResponse, error)
 	var members []models.TeamMember
 	for rows.Next() {
 		var member models.TeamMember
-		err := rows.Scan(&member.UserID, &member.Username, &member.IsActive)
+		err := rows.Scan(&member.UserID, &member.Username, &member.IsActive, &member.Role, &member.Region)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan team member: %w", err)
 		}
+		if member.Username, err = s.openPII(columnUsername, member.Username); err != nil {
+			return nil, err
+		}
 		members = append(members, member)
 	}
 	
@@ -125,6 +578,11 @@ func (s *PostgresStorage) GetTeam(teamName string) (*models.TeamResponse, error)
 		return nil, fmt.Errorf("error iterating team members: %w", err)
 	}
 	
+	// sorted here, encrypted usernames can't be ordered by the database
+	slices.SortFunc(members, func(a, b models.TeamMember) int {
+		return cmp.Compare(a.Username, b.Username)
+	})
+	
 	return &models.TeamResponse{
 		TeamName: teamName,
 		Members:  members,
@@ -133,18 +591,66 @@ func (s *PostgresStorage) GetTeam(teamName string) (*models.TeamResponse, error)
 
 // USERS
 
-func (s *PostgresStorage) CreateOrUpdateUser(user *models.User) error {
+const userColumns = "user_id, username, team_name, is_active, role, max_open_reviews, timezone, digest_hour, last_digest_at, " +
+	"quiet_hours_start, quiet_hours_end, is_junior, region, max_daily_assignments, manager_id, tags, notification_address, locale"
+
+// rowScanner - common part of *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanUser reads userColumns into user, decrypting personal data
+func (s *pgRepos) scanUser(row rowScanner, user *models.User) error {
+	err := row.Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.Role,
+		&user.MaxOpenReviews,
+		&user.Timezone,
+		&user.DigestHour,
+		&user.LastDigestAt,
+		&user.QuietHoursStart,
+		&user.QuietHoursEnd,
+		&user.IsJunior,
+		&user.Region,
+		&user.MaxDailyAssignments,
+		&user.ManagerID,
+		pq.Array(&user.Tags),
+		&user.NotificationAddress,
+		&user.Locale,
+	)
+	if err != nil {
+		return err
+	}
+	
+	if user.Username, err = s.openPII(columnUsername, user.Username); err != nil {
+		return err
+	}
+	user.NotificationAddress, err = s.openPII(columnNotificationAddress, user.NotificationAddress)
+	return err
+}
+
+func (s *pgRepos) CreateOrUpdateUser(user *models.User) error {
+	username, err := s.sealPII(columnUsername, user.Username)
+	if err != nil {
+		return err
+	}
+	
 	query := `
-		INSERT INTO users (user_id, username, team_name, is_active)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO users (user_id, username, team_name, is_active, role, region)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		ON CONFLICT (user_id) 
 		DO UPDATE SET 
 			username = EXCLUDED.username,
 			team_name = EXCLUDED.team_name,
-			is_active = EXCLUDED.is_active
+			is_active = EXCLUDED.is_active,
+			role = EXCLUDED.role,
+			region = EXCLUDED.region
 	`
 	
-	_, err := s.db.Exec(query, user.UserID, user.Username, user.TeamName, user.IsActive)
+	_, err = s.db.Exec(query, user.UserID, username, user.TeamName, user.IsActive, user.Role, user.Region)
 	if err != nil {
 		return fmt.Errorf("failed to create or update user: %w", err)
 	}
@@ -152,20 +658,15 @@ func (s *PostgresStorage) CreateOrUpdateUser(user *models.User) error {
 	return nil
 }
 
-func (s *PostgresStorage) GetUser(userID string) (*models.User, error) {
+func (s *pgRepos) GetUser(userID string) (*models.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE user_id = $1
 	`
 	
 	var user models.User
-	err := s.db.QueryRow(query, userID).Scan(
-		&user.UserID,
-		&user.Username,
-		&user.TeamName,
-		&user.IsActive,
-	)
+	err := s.scanUser(s.db.QueryRow(query, userID), &user)
 	
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found")
@@ -177,7 +678,7 @@ func (s *PostgresStorage) GetUser(userID string) (*models.User, error) {
 	return &user, nil
 }
 
-func (s *PostgresStorage) SetUserActive(userID string, isActive bool) error {
+func (s *pgRepos) SetUserActive(userID string, isActive bool) error {
 	query := "UPDATE users SET is_active = $1 WHERE user_id = $2"
 	
 	result, err := s.db.Exec(query, isActive, userID)
@@ -197,13 +698,43 @@ func (s *PostgresStorage) SetUserActive(userID string, isActive bool) error {
 	return nil
 }
 
-func (s *PostgresStorage) GetActiveTeamMembers(teamName string, excludeUserID string) ([]models.User, error) {
+func (s *pgRepos) SetUserJunior(userID string, isJunior bool) error {
+	result, err := s.db.Exec("UPDATE users SET is_junior = $1 WHERE user_id = $2", isJunior, userID)
+	if err != nil {
+		return fmt.Errorf("failed to set user junior: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+	
+	return nil
+}
+
+func (s *pgRepos) GetActiveTeamMembers(teamName string, excludeUserID string) ([]models.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE team_name = $1 
 		AND is_active = true 
 		AND user_id != $2
+		AND NOT EXISTS (
+			SELECT 1 FROM user_vacations v
+			WHERE v.user_id = users.user_id
+			AND v.starts_at <= NOW() AT TIME ZONE 'UTC'
+			AND v.ends_at > NOW() AT TIME ZONE 'UTC'
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM team_holidays h
+			WHERE h.team_name = users.team_name
+			AND h.holiday_date = (NOW() AT TIME ZONE 'UTC')::date
+			AND (h.region = '' OR h.region = users.region)
+		)
 		ORDER BY user_id
 	`
 	
@@ -220,7 +751,7 @@ func (s *PostgresStorage) GetActiveTeamMembers(teamName string, excludeUserID st
 	var users []models.User
 	for rows.Next() {
 		var user models.User
-		err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+		err := s.scanUser(rows, &user)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
@@ -236,18 +767,26 @@ func (s *PostgresStorage) GetActiveTeamMembers(teamName string, excludeUserID st
 
 // PULL REQUESTS
 
-func (s *PostgresStorage) CreatePullRequest(pr *models.PullRequest) error {
+func (s *pgRepos) CreatePullRequest(pr *models.PullRequest) error {
 	query := `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, team_name, repository_id, status, priority, size, reviewer_pool, created_at,
+			external_id, external_url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 	
-	_, err := s.db.Exec(query, 
-		pr.PullRequestID, 
-		pr.PullRequestName, 
-		pr.AuthorID, 
+	_, err := s.db.Exec(query,
+		pr.PullRequestID,
+		pr.PullRequestName,
+		pr.AuthorID,
+		pr.TeamName,
+		pr.RepositoryID,
 		pr.Status,
+		pr.Priority,
+		pr.Size,
+		pr.ReviewerPool,
 		pr.CreatedAt,
+		pr.ExternalID,
+		pr.ExternalURL,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create pull request: %w", err)
@@ -256,7 +795,7 @@ func (s *PostgresStorage) CreatePullRequest(pr *models.PullRequest) error {
 	return nil
 }
 
-func (s *PostgresStorage) PRExists(prID string) (bool, error) {
+func (s *pgRepos) PRExists(prID string) (bool, error) {
 	query := "SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)"
 	
 	var exists bool
@@ -268,11 +807,36 @@ func (s *PostgresStorage) PRExists(prID string) (bool, error) {
 	return exists, nil
 }
 
-func (s *PostgresStorage) GetPullRequest(prID string) (*models.PullRequest, error) {
+// GetPRIDByExternalKey returns ID of the PR with either external key, empty if there is none
+func (s *pgRepos) GetPRIDByExternalKey(externalID, externalURL string) (string, error) {
 	query := `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id
 		FROM pull_requests
-		WHERE pull_request_id = $1
+		WHERE (external_id <> '' AND external_id = $1) OR (external_url <> '' AND external_url = $2)
+		ORDER BY external_id = $1 DESC
+		LIMIT 1
+	`
+	
+	var prID string
+	err := s.db.QueryRow(query, externalID, externalURL).Scan(&prID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get PR by external key: %w", err)
+	}
+	
+	return prID, nil
+}
+
+func (s *pgRepos) GetPullRequest(prID string) (*models.PullRequest, error) {
+	query := `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.team_name, pr.repository_id, pr.status,
+			pr.priority, pr.size, pr.reviewer_pool, pr.created_at, pr.merged_at, pr.merged_by, pr.merge_commit,
+			pr.merge_url, pr.external_id, pr.external_url, COALESCE(ph.phase, 0)
+		FROM pull_requests pr
+		LEFT JOIN review_phases ph ON ph.pull_request_id = pr.pull_request_id
+		WHERE pr.pull_request_id = $1
 	`
 	
 	var pr models.PullRequest
@@ -280,9 +844,20 @@ func (s *PostgresStorage) GetPullRequest(prID string) (*models.PullRequest, erro
 		&pr.PullRequestID,
 		&pr.PullRequestName,
 		&pr.AuthorID,
+		&pr.TeamName,
+		&pr.RepositoryID,
 		&pr.Status,
+		&pr.Priority,
+		&pr.Size,
+		&pr.ReviewerPool,
 		&pr.CreatedAt,
 		&pr.MergedAt,
+		&pr.MergedBy,
+		&pr.MergeCommit,
+		&pr.MergeURL,
+		&pr.ExternalID,
+		&pr.ExternalURL,
+		&pr.ReviewPhase,
 	)
 	
 	if err == sql.ErrNoRows {
@@ -298,18 +873,26 @@ func (s *PostgresStorage) GetPullRequest(prID string) (*models.PullRequest, erro
 	}
 	pr.AssignedReviewers = reviewers
 	
+	shadows, err := s.GetShadowReviewers(prID)
+	if err != nil {
+		return nil, err
+	}
+	pr.ShadowReviewers = shadows
+	
 	return &pr, nil
 }
 
 // MergePullRequest marks PR as MERGED (idempotent operation)
-func (s *PostgresStorage) MergePullRequest(prID string) error {
+// MergePullRequest keeps merge time and metadata of the first merge on repeated calls
+func (s *pgRepos) MergePullRequest(prID string, merge models.MergeInfo) error {
 	query := `
 		UPDATE pull_requests 
-		SET status = 'MERGED', merged_at = CURRENT_TIMESTAMP
+		SET status = 'MERGED', merged_at = CURRENT_TIMESTAMP,
+			merged_by = $2, merge_commit = $3, merge_url = $4
 		WHERE pull_request_id = $1 AND status = 'OPEN'
 	`
 	
-	result, err := s.db.Exec(query, prID)
+	result, err := s.db.Exec(query, prID, merge.MergedBy, merge.MergeCommit, merge.MergeURL)
 	if err != nil {
 		return fmt.Errorf("failed to merge pull request: %w", err)
 	}
@@ -332,16 +915,43 @@ func (s *PostgresStorage) MergePullRequest(prID string) error {
 	return nil
 }
 
+// SetPullRequestStatus moves PR from one status to another, returns false if it isn't in from status
+func (s *pgRepos) SetPullRequestStatus(prID, from, to string) (bool, error) {
+	query := `
+		UPDATE pull_requests
+		SET status = $3
+		WHERE pull_request_id = $1 AND status = $2
+	`
+	
+	result, err := s.db.Exec(query, prID, from, to)
+	if err != nil {
+		return false, fmt.Errorf("failed to set pull request status: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
 // REVIEWERS
 
-func (s *PostgresStorage) AddReviewer(prID, userID string) error {
+// AddReviewer assigns the reviewer on behalf of the owning team
+func (s *pgRepos) AddReviewer(prID, userID, assignmentType string) error {
+	return s.AddTeamReviewer(prID, userID, "", assignmentType)
+}
+
+// AddTeamReviewer assigns the reviewer on behalf of teamName, empty for the owning team
+func (s *pgRepos) AddTeamReviewer(prID, userID, teamName, assignmentType string) error {
 	query := `
-		INSERT INTO pr_reviewers (pull_request_id, user_id)
-		VALUES ($1, $2)
+		INSERT INTO pr_reviewers (pull_request_id, user_id, team_name, assignment_type)
+		VALUES ($1, $2, $3, $4)
 		ON CONFLICT DO NOTHING
 	`
 	
-	_, err := s.db.Exec(query, prID, userID)
+	_, err := s.db.Exec(query, prID, userID, teamName, assignmentType)
 	if err != nil {
 		return fmt.Errorf("failed to add reviewer: %w", err)
 	}
@@ -349,7 +959,7 @@ func (s *PostgresStorage) AddReviewer(prID, userID string) error {
 	return nil
 }
 
-func (s *PostgresStorage) RemoveReviewer(prID, userID string) error {
+func (s *pgRepos) RemoveReviewer(prID, userID string) error {
 	query := "DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2"
 	
 	_, err := s.db.Exec(query, prID, userID)
@@ -360,7 +970,7 @@ func (s *PostgresStorage) RemoveReviewer(prID, userID string) error {
 	return nil
 }
 
-func (s *PostgresStorage) GetReviewers(prID string) ([]string, error) {
+func (s *pgRepos) GetReviewers(prID string) ([]string, error) {
 	query := `
 		SELECT user_id 
 		FROM pr_reviewers 
@@ -396,7 +1006,7 @@ func (s *PostgresStorage) GetReviewers(prID string) ([]string, error) {
 }
 
 // IsReviewerAssigned checks if user is assigned as reviewer for PR
-func (s *PostgresStorage) IsReviewerAssigned(prID, userID string) (bool, error) {
+func (s *pgRepos) IsReviewerAssigned(prID, userID string) (bool, error) {
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM pr_reviewers 
@@ -413,17 +1023,96 @@ func (s *PostgresStorage) IsReviewerAssigned(prID, userID string) (bool, error)
 	return assigned, nil
 }
 
-// GetPRsByReviewer returns all PRs where user is reviewer
-func (s *PostgresStorage) GetPRsByReviewer(userID string) ([]models.PullRequestShort, error) {
+func (s *pgRepos) GetReviewerAssignment(prID, userID string) (*models.PRReviewer, error) {
 	query := `
-		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
+		SELECT pull_request_id, user_id, status, assignment_type, team_name, assigned_at, first_action_at,
+			sla_paused_at, sla_paused_seconds, first_action_paused_seconds
+		FROM pr_reviewers
+		WHERE pull_request_id = $1 AND user_id = $2
+	`
+	
+	var reviewer models.PRReviewer
+	err := s.db.QueryRow(query, prID, userID).Scan(
+		&reviewer.PullRequestID,
+		&reviewer.UserID,
+		&reviewer.Status,
+		&reviewer.AssignmentType,
+		&reviewer.TeamName,
+		&reviewer.AssignedAt,
+		&reviewer.FirstActionAt,
+		&reviewer.SLAPausedAt,
+		&reviewer.SLAPausedSeconds,
+		&reviewer.FirstActionPausedSeconds,
+	)
+	
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("reviewer not assigned")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewer assignment: %w", err)
+	}
+	
+	return &reviewer, nil
+}
+
+// prSortColumns maps PR listing sort fields to indexed columns,
+// deadline follows assignment time since all rows share the reviewer's team SLA
+var prSortColumns = map[string]string{
+	"created_at": "pr.created_at",
+	"deadline":   "r.assigned_at",
+	"priority":   "pr.priority_rank",
+	"name":       "pr.pull_request_name",
+}
+
+// priorityRanks matches pull_requests.priority_rank
+var priorityRanks = map[string]int{"LOW": 0, "NORMAL": 1, "HIGH": 2, "URGENT": 3}
+
+// GetPRsByReviewer returns a page of PRs where user is reviewer or shadow in the requested order
+func (s *pgRepos) GetPRsByReviewer(userID string, sort models.PRSort, page models.Page) ([]models.PullRequestShort, error) {
+	column, ok := prSortColumns[sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field %q", sort.Field)
+	}
+	direction, cmp := "ASC", ">"
+	if sort.Desc {
+		direction, cmp = "DESC", "<"
+	}
+	
+	var after interface{}
+	var afterID string
+	if page.After != nil {
+		afterID = page.After.ID
+		switch sort.Field {
+		case "priority":
+			after = priorityRanks[page.After.Value]
+		case "name":
+			after = page.After.Value
+		default:
+			after = page.After.CreatedAt
+		}
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.priority,
+			pr.created_at, r.assigned_at, r.shadow, r.sla_paused_at, r.sla_paused_seconds
 		FROM pull_requests pr
-		INNER JOIN pr_reviewers r ON pr.pull_request_id = r.pull_request_id
+		INNER JOIN (
+			SELECT pull_request_id, user_id, assigned_at, false AS shadow, sla_paused_at, sla_paused_seconds
+			FROM pr_reviewers
+			UNION ALL
+			SELECT sh.pull_request_id, sh.user_id, sh.assigned_at, true, NULL, 0 FROM pr_shadow_reviewers sh
+			WHERE NOT EXISTS (
+				SELECT 1 FROM pr_reviewers rv
+				WHERE rv.pull_request_id = sh.pull_request_id AND rv.user_id = sh.user_id
+			)
+		) r ON pr.pull_request_id = r.pull_request_id
 		WHERE r.user_id = $1
-		ORDER BY pr.created_at DESC
-	`
+			AND ($2 OR (%[1]s, pr.pull_request_id) %[2]s ($3, $4))
+		ORDER BY %[1]s %[3]s, pr.pull_request_id %[3]s
+		LIMIT $5
+	`, column, cmp, direction)
 	
-	rows, err := s.db.Query(query, userID)
+	rows, err := s.db.Query(query, userID, page.After == nil, after, afterID, page.Limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PRs by reviewer: %w", err)
 	}
@@ -436,7 +1125,8 @@ func (s *PostgresStorage) GetPRsByReviewer(userID string) ([]models.PullRequestS
 	var prs []models.PullRequestShort
 	for rows.Next() {
 		var pr models.PullRequestShort
-		err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status)
+		err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.Priority,
+			&pr.CreatedAt, &pr.AssignedAt, &pr.Shadow, &pr.SLAPausedAt, &pr.SLAPausedSeconds)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
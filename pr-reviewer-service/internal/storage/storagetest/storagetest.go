@@ -0,0 +1,2792 @@
+// Package storagetest is a conformance suite for storage.Storage implementations.
+// A backend test calls Run with a factory returning an empty storage:
+//
+//	func TestPostgres(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) storage.Storage { return newEmptyDB(t) })
+//	}
+//
+// Every Storage method has a case here, a new method adds its case in the same change.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-service/internal/models"
+	"pr-reviewer-service/internal/storage"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Factory returns an empty storage, called once per subtest
+type Factory func(t *testing.T) storage.Storage
+
+// Run exercises storage semantics the service relies on
+func Run(t *testing.T, newStorage Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s storage.Storage)
+	}{
+		{"Teams", testTeams},
+		{"Users", testUsers},
+		{"ActiveTeamMembers", testActiveTeamMembers},
+		{"TeamHolidays", testTeamHolidays},
+		{"PullRequests", testPullRequests},
+		{"MergeIsIdempotent", testMergeIsIdempotent},
+		{"PRStatusTransitions", testPRStatusTransitions},
+		{"Reviewers", testReviewers},
+		{"ReviewActions", testReviewActions},
+		{"ReviewPhases", testReviewPhases},
+		{"ShadowReviewers", testShadowReviewers},
+		{"ReviewSessions", testReviewSessions},
+		{"ReviewDecisions", testReviewDecisions},
+		{"ReviewTeams", testReviewTeams},
+		{"FollowUps", testFollowUps},
+		{"PRDependencies", testPRDependencies},
+		{"StackedPRs", testStackedPRs},
+		{"PRReverts", testPRReverts},
+		{"RecentPRsByAuthor", testRecentPRsByAuthor},
+		{"UserManagers", testUserManagers},
+		{"ProvisioningListings", testProvisioningListings},
+		{"APITokens", testAPITokens},
+		{"TeamBoard", testTeamBoard},
+		{"ArchiveMergedPRs", testArchiveMergedPRs},
+		{"TransferUser", testTransferUser},
+		{"NotificationRoutes", testNotificationRoutes},
+		{"AssignmentDeclines", testAssignmentDeclines},
+		{"TeamAvailability", testTeamAvailability},
+		{"UserProfile", testUserProfile},
+		{"AddTeamMember", testAddTeamMember},
+		{"UserIdentities", testUserIdentities},
+		{"NoCandidateFallback", testNoCandidateFallback},
+		{"PRTemplates", testPRTemplates},
+		{"AssignmentRules", testAssignmentRules},
+		{"TeamPolicy", testTeamPolicy},
+		{"Absences", testAbsences},
+		{"RecentAssignments", testRecentAssignments},
+		{"PendingAssignments", testPendingAssignments},
+		{"StalledPRs", testStalledPRs},
+		{"ReviewerPools", testReviewerPools},
+		{"Repositories", testRepositories},
+		{"SizeRules", testSizeRules},
+		{"RiskRules", testRiskRules},
+		{"PRCreations", testPRCreations},
+		{"PRExternalKeys", testPRExternalKeys},
+		{"MovableAssignments", testMovableAssignments},
+		{"TeamBootstrapRollback", testTeamBootstrapRollback},
+		{"StatusChecks", testStatusChecks},
+		{"Leaderboard", testLeaderboard},
+		{"SLAPauses", testSLAPauses},
+		{"KeysetPagination", testKeysetPagination},
+		{"UnitOfWorkCommit", testUnitOfWorkCommit},
+		{"UnitOfWorkRollback", testUnitOfWorkRollback},
+		{"TeamLock", testTeamLock},
+		{"Leases", testLeases},
+		{"WebhookDeliveries", testWebhookDeliveries},
+		{"JobQueue", testJobQueue},
+		{"TeamListing", testTeamListing},
+		{"FirstReviewStats", testFirstReviewStats},
+		{"CalendarTokens", testCalendarTokens},
+		{"Checklists", testChecklists},
+		{"ReviewCapacity", testReviewCapacity},
+		{"ReadModels", testReadModels},
+		{"Escalations", testEscalations},
+		{"SLABreaches", testSLABreaches},
+		{"Digests", testDigests},
+		{"TeamReports", testTeamReports},
+		{"NotificationTemplates", testNotificationTemplates},
+		{"PRSubscriptions", testPRSubscriptions},
+		{"PRComments", testPRComments},
+		{"Handoffs", testHandoffs},
+		{"AuditLog", testAuditLog},
+		{"PRTimeline", testPRTimeline},
+		{"PRProjection", testPRProjection},
+		{"Notifications", testNotifications},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStorage(t))
+		})
+	}
+}
+
+var newestFirst = models.PRSort{Field: "created_at", Desc: true}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// seedTeam creates team with active members
+func seedTeam(t *testing.T, s storage.Storage, teamName string, userIDs ...string) {
+	t.Helper()
+	must(t, s.CreateTeam(teamName))
+	for _, userID := range userIDs {
+		must(t, s.CreateOrUpdateUser(&models.User{
+			UserID:   userID,
+			Username: "name-" + userID,
+			TeamName: teamName,
+			IsActive: true,
+			Role:     "member",
+		}))
+	}
+}
+
+// seedPR creates OPEN PR owned by the author's team
+func seedPR(t *testing.T, s storage.Storage, prID, authorID string) {
+	t.Helper()
+	author, err := s.GetUser(authorID)
+	must(t, err)
+	must(t, s.CreatePullRequest(&models.PullRequest{
+		PullRequestID:   prID,
+		PullRequestName: "name-" + prID,
+		AuthorID:        authorID,
+		TeamName:        author.TeamName,
+		Status:          "OPEN",
+		Priority:        "NORMAL",
+		CreatedAt:       time.Now().UTC(),
+	}))
+}
+
+func testTeams(t *testing.T, s storage.Storage) {
+	exists, err := s.TeamExists("backend")
+	must(t, err)
+	if exists {
+		t.Fatal("team exists before creation")
+	}
+	if _, err := s.GetTeam("backend"); err == nil {
+		t.Fatal("GetTeam of missing team must fail")
+	}
+	
+	seedTeam(t, s, "backend", "u2", "u1")
+	
+	exists, err = s.TeamExists("backend")
+	must(t, err)
+	if !exists {
+		t.Fatal("team doesn't exist after creation")
+	}
+	if err := s.CreateTeam("backend"); err == nil {
+		t.Fatal("duplicate team must fail")
+	}
+	
+	team, err := s.GetTeam("backend")
+	must(t, err)
+	if len(team.Members) != 2 || team.Members[0].UserID != "u1" || team.Members[1].UserID != "u2" {
+		t.Fatalf("members must be ordered by username, got %+v", team.Members)
+	}
+}
+
+func testUsers(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	seedTeam(t, s, "frontend")
+	
+	if _, err := s.GetUser("missing"); err == nil {
+		t.Fatal("GetUser of missing user must fail")
+	}
+	if err := s.SetUserActive("missing", false); err == nil {
+		t.Fatal("SetUserActive of missing user must fail")
+	}
+	
+	// upsert moves the user and updates fields
+	must(t, s.CreateOrUpdateUser(&models.User{
+		UserID:   "u1",
+		Username: "renamed",
+		TeamName: "frontend",
+		IsActive: true,
+		Role:     "lead",
+	}))
+	user, err := s.GetUser("u1")
+	must(t, err)
+	if user.Username != "renamed" || user.TeamName != "frontend" || user.Role != "lead" {
+		t.Fatalf("user not updated: %+v", user)
+	}
+	
+	must(t, s.SetUserActive("u1", false))
+	user, err = s.GetUser("u1")
+	must(t, err)
+	if user.IsActive {
+		t.Fatal("user still active")
+	}
+}
+
+func testActiveTeamMembers(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u3", "u1", "u2", "u4")
+	must(t, s.SetUserActive("u4", false))
+	
+	members, err := s.GetActiveTeamMembers("backend", "u2")
+	must(t, err)
+	
+	var ids []string
+	for _, member := range members {
+		ids = append(ids, member.UserID)
+	}
+	if len(ids) != 2 || ids[0] != "u1" || ids[1] != "u3" {
+		t.Fatalf("expected [u1 u3] ordered by id without excluded and inactive, got %v", ids)
+	}
+}
+
+func testTeamHolidays(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2", "u3")
+	must(t, s.CreateOrUpdateUser(&models.User{
+		UserID:   "u3",
+		Username: "name-u3",
+		TeamName: "backend",
+		IsActive: true,
+		Region:   "RU",
+	}))
+	
+	today := time.Now().UTC().Format("2006-01-02")
+	must(t, s.ReplaceTeamHolidays("backend", []models.Holiday{
+		{Date: "2030-01-01", Name: "later"},
+		{Date: today, Region: "RU"},
+	}))
+	
+	holidays, err := s.GetTeamHolidays("backend")
+	must(t, err)
+	if len(holidays) != 2 || holidays[0].Date != today || holidays[0].Region != "RU" || holidays[1].Name != "later" {
+		t.Fatalf("unexpected holidays: %+v", holidays)
+	}
+	
+	// u3's region is off today
+	members, err := s.GetActiveTeamMembers("backend", "u1")
+	must(t, err)
+	if len(members) != 1 || members[0].UserID != "u2" {
+		t.Fatalf("members on holiday must be skipped: %+v", members)
+	}
+	
+	must(t, s.ReplaceTeamHolidays("backend", nil))
+	holidays, err = s.GetTeamHolidays("backend")
+	must(t, err)
+	if len(holidays) != 0 {
+		t.Fatalf("calendar must be cleared: %+v", holidays)
+	}
+}
+
+func testPullRequests(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	
+	exists, err := s.PRExists("pr-1")
+	must(t, err)
+	if exists {
+		t.Fatal("PR exists before creation")
+	}
+	if _, err := s.GetPullRequest("pr-1"); err == nil {
+		t.Fatal("GetPullRequest of missing PR must fail")
+	}
+	if err := s.MergePullRequest("pr-1", models.MergeInfo{}); err == nil {
+		t.Fatal("merging missing PR must fail")
+	}
+	
+	seedPR(t, s, "pr-1", "u1")
+	if err := s.CreatePullRequest(&models.PullRequest{
+		PullRequestID: "pr-1",
+		AuthorID:      "u1",
+		TeamName:      "backend",
+		Status:        "OPEN",
+		Priority:      "NORMAL",
+	}); err == nil {
+		t.Fatal("duplicate PR must fail")
+	}
+	
+	pr, err := s.GetPullRequest("pr-1")
+	must(t, err)
+	if pr.PullRequestName != "name-pr-1" || pr.AuthorID != "u1" || pr.TeamName != "backend" ||
+		pr.Status != "OPEN" || pr.Priority != "NORMAL" {
+		t.Fatalf("unexpected PR: %+v", pr)
+	}
+	if pr.MergedAt != nil || len(pr.AssignedReviewers) != 0 {
+		t.Fatalf("new PR must be unmerged without reviewers: %+v", pr)
+	}
+}
+
+func testMergeIsIdempotent(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	seedPR(t, s, "pr-1", "u1")
+	
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{MergedBy: "u1", MergeCommit: "abc123"}))
+	first, err := s.GetPullRequest("pr-1")
+	must(t, err)
+	if first.Status != "MERGED" || first.MergedAt == nil || first.MergedBy != "u1" || first.MergeCommit != "abc123" {
+		t.Fatalf("PR not merged: %+v", first)
+	}
+	
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{MergedBy: "other"}))
+	second, err := s.GetPullRequest("pr-1")
+	must(t, err)
+	if !second.MergedAt.Equal(*first.MergedAt) {
+		t.Fatalf("repeated merge changed merged_at: %v -> %v", first.MergedAt, second.MergedAt)
+	}
+	if second.MergedBy != "u1" || second.MergeCommit != "abc123" {
+		t.Fatalf("repeated merge changed metadata: %+v", second)
+	}
+}
+
+func testPRStatusTransitions(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	
+	moved, err := s.SetPullRequestStatus("pr-1", "OPEN", "CLOSED")
+	must(t, err)
+	if !moved {
+		t.Fatal("expected PR to be closed")
+	}
+	moved, err = s.SetPullRequestStatus("pr-1", "OPEN", "CLOSED")
+	must(t, err)
+	if moved {
+		t.Fatal("closed PR must not match OPEN")
+	}
+	
+	assignments, err := s.GetOpenAssignmentsByReviewer("u1")
+	must(t, err)
+	if len(assignments) != 0 {
+		t.Fatalf("reviews of closed PR must not be open: %+v", assignments)
+	}
+	// merge only applies to open PRs
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{MergedBy: "u1"}))
+	pr, err := s.GetPullRequest("pr-1")
+	must(t, err)
+	if pr.Status != "CLOSED" || len(pr.AssignedReviewers) != 1 {
+		t.Fatalf("unexpected closed PR: %+v", pr)
+	}
+	
+	moved, err = s.SetPullRequestStatus("pr-1", "CLOSED", "OPEN")
+	must(t, err)
+	if !moved {
+		t.Fatal("expected PR to be reopened")
+	}
+	assignments, err = s.GetOpenAssignmentsByReviewer("u1")
+	must(t, err)
+	if len(assignments) != 1 {
+		t.Fatalf("reopened PR must bring its reviews back: %+v", assignments)
+	}
+}
+
+func testReviewers(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedPR(t, s, "pr-1", "author")
+	
+	must(t, s.AddReviewer("pr-1", "u2", "AUTO"))
+	must(t, s.AddReviewer("pr-1", "u1", "MANUAL"))
+	// repeated assignment is ignored
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	
+	reviewers, err := s.GetReviewers("pr-1")
+	must(t, err)
+	if len(reviewers) != 2 || reviewers[0] != "u1" || reviewers[1] != "u2" {
+		t.Fatalf("expected [u1 u2], got %v", reviewers)
+	}
+	
+	assignment, err := s.GetReviewerAssignment("pr-1", "u1")
+	must(t, err)
+	if assignment.AssignmentType != "MANUAL" || assignment.Status != "PENDING" || assignment.FirstActionAt != nil {
+		t.Fatalf("unexpected assignment: %+v", assignment)
+	}
+	if _, err := s.GetReviewerAssignment("pr-1", "author"); err == nil {
+		t.Fatal("GetReviewerAssignment of unassigned user must fail")
+	}
+	
+	prs, err := s.GetPRsByReviewer("u2", newestFirst, models.Page{Limit: 10})
+	must(t, err)
+	if len(prs) != 1 || prs[0].PullRequestID != "pr-1" {
+		t.Fatalf("unexpected PRs by reviewer: %+v", prs)
+	}
+	
+	must(t, s.RemoveReviewer("pr-1", "u2"))
+	// removing unassigned reviewer is a no-op
+	must(t, s.RemoveReviewer("pr-1", "u2"))
+	
+	assigned, err := s.IsReviewerAssigned("pr-1", "u2")
+	must(t, err)
+	if assigned {
+		t.Fatal("reviewer still assigned after removal")
+	}
+	assigned, err = s.IsReviewerAssigned("pr-1", "u1")
+	must(t, err)
+	if !assigned {
+		t.Fatal("remaining reviewer lost")
+	}
+}
+
+func testReviewActions(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	
+	if err := s.RecordReviewAction("pr-1", "author", "ACCEPTED"); err == nil {
+		t.Fatal("action of unassigned reviewer must fail")
+	}
+	
+	// empty status only stamps the first action
+	must(t, s.RecordReviewAction("pr-1", "u1", ""))
+	assignment, err := s.GetReviewerAssignment("pr-1", "u1")
+	must(t, err)
+	if assignment.Status != "PENDING" || assignment.FirstActionAt == nil {
+		t.Fatalf("first action not stamped: %+v", assignment)
+	}
+	firstAction := *assignment.FirstActionAt
+	
+	must(t, s.RecordReviewAction("pr-1", "u1", "APPROVED"))
+	must(t, s.RecordReviewAction("pr-1", "u1", "ACCEPTED"))
+	assignment, err = s.GetReviewerAssignment("pr-1", "u1")
+	must(t, err)
+	if assignment.Status != "APPROVED" {
+		t.Fatalf("approval must be final, got %s", assignment.Status)
+	}
+	if !assignment.FirstActionAt.Equal(firstAction) {
+		t.Fatal("first action time changed")
+	}
+}
+
+func testReviewPhases(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.CreateReviewPhase("pr-1", 1))
+	
+	pr, err := s.GetPullRequest("pr-1")
+	must(t, err)
+	if pr.ReviewPhase != 1 {
+		t.Fatalf("PR must be in the first phase, got %d", pr.ReviewPhase)
+	}
+	
+	pending, err := s.GetPendingPhases()
+	must(t, err)
+	if len(pending) != 0 {
+		t.Fatalf("phase is pending before approval: %+v", pending)
+	}
+	
+	must(t, s.RecordReviewAction("pr-1", "u1", "APPROVED"))
+	pending, err = s.GetPendingPhases()
+	must(t, err)
+	if len(pending) != 1 || pending[0].TeamName != "backend" || pending[0].SecondPhaseReviewers != 1 {
+		t.Fatalf("unexpected pending phases: %+v", pending)
+	}
+	
+	advanced, err := s.AdvanceReviewPhase("pr-1", time.Now().UTC())
+	must(t, err)
+	if !advanced {
+		t.Fatal("first advance must succeed")
+	}
+	advanced, err = s.AdvanceReviewPhase("pr-1", time.Now().UTC())
+	must(t, err)
+	if advanced {
+		t.Fatal("phase advanced twice")
+	}
+	
+	pending, err = s.GetPendingPhases()
+	must(t, err)
+	if len(pending) != 0 {
+		t.Fatalf("advanced phase still pending: %+v", pending)
+	}
+}
+
+func testShadowReviewers(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "junior")
+	must(t, s.SetUserJunior("junior", true))
+	if err := s.SetUserJunior("missing", true); err == nil {
+		t.Fatal("SetUserJunior of missing user must fail")
+	}
+	
+	user, err := s.GetUser("junior")
+	must(t, err)
+	if !user.IsJunior {
+		t.Fatal("user must be junior")
+	}
+	
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.AddShadowReviewer("pr-1", "junior"))
+	must(t, s.AddShadowReviewer("pr-1", "junior"))
+	
+	pr, err := s.GetPullRequest("pr-1")
+	must(t, err)
+	if len(pr.AssignedReviewers) != 1 || len(pr.ShadowReviewers) != 1 || pr.ShadowReviewers[0] != "junior" {
+		t.Fatalf("shadows must be kept apart from reviewers: %+v", pr)
+	}
+	shadows, err := s.GetShadowReviewers("pr-1")
+	must(t, err)
+	if len(shadows) != 1 || shadows[0] != "junior" {
+		t.Fatalf("unexpected shadows: %v", shadows)
+	}
+	
+	prs, err := s.GetPRsByReviewer("junior", newestFirst, models.Page{Limit: 10})
+	must(t, err)
+	if len(prs) != 1 || !prs[0].Shadow {
+		t.Fatalf("shadow PR must be listed with shadow flag: %+v", prs)
+	}
+	
+	// a shadow promoted to reviewer is listed once
+	must(t, s.AddReviewer("pr-1", "junior", "MANUAL"))
+	prs, err = s.GetPRsByReviewer("junior", newestFirst, models.Page{Limit: 10})
+	must(t, err)
+	if len(prs) != 1 || prs[0].Shadow {
+		t.Fatalf("promoted shadow must be listed as reviewer: %+v", prs)
+	}
+}
+
+func testReviewSessions(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	
+	startedAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	session, err := s.StartReviewSession("pr-1", "u1", startedAt)
+	must(t, err)
+	again, err := s.StartReviewSession("pr-1", "u1", startedAt.Add(time.Minute))
+	must(t, err)
+	if again.SessionID != session.SessionID {
+		t.Fatal("start must return the running session")
+	}
+	
+	finished, err := s.FinishReviewSession(session.SessionID, startedAt.Add(30*time.Minute))
+	must(t, err)
+	if !finished {
+		t.Fatal("first finish must succeed")
+	}
+	finished, err = s.FinishReviewSession(session.SessionID, startedAt.Add(40*time.Minute))
+	must(t, err)
+	if finished {
+		t.Fatal("session finished twice")
+	}
+	
+	open, err := s.GetOpenReviewSession("pr-1", "u1")
+	must(t, err)
+	if open != nil {
+		t.Fatalf("no session must be running: %+v", open)
+	}
+	
+	stats, err := s.GetReviewTimeStatsByUser("u1", startedAt.Add(-time.Minute))
+	must(t, err)
+	if len(stats) != 1 || stats[0].Sessions != 1 || stats[0].Reviews != 1 || stats[0].TotalSeconds != 1800 {
+		t.Fatalf("unexpected review time stats: %+v", stats)
+	}
+	
+	stats, err = s.GetReviewTimeStatsByTeam("", startedAt.Add(-time.Minute))
+	must(t, err)
+	if len(stats) != 1 || stats[0].TeamName != "backend" || stats[0].UserID != "" || stats[0].TotalSeconds != 1800 {
+		t.Fatalf("unexpected team review time stats: %+v", stats)
+	}
+}
+
+func testReviewDecisions(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	
+	must(t, s.AddReviewDecision(&models.ReviewDecision{
+		PullRequestID: "pr-1", UserID: "u1", Decision: "CHANGES_REQUESTED", Revision: "abc",
+	}))
+	approval := &models.ReviewDecision{PullRequestID: "pr-1", UserID: "u1", Decision: "APPROVED", Revision: "def"}
+	must(t, s.AddReviewDecision(approval))
+	if approval.ID == 0 || approval.DecidedAt.IsZero() {
+		t.Fatalf("decision must be stamped: %+v", approval)
+	}
+	if err := s.AddReviewDecision(&models.ReviewDecision{PullRequestID: "pr-1", UserID: "u1", Decision: "MAYBE"}); err == nil {
+		t.Fatal("unknown decision must be rejected")
+	}
+	
+	decisions, err := s.GetReviewDecisions("pr-1")
+	must(t, err)
+	if len(decisions) != 2 || decisions[0].Decision != "CHANGES_REQUESTED" || decisions[1].Revision != "def" {
+		t.Fatalf("unexpected decisions: %+v", decisions)
+	}
+}
+
+func testReviewTeams(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedTeam(t, s, "security", "sec1")
+	seedPR(t, s, "pr-1", "author")
+	
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.AddTeamReviewer("pr-1", "sec1", "security", "AUTO"))
+	must(t, s.SaveReviewTeam(&models.ReviewTeam{PullRequestID: "pr-1", TeamName: "security", RequiredApprovals: 1}))
+	must(t, s.SaveReviewTeam(&models.ReviewTeam{PullRequestID: "pr-1", TeamName: "security", RequiredApprovals: 2}))
+	
+	teams, err := s.GetReviewTeams("pr-1")
+	must(t, err)
+	if len(teams) != 1 || teams[0].RequiredApprovals != 2 {
+		t.Fatalf("saving the team again must update its approvals: %+v", teams)
+	}
+	
+	reviewers, err := s.GetReviewerAssignments("pr-1")
+	must(t, err)
+	byUser := map[string]string{}
+	for _, r := range reviewers {
+		byUser[r.UserID] = r.TeamName
+	}
+	if byUser["u1"] != "backend" || byUser["sec1"] != "security" {
+		t.Fatalf("unexpected reviewer teams: %v", byUser)
+	}
+	
+	assignment, err := s.GetReviewerAssignment("pr-1", "sec1")
+	must(t, err)
+	if assignment.TeamName != "security" {
+		t.Fatalf("unexpected assignment team: %q", assignment.TeamName)
+	}
+}
+
+func testFollowUps(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{}))
+	
+	now := time.Now().UTC()
+	followUp := &models.FollowUp{PullRequestID: "pr-1", UserID: "u1", RequestedBy: "author", SLAHours: 4,
+		CreatedAt: now.Add(-5 * time.Hour), DueAt: now.Add(-time.Hour)}
+	created, err := s.CreateFollowUp(followUp)
+	must(t, err)
+	if !created || followUp.ID == 0 {
+		t.Fatalf("follow-up must be created with its id: %+v", followUp)
+	}
+	created, err = s.CreateFollowUp(&models.FollowUp{PullRequestID: "pr-1", UserID: "u1", RequestedBy: "author",
+		SLAHours: 4, CreatedAt: now, DueAt: now.Add(time.Hour)})
+	must(t, err)
+	if created {
+		t.Fatal("PR can't have two open follow-ups")
+	}
+	
+	archived, err := s.ArchiveMergedPRs(now.Add(time.Hour), 10)
+	must(t, err)
+	if len(archived) != 0 {
+		t.Fatalf("PR with an open follow-up must not be archived: %v", archived)
+	}
+	
+	overdue, err := s.GetOverdueFollowUps(now)
+	must(t, err)
+	if len(overdue) != 1 || overdue[0].ID != followUp.ID {
+		t.Fatalf("unexpected overdue follow-ups: %+v", overdue)
+	}
+	marked, err := s.MarkFollowUpBreached(followUp.ID, now)
+	must(t, err)
+	if !marked {
+		t.Fatal("first breach must be marked")
+	}
+	marked, err = s.MarkFollowUpBreached(followUp.ID, now)
+	must(t, err)
+	if marked {
+		t.Fatal("breach must be marked once")
+	}
+	if overdue, err = s.GetOverdueFollowUps(now); err != nil || len(overdue) != 0 {
+		t.Fatalf("notified follow-up must not be overdue again: %+v, %v", overdue, err)
+	}
+	must(t, s.UnmarkFollowUpBreached(followUp.ID))
+	if overdue, err = s.GetOverdueFollowUps(now); err != nil || len(overdue) != 1 {
+		t.Fatalf("unmarked follow-up must be overdue again: %+v, %v", overdue, err)
+	}
+	
+	missing, err := s.CompleteFollowUp("pr-1", "author", now)
+	must(t, err)
+	if missing != nil {
+		t.Fatalf("only the follow-up reviewer can complete it: %+v", missing)
+	}
+	completed, err := s.CompleteFollowUp("pr-1", "u1", now)
+	must(t, err)
+	if completed == nil || completed.CompletedAt == nil {
+		t.Fatalf("unexpected completed follow-up: %+v", completed)
+	}
+	
+	open, err := s.GetUserFollowUps("u1", false)
+	must(t, err)
+	all, err := s.GetUserFollowUps("u1", true)
+	must(t, err)
+	if len(open) != 0 || len(all) != 1 {
+		t.Fatalf("unexpected follow-ups: open %+v, all %+v", open, all)
+	}
+}
+
+func testPRDependencies(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author")
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "author")
+	seedPR(t, s, "pr-3", "author")
+	
+	must(t, s.AddPRDependency("pr-2", "pr-1"))
+	must(t, s.AddPRDependency("pr-2", "pr-1"))
+	must(t, s.AddPRDependency("pr-3", "pr-2"))
+	if err := s.AddPRDependency("pr-1", "pr-1"); err == nil {
+		t.Fatal("PR must not depend on itself")
+	}
+	
+	transitive, err := s.DependencyPathExists("pr-3", "pr-1")
+	must(t, err)
+	reverse, err := s.DependencyPathExists("pr-1", "pr-3")
+	must(t, err)
+	if !transitive || reverse {
+		t.Fatalf("unexpected dependency paths: pr-3 -> pr-1 %v, pr-1 -> pr-3 %v", transitive, reverse)
+	}
+	
+	dependencies, err := s.GetPRDependencies([]string{"pr-2"})
+	must(t, err)
+	if len(dependencies) != 2 {
+		t.Fatalf("both directions must be returned: %+v", dependencies)
+	}
+	
+	open, err := s.GetOpenDependencies("pr-2")
+	must(t, err)
+	if len(open) != 1 || open[0] != "pr-1" {
+		t.Fatalf("unexpected open dependencies: %v", open)
+	}
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{}))
+	open, err = s.GetOpenDependencies("pr-2")
+	must(t, err)
+	if len(open) != 0 {
+		t.Fatalf("merged dependency must not be open: %v", open)
+	}
+	
+	removed, err := s.RemovePRDependency("pr-3", "pr-2")
+	must(t, err)
+	again, err := s.RemovePRDependency("pr-3", "pr-2")
+	must(t, err)
+	if !removed || again {
+		t.Fatalf("unexpected unlink results: %v, %v", removed, again)
+	}
+}
+
+func testStackedPRs(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author")
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "author")
+	seedPR(t, s, "pr-3", "author")
+	
+	member, err := s.GetStackMember("pr-1")
+	must(t, err)
+	if member != nil {
+		t.Fatalf("PR must not be stacked yet: %+v", member)
+	}
+	
+	must(t, s.AddStackedPR("pr-2", "pr-1"))
+	must(t, s.AddStackedPR("pr-3", "pr-2"))
+	if err := s.AddStackedPR("pr-3", "pr-1"); err == nil {
+		t.Fatal("PR must not be stacked twice")
+	}
+	
+	stack, err := s.GetStack("pr-1")
+	must(t, err)
+	if len(stack) != 3 || stack[0].PullRequestID != "pr-1" || stack[0].ParentID != "" {
+		t.Fatalf("unexpected stack: %+v", stack)
+	}
+	if top := stack[2]; top.PullRequestID != "pr-3" || top.BaseID != "pr-1" || top.ParentID != "pr-2" || top.Position != 2 {
+		t.Fatalf("unexpected stack top: %+v", top)
+	}
+}
+
+func testPRReverts(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "author")
+	seedPR(t, s, "pr-3", "author")
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{}))
+	must(t, s.MergePullRequest("pr-2", models.MergeInfo{}))
+	since := time.Now().UTC().Add(-time.Hour)
+	
+	missing, err := s.GetPRRevert("pr-3")
+	must(t, err)
+	if missing != nil {
+		t.Fatalf("PR must not be a revert yet: %+v", missing)
+	}
+	
+	revert := &models.PRRevert{PullRequestID: "pr-3", RevertedID: "pr-1", TeamName: "backend",
+		RevertedMergedAt: time.Now().UTC(), Reviewers: []string{"u1"}, MarkedBy: "author"}
+	saved, err := s.SavePRRevert(revert)
+	must(t, err)
+	if !saved || revert.CreatedAt.IsZero() {
+		t.Fatalf("revert must be saved with its creation time: %+v", revert)
+	}
+	saved, err = s.SavePRRevert(&models.PRRevert{PullRequestID: "pr-3", RevertedID: "pr-2", TeamName: "backend",
+		RevertedMergedAt: time.Now().UTC()})
+	must(t, err)
+	if saved {
+		t.Fatal("PR must revert one PR")
+	}
+	
+	stored, err := s.GetPRRevert("pr-3")
+	must(t, err)
+	if stored == nil || stored.RevertedID != "pr-1" || len(stored.Reviewers) != 1 || stored.Reviewers[0] != "u1" {
+		t.Fatalf("unexpected revert: %+v", stored)
+	}
+	
+	stats, err := s.GetRevertStats("backend", since)
+	must(t, err)
+	if stats.Merged != 2 || stats.Reverted != 1 || stats.RevertRate != 0.5 {
+		t.Fatalf("unexpected revert stats: %+v", stats)
+	}
+}
+
+func testRecentPRsByAuthor(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "other")
+	since := time.Now().UTC().Add(-time.Minute)
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "other")
+	
+	prs, err := s.GetRecentPRsByAuthor("author", since)
+	must(t, err)
+	if len(prs) != 1 || prs[0].PullRequestID != "pr-1" || prs[0].PullRequestName != "name-pr-1" || prs[0].Status != "OPEN" {
+		t.Fatalf("unexpected recent PRs: %+v", prs)
+	}
+	
+	prs, err = s.GetRecentPRsByAuthor("author", time.Now().UTC().Add(time.Hour))
+	must(t, err)
+	if len(prs) != 0 {
+		t.Fatalf("PRs before the window must be skipped: %+v", prs)
+	}
+}
+
+func testUserManagers(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	
+	must(t, s.ImportUserManagers([]models.UserManager{{UserID: "u1", ManagerID: "boss"}}))
+	if err := s.ImportUserManagers([]models.UserManager{{UserID: "u2", ManagerID: "boss"}, {UserID: "ghost"}}); err == nil {
+		t.Fatal("import with unknown user must fail")
+	}
+	
+	members, err := s.GetActiveTeamMembers("backend", "")
+	must(t, err)
+	if len(members) != 2 || members[0].ManagerID != "boss" || members[1].ManagerID != "" {
+		t.Fatalf("failed import must be rolled back: %+v", members)
+	}
+}
+
+func testProvisioningListings(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	seedTeam(t, s, "frontend", "u3")
+	
+	users, err := s.ListUsers(1, 5)
+	must(t, err)
+	total, err := s.CountUsers()
+	must(t, err)
+	if total != 3 || len(users) != 2 || users[0].UserID != "u2" {
+		t.Fatalf("unexpected users page: total %d, %+v", total, users)
+	}
+	
+	names, err := s.ListTeamNames(0, 1)
+	must(t, err)
+	teams, err := s.CountTeams()
+	must(t, err)
+	if teams != 2 || len(names) != 1 || names[0] != "backend" {
+		t.Fatalf("unexpected teams page: total %d, %v", teams, names)
+	}
+}
+
+func testAPITokens(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	
+	now := time.Now().UTC().Truncate(time.Second)
+	token := &models.APIToken{UserID: "u1", Name: "ci", Scopes: []string{"read"}, ExpiresAt: now.Add(time.Hour), CreatedAt: now}
+	must(t, s.CreateAPIToken(token, "hash-1"))
+	if token.TokenID == 0 {
+		t.Fatal("token id must be filled")
+	}
+	
+	found, err := s.GetAPITokenByHash("hash-1")
+	must(t, err)
+	if found == nil || found.UserID != "u1" || len(found.Scopes) != 1 || found.LastUsedAt != nil {
+		t.Fatalf("unexpected token: %+v", found)
+	}
+	missing, err := s.GetAPITokenByHash("hash-2")
+	must(t, err)
+	if missing != nil {
+		t.Fatalf("unknown hash must return nil: %+v", missing)
+	}
+	
+	must(t, s.TouchAPIToken(token.TokenID, now))
+	revoked, err := s.RevokeAPIToken("u2", token.TokenID, now)
+	must(t, err)
+	if revoked {
+		t.Fatal("token must not be revoked by another user")
+	}
+	revoked, err = s.RevokeAPIToken("u1", token.TokenID, now)
+	must(t, err)
+	again, err := s.RevokeAPIToken("u1", token.TokenID, now)
+	must(t, err)
+	if !revoked || again {
+		t.Fatalf("revoke must succeed once: %v, %v", revoked, again)
+	}
+	
+	tokens, err := s.ListAPITokens("u1")
+	must(t, err)
+	if len(tokens) != 1 || tokens[0].RevokedAt == nil || tokens[0].LastUsedAt == nil {
+		t.Fatalf("unexpected tokens: %+v", tokens)
+	}
+}
+
+func testTeamBoard(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	seedTeam(t, s, "frontend", "u3")
+	seedPR(t, s, "pr-1", "u1")
+	seedPR(t, s, "pr-2", "u1")
+	seedPR(t, s, "pr-3", "u3")
+	must(t, s.AddReviewer("pr-1", "u2", "AUTO"))
+	must(t, s.AddReviewer("pr-3", "u2", "AUTO"))
+	
+	prs, err := s.GetTeamOpenPRs("backend")
+	must(t, err)
+	if len(prs) != 2 || prs[0].PullRequestID != "pr-1" || prs[1].PullRequestID != "pr-2" {
+		t.Fatalf("unexpected open PRs: %+v", prs)
+	}
+	
+	assignments, err := s.GetTeamOpenAssignments("backend")
+	must(t, err)
+	if len(assignments) != 1 || assignments[0].PullRequestID != "pr-1" || assignments[0].ReviewerTeam != "backend" {
+		t.Fatalf("unexpected assignments: %+v", assignments)
+	}
+}
+
+func testArchiveMergedPRs(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	seedPR(t, s, "pr-1", "u1")
+	seedPR(t, s, "pr-2", "u1")
+	seedPR(t, s, "pr-3", "u1")
+	must(t, s.AddReviewer("pr-1", "u2", "AUTO"))
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{MergedBy: "u1"}))
+	must(t, s.MergePullRequest("pr-2", models.MergeInfo{}))
+	must(t, s.AddPRDependency("pr-3", "pr-2"))
+	must(t, s.AddPREvent(&models.PREvent{PullRequestID: "pr-1", EventType: "PR_MERGED", ActorID: "u1", Payload: map[string]interface{}{}}))
+	
+	archived, err := s.ArchiveMergedPRs(time.Now().Add(time.Hour), 10)
+	must(t, err)
+	if len(archived) != 1 || archived[0] != "pr-1" {
+		t.Fatalf("only PR without open dependents must be archived: %v", archived)
+	}
+	if _, err := s.GetPullRequest("pr-1"); err == nil {
+		t.Fatal("archived PR must leave the hot table")
+	}
+	
+	pr, err := s.GetArchivedPullRequest("pr-1")
+	must(t, err)
+	if pr == nil || pr.Status != "MERGED" || pr.MergedBy != "u1" || len(pr.AssignedReviewers) != 1 {
+		t.Fatalf("unexpected archived PR: %+v", pr)
+	}
+	// rows of other tables are kept instead of cascading away
+	history, err := s.GetArchivedPRHistory("pr-1")
+	must(t, err)
+	tables := make(map[string]int)
+	for _, row := range history {
+		tables[row.SourceTable]++
+	}
+	if tables["pr_events"] != 1 || tables["pr_reviewers"] != 1 {
+		t.Fatalf("PR history must be archived: %v", tables)
+	}
+	
+	missing, err := s.GetArchivedPullRequest("pr-2")
+	must(t, err)
+	if missing != nil {
+		t.Fatalf("PR that isn't archived must return nil: %+v", missing)
+	}
+}
+
+func testTransferUser(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	seedTeam(t, s, "frontend", "u3")
+	must(t, s.ReplaceReviewerPool("backend", "core", []string{"u1", "u2"}))
+	
+	if err := s.TransferUser("u1", "frontend", "backend"); err == nil {
+		t.Fatal("transfer from a team the user isn't in must fail")
+	}
+	must(t, s.TransferUser("u1", "backend", "frontend"))
+	
+	user, err := s.GetUser("u1")
+	must(t, err)
+	members, err := s.GetReviewerPoolMembers("backend", "core")
+	must(t, err)
+	if user.TeamName != "frontend" || len(members) != 1 || members[0] != "u2" {
+		t.Fatalf("user must move and leave old pools: %+v, %v", user, members)
+	}
+}
+
+func testNotificationRoutes(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	routes := map[string][]string{"ASSIGNMENT": {"slack"}, "SLA_BREACH": {"email", "slack"}, "DIGEST": {}}
+	must(t, s.SaveTeamSettings(&models.TeamSettings{TeamName: "backend", ReviewSLAHours: 24, NotificationRoutes: routes}))
+	
+	settings, err := s.GetTeamSettings("backend")
+	must(t, err)
+	if len(settings.NotificationRoutes) != 3 || len(settings.NotificationRoutes["SLA_BREACH"]) != 2 ||
+		settings.NotificationRoutes["DIGEST"] == nil || len(settings.NotificationRoutes["DIGEST"]) != 0 {
+		t.Fatalf("notification routes must round-trip: %+v", settings.NotificationRoutes)
+	}
+	
+	must(t, s.SaveTeamSettings(&models.TeamSettings{TeamName: "backend", ReviewSLAHours: 24}))
+	settings, err = s.GetTeamSettings("backend")
+	must(t, err)
+	if settings.NotificationRoutes != nil {
+		t.Fatalf("cleared routes must read back empty: %+v", settings.NotificationRoutes)
+	}
+}
+
+func testAssignmentDeclines(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2", "u3")
+	for _, d := range []models.AssignmentDecline{
+		{PullRequestID: "pr-1", UserID: "u1", TeamName: "backend", Reason: "TOO_BUSY", ReplacedBy: "u2"},
+		{PullRequestID: "pr-2", UserID: "u1", TeamName: "backend", Reason: "TOO_BUSY", ReplacedBy: "u3"},
+		{PullRequestID: "pr-3", UserID: "u2", TeamName: "backend", Reason: "CONFLICT", Comment: "my own design", ReplacedBy: "u1"},
+	} {
+		must(t, s.AddAssignmentDecline(&d))
+		if d.ID == 0 || d.CreatedAt.IsZero() {
+			t.Fatalf("decline must get id and time: %+v", d)
+		}
+	}
+	if err := s.AddAssignmentDecline(&models.AssignmentDecline{PullRequestID: "pr-4", UserID: "u1",
+		TeamName: "backend", Reason: "BORED", ReplacedBy: "u2"}); err == nil {
+		t.Fatal("unknown reason must be rejected")
+	}
+	
+	counts, err := s.GetDeclineCounts("backend", time.Now().Add(-time.Hour))
+	must(t, err)
+	if len(counts) != 2 || counts[0].UserID != "u1" || counts[0].Count != 2 || counts[1].Reason != "CONFLICT" {
+		t.Fatalf("unexpected decline counts: %+v", counts)
+	}
+	counts, err = s.GetDeclineCounts("backend", time.Now().Add(time.Hour))
+	must(t, err)
+	if len(counts) != 0 {
+		t.Fatalf("declines before the period must not count: %+v", counts)
+	}
+}
+
+func testTeamAvailability(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2", "u3")
+	must(t, s.SetUserActive("u3", false))
+	now := time.Now().UTC()
+	must(t, s.ReplaceVacations("u1", "MANUAL", now.Add(-48*time.Hour), []models.Vacation{
+		{StartsAt: now.Add(-24 * time.Hour), EndsAt: now.Add(24 * time.Hour)},
+		{StartsAt: now.Add(-time.Hour), EndsAt: now.Add(72 * time.Hour)},
+	}))
+	must(t, s.ReplaceVacations("u2", "MANUAL", now.Add(-48*time.Hour), []models.Vacation{
+		{StartsAt: now.Add(24 * time.Hour), EndsAt: now.Add(48 * time.Hour)},
+	}))
+	
+	members, err := s.GetTeamMembers("backend")
+	must(t, err)
+	if len(members) != 3 || members[2].UserID != "u3" || members[2].IsActive {
+		t.Fatalf("inactive members must be listed: %+v", members)
+	}
+	
+	vacations, err := s.GetTeamVacations("backend", now)
+	must(t, err)
+	if len(vacations) != 1 || vacations["u1"].Sub(now.Add(72*time.Hour)).Abs() > time.Second {
+		t.Fatalf("only the current vacation must count, with its latest end: %v", vacations)
+	}
+}
+
+func testUserProfile(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	user, err := s.GetUser("u1")
+	must(t, err)
+	if len(user.Tags) != 0 || user.NotificationAddress != "" {
+		t.Fatalf("new user must have an empty profile: %+v", user)
+	}
+	
+	must(t, s.UpdateUserProfile("u1", &models.UserProfile{Username: "Alice", Tags: []string{"go", "sql"},
+		Timezone: "Europe/Berlin", Role: "lead", NotificationAddress: "alice@example.com", Locale: "ru"}))
+	user, err = s.GetUser("u1")
+	must(t, err)
+	if user.Username != "Alice" || len(user.Tags) != 2 || user.Tags[1] != "sql" || user.Timezone != "Europe/Berlin" ||
+		user.Role != "lead" || user.NotificationAddress != "alice@example.com" || user.Locale != "ru" || user.TeamName != "backend" {
+		t.Fatalf("profile must be updated: %+v", user)
+	}
+	
+	if err := s.UpdateUserProfile("missing", &models.UserProfile{Username: "x", Timezone: "UTC", Role: "member"}); err == nil {
+		t.Fatal("updating a missing user must fail")
+	}
+}
+
+func testAddTeamMember(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	
+	event := &models.UserEvent{UserID: "u2", EventType: "USER_JOINED_TEAM", Payload: map[string]interface{}{"team_name": "backend"}}
+	added, err := s.AddTeamMember(&models.User{UserID: "u2", Username: "u2", TeamName: "backend", IsActive: true, Role: "member"}, event)
+	must(t, err)
+	if !added || event.ID == 0 || event.CreatedAt.IsZero() {
+		t.Fatalf("new member must be added with its event: %v %+v", added, event)
+	}
+	user, err := s.GetUser("u2")
+	must(t, err)
+	if user.TeamName != "backend" || !user.IsActive || user.Timezone != "UTC" {
+		t.Fatalf("unexpected new member: %+v", user)
+	}
+	
+	again := &models.UserEvent{UserID: "u1", EventType: "USER_JOINED_TEAM"}
+	added, err = s.AddTeamMember(&models.User{UserID: "u1", Username: "renamed", TeamName: "backend", Role: "member"}, again)
+	must(t, err)
+	if added || again.ID != 0 {
+		t.Fatal("existing user must not be added again")
+	}
+	user, err = s.GetUser("u1")
+	must(t, err)
+	if user.Username == "renamed" {
+		t.Fatal("existing user must stay unchanged")
+	}
+}
+
+func testUserIdentities(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	
+	identity := &models.UserIdentity{UserID: "u1", Provider: "github", ExternalID: "alice"}
+	linked, err := s.LinkUserIdentity(identity)
+	must(t, err)
+	if !linked || identity.CreatedAt.IsZero() {
+		t.Fatalf("identity must be linked: %v %+v", linked, identity)
+	}
+	relinked, err := s.LinkUserIdentity(&models.UserIdentity{UserID: "u2", Provider: "github", ExternalID: "alice"})
+	must(t, err)
+	if relinked {
+		t.Fatal("linked identity must not move to another user")
+	}
+	_, err = s.LinkUserIdentity(&models.UserIdentity{UserID: "u1", Provider: "gitlab", ExternalID: "alice"})
+	must(t, err)
+	
+	userID, err := s.ResolveIdentity("github", "alice")
+	must(t, err)
+	if userID != "u1" {
+		t.Fatalf("identity resolved to %q", userID)
+	}
+	userID, err = s.ResolveIdentity("github", "bob")
+	must(t, err)
+	if userID != "" {
+		t.Fatalf("unlinked identity resolved to %q", userID)
+	}
+	
+	identities, err := s.GetUserIdentities("u1")
+	must(t, err)
+	if len(identities) != 2 || identities[0].Provider != "github" || identities[1].Provider != "gitlab" {
+		t.Fatalf("unexpected identities: %+v", identities)
+	}
+	
+	unlinked, err := s.UnlinkUserIdentity("u2", "github", "alice")
+	must(t, err)
+	if unlinked {
+		t.Fatal("identity of another user must not be unlinked")
+	}
+	unlinked, err = s.UnlinkUserIdentity("u1", "github", "alice")
+	must(t, err)
+	if !unlinked {
+		t.Fatal("identity must be unlinked")
+	}
+	userID, err = s.ResolveIdentity("github", "alice")
+	must(t, err)
+	if userID != "" {
+		t.Fatalf("unlinked identity resolved to %q", userID)
+	}
+}
+
+func testNoCandidateFallback(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	seedTeam(t, s, "platform", "u2")
+	must(t, s.SaveTeamSettings(&models.TeamSettings{TeamName: "backend", ReviewSLAHours: 24, DependencyPolicy: "NONE",
+		TransferReviews: "KEEP", NoCandidateFallback: "PARENT_TEAM", ParentTeam: "platform", BlindReview: true}))
+	
+	settings, err := s.GetTeamSettings("backend")
+	must(t, err)
+	if settings.NoCandidateFallback != "PARENT_TEAM" || settings.ParentTeam != "platform" || !settings.BlindReview {
+		t.Fatalf("fallback must round-trip: %+v", settings)
+	}
+}
+
+func testPRTemplates(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	must(t, s.ReplacePRTemplates("backend", []models.PRTemplate{
+		{Name: "hotfix", NamePrefix: "hotfix/", Priority: "URGENT"},
+		{Name: "security", Labels: []string{"security"}},
+	}))
+	
+	templates, err := s.GetPRTemplates("backend")
+	must(t, err)
+	if len(templates) != 2 || templates[0].Name != "hotfix" || templates[1].Labels[0] != "security" ||
+		len(templates[0].Labels) != 0 {
+		t.Fatalf("templates must keep their order: %+v", templates)
+	}
+	
+	must(t, s.ReplacePRTemplates("backend", nil))
+	templates, err = s.GetPRTemplates("backend")
+	must(t, err)
+	if len(templates) != 0 {
+		t.Fatalf("templates must be replaced: %+v", templates)
+	}
+}
+
+func testAssignmentRules(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	must(t, s.ReplaceAssignmentRules("backend", []models.AssignmentRule{
+		{Name: "security", Conditions: []models.RuleCondition{{Field: "label", Value: "security"}},
+			Action: "ADD_POOL_REVIEWER", ReviewerPool: "security", Count: 2},
+		{Name: "owner", Conditions: []models.RuleCondition{{Field: "size", Value: "XL"}},
+			Action: "ADD_REVIEWER", UserID: "u1", Count: 1},
+	}))
+	
+	rules, err := s.GetAssignmentRules("backend")
+	must(t, err)
+	if len(rules) != 2 || rules[0].Name != "security" || rules[0].Count != 2 ||
+		rules[0].Conditions[0].Value != "security" || rules[1].UserID != "u1" {
+		t.Fatalf("rules must round-trip in order: %+v", rules)
+	}
+	
+	must(t, s.ReplaceAssignmentRules("backend", nil))
+	rules, err = s.GetAssignmentRules("backend")
+	must(t, err)
+	if len(rules) != 0 {
+		t.Fatalf("rules must be replaced: %+v", rules)
+	}
+}
+
+func testTeamPolicy(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	teamPolicy, err := s.GetTeamPolicy("backend")
+	must(t, err)
+	if teamPolicy != nil {
+		t.Fatalf("team without policy must get nil: %+v", teamPolicy)
+	}
+	
+	must(t, s.SaveTeamPolicy(&models.TeamPolicy{TeamName: "backend", Source: "1", UpdatedBy: "u1"}))
+	must(t, s.SaveTeamPolicy(&models.TeamPolicy{TeamName: "backend", Source: "-open_reviews", UpdatedBy: "u1"}))
+	teamPolicy, err = s.GetTeamPolicy("backend")
+	must(t, err)
+	if teamPolicy == nil || teamPolicy.Source != "-open_reviews" || teamPolicy.UpdatedAt == nil {
+		t.Fatalf("policy must be replaced: %+v", teamPolicy)
+	}
+	
+	must(t, s.DeleteTeamPolicy("backend"))
+	teamPolicy, err = s.GetTeamPolicy("backend")
+	must(t, err)
+	if teamPolicy != nil {
+		t.Fatalf("policy must be deleted: %+v", teamPolicy)
+	}
+}
+
+func testAbsences(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	now := time.Now().UTC().Truncate(time.Second)
+	absence := &models.Vacation{UserID: "u1", StartsAt: now.Add(48 * time.Hour), EndsAt: now.Add(96 * time.Hour), Source: "MANUAL"}
+	must(t, s.AddVacation(absence))
+	must(t, s.AddVacation(&models.Vacation{UserID: "u1", StartsAt: now.Add(24 * time.Hour), EndsAt: now.Add(30 * time.Hour), Source: "MANUAL"}))
+	must(t, s.AddVacation(&models.Vacation{UserID: "u2", StartsAt: now.Add(240 * time.Hour), EndsAt: now.Add(300 * time.Hour), Source: "MANUAL"}))
+	
+	absences, err := s.GetUserVacations("u1", now)
+	must(t, err)
+	if len(absences) != 2 || absences[1].ID != absence.ID || absences[0].StartsAt.After(absences[1].StartsAt) {
+		t.Fatalf("absences must be listed earliest first: %+v", absences)
+	}
+	
+	upcoming, err := s.GetUpcomingVacations("backend", now, now.Add(72*time.Hour))
+	must(t, err)
+	if len(upcoming) != 1 || upcoming["u1"].Sub(now.Add(24*time.Hour)).Abs() > time.Second {
+		t.Fatalf("only the earliest absence within the window must count: %v", upcoming)
+	}
+	
+	deleted, err := s.DeleteVacation("u1", absence.ID, "GOOGLE_CALENDAR")
+	must(t, err)
+	if deleted {
+		t.Fatal("absence of another source must not be deleted")
+	}
+	deleted, err = s.DeleteVacation("u1", absence.ID, "MANUAL")
+	must(t, err)
+	if !deleted {
+		t.Fatal("absence must be deleted")
+	}
+}
+
+func testRecentAssignments(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.AddReviewer("pr-2", "u1", "VOLUNTEER"))
+	
+	counts, err := s.CountRecentAssignments("backend", time.Now().UTC().Add(-time.Hour))
+	must(t, err)
+	if counts["u1"] != 2 || counts["u2"] != 0 {
+		t.Fatalf("unexpected recent assignments: %v", counts)
+	}
+	
+	limit := 3
+	must(t, s.SetUserMaxDailyAssignments("u1", &limit))
+	user, err := s.GetUser("u1")
+	must(t, err)
+	if user.MaxDailyAssignments == nil || *user.MaxDailyAssignments != 3 {
+		t.Fatalf("daily limit not stored: %+v", user)
+	}
+}
+
+func testPendingAssignments(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author")
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "author")
+	
+	must(t, s.QueuePendingAssignment("pr-1", "backend", 1))
+	must(t, s.QueuePendingAssignment("pr-2", "backend", 2))
+	must(t, s.QueuePendingAssignment("pr-1", "backend", 1))
+	
+	pending, err := s.GetPendingAssignments("backend")
+	must(t, err)
+	if len(pending) != 2 || pending[0].PullRequestID != "pr-1" || pending[0].Reviewers != 2 {
+		t.Fatalf("unexpected queue: %+v", pending)
+	}
+	
+	must(t, s.ResolvePendingAssignment("pr-1", "backend", 1))
+	must(t, s.ResolvePendingAssignment("pr-2", "backend", 2))
+	pending, err = s.GetPendingAssignments("")
+	must(t, err)
+	if len(pending) != 1 || pending[0].PullRequestID != "pr-1" || pending[0].Reviewers != 1 {
+		t.Fatalf("served entries must leave the queue: %+v", pending)
+	}
+	
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{}))
+	pending, err = s.GetPendingAssignments("")
+	must(t, err)
+	if len(pending) != 0 {
+		t.Fatalf("merged PRs must not be queued: %+v", pending)
+	}
+	
+	must(t, s.QueuePendingAssignment("pr-2", "backend", 1))
+	must(t, s.DeletePendingAssignments("pr-2"))
+	pending, err = s.GetPendingAssignments("")
+	must(t, err)
+	if len(pending) != 0 {
+		t.Fatalf("deleted entries must leave the queue: %+v", pending)
+	}
+}
+
+func testStalledPRs(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "author")
+	must(t, s.AddReviewer("pr-2", "u1", "AUTO"))
+	must(t, s.RecordReviewAction("pr-2", "u1", "APPROVED"))
+	
+	later := time.Now().UTC().Add(2 * time.Hour)
+	stalled, err := s.GetStalledPRs(later)
+	must(t, err)
+	if len(stalled) != 0 {
+		t.Fatalf("teams without threshold must not escalate: %+v", stalled)
+	}
+	
+	hours := 1
+	must(t, s.SaveTeamSettings(&models.TeamSettings{TeamName: "backend", ReviewSLAHours: 24, LeadEscalationHours: &hours}))
+	stalled, err = s.GetStalledPRs(later)
+	must(t, err)
+	if len(stalled) != 1 || stalled[0].PullRequestID != "pr-1" || stalled[0].ThresholdHours != 1 {
+		t.Fatalf("unexpected stalled PRs: %+v", stalled)
+	}
+	
+	applied, err := s.RecordLeadEscalation("pr-1")
+	must(t, err)
+	if !applied {
+		t.Fatal("first lead escalation must apply")
+	}
+	applied, err = s.RecordLeadEscalation("pr-1")
+	must(t, err)
+	if applied {
+		t.Fatal("PR escalated to lead twice")
+	}
+	
+	stalled, err = s.GetStalledPRs(later)
+	must(t, err)
+	if len(stalled) != 0 {
+		t.Fatalf("escalated PR still stalled: %+v", stalled)
+	}
+}
+
+func testReviewerPools(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2", "u3")
+	
+	must(t, s.ReplaceReviewerPool("backend", "oncall", []string{"u3", "u1"}))
+	must(t, s.ReplaceReviewerPool("backend", "api", []string{"u2"}))
+	
+	members, err := s.GetReviewerPoolMembers("backend", "oncall")
+	must(t, err)
+	if len(members) != 2 || members[0] != "u1" || members[1] != "u3" {
+		t.Fatalf("unexpected pool members: %v", members)
+	}
+	
+	pools, err := s.GetReviewerPools("backend")
+	must(t, err)
+	if len(pools) != 2 || pools[0].PoolName != "api" || pools[1].PoolName != "oncall" {
+		t.Fatalf("pools must be ordered by name, got %+v", pools)
+	}
+	
+	// empty member list deletes the pool
+	must(t, s.ReplaceReviewerPool("backend", "oncall", nil))
+	members, err = s.GetReviewerPoolMembers("backend", "oncall")
+	must(t, err)
+	if len(members) != 0 {
+		t.Fatalf("deleted pool still has members: %v", members)
+	}
+	
+	must(t, s.CreatePullRequest(&models.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "name-pr-1",
+		AuthorID:        "u1",
+		TeamName:        "backend",
+		Status:          "OPEN",
+		Priority:        "NORMAL",
+		ReviewerPool:    "api",
+		CreatedAt:       time.Now().UTC(),
+	}))
+	pr, err := s.GetPullRequest("pr-1")
+	must(t, err)
+	if pr.ReviewerPool != "api" {
+		t.Fatalf("reviewer pool not stored: %+v", pr)
+	}
+}
+
+func testRepositories(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1")
+	seedTeam(t, s, "frontend", "u2")
+	
+	repo, err := s.GetRepository("org/api")
+	must(t, err)
+	if repo != nil {
+		t.Fatalf("unknown repository must be nil, got %+v", repo)
+	}
+	
+	must(t, s.SaveRepository(&models.Repository{RepositoryID: "org/api", TeamName: "backend"}))
+	must(t, s.SaveRepository(&models.Repository{RepositoryID: "org/web", TeamName: "backend"}))
+	// saving again moves the repo
+	must(t, s.SaveRepository(&models.Repository{RepositoryID: "org/web", TeamName: "frontend"}))
+	
+	repos, err := s.ListRepositories("backend")
+	must(t, err)
+	if len(repos) != 1 || repos[0].RepositoryID != "org/api" {
+		t.Fatalf("unexpected backend repositories: %+v", repos)
+	}
+	all, err := s.ListRepositories("")
+	must(t, err)
+	if len(all) != 2 {
+		t.Fatalf("expected 2 repositories, got %+v", all)
+	}
+	
+	// PR of an outside contributor belongs to the repo's team
+	must(t, s.CreatePullRequest(&models.PullRequest{
+		PullRequestID:   "org/web#1",
+		PullRequestName: "name-web-1",
+		AuthorID:        "u1",
+		TeamName:        "frontend",
+		RepositoryID:    "org/web",
+		Status:          "OPEN",
+		Priority:        "NORMAL",
+		CreatedAt:       time.Now().UTC(),
+	}))
+	pr, err := s.GetPullRequest("org/web#1")
+	must(t, err)
+	if pr.TeamName != "frontend" || pr.RepositoryID != "org/web" {
+		t.Fatalf("PR ownership not stored: %+v", pr)
+	}
+	
+	must(t, s.ReplacePathOwners("org/web", "frontend", []string{"ui", "shared"}))
+	must(t, s.ReplacePathOwners("org/web", "backend", []string{"shared"}))
+	owners, err := s.GetPathOwners("org/web")
+	must(t, err)
+	if len(owners) != 2 || owners[0].TeamName != "backend" || len(owners[1].Prefixes) != 2 || owners[1].Prefixes[0] != "shared" {
+		t.Fatalf("unexpected path owners: %+v", owners)
+	}
+	
+	// path owners go away with the repository
+	must(t, s.DeleteRepository("org/web"))
+	owners, err = s.GetPathOwners("org/web")
+	must(t, err)
+	if len(owners) != 0 {
+		t.Fatalf("path owners of deleted repository: %+v", owners)
+	}
+	if err := s.DeleteRepository("org/web"); err == nil {
+		t.Fatal("deleting missing repository must fail")
+	}
+}
+
+func testSizeRules(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend")
+	
+	small, large := 50, 500
+	must(t, s.ReplaceSizeRules("backend", []models.SizeRule{
+		{Size: "XL", Reviewers: 3},
+		{Size: "L", MaxLines: &large, Reviewers: 2},
+		{Size: "XS", MaxLines: &small, Reviewers: 1},
+	}))
+	
+	rules, err := s.GetSizeRules("backend")
+	must(t, err)
+	if len(rules) != 3 || rules[0].Size != "XS" || rules[1].Size != "L" || rules[2].Size != "XL" || rules[2].MaxLines != nil {
+		t.Fatalf("rules must be ordered by threshold with unbounded last, got %+v", rules)
+	}
+	
+	must(t, s.ReplaceSizeRules("backend", nil))
+	rules, err = s.GetSizeRules("backend")
+	must(t, err)
+	if len(rules) != 0 {
+		t.Fatalf("rules not cleared: %+v", rules)
+	}
+}
+
+func testRiskRules(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend")
+	
+	must(t, s.ReplaceRiskRules(&models.TeamRiskRules{
+		TeamName: "backend",
+		Rules: []models.RiskRule{
+			{MinScore: 40, Reviewers: 2, SeniorReviewers: 1},
+			{MinScore: 70, Reviewers: 3, SeniorReviewers: 2},
+		},
+		Signals: []models.RiskSignal{{Kind: "PATH", Value: "billing", Weight: 25}, {Kind: "LABEL", Value: "migration", Weight: 30}},
+	}))
+	
+	rules, err := s.GetRiskRules("backend")
+	must(t, err)
+	if len(rules.Rules) != 2 || rules.Rules[0].MinScore != 70 || rules.Rules[0].SeniorReviewers != 2 {
+		t.Fatalf("rules must be ordered from the highest threshold, got %+v", rules.Rules)
+	}
+	if len(rules.Signals) != 2 || rules.Signals[0].Kind != "LABEL" || rules.Signals[1].Weight != 25 {
+		t.Fatalf("unexpected signals: %+v", rules.Signals)
+	}
+	
+	must(t, s.ReplaceRiskRules(&models.TeamRiskRules{TeamName: "backend"}))
+	rules, err = s.GetRiskRules("backend")
+	must(t, err)
+	if len(rules.Rules) != 0 || len(rules.Signals) != 0 {
+		t.Fatalf("rules not cleared: %+v", rules)
+	}
+}
+
+func testPRCreations(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedTeam(t, s, "frontend", "u2")
+	since := time.Now().UTC().Add(-time.Hour)
+	
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	// reviewers of other teams don't count for the owning team
+	must(t, s.AddTeamReviewer("pr-1", "u2", "frontend", "AUTO"))
+	seedPR(t, s, "pr-2", "author")
+	seedPR(t, s, "pr-3", "u2")
+	
+	prs, err := s.GetPRCreations("backend", since)
+	must(t, err)
+	if len(prs) != 2 || prs[0].PullRequestID != "pr-1" || prs[1].PullRequestID != "pr-2" {
+		t.Fatalf("expected team PRs oldest first, got %+v", prs)
+	}
+	if len(prs[0].Reviewers) != 1 || prs[0].Reviewers[0] != "u1" || len(prs[1].Reviewers) != 0 {
+		t.Fatalf("unexpected reviewers: %+v", prs)
+	}
+	if prs[0].ClosedAt != nil {
+		t.Fatalf("open PR has closed_at: %+v", prs[0])
+	}
+	
+	moved, err := s.SetPullRequestStatus("pr-2", "OPEN", "CLOSED")
+	must(t, err)
+	if !moved {
+		t.Fatal("expected pr-2 to close")
+	}
+	prs, err = s.GetPRCreations("backend", since)
+	must(t, err)
+	if prs[1].ClosedAt == nil {
+		t.Fatalf("closed PR has no closed_at: %+v", prs[1])
+	}
+	
+	prs, err = s.GetPRCreations("backend", time.Now().UTC().Add(time.Hour))
+	must(t, err)
+	if len(prs) != 0 {
+		t.Fatalf("PRs created before since returned: %+v", prs)
+	}
+}
+
+func testPRExternalKeys(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author")
+	
+	newPR := func(prID, externalID, externalURL string) *models.PullRequest {
+		return &models.PullRequest{
+			PullRequestID:   prID,
+			PullRequestName: "name-" + prID,
+			AuthorID:        "author",
+			TeamName:        "backend",
+			Status:          "OPEN",
+			Priority:        "NORMAL",
+			CreatedAt:       time.Now().UTC(),
+			ExternalID:      externalID,
+			ExternalURL:     externalURL,
+		}
+	}
+	must(t, s.CreatePullRequest(newPR("org/api#1", "github:1", "https://github.com/org/api/pull/1")))
+	// PRs without external keys don't conflict
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "author")
+	
+	pr, err := s.GetPullRequest("org/api#1")
+	must(t, err)
+	if pr.ExternalID != "github:1" || pr.ExternalURL != "https://github.com/org/api/pull/1" {
+		t.Fatalf("external keys not stored: %+v", pr)
+	}
+	
+	for _, key := range [][2]string{{"github:1", ""}, {"", "https://github.com/org/api/pull/1"}, {"github:1", "https://other"}} {
+		prID, err := s.GetPRIDByExternalKey(key[0], key[1])
+		must(t, err)
+		if prID != "org/api#1" {
+			t.Fatalf("PR not found by %v, got %q", key, prID)
+		}
+	}
+	prID, err := s.GetPRIDByExternalKey("", "")
+	must(t, err)
+	if prID != "" {
+		t.Fatalf("empty keys must not match, got %q", prID)
+	}
+	
+	if err := s.CreatePullRequest(newPR("renamed/api#1", "github:1", "")); err == nil {
+		t.Fatal("duplicate external_id must fail")
+	}
+	if err := s.CreatePullRequest(newPR("renamed/api#1", "", "https://github.com/org/api/pull/1")); err == nil {
+		t.Fatal("duplicate external_url must fail")
+	}
+}
+
+func testMovableAssignments(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2", "u3")
+	seedTeam(t, s, "frontend", "u4")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.AddReviewer("pr-1", "u2", "MANUAL"))
+	must(t, s.AddReviewer("pr-1", "u3", "AUTO"))
+	must(t, s.AddTeamReviewer("pr-1", "u4", "frontend", "AUTO"))
+	// started reviews stay with their reviewer
+	must(t, s.RecordReviewAction("pr-1", "u3", "ACCEPTED"))
+	
+	assignments, err := s.GetMovableAssignments("backend")
+	must(t, err)
+	if len(assignments) != 1 || assignments[0].ReviewerID != "u1" || assignments[0].TeamName != "backend" {
+		t.Fatalf("expected only u1's automatic untouched review, got %+v", assignments)
+	}
+	
+	assignments, err = s.GetMovableAssignments("frontend")
+	must(t, err)
+	if len(assignments) != 1 || assignments[0].ReviewerID != "u4" || assignments[0].TeamName != "frontend" {
+		t.Fatalf("expected the review u4 does for frontend, got %+v", assignments)
+	}
+	
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{MergedBy: "author"}))
+	assignments, err = s.GetMovableAssignments("backend")
+	must(t, err)
+	if len(assignments) != 0 {
+		t.Fatalf("reviews of merged PR are not movable: %+v", assignments)
+	}
+}
+
+func testTeamBootstrapRollback(t *testing.T, s storage.Storage) {
+	boom := errors.New("boom")
+	err := s.InTx(func(repos storage.Repos) error {
+		must(t, repos.CreateTeam("backend"))
+		must(t, repos.SaveTeamSettings(&models.TeamSettings{TeamName: "backend", ReviewSLAHours: 8, ReviewerCount: 3}))
+		must(t, repos.SaveTeamPolicy(&models.TeamPolicy{TeamName: "backend", Source: "0"}))
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected fn error, got %v", err)
+	}
+	exists, err := s.TeamExists("backend")
+	must(t, err)
+	if exists {
+		t.Fatal("team of rolled back transaction exists")
+	}
+	
+	must(t, s.InTx(func(repos storage.Repos) error {
+		if err := repos.CreateTeam("backend"); err != nil {
+			return err
+		}
+		if err := repos.SaveTeamSettings(&models.TeamSettings{TeamName: "backend", ReviewSLAHours: 8, ReviewerCount: 3}); err != nil {
+			return err
+		}
+		return repos.SaveTeamPolicy(&models.TeamPolicy{TeamName: "backend", Source: "0"})
+	}))
+	settings, err := s.GetTeamSettings("backend")
+	must(t, err)
+	if settings == nil || settings.ReviewSLAHours != 8 || settings.ReviewerCount != 3 {
+		t.Fatalf("settings not saved in transaction: %+v", settings)
+	}
+	policy, err := s.GetTeamPolicy("backend")
+	must(t, err)
+	if policy == nil || policy.Source != "0" {
+		t.Fatalf("policy not saved in transaction: %+v", policy)
+	}
+}
+
+func testStatusChecks(t *testing.T, s storage.Storage) {
+	must(t, s.Ping(context.Background()))
+	
+	started := time.Now().UTC().Truncate(time.Second)
+	must(t, s.RecordJobRun("escalations", "host-1", started, started.Add(time.Second), "boom"))
+	must(t, s.RecordJobRun("escalations", "host-2", started.Add(time.Minute), started.Add(time.Minute+time.Second), ""))
+	runs, err := s.GetSchedulerRuns()
+	must(t, err)
+	if len(runs) != 1 || runs[0].Holder != "host-2" || runs[0].LastError != "" || !runs[0].StartedAt.Equal(started.Add(time.Minute)) {
+		t.Fatalf("expected the latest run only, got %+v", runs)
+	}
+	
+	must(t, s.EnqueueJob(&models.Job{Kind: "PUBLISH_EVENT"}))
+	must(t, s.EnqueueJob(&models.Job{Kind: "PUBLISH_EVENT"}))
+	depths, err := s.GetQueueDepths()
+	must(t, err)
+	byName := make(map[string]models.QueueDepth, len(depths))
+	for _, depth := range depths {
+		byName[depth.Name] = depth
+	}
+	if depth := byName["PUBLISH_EVENT"]; depth.Pending != 2 || depth.OldestAt == nil {
+		t.Fatalf("expected 2 pending PUBLISH_EVENT jobs, got %+v", depth)
+	}
+	if _, ok := byName[storage.QueuePendingAssignments]; !ok {
+		t.Fatalf("pending assignments queue missing: %+v", depths)
+	}
+}
+
+func testLeaderboard(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "author")
+	seedPR(t, s, "pr-3", "author")
+	since := time.Now().UTC().Add(-time.Hour)
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.AddReviewer("pr-2", "u1", "AUTO"))
+	must(t, s.AddReviewer("pr-2", "u2", "AUTO"))
+	must(t, s.RecordReviewAction("pr-1", "u1", "APPROVED"))
+	must(t, s.RecordReviewAction("pr-2", "u1", "APPROVED"))
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{}))
+	_, err := s.SavePRRevert(&models.PRRevert{PullRequestID: "pr-3", RevertedID: "pr-1", TeamName: "backend",
+		RevertedMergedAt: time.Now().UTC(), Reviewers: []string{"u1"}, MarkedBy: "author"})
+	must(t, err)
+	
+	entries, err := s.GetLeaderboardEntries("backend", since)
+	must(t, err)
+	if len(entries) != 2 {
+		t.Fatalf("expected entries of reviewers only, got %+v", entries)
+	}
+	u1, u2 := entries[0], entries[1]
+	if u1.UserID != "u1" || u1.CompletedReviews != 2 || u1.RevertedApprovals != 1 || u1.MedianTurnaroundSeconds == nil {
+		t.Fatalf("unexpected u1 entry: %+v", u1)
+	}
+	if u2.UserID != "u2" || u2.CompletedReviews != 0 || u2.MedianTurnaroundSeconds != nil {
+		t.Fatalf("unexpected u2 entry: %+v", u2)
+	}
+	
+	entries, err = s.GetLeaderboardEntries("backend", time.Now().UTC().Add(time.Hour))
+	must(t, err)
+	if len(entries) != 0 {
+		t.Fatalf("assignments before the period must not count: %+v", entries)
+	}
+}
+
+func testSLAPauses(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.AddReviewer("pr-1", "u2", "AUTO"))
+	
+	paused, err := s.PauseAssignmentSLA("pr-1", "u1")
+	must(t, err)
+	if !paused {
+		t.Fatal("expected the SLA to be paused")
+	}
+	paused, err = s.PauseAssignmentSLA("pr-1", "u1")
+	must(t, err)
+	if paused {
+		t.Fatal("paused SLA must not be paused again")
+	}
+	
+	assignments, err := s.GetOpenAssignmentsByReviewer("u1")
+	must(t, err)
+	if len(assignments) != 1 || assignments[0].SLAPausedAt == nil {
+		t.Fatalf("expected paused assignment, got %+v", assignments)
+	}
+	
+	// the first action while paused keeps the pause out of turnaround
+	must(t, s.RecordReviewAction("pr-1", "u1", "ACCEPTED"))
+	resumed, err := s.ResumeAssignmentSLA("pr-1", "")
+	must(t, err)
+	if len(resumed) != 1 || resumed[0] != "u1" {
+		t.Fatalf("expected u1 resumed, got %v", resumed)
+	}
+	reviewer, err := s.GetReviewerAssignment("pr-1", "u1")
+	must(t, err)
+	if reviewer.SLAPausedAt != nil || reviewer.SLAPausedSeconds < reviewer.FirstActionPausedSeconds {
+		t.Fatalf("unexpected resumed assignment: %+v", reviewer)
+	}
+	
+	resumed, err = s.ResumeAssignmentSLA("pr-1", "u2")
+	must(t, err)
+	if len(resumed) != 0 {
+		t.Fatalf("running SLA must not be resumed, got %v", resumed)
+	}
+}
+
+func testKeysetPagination(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	for _, prID := range []string{"pr-1", "pr-2", "pr-3"} {
+		seedPR(t, s, prID, "author")
+		must(t, s.AddReviewer(prID, "u1", "AUTO"))
+	}
+	
+	first, err := s.GetPRsByReviewer("u1", newestFirst, models.Page{Limit: 2})
+	must(t, err)
+	if len(first) != 2 {
+		t.Fatalf("expected 2 rows on first page, got %d", len(first))
+	}
+	
+	last := first[len(first)-1]
+	rest, err := s.GetPRsByReviewer("u1", newestFirst, models.Page{
+		After: &models.Cursor{CreatedAt: last.CreatedAt, ID: last.PullRequestID},
+		Limit: 2,
+	})
+	must(t, err)
+	if len(rest) != 1 {
+		t.Fatalf("expected 1 row on second page, got %d", len(rest))
+	}
+	for _, pr := range first {
+		if pr.PullRequestID == rest[0].PullRequestID {
+			t.Fatalf("%s returned on both pages", pr.PullRequestID)
+		}
+	}
+}
+
+func testUnitOfWorkCommit(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	
+	must(t, s.InTx(func(repos storage.Repos) error {
+		if err := repos.RemoveReviewer("pr-1", "u1"); err != nil {
+			return err
+		}
+		return repos.AddReviewer("pr-1", "u2", "VOLUNTEER")
+	}))
+	
+	reviewers, err := s.GetReviewers("pr-1")
+	must(t, err)
+	if len(reviewers) != 1 || reviewers[0] != "u2" {
+		t.Fatalf("transaction not committed, reviewers %v", reviewers)
+	}
+}
+
+func testUnitOfWorkRollback(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.QueuePendingAssignment("pr-1", "backend", 1))
+	
+	errAbort := errors.New("abort")
+	err := s.InTx(func(repos storage.Repos) error {
+		if err := repos.RemoveReviewer("pr-1", "u1"); err != nil {
+			return err
+		}
+		if err := repos.AddReviewer("pr-1", "u2", "AUTO"); err != nil {
+			return err
+		}
+		if err := repos.MergePullRequest("pr-1", models.MergeInfo{MergedBy: "author"}); err != nil {
+			return err
+		}
+		if err := repos.DeletePendingAssignments("pr-1"); err != nil {
+			return err
+		}
+		if err := repos.AddPREvent(&models.PREvent{PullRequestID: "pr-1", EventType: "PR_MERGED"}); err != nil {
+			return err
+		}
+		if err := repos.AddAuditEntry(&models.AuditEntry{ActorID: "author", Action: "MERGE", PullRequestID: "pr-1"}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("InTx must return fn error, got %v", err)
+	}
+	
+	reviewers, err := s.GetReviewers("pr-1")
+	must(t, err)
+	if len(reviewers) != 1 || reviewers[0] != "u1" {
+		t.Fatalf("transaction not rolled back, reviewers %v", reviewers)
+	}
+	pr, err := s.GetPullRequest("pr-1")
+	must(t, err)
+	if pr.Status != "OPEN" {
+		t.Fatalf("merge of rolled back transaction is stored: %+v", pr)
+	}
+	pending, err := s.GetPendingAssignments("backend")
+	must(t, err)
+	if len(pending) != 1 {
+		t.Fatalf("pending assignment of rolled back transaction is deleted: %+v", pending)
+	}
+	events, err := s.GetPREvents("pr-1")
+	must(t, err)
+	entries, err := s.ListAuditEntries(models.Page{Limit: 10})
+	must(t, err)
+	if len(events) != 0 || len(entries) != 0 {
+		t.Fatalf("history of rolled back transaction is stored: %+v, %+v", events, entries)
+	}
+}
+
+func testTeamLock(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend")
+	
+	errInside := errors.New("inside")
+	if err := s.WithTeamLock("backend", func() error { return errInside }); !errors.Is(err, errInside) {
+		t.Fatalf("WithTeamLock must return fn error, got %v", err)
+	}
+	
+	// the lock is released, second holder doesn't block
+	done := make(chan error, 1)
+	go func() {
+		done <- s.WithTeamLock("backend", func() error { return nil })
+	}()
+	select {
+	case err := <-done:
+		must(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("team lock was not released")
+	}
+}
+
+func testLeases(t *testing.T, s storage.Storage) {
+	acquired, err := s.AcquireLease("scheduler", "a", time.Minute)
+	must(t, err)
+	if !acquired {
+		t.Fatal("free lease not acquired")
+	}
+	
+	acquired, err = s.AcquireLease("scheduler", "b", time.Minute)
+	must(t, err)
+	if acquired {
+		t.Fatal("lease held by another holder was acquired")
+	}
+	
+	acquired, err = s.AcquireLease("scheduler", "a", time.Minute)
+	must(t, err)
+	if !acquired {
+		t.Fatal("holder can't renew own lease")
+	}
+	
+	// release by non-holder is ignored
+	must(t, s.ReleaseLease("scheduler", "b"))
+	must(t, s.ReleaseLease("scheduler", "a"))
+	
+	acquired, err = s.AcquireLease("scheduler", "b", time.Minute)
+	must(t, err)
+	if !acquired {
+		t.Fatal("released lease not acquired")
+	}
+}
+
+func testWebhookDeliveries(t *testing.T, s storage.Storage) {
+	recorded, err := s.RecordWebhookDelivery("github", "d1", time.Hour)
+	must(t, err)
+	if !recorded {
+		t.Fatal("new delivery not recorded")
+	}
+	recorded, err = s.RecordWebhookDelivery("github", "d1", time.Hour)
+	must(t, err)
+	if recorded {
+		t.Fatal("repeated delivery recorded")
+	}
+	// ids are unique per source
+	recorded, err = s.RecordWebhookDelivery("gitlab", "d1", time.Hour)
+	must(t, err)
+	if !recorded {
+		t.Fatal("delivery of another source not recorded")
+	}
+	
+	must(t, s.ForgetWebhookDelivery("github", "d1"))
+	recorded, err = s.RecordWebhookDelivery("github", "d1", -time.Second)
+	must(t, err)
+	if !recorded {
+		t.Fatal("forgotten delivery not recorded")
+	}
+	// an expired delivery may come again
+	recorded, err = s.RecordWebhookDelivery("github", "d1", time.Hour)
+	must(t, err)
+	if !recorded {
+		t.Fatal("expired delivery not recorded")
+	}
+}
+
+func testJobQueue(t *testing.T, s storage.Storage) {
+	job := &models.Job{Kind: "TEST", Payload: []byte(`{"n":1}`), MaxAttempts: 2}
+	must(t, s.EnqueueJob(job))
+	if job.ID == 0 || job.Status != "QUEUED" {
+		t.Fatalf("job not enqueued: %+v", job)
+	}
+	
+	claimed, err := s.ClaimJobs("w1", 10, time.Minute)
+	must(t, err)
+	if len(claimed) != 1 || claimed[0].ID != job.ID || claimed[0].Attempts != 1 {
+		t.Fatalf("unexpected claim: %+v", claimed)
+	}
+	
+	// claimed job is invisible to other workers
+	claimed, err = s.ClaimJobs("w2", 10, time.Minute)
+	must(t, err)
+	if len(claimed) != 0 {
+		t.Fatalf("running job claimed twice: %+v", claimed)
+	}
+	
+	must(t, s.FailJob(job.ID, "boom", nil))
+	dead, err := s.ListJobs("DEAD", models.Page{Limit: 10})
+	must(t, err)
+	if len(dead) != 1 || dead[0].ID != job.ID {
+		t.Fatalf("failed job without retry must be DEAD: %+v", dead)
+	}
+	
+	must(t, s.RetryJob(job.ID))
+	if err := s.RetryJob(job.ID); err == nil {
+		t.Fatal("retrying non-dead job must fail")
+	}
+	
+	claimed, err = s.ClaimJobs("w1", 10, time.Minute)
+	must(t, err)
+	if len(claimed) != 1 || claimed[0].Attempts != 1 {
+		t.Fatalf("retried job must start a fresh attempt budget: %+v", claimed)
+	}
+	
+	must(t, s.CompleteJob(job.ID))
+	done, err := s.ListJobs("DONE", models.Page{Limit: 10})
+	must(t, err)
+	if len(done) != 1 || done[0].ID != job.ID {
+		t.Fatalf("job not completed: %+v", done)
+	}
+}
+
+func testTeamListing(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	seedTeam(t, s, "frontend")
+	seedTeam(t, s, "mobile", "u3")
+	
+	first, err := s.ListTeams(models.Page{Limit: 2})
+	must(t, err)
+	if len(first) != 2 || first[0].TeamName != "backend" || first[0].MemberCount != 2 || first[1].MemberCount != 0 {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+	last := first[len(first)-1]
+	rest, err := s.ListTeams(models.Page{After: &models.Cursor{CreatedAt: last.CreatedAt, ID: last.TeamName}, Limit: 2})
+	must(t, err)
+	if len(rest) != 1 || rest[0].TeamName != "mobile" || rest[0].MemberCount != 1 {
+		t.Fatalf("unexpected second page: %+v", rest)
+	}
+	
+	names, err := s.GetTeamNames()
+	must(t, err)
+	if len(names) != 3 || names[0] != "backend" || names[2] != "mobile" {
+		t.Fatalf("team names must be ordered: %v", names)
+	}
+}
+
+func testFirstReviewStats(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedTeam(t, s, "frontend", "u3")
+	seedPR(t, s, "pr-1", "author")
+	for _, userID := range []string{"u1", "u2", "u3"} {
+		must(t, s.AddReviewer("pr-1", userID, "AUTO"))
+	}
+	must(t, s.RecordReviewAction("pr-1", "u1", "APPROVED"))
+	must(t, s.RecordReviewAction("pr-1", "u3", ""))
+	
+	// u2 hasn't acted yet, so it's no sample
+	since := time.Now().UTC().Add(-time.Hour)
+	byTeam, err := s.GetFirstReviewStatsByTeam("", since)
+	must(t, err)
+	if len(byTeam) != 2 || byTeam[0].TeamName != "backend" || byTeam[0].Samples != 1 || byTeam[1].TeamName != "frontend" {
+		t.Fatalf("unexpected team stats: %+v", byTeam)
+	}
+	
+	byUser, err := s.GetFirstReviewStatsByUser("u1", since)
+	must(t, err)
+	if len(byUser) != 1 || byUser[0].UserID != "u1" || byUser[0].TeamName != "backend" || byUser[0].P90Seconds < 0 {
+		t.Fatalf("unexpected user stats: %+v", byUser)
+	}
+	
+	later, err := s.GetFirstReviewStatsByTeam("backend", time.Now().UTC().Add(time.Hour))
+	must(t, err)
+	if len(later) != 0 {
+		t.Fatalf("assignments before since must not count: %+v", later)
+	}
+}
+
+func testCalendarTokens(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	
+	expires := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	must(t, s.SaveCalendarToken(&models.CalendarToken{UserID: "u2", RefreshToken: "r2", AccessToken: "a2", ExpiresAt: expires}))
+	must(t, s.SaveCalendarToken(&models.CalendarToken{UserID: "u1", RefreshToken: "r1", AccessToken: "a1", ExpiresAt: expires}))
+	synced := expires.Add(-30 * time.Minute)
+	must(t, s.SaveCalendarToken(&models.CalendarToken{UserID: "u1", RefreshToken: "r1", AccessToken: "a1-refreshed",
+		ExpiresAt: expires.Add(time.Hour), LastSyncedAt: &synced}))
+	
+	tokens, err := s.GetCalendarTokens()
+	must(t, err)
+	if len(tokens) != 2 || tokens[0].UserID != "u1" || tokens[0].AccessToken != "a1-refreshed" ||
+		!tokens[0].ExpiresAt.Equal(expires.Add(time.Hour)) || tokens[0].LastSyncedAt == nil || tokens[1].LastSyncedAt != nil {
+		t.Fatalf("saving the token again must update it: %+v", tokens)
+	}
+	
+	must(t, s.DeleteCalendarToken("u1"))
+	must(t, s.DeleteCalendarToken("u1"))
+	tokens, err = s.GetCalendarTokens()
+	must(t, err)
+	if len(tokens) != 1 || tokens[0].UserID != "u2" {
+		t.Fatalf("deleted token must be gone: %+v", tokens)
+	}
+	
+	recorded, err := s.RecordCalendarState("n1", time.Hour)
+	must(t, err)
+	if !recorded {
+		t.Fatal("new state not recorded")
+	}
+	if recorded, err = s.RecordCalendarState("n1", time.Hour); err != nil || recorded {
+		t.Fatalf("state must be used once: %v, %v", recorded, err)
+	}
+	if recorded, err = s.RecordCalendarState("n2", -time.Second); err != nil || !recorded {
+		t.Fatalf("another state must be recorded: %v, %v", recorded, err)
+	}
+	// an expired state is dropped, its signed issue time keeps it from being accepted again
+	if recorded, err = s.RecordCalendarState("n2", time.Hour); err != nil || !recorded {
+		t.Fatalf("expired state must be recorded again: %v, %v", recorded, err)
+	}
+}
+
+func testChecklists(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.AddReviewer("pr-1", "u2", "AUTO"))
+	
+	template, err := s.GetChecklistTemplate("backend")
+	must(t, err)
+	if len(template) != 0 {
+		t.Fatalf("team without a checklist must have an empty template: %v", template)
+	}
+	must(t, s.ReplaceChecklistTemplate("backend", []string{"tests", "docs", "migrations"}))
+	must(t, s.ReplaceChecklistTemplate("backend", []string{"tests", "docs"}))
+	template, err = s.GetChecklistTemplate("backend")
+	must(t, err)
+	if len(template) != 2 || template[0] != "tests" || template[1] != "docs" {
+		t.Fatalf("template must be replaced in order: %v", template)
+	}
+	
+	must(t, s.CreateReviewChecklist("pr-1", "u1", template))
+	must(t, s.CreateReviewChecklist("pr-1", "u2", template[:1]))
+	items, err := s.GetReviewChecklist("pr-1", "u1")
+	must(t, err)
+	if len(items) != 2 || items[0].Title != "tests" || items[0].Checked || items[0].CheckedAt != nil {
+		t.Fatalf("unexpected checklist: %+v", items)
+	}
+	unchecked, err := s.CountUncheckedItems("pr-1")
+	must(t, err)
+	if unchecked != 3 {
+		t.Fatalf("expected 3 unchecked items of both reviewers, got %d", unchecked)
+	}
+	
+	must(t, s.SetChecklistItemChecked("pr-1", "u1", items[0].ID, true))
+	if err := s.SetChecklistItemChecked("pr-1", "u2", items[1].ID, true); err == nil {
+		t.Fatal("reviewer must not check an item of another reviewer")
+	}
+	items, err = s.GetReviewChecklist("pr-1", "u1")
+	must(t, err)
+	if !items[0].Checked || items[0].CheckedAt == nil || items[1].Checked {
+		t.Fatalf("only the first item must be checked: %+v", items)
+	}
+	if unchecked, err = s.CountUncheckedItems("pr-1"); err != nil || unchecked != 2 {
+		t.Fatalf("expected 2 unchecked items, got %d, %v", unchecked, err)
+	}
+	
+	must(t, s.SetChecklistItemChecked("pr-1", "u1", items[0].ID, false))
+	if unchecked, err = s.CountUncheckedItems("pr-1"); err != nil || unchecked != 3 {
+		t.Fatalf("unchecked item must count again, got %d, %v", unchecked, err)
+	}
+	
+	// a removed reviewer's checklist goes with the assignment
+	must(t, s.RemoveReviewer("pr-1", "u2"))
+	if unchecked, err = s.CountUncheckedItems("pr-1"); err != nil || unchecked != 2 {
+		t.Fatalf("removed reviewer's items must not count, got %d, %v", unchecked, err)
+	}
+}
+
+func testReviewCapacity(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedTeam(t, s, "frontend", "fauthor")
+	
+	limit := 2
+	must(t, s.SetUserMaxOpenReviews("u1", &limit))
+	if err := s.SetUserMaxOpenReviews("missing", &limit); err == nil {
+		t.Fatal("SetUserMaxOpenReviews of missing user must fail")
+	}
+	user, err := s.GetUser("u1")
+	must(t, err)
+	if user.MaxOpenReviews == nil || *user.MaxOpenReviews != 2 {
+		t.Fatalf("personal cap not stored: %+v", user.MaxOpenReviews)
+	}
+	must(t, s.SetUserMaxOpenReviews("u1", nil))
+	if user, err = s.GetUser("u1"); err != nil || user.MaxOpenReviews != nil {
+		t.Fatalf("nil cap must fall back to the team cap: %+v, %v", user, err)
+	}
+	
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "fauthor")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.AddReviewer("pr-1", "u2", "AUTO"))
+	must(t, s.AddReviewer("pr-2", "u1", "MANUAL"))
+	
+	// assignments count by the PR's team, not the reviewer's
+	count, err := s.CountTeamAssignmentsSince("backend", time.Now().UTC().Add(-time.Hour))
+	must(t, err)
+	if count != 2 {
+		t.Fatalf("expected 2 assignments on backend PRs, got %d", count)
+	}
+	if count, err = s.CountTeamAssignmentsSince("backend", time.Now().UTC().Add(time.Hour)); err != nil || count != 0 {
+		t.Fatalf("assignments before since must not count, got %d, %v", count, err)
+	}
+}
+
+func testReadModels(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedPR(t, s, "pr-1", "author")
+	apply := func(eventType string, payload map[string]interface{}) {
+		t.Helper()
+		event := &models.PREvent{PullRequestID: "pr-1", EventType: eventType, Payload: payload}
+		must(t, s.AddPREvent(event))
+		must(t, s.ApplyStatsEvent(event))
+	}
+	
+	apply("PR_CREATED", nil)
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	apply("REVIEWER_ASSIGNED", map[string]interface{}{"user_id": "u1"})
+	loads, err := s.GetOpenReviewLoads("backend")
+	must(t, err)
+	if len(loads) != 3 || loads["u1"] != 1 || loads["u2"] != 0 {
+		t.Fatalf("assignment must add to the load: %v", loads)
+	}
+	
+	must(t, s.RemoveReviewer("pr-1", "u1"))
+	must(t, s.AddReviewer("pr-1", "u2", "AUTO"))
+	apply("REVIEWER_REASSIGNED", map[string]interface{}{"old_user_id": "u1", "new_user_id": "u2"})
+	if loads, err = s.GetOpenReviewLoads("backend"); err != nil || loads["u1"] != 0 || loads["u2"] != 1 {
+		t.Fatalf("reassignment must move the load: %v, %v", loads, err)
+	}
+	
+	must(t, s.MergePullRequest("pr-1", models.MergeInfo{}))
+	apply("PR_MERGED", nil)
+	if loads, err = s.GetOpenReviewLoads("backend"); err != nil || loads["u2"] != 0 {
+		t.Fatalf("merge must release the load: %v, %v", loads, err)
+	}
+	
+	now := time.Now().UTC()
+	report := &models.TeamReport{TeamName: "backend", From: now.Add(-7 * 24 * time.Hour), To: now.Add(7 * 24 * time.Hour)}
+	must(t, s.FillTeamReport(report))
+	if report.PRsCreated != 1 || report.PRsMerged != 1 || report.Assignments != 2 || report.AvgTurnaroundHours == nil {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	
+	// an event that was never stored drifts the read models until they are rebuilt
+	must(t, s.ApplyStatsEvent(&models.PREvent{PullRequestID: "pr-1", EventType: "REVIEWER_ASSIGNED",
+		Payload: map[string]interface{}{"user_id": "u1"}, CreatedAt: now}))
+	if loads, err = s.GetOpenReviewLoads("backend"); err != nil || loads["u1"] != 1 {
+		t.Fatalf("unexpected drifted load: %v, %v", loads, err)
+	}
+	must(t, s.RebuildReadModels())
+	if loads, err = s.GetOpenReviewLoads("backend"); err != nil || loads["u1"] != 0 || loads["u2"] != 0 {
+		t.Fatalf("rebuilt load must come from open PRs: %v, %v", loads, err)
+	}
+	report = &models.TeamReport{TeamName: "backend", From: now.Add(-7 * 24 * time.Hour), To: now.Add(7 * 24 * time.Hour)}
+	must(t, s.FillTeamReport(report))
+	if report.PRsCreated != 1 || report.PRsMerged != 1 || report.Assignments != 2 {
+		t.Fatalf("rebuilt report must count stored events: %+v", report)
+	}
+}
+
+func testEscalations(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "lead1", "lead2")
+	for _, leadID := range []string{"lead1", "lead2"} {
+		must(t, s.CreateOrUpdateUser(&models.User{UserID: leadID, Username: "name-" + leadID, TeamName: "backend",
+			IsActive: true, Role: "lead"}))
+	}
+	must(t, s.SetUserActive("lead2", false))
+	
+	leads, err := s.GetTeamLeads("backend")
+	must(t, err)
+	if len(leads) != 1 || leads[0].UserID != "lead1" {
+		t.Fatalf("only active leads must be returned: %+v", leads)
+	}
+	
+	must(t, s.ReplaceEscalationRules("backend", []models.EscalationRule{
+		{OverdueHours: 24, Action: "REASSIGN"},
+		{OverdueHours: 4, Action: "NOTIFY_LEAD"},
+	}))
+	if err := s.ReplaceEscalationRules("backend", []models.EscalationRule{{OverdueHours: 1, Action: "PAGE"}}); err == nil {
+		t.Fatal("unknown escalation action must be rejected")
+	}
+	rules, err := s.GetEscalationRules("backend")
+	must(t, err)
+	if len(rules) != 2 || rules[0].OverdueHours != 4 || rules[0].Action != "NOTIFY_LEAD" || rules[0].ID == 0 ||
+		rules[1].TeamName != "backend" {
+		t.Fatalf("failed replacement must keep the rules ordered by hours: %+v", rules)
+	}
+	
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.AddReviewer("pr-2", "u1", "AUTO"))
+	must(t, s.MergePullRequest("pr-2", models.MergeInfo{}))
+	assignments, err := s.GetOpenAssignments()
+	must(t, err)
+	if len(assignments) != 1 || assignments[0].PullRequestID != "pr-1" || assignments[0].ReviewerID != "u1" ||
+		assignments[0].TeamName != "backend" || assignments[0].ReviewerTeam != "backend" {
+		t.Fatalf("only reviews of open PRs must be returned: %+v", assignments)
+	}
+	
+	applied, err := s.EscalationApplied("pr-1", "u1", rules[0].ID)
+	must(t, err)
+	if applied {
+		t.Fatal("rule applied before it was recorded")
+	}
+	recorded, err := s.RecordEscalation("pr-1", "u1", rules[0].ID)
+	must(t, err)
+	if !recorded {
+		t.Fatal("first escalation must be recorded")
+	}
+	if applied, err = s.EscalationApplied("pr-1", "u1", rules[0].ID); err != nil || !applied {
+		t.Fatalf("recorded rule must be applied: %v, %v", applied, err)
+	}
+	if recorded, err = s.RecordEscalation("pr-1", "u1", rules[0].ID); err != nil || recorded {
+		t.Fatalf("rule must apply to the assignment once: %v, %v", recorded, err)
+	}
+	if recorded, err = s.RecordEscalation("pr-1", "u1", rules[1].ID); err != nil || !recorded {
+		t.Fatalf("another rule must be recorded: %v, %v", recorded, err)
+	}
+}
+
+func testSLABreaches(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	
+	assignedAt := time.Now().UTC().Add(-48 * time.Hour).Truncate(time.Second)
+	recorded, err := s.RecordSLABreach("pr-1", "u1", assignedAt)
+	must(t, err)
+	if !recorded {
+		t.Fatal("first breach must be recorded")
+	}
+	if recorded, err = s.RecordSLABreach("pr-1", "u1", assignedAt); err != nil || recorded {
+		t.Fatalf("breach must be alerted once: %v, %v", recorded, err)
+	}
+	// a later assignment of the same reviewer is another breach
+	if recorded, err = s.RecordSLABreach("pr-1", "u1", assignedAt.Add(time.Hour)); err != nil || !recorded {
+		t.Fatalf("breach of a new assignment must be recorded: %v, %v", recorded, err)
+	}
+	
+	must(t, s.DeleteSLABreach("pr-1", "u1", assignedAt))
+	if recorded, err = s.RecordSLABreach("pr-1", "u1", assignedAt); err != nil || !recorded {
+		t.Fatalf("deleted breach must be alerted again: %v, %v", recorded, err)
+	}
+}
+
+func testDigests(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2", "u3")
+	
+	hour := 9
+	must(t, s.SetUserDigest("u1", &hour, "Europe/Moscow"))
+	must(t, s.SetUserDigest("u3", &hour, "UTC"))
+	must(t, s.SetUserActive("u3", false))
+	if err := s.SetUserDigest("missing", &hour, "UTC"); err == nil {
+		t.Fatal("SetUserDigest of missing user must fail")
+	}
+	
+	subscribers, err := s.GetDigestSubscribers()
+	must(t, err)
+	if len(subscribers) != 1 || subscribers[0].UserID != "u1" || subscribers[0].DigestHour == nil ||
+		*subscribers[0].DigestHour != 9 || subscribers[0].Timezone != "Europe/Moscow" || subscribers[0].LastDigestAt != nil {
+		t.Fatalf("only active users with a digest hour must subscribe: %+v", subscribers)
+	}
+	
+	sentAt := time.Now().UTC().Truncate(time.Second)
+	must(t, s.MarkDigestSent("u1", sentAt))
+	user, err := s.GetUser("u1")
+	must(t, err)
+	if user.LastDigestAt == nil || !user.LastDigestAt.Equal(sentAt) {
+		t.Fatalf("digest time not stored: %v", user.LastDigestAt)
+	}
+	
+	must(t, s.SetUserDigest("u1", nil, "UTC"))
+	if subscribers, err = s.GetDigestSubscribers(); err != nil || len(subscribers) != 0 {
+		t.Fatalf("nil hour must disable the digest: %+v, %v", subscribers, err)
+	}
+}
+
+func testTeamReports(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedTeam(t, s, "frontend", "u3")
+	for _, prID := range []string{"pr-1", "pr-2", "pr-3"} {
+		seedPR(t, s, prID, "author")
+	}
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	must(t, s.AddReviewer("pr-2", "u1", "AUTO"))
+	must(t, s.AddReviewer("pr-1", "u3", "AUTO"))
+	must(t, s.AddReviewer("pr-3", "u2", "AUTO"))
+	must(t, s.MergePullRequest("pr-3", models.MergeInfo{}))
+	
+	// u2's only review is merged, u3 belongs to another team
+	bottlenecks, err := s.GetTeamBottlenecks("backend", 5)
+	must(t, err)
+	if len(bottlenecks) != 1 || bottlenecks[0].UserID != "u1" || bottlenecks[0].OpenReviews != 2 ||
+		bottlenecks[0].OldestAssignedAt.IsZero() {
+		t.Fatalf("unexpected bottlenecks: %+v", bottlenecks)
+	}
+	
+	recorded, err := s.RecordTeamReport("backend", "2030-W01")
+	must(t, err)
+	if !recorded {
+		t.Fatal("first report must be recorded")
+	}
+	if recorded, err = s.RecordTeamReport("backend", "2030-W01"); err != nil || recorded {
+		t.Fatalf("weekly report must be delivered once: %v, %v", recorded, err)
+	}
+	if recorded, err = s.RecordTeamReport("backend", "2030-W02"); err != nil || !recorded {
+		t.Fatalf("report of the next week must be recorded: %v, %v", recorded, err)
+	}
+}
+
+func testNotificationTemplates(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend")
+	
+	body, err := s.GetNotificationTemplate("backend", "DIGEST")
+	must(t, err)
+	if body != "" {
+		t.Fatalf("team without a template must use the default, got %q", body)
+	}
+	
+	must(t, s.SaveNotificationTemplate("backend", "DIGEST", "first"))
+	must(t, s.SaveNotificationTemplate("backend", "DIGEST", "second"))
+	must(t, s.SaveNotificationTemplate("backend", "SLA_BREACH", "breach"))
+	if body, err = s.GetNotificationTemplate("backend", "DIGEST"); err != nil || body != "second" {
+		t.Fatalf("saving the template again must update it: %q, %v", body, err)
+	}
+	templates, err := s.GetNotificationTemplates("backend")
+	must(t, err)
+	if len(templates) != 2 || templates["DIGEST"] != "second" || templates["SLA_BREACH"] != "breach" {
+		t.Fatalf("unexpected templates: %v", templates)
+	}
+	
+	must(t, s.DeleteNotificationTemplate("backend", "DIGEST"))
+	must(t, s.DeleteNotificationTemplate("backend", "DIGEST"))
+	if body, err = s.GetNotificationTemplate("backend", "DIGEST"); err != nil || body != "" {
+		t.Fatalf("deleted template must fall back to the default: %q, %v", body, err)
+	}
+	if templates, err = s.GetNotificationTemplates("backend"); err != nil || len(templates) != 1 {
+		t.Fatalf("only the other template must stay: %v, %v", templates, err)
+	}
+}
+
+func testPRSubscriptions(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedPR(t, s, "pr-1", "author")
+	
+	muted, err := s.IsPRMuted("pr-1", "u1")
+	must(t, err)
+	if muted {
+		t.Fatal("PR muted before muting")
+	}
+	must(t, s.MutePR("pr-1", "u1"))
+	must(t, s.MutePR("pr-1", "u1"))
+	if muted, err = s.IsPRMuted("pr-1", "u1"); err != nil || !muted {
+		t.Fatalf("PR must be muted: %v, %v", muted, err)
+	}
+	if muted, err = s.IsPRMuted("pr-1", "u2"); err != nil || muted {
+		t.Fatalf("mute must be personal: %v, %v", muted, err)
+	}
+	must(t, s.UnmutePR("pr-1", "u1"))
+	if muted, err = s.IsPRMuted("pr-1", "u1"); err != nil || muted {
+		t.Fatalf("PR must be unmuted: %v, %v", muted, err)
+	}
+	if err := s.MutePR("missing", "u1"); err == nil {
+		t.Fatal("muting a missing PR must fail")
+	}
+	
+	watchers, err := s.GetPRWatchers("pr-1")
+	must(t, err)
+	if watchers == nil || len(watchers) != 0 {
+		t.Fatalf("PR without watchers must return an empty list: %#v", watchers)
+	}
+	must(t, s.AddPRWatcher("pr-1", "u2"))
+	must(t, s.AddPRWatcher("pr-1", "u1"))
+	must(t, s.AddPRWatcher("pr-1", "u2"))
+	if watchers, err = s.GetPRWatchers("pr-1"); err != nil || len(watchers) != 2 {
+		t.Fatalf("watching twice must be kept once: %v, %v", watchers, err)
+	}
+	must(t, s.RemovePRWatcher("pr-1", "u2"))
+	if watchers, err = s.GetPRWatchers("pr-1"); err != nil || len(watchers) != 1 || watchers[0] != "u1" {
+		t.Fatalf("removed watcher must be gone: %v, %v", watchers, err)
+	}
+}
+
+func testPRComments(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	
+	first := &models.PRComment{PullRequestID: "pr-1", AuthorID: "u1", Body: "looks good"}
+	must(t, s.AddPRComment(first))
+	second := &models.PRComment{PullRequestID: "pr-1", AuthorID: "author", Body: "thanks"}
+	must(t, s.AddPRComment(second))
+	if first.ID == 0 || first.CreatedAt.IsZero() || second.ID <= first.ID {
+		t.Fatalf("comments must be stamped: %+v, %+v", first, second)
+	}
+	
+	if err := s.AddPRComment(&models.PRComment{PullRequestID: "missing", AuthorID: "u1", Body: "x"}); err == nil {
+		t.Fatal("comment on a missing PR must fail")
+	}
+}
+
+func testHandoffs(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2", "u3")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	
+	now := time.Now().UTC()
+	handoff := &models.ReviewerHandoff{PullRequestID: "pr-1", FromUserID: "u1", ToUserID: "u2", ExpiresAt: now.Add(time.Hour)}
+	created, err := s.CreateHandoff(handoff)
+	must(t, err)
+	if !created || handoff.ID == 0 || handoff.Status != "PENDING" {
+		t.Fatalf("handoff must be created pending: %+v", handoff)
+	}
+	created, err = s.CreateHandoff(&models.ReviewerHandoff{PullRequestID: "pr-1", FromUserID: "u1", ToUserID: "u3",
+		ExpiresAt: now.Add(time.Hour)})
+	must(t, err)
+	if created {
+		t.Fatal("reviewer can't have two pending handoffs on a PR")
+	}
+	
+	stored, err := s.GetHandoff(handoff.ID)
+	must(t, err)
+	if stored.ToUserID != "u2" || stored.Status != "PENDING" || stored.ResolvedAt != nil {
+		t.Fatalf("unexpected handoff: %+v", stored)
+	}
+	if _, err := s.GetHandoff(handoff.ID + 1000); err == nil {
+		t.Fatal("GetHandoff of missing handoff must fail")
+	}
+	
+	must(t, s.CompleteHandoff(handoff.ID, now))
+	if err := s.CompleteHandoff(handoff.ID, now); err == nil {
+		t.Fatal("accepted handoff must not be completed again")
+	}
+	reviewers, err := s.GetReviewers("pr-1")
+	must(t, err)
+	if len(reviewers) != 1 || reviewers[0] != "u2" {
+		t.Fatalf("accepted handoff must swap reviewers: %v", reviewers)
+	}
+	assignment, err := s.GetReviewerAssignment("pr-1", "u2")
+	must(t, err)
+	if assignment.AssignmentType != "MANUAL" {
+		t.Fatalf("handed off review must be manual: %+v", assignment)
+	}
+	
+	declined := &models.ReviewerHandoff{PullRequestID: "pr-1", FromUserID: "u2", ToUserID: "u3", ExpiresAt: now.Add(time.Hour)}
+	if created, err = s.CreateHandoff(declined); err != nil || !created {
+		t.Fatalf("new handoff must be created: %v, %v", created, err)
+	}
+	must(t, s.ResolveHandoff(declined.ID, "DECLINED", now))
+	if err := s.ResolveHandoff(declined.ID, "DECLINED", now); err == nil {
+		t.Fatal("resolved handoff must not be resolved again")
+	}
+	if stored, err = s.GetHandoff(declined.ID); err != nil || stored.Status != "DECLINED" || stored.ResolvedAt == nil {
+		t.Fatalf("handoff must be declined: %+v, %v", stored, err)
+	}
+	
+	expired := &models.ReviewerHandoff{PullRequestID: "pr-1", FromUserID: "u2", ToUserID: "u3", ExpiresAt: now.Add(-time.Minute)}
+	if created, err = s.CreateHandoff(expired); err != nil || !created {
+		t.Fatalf("handoff after a declined one must be created: %v, %v", created, err)
+	}
+	if err := s.CompleteHandoff(expired.ID, now); err == nil {
+		t.Fatal("expired handoff must not be completed")
+	}
+	count, err := s.ExpireHandoffs(now)
+	must(t, err)
+	if count != 1 {
+		t.Fatalf("expected 1 expired handoff, got %d", count)
+	}
+	if count, err = s.ExpireHandoffs(now); err != nil || count != 0 {
+		t.Fatalf("handoff must expire once: %d, %v", count, err)
+	}
+	if stored, err = s.GetHandoff(expired.ID); err != nil || stored.Status != "EXPIRED" {
+		t.Fatalf("handoff must be expired: %+v, %v", stored, err)
+	}
+}
+
+func testAuditLog(t *testing.T, s storage.Storage) {
+	first := &models.AuditEntry{ActorID: "admin", Action: "MERGE", PullRequestID: "pr-1",
+		Details: map[string]interface{}{"merged_by": "u1"}}
+	must(t, s.AddAuditEntry(first))
+	second := &models.AuditEntry{ActorID: "admin", Action: "TOKEN_MINT"}
+	must(t, s.AddAuditEntry(second))
+	if first.ID == 0 || first.CreatedAt.IsZero() || second.ID <= first.ID {
+		t.Fatalf("entries must be stamped: %+v, %+v", first, second)
+	}
+	
+	entries, err := s.ListAuditEntries(models.Page{Limit: 10})
+	must(t, err)
+	if len(entries) != 2 || entries[0].ID != second.ID || entries[0].PullRequestID != "" ||
+		entries[1].PullRequestID != "pr-1" || entries[1].Details["merged_by"] != "u1" {
+		t.Fatalf("entries must be listed newest first: %+v", entries)
+	}
+	
+	page, err := s.ListAuditEntries(models.Page{Limit: 1})
+	must(t, err)
+	rest, err := s.ListAuditEntries(models.Page{
+		After: &models.Cursor{CreatedAt: page[0].CreatedAt, ID: strconv.FormatInt(page[0].ID, 10)},
+		Limit: 10,
+	})
+	must(t, err)
+	if len(page) != 1 || len(rest) != 1 || rest[0].ID != first.ID {
+		t.Fatalf("unexpected pages: %+v, %+v", page, rest)
+	}
+}
+
+func testPRTimeline(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1")
+	seedPR(t, s, "pr-1", "author")
+	seedPR(t, s, "pr-2", "author")
+	
+	must(t, s.AddPREvent(&models.PREvent{PullRequestID: "pr-1", EventType: "PR_CREATED", ActorID: "author"}))
+	must(t, s.AddPREvent(&models.PREvent{PullRequestID: "pr-2", EventType: "PR_CREATED", ActorID: "author"}))
+	must(t, s.AddPREvent(&models.PREvent{PullRequestID: "pr-1", EventType: "REVIEWER_ASSIGNED",
+		Payload: map[string]interface{}{"user_id": "u1"}}))
+	if err := s.AddPREvent(&models.PREvent{PullRequestID: "missing", EventType: "PR_CREATED"}); err == nil {
+		t.Fatal("event of a missing PR must fail")
+	}
+	
+	events, err := s.GetPREvents("pr-1")
+	must(t, err)
+	if len(events) != 2 || events[0].EventType != "PR_CREATED" || events[0].ActorID != "author" ||
+		events[1].ActorID != "" || events[1].Payload["user_id"] != "u1" {
+		t.Fatalf("unexpected PR events: %+v", events)
+	}
+	
+	page, err := s.GetPRTimeline("pr-1", models.Page{Limit: 1})
+	must(t, err)
+	rest, err := s.GetPRTimeline("pr-1", models.Page{
+		After: &models.Cursor{CreatedAt: page[0].CreatedAt, ID: strconv.FormatInt(page[0].ID, 10)},
+		Limit: 10,
+	})
+	must(t, err)
+	if len(page) != 1 || page[0].EventType != "PR_CREATED" || len(rest) != 1 || rest[0].EventType != "REVIEWER_ASSIGNED" {
+		t.Fatalf("unexpected timeline pages: %+v, %+v", page, rest)
+	}
+	
+	all, err := s.ListPREvents(models.PREventFilter{}, 0, 10)
+	must(t, err)
+	if len(all) != 3 || all[0].ID >= all[1].ID || all[1].PullRequestID != "pr-2" {
+		t.Fatalf("events must be listed in id order: %+v", all)
+	}
+	after, err := s.ListPREvents(models.PREventFilter{PullRequestID: "pr-1"}, events[0].ID, 10)
+	must(t, err)
+	if len(after) != 1 || after[0].ID != events[1].ID {
+		t.Fatalf("unexpected filtered events: %+v", after)
+	}
+	future, err := s.ListPREvents(models.PREventFilter{From: time.Now().UTC().Add(time.Hour)}, 0, 10)
+	must(t, err)
+	if len(future) != 0 {
+		t.Fatalf("events before from must be skipped: %+v", future)
+	}
+}
+
+func testPRProjection(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "author", "u1", "u2")
+	seedPR(t, s, "pr-1", "author")
+	must(t, s.AddReviewer("pr-1", "u1", "AUTO"))
+	
+	pr, err := s.GetPullRequest("pr-1")
+	must(t, err)
+	mergedAt := time.Now().UTC().Truncate(time.Second)
+	state := &models.PRState{
+		PullRequest: *pr,
+		Reviewers: []models.PRReviewer{
+			{UserID: "u2", Status: "APPROVED", AssignmentType: "MANUAL", AssignedAt: mergedAt.Add(-time.Hour)},
+		},
+	}
+	state.PullRequest.Status = "MERGED"
+	state.PullRequest.MergedAt = &mergedAt
+	state.PullRequest.MergedBy = "u2"
+	must(t, s.ReplacePRProjection(state))
+	
+	pr, err = s.GetPullRequest("pr-1")
+	must(t, err)
+	if pr.Status != "MERGED" || pr.MergedBy != "u2" || len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "u2" {
+		t.Fatalf("projection must replace the PR and its reviewers: %+v", pr)
+	}
+	assignment, err := s.GetReviewerAssignment("pr-1", "u2")
+	must(t, err)
+	if assignment.Status != "APPROVED" || assignment.AssignmentType != "MANUAL" {
+		t.Fatalf("unexpected projected reviewer: %+v", assignment)
+	}
+	
+	// a PR missing from the table is inserted, without a team it belongs to the author's
+	must(t, s.ReplacePRProjection(&models.PRState{PullRequest: models.PullRequest{
+		PullRequestID: "pr-2", PullRequestName: "replayed", AuthorID: "author", Status: "OPEN", Priority: "NORMAL",
+		CreatedAt: mergedAt,
+	}}))
+	pr, err = s.GetPullRequest("pr-2")
+	must(t, err)
+	if pr.TeamName != "backend" || pr.PullRequestName != "replayed" || len(pr.AssignedReviewers) != 0 {
+		t.Fatalf("unexpected inserted projection: %+v", pr)
+	}
+}
+
+func testNotifications(t *testing.T, s storage.Storage) {
+	seedTeam(t, s, "backend", "u1", "u2")
+	
+	now := time.Now().UTC().Truncate(time.Second)
+	firstAt, secondAt, later := now.Add(-2*time.Minute), now.Add(-time.Minute), now.Add(time.Hour)
+	first := &models.Notification{UserID: "u1", Kind: "ASSIGNMENT", PullRequestID: "pr-1", Message: "first", DeliveredAt: &firstAt}
+	second := &models.Notification{UserID: "u1", Kind: "MENTION", Message: "second", DeliveredAt: &secondAt}
+	deferred := &models.Notification{UserID: "u1", Kind: "DIGEST", Message: "later", DeliverAfter: &later}
+	for _, n := range []*models.Notification{first, second, deferred} {
+		must(t, s.CreateNotification(n))
+	}
+	must(t, s.CreateNotification(&models.Notification{UserID: "u2", Kind: "DIGEST", Message: "other", DeliveredAt: &now}))
+	if first.ID == 0 || first.CreatedAt.IsZero() {
+		t.Fatalf("notification must be stamped: %+v", first)
+	}
+	
+	notifications, err := s.GetNotifications("u1", models.Page{Limit: 10})
+	must(t, err)
+	if len(notifications) != 2 || notifications[0].ID != second.ID || notifications[0].PullRequestID != "" ||
+		notifications[1].PullRequestID != "pr-1" {
+		t.Fatalf("only delivered notifications newest first: %+v", notifications)
+	}
+	rest, err := s.GetNotifications("u1", models.Page{
+		After: &models.Cursor{CreatedAt: *notifications[0].DeliveredAt, ID: strconv.FormatInt(notifications[0].ID, 10)},
+		Limit: 10,
+	})
+	must(t, err)
+	if len(rest) != 1 || rest[0].ID != first.ID {
+		t.Fatalf("unexpected second page: %+v", rest)
+	}
+	
+	released, err := s.ReleaseDeferredNotifications(now)
+	must(t, err)
+	if len(released) != 0 {
+		t.Fatalf("notification released before its time: %+v", released)
+	}
+	released, err = s.ReleaseDeferredNotifications(later.Add(time.Minute))
+	must(t, err)
+	if len(released) != 1 || released[0].ID != deferred.ID || released[0].DeliveredAt == nil {
+		t.Fatalf("deferred notification must be released: %+v", released)
+	}
+	if released, err = s.ReleaseDeferredNotifications(later.Add(time.Minute)); err != nil || len(released) != 0 {
+		t.Fatalf("notification must be released once: %+v, %v", released, err)
+	}
+	
+	start, end := 22, 7
+	must(t, s.SetUserQuietHours("u1", &start, &end))
+	user, err := s.GetUser("u1")
+	must(t, err)
+	if user.QuietHoursStart == nil || *user.QuietHoursStart != 22 || user.QuietHoursEnd == nil || *user.QuietHoursEnd != 7 {
+		t.Fatalf("quiet hours not stored: %+v", user)
+	}
+	invalid := 24
+	if err := s.SetUserQuietHours("u1", &invalid, &end); err == nil {
+		t.Fatal("hour out of range must be rejected")
+	}
+	if err := s.SetUserQuietHours("missing", &start, &end); err == nil {
+		t.Fatal("SetUserQuietHours of missing user must fail")
+	}
+	must(t, s.SetUserQuietHours("u1", nil, nil))
+	if user, err = s.GetUser("u1"); err != nil || user.QuietHoursStart != nil || user.QuietHoursEnd != nil {
+		t.Fatalf("nil hours must clear quiet hours: %+v, %v", user, err)
+	}
+}
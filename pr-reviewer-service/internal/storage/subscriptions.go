@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+)
+
+// MUTES
+
+func (s *PostgresStorage) MutePR(prID, userID string) error {
+	query := `
+		INSERT INTO pr_mutes (pull_request_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (pull_request_id, user_id) DO NOTHING
+	`
+	
+	_, err := s.db.Exec(query, prID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mute PR: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) UnmutePR(prID, userID string) error {
+	query := "DELETE FROM pr_mutes WHERE pull_request_id = $1 AND user_id = $2"
+	
+	_, err := s.db.Exec(query, prID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unmute PR: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) IsPRMuted(prID, userID string) (bool, error) {
+	query := "SELECT EXISTS(SELECT 1 FROM pr_mutes WHERE pull_request_id = $1 AND user_id = $2)"
+	
+	var muted bool
+	err := s.db.QueryRow(query, prID, userID).Scan(&muted)
+	if err != nil {
+		return false, fmt.Errorf("failed to check PR mute: %w", err)
+	}
+	
+	return muted, nil
+}
+
+// WATCHERS
+
+func (s *PostgresStorage) AddPRWatcher(prID, userID string) error {
+	query := `
+		INSERT INTO pr_watchers (pull_request_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (pull_request_id, user_id) DO NOTHING
+	`
+	
+	_, err := s.db.Exec(query, prID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to add PR watcher: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) RemovePRWatcher(prID, userID string) error {
+	query := "DELETE FROM pr_watchers WHERE pull_request_id = $1 AND user_id = $2"
+	
+	_, err := s.db.Exec(query, prID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove PR watcher: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) GetPRWatchers(prID string) ([]string, error) {
+	query := "SELECT user_id FROM pr_watchers WHERE pull_request_id = $1 ORDER BY created_at, user_id"
+	
+	rows, err := s.db.Query(query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR watchers: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	watchers := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan PR watcher: %w", err)
+		}
+		watchers = append(watchers, userID)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating PR watchers: %w", err)
+	}
+	
+	return watchers, nil
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// NOTIFICATION TEMPLATES
+
+// GetNotificationTemplate returns empty string if team uses the default template
+func (s *PostgresStorage) GetNotificationTemplate(teamName, kind string) (string, error) {
+	query := "SELECT body FROM notification_templates WHERE team_name = $1 AND kind = $2"
+	
+	var body string
+	err := s.db.QueryRow(query, teamName, kind).Scan(&body)
+	
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get notification template: %w", err)
+	}
+	
+	return body, nil
+}
+
+// GetNotificationTemplates returns team's custom templates by kind
+func (s *PostgresStorage) GetNotificationTemplates(teamName string) (map[string]string, error) {
+	query := "SELECT kind, body FROM notification_templates WHERE team_name = $1"
+	
+	rows, err := s.db.Query(query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification templates: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	templates := make(map[string]string)
+	for rows.Next() {
+		var kind, body string
+		if err := rows.Scan(&kind, &body); err != nil {
+			return nil, fmt.Errorf("failed to scan notification template: %w", err)
+		}
+		templates[kind] = body
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notification templates: %w", err)
+	}
+	
+	return templates, nil
+}
+
+func (s *PostgresStorage) SaveNotificationTemplate(teamName, kind, body string) error {
+	query := `
+		INSERT INTO notification_templates (team_name, kind, body)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (team_name, kind)
+		DO UPDATE SET body = EXCLUDED.body
+	`
+	
+	_, err := s.db.Exec(query, teamName, kind, body)
+	if err != nil {
+		return fmt.Errorf("failed to save notification template: %w", err)
+	}
+	
+	return nil
+}
+
+func (s *PostgresStorage) DeleteNotificationTemplate(teamName, kind string) error {
+	query := "DELETE FROM notification_templates WHERE team_name = $1 AND kind = $2"
+	
+	_, err := s.db.Exec(query, teamName, kind)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification template: %w", err)
+	}
+	
+	return nil
+}
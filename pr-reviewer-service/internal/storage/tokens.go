@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"pr-reviewer-service/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// API TOKENS
+
+const apiTokenColumns = "token_id, user_id, name, scopes, expires_at, created_at, last_used_at, revoked_at"
+
+func scanAPIToken(row rowScanner, token *models.APIToken) error {
+	return row.Scan(
+		&token.TokenID,
+		&token.UserID,
+		&token.Name,
+		pq.Array(&token.Scopes),
+		&token.ExpiresAt,
+		&token.CreatedAt,
+		&token.LastUsedAt,
+		&token.RevokedAt,
+	)
+}
+
+// CreateAPIToken stores token under its hash and fills id and creation time
+func (s *PostgresStorage) CreateAPIToken(token *models.APIToken, tokenHash string) error {
+	query := `
+		INSERT INTO api_tokens (user_id, name, token_hash, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING token_id
+	`
+	
+	err := s.db.QueryRow(query, token.UserID, token.Name, tokenHash, pq.Array(token.Scopes), token.ExpiresAt,
+		token.CreatedAt).Scan(&token.TokenID)
+	if err != nil {
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+	
+	return nil
+}
+
+// ListAPITokens returns user's tokens including revoked and expired ones, newest first
+func (s *PostgresStorage) ListAPITokens(userID string) ([]models.APIToken, error) {
+	query := `
+		SELECT ` + apiTokenColumns + `
+		FROM api_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC, token_id DESC
+	`
+	
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Failed to close rows: %v", err)
+		}
+	}()
+	
+	var tokens []models.APIToken
+	for rows.Next() {
+		var token models.APIToken
+		if err := scanAPIToken(rows, &token); err != nil {
+			return nil, fmt.Errorf("failed to scan API token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating API tokens: %w", err)
+	}
+	
+	return tokens, nil
+}
+
+// GetAPITokenByHash returns nil if no token has the hash
+func (s *PostgresStorage) GetAPITokenByHash(tokenHash string) (*models.APIToken, error) {
+	query := "SELECT " + apiTokenColumns + " FROM api_tokens WHERE token_hash = $1"
+	
+	var token models.APIToken
+	err := scanAPIToken(s.db.QueryRow(query, tokenHash), &token)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+	
+	return &token, nil
+}
+
+// RevokeAPIToken returns false if user has no such active token
+func (s *PostgresStorage) RevokeAPIToken(userID string, tokenID int64, at time.Time) (bool, error) {
+	query := `
+		UPDATE api_tokens SET revoked_at = $3
+		WHERE token_id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+	
+	result, err := s.db.Exec(query, tokenID, userID, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke API token: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+func (s *PostgresStorage) TouchAPIToken(tokenID int64, at time.Time) error {
+	if _, err := s.db.Exec("UPDATE api_tokens SET last_used_at = $2 WHERE token_id = $1", tokenID, at); err != nil {
+		return fmt.Errorf("failed to touch API token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// TEAM TRANSFERS
+
+// TransferUser moves user to another team and drops their reviewer pools in the old one
+func (s *PostgresStorage) TransferUser(userID, fromTeam, toTeam string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Failed to rollback transaction: %v", err)
+		}
+	}()
+	
+	result, err := tx.Exec("UPDATE users SET team_name = $1 WHERE user_id = $2 AND team_name = $3", toTeam, userID, fromTeam)
+	if err != nil {
+		return fmt.Errorf("failed to transfer user: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("user %s is not in team %s", userID, fromTeam)
+	}
+	
+	if _, err := tx.Exec("DELETE FROM reviewer_pools WHERE team_name = $1 AND user_id = $2", fromTeam, userID); err != nil {
+		return fmt.Errorf("failed to remove user from pools: %w", err)
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit user transfer: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,117 @@
+package strategy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Candidate - team member eligible to review the PR
+type Candidate struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// Request - reviewer selection input, PR fields are empty for previews
+type Request struct {
+	TeamName        string      `json:"team_name"`
+	PullRequestID   string      `json:"pull_request_id,omitempty"`
+	PullRequestName string      `json:"pull_request_name,omitempty"`
+	AuthorID        string      `json:"author_id,omitempty"`
+	Priority        string      `json:"priority,omitempty"`
+	Size            string      `json:"size,omitempty"`
+	RepositoryID    string      `json:"repository_id,omitempty"`
+	Labels          []string    `json:"labels,omitempty"`
+	ReviewerPool    string      `json:"reviewer_pool,omitempty"`
+	Count           int         `json:"count"`
+	Assigned        []string    `json:"assigned,omitempty"`  // current reviewers, never picked again
+	Preferred       []string    `json:"preferred,omitempty"` // picked first when eligible, e.g. reviewers of the stack base
+	Seniors         int         `json:"seniors,omitempty"`   // at least this many picked reviewers aren't juniors when eligible
+	Candidates      []Candidate `json:"candidates"`
+}
+
+// Ranker orders candidate user IDs by preference, best first
+type Ranker interface {
+	Rank(ctx context.Context, req Request) ([]string, error)
+}
+
+// Config - external decision service settings
+type Config struct {
+	URL     string
+	Timeout time.Duration
+}
+
+// ConfigFromEnv reads REVIEWER_STRATEGY_URL and REVIEWER_STRATEGY_TIMEOUT (default 2s)
+func ConfigFromEnv() Config {
+	cfg := Config{
+		URL:     os.Getenv("REVIEWER_STRATEGY_URL"),
+		Timeout: 2 * time.Second,
+	}
+	if raw := os.Getenv("REVIEWER_STRATEGY_TIMEOUT"); raw != "" {
+		if timeout, err := time.ParseDuration(raw); err == nil {
+			cfg.Timeout = timeout
+		} else {
+			log.Printf("Invalid REVIEWER_STRATEGY_TIMEOUT %q, using %s", raw, cfg.Timeout)
+		}
+	}
+	return cfg
+}
+
+// Enabled reports whether external strategy is configured
+func (c Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// HTTPRanker posts the request to the decision service and reads {"ranking": [...]}
+type HTTPRanker struct {
+	cfg  Config
+	http *http.Client
+}
+
+func NewHTTPRanker(cfg Config) *HTTPRanker {
+	return &HTTPRanker{
+		cfg:  cfg,
+		http: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (r *HTTPRanker) Rank(ctx context.Context, rankReq Request) ([]string, error) {
+	body, err := json.Marshal(rankReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal strategy request: %w", err)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build strategy request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	
+	resp, err := r.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call decision service: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Failed to close response body: %v", err)
+		}
+	}()
+	
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("decision service returned %d", resp.StatusCode)
+	}
+	
+	var result struct {
+		Ranking []string `json:"ranking"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode decision service response: %w", err)
+	}
+	
+	return result.Ranking, nil
+}
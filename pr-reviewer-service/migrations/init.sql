@@ -1,35 +1,695 @@
 
 CREATE TABLE teams (
-	team_name VARCHAR(255) PRIMARY KEY
+	team_name VARCHAR(255) PRIMARY KEY,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE TABLE users (
 	user_id VARCHAR(255) PRIMARY KEY,
-	username VARCHAR(255) NOT NULL,
+	username TEXT NOT NULL,
 	team_name VARCHAR(255) NOT NULL,
 	is_active BOOLEAN NOT NULL DEFAULT true,
-	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE RESTRICT
+	role VARCHAR(20) NOT NULL DEFAULT 'member',
+	max_open_reviews INTEGER CHECK (max_open_reviews >= 0),
+	max_daily_assignments INTEGER CHECK (max_daily_assignments >= 0),
+	timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+	digest_hour INTEGER CHECK (digest_hour BETWEEN 0 AND 23),
+	last_digest_at TIMESTAMP,
+	quiet_hours_start INTEGER CHECK (quiet_hours_start BETWEEN 0 AND 23),
+	quiet_hours_end INTEGER CHECK (quiet_hours_end BETWEEN 0 AND 23),
+	is_junior BOOLEAN NOT NULL DEFAULT FALSE,
+	region VARCHAR(50) NOT NULL DEFAULT '',
+	manager_id VARCHAR(255) NOT NULL DEFAULT '',
+	tags TEXT[] NOT NULL DEFAULT '{}',
+	notification_address TEXT NOT NULL DEFAULT '',
+	locale VARCHAR(10) NOT NULL DEFAULT '',
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE RESTRICT,
+	CHECK (role IN ('member', 'lead', 'admin'))
 );
 
 CREATE TABLE pull_requests (
 	pull_request_id VARCHAR(255) PRIMARY KEY,
 	pull_request_name VARCHAR(255) NOT NULL,
 	author_id VARCHAR(255) NOT NULL,
+	team_name VARCHAR(255) NOT NULL,
+	repository_id VARCHAR(255) NOT NULL DEFAULT '',
 	status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+	priority VARCHAR(20) NOT NULL DEFAULT 'NORMAL',
+	size VARCHAR(2) NOT NULL DEFAULT '',
+	reviewer_pool VARCHAR(255) NOT NULL DEFAULT '',
+	priority_rank SMALLINT GENERATED ALWAYS AS (
+		CASE priority WHEN 'LOW' THEN 0 WHEN 'NORMAL' THEN 1 WHEN 'HIGH' THEN 2 ELSE 3 END
+	) STORED,
 	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 	merged_at TIMESTAMP,
+	merged_by VARCHAR(255) NOT NULL DEFAULT '',
+	merge_commit VARCHAR(255) NOT NULL DEFAULT '',
+	merge_url VARCHAR(1024) NOT NULL DEFAULT '',
+	external_id VARCHAR(255) NOT NULL DEFAULT '',
+	external_url VARCHAR(1024) NOT NULL DEFAULT '',
 	FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE RESTRICT,
-	CHECK (status IN ('OPEN', 'MERGED'))
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE RESTRICT,
+	CHECK (status IN ('OPEN', 'MERGED', 'CLOSED')),
+	CHECK (priority IN ('LOW', 'NORMAL', 'HIGH', 'URGENT'))
 );
 
 CREATE TABLE pr_reviewers (
 	pull_request_id VARCHAR(255) NOT NULL,
 	user_id VARCHAR(255) NOT NULL,
+	assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+	first_action_at TIMESTAMP,
+	assignment_type VARCHAR(20) NOT NULL DEFAULT 'AUTO',
+	team_name VARCHAR(255) NOT NULL DEFAULT '',
+	sla_paused_at TIMESTAMP,
+	sla_paused_seconds BIGINT NOT NULL DEFAULT 0,
+	first_action_paused_seconds BIGINT NOT NULL DEFAULT 0,
 	PRIMARY KEY (pull_request_id, user_id),
 	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
-	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT,
+	CHECK (status IN ('PENDING', 'ACCEPTED', 'APPROVED')),
+	CHECK (assignment_type IN ('AUTO', 'MANUAL', 'VOLUNTEER', 'ESCALATION'))
 );
 
 CREATE INDEX idx_users_team_name ON users(team_name);
+CREATE INDEX idx_pull_requests_created_at ON pull_requests(created_at, pull_request_id);
+CREATE INDEX idx_pull_requests_priority ON pull_requests(priority_rank, pull_request_id);
+CREATE INDEX idx_pull_requests_name ON pull_requests(pull_request_name, pull_request_id);
+CREATE INDEX idx_pr_reviewers_user_assigned ON pr_reviewers(user_id, assigned_at, pull_request_id);
 CREATE INDEX idx_pull_requests_author_id ON pull_requests(author_id);
-CREATE INDEX idx_pr_reviewers_user_id ON pr_reviewers(user_id);
\ No newline at end of file
+CREATE INDEX idx_pull_requests_team_name ON pull_requests(team_name);
+CREATE UNIQUE INDEX idx_pull_requests_external_id ON pull_requests(external_id) WHERE external_id <> '';
+CREATE UNIQUE INDEX idx_pull_requests_external_url ON pull_requests(external_url) WHERE external_url <> '';
+CREATE INDEX idx_pr_reviewers_user_id ON pr_reviewers(user_id);
+
+CREATE TABLE user_vacations (
+	id SERIAL PRIMARY KEY,
+	user_id VARCHAR(255) NOT NULL,
+	starts_at TIMESTAMP NOT NULL,
+	ends_at TIMESTAMP NOT NULL,
+	source VARCHAR(50) NOT NULL DEFAULT 'MANUAL',
+	external_id VARCHAR(255),
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+	CHECK (ends_at > starts_at)
+);
+
+CREATE TABLE user_calendar_tokens (
+	user_id VARCHAR(255) PRIMARY KEY,
+	refresh_token TEXT NOT NULL,
+	access_token TEXT NOT NULL DEFAULT '',
+	expires_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	last_synced_at TIMESTAMP,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_vacations_user_period ON user_vacations(user_id, starts_at, ends_at);
+
+CREATE TABLE calendar_oauth_states (
+	nonce VARCHAR(64) PRIMARY KEY,
+	expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_calendar_oauth_states_expires ON calendar_oauth_states(expires_at);
+
+CREATE TABLE team_settings (
+	team_name VARCHAR(255) PRIMARY KEY,
+	review_sla_hours INTEGER NOT NULL DEFAULT 24 CHECK (review_sla_hours > 0),
+	max_open_reviews INTEGER CHECK (max_open_reviews >= 0),
+	max_daily_assignments INTEGER CHECK (max_daily_assignments >= 0),
+	strict_merge BOOLEAN NOT NULL DEFAULT FALSE,
+	two_phase_review BOOLEAN NOT NULL DEFAULT FALSE,
+	shadow_reviewers INTEGER NOT NULL DEFAULT 0 CHECK (shadow_reviewers >= 0),
+	lead_escalation_hours INTEGER CHECK (lead_escalation_hours > 0),
+	dependency_policy VARCHAR(10) NOT NULL DEFAULT 'NONE' CHECK (dependency_policy IN ('NONE', 'WARN', 'BLOCK')),
+	transfer_reviews VARCHAR(10) NOT NULL DEFAULT 'KEEP' CHECK (transfer_reviews IN ('KEEP', 'REASSIGN')),
+	notification_routes JSONB NOT NULL DEFAULT '{}',
+	no_candidate_fallback VARCHAR(20) NOT NULL DEFAULT 'FAIL' CHECK (no_candidate_fallback IN ('FAIL', 'KEEP', 'PARENT_TEAM', 'QUEUE', 'LEAD')),
+	parent_team VARCHAR(255) NOT NULL DEFAULT '',
+	absence_reserve_days INTEGER NOT NULL DEFAULT 0 CHECK (absence_reserve_days >= 0),
+	blind_review BOOLEAN NOT NULL DEFAULT FALSE,
+	reviewer_count INTEGER NOT NULL DEFAULT 2 CHECK (reviewer_count >= 0),
+	leaderboard BOOLEAN NOT NULL DEFAULT FALSE,
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE escalation_rules (
+	id SERIAL PRIMARY KEY,
+	team_name VARCHAR(255) NOT NULL,
+	overdue_hours INTEGER NOT NULL CHECK (overdue_hours >= 0),
+	action VARCHAR(20) NOT NULL,
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE,
+	UNIQUE (team_name, overdue_hours, action),
+	CHECK (action IN ('NOTIFY_LEAD', 'NOTIFY_MANAGER', 'REASSIGN'))
+);
+
+CREATE TABLE pr_escalations (
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	rule_id INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pull_request_id, user_id, rule_id),
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (rule_id) REFERENCES escalation_rules(id) ON DELETE CASCADE
+);
+
+CREATE TABLE pr_events (
+	id BIGSERIAL PRIMARY KEY,
+	pull_request_id VARCHAR(255) NOT NULL,
+	event_type VARCHAR(50) NOT NULL,
+	actor_id VARCHAR(255),
+	payload JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
+
+CREATE TABLE notifications (
+	id BIGSERIAL PRIMARY KEY,
+	user_id VARCHAR(255) NOT NULL,
+	kind VARCHAR(50) NOT NULL,
+	pull_request_id VARCHAR(255),
+	message TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	deliver_after TIMESTAMP,
+	delivered_at TIMESTAMP,
+	read_at TIMESTAMP,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pr_events_pull_request_id ON pr_events(pull_request_id, created_at);
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, delivered_at);
+CREATE INDEX idx_notifications_deferred ON notifications(deliver_after) WHERE delivered_at IS NULL;
+
+CREATE TABLE sla_breaches (
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	assigned_at TIMESTAMP NOT NULL,
+	alerted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pull_request_id, user_id, assigned_at),
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
+
+CREATE TABLE team_reports (
+	team_name VARCHAR(255) NOT NULL,
+	week VARCHAR(8) NOT NULL,
+	sent_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (team_name, week),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE notification_templates (
+	team_name VARCHAR(255) NOT NULL,
+	kind VARCHAR(50) NOT NULL,
+	body TEXT NOT NULL,
+	PRIMARY KEY (team_name, kind),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE pr_mutes (
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pull_request_id, user_id),
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE pr_watchers (
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pull_request_id, user_id),
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE pr_comments (
+	id BIGSERIAL PRIMARY KEY,
+	pull_request_id VARCHAR(255) NOT NULL,
+	author_id VARCHAR(255) NOT NULL,
+	body TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pr_comments_pull_request_id ON pr_comments(pull_request_id, created_at);
+
+CREATE TABLE checklist_templates (
+	team_name VARCHAR(255) NOT NULL,
+	position INTEGER NOT NULL,
+	title VARCHAR(255) NOT NULL,
+	PRIMARY KEY (team_name, position),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE review_checklist_items (
+	id BIGSERIAL PRIMARY KEY,
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	position INTEGER NOT NULL,
+	title VARCHAR(255) NOT NULL,
+	checked_at TIMESTAMP,
+	FOREIGN KEY (pull_request_id, user_id) REFERENCES pr_reviewers(pull_request_id, user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_review_checklist_items_reviewer ON review_checklist_items(pull_request_id, user_id);
+
+CREATE TABLE audit_log (
+	id BIGSERIAL PRIMARY KEY,
+	actor_id VARCHAR(255) NOT NULL,
+	action VARCHAR(50) NOT NULL,
+	pull_request_id VARCHAR(255),
+	details JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
+
+CREATE TABLE reviewer_handoffs (
+	id BIGSERIAL PRIMARY KEY,
+	pull_request_id VARCHAR(255) NOT NULL,
+	from_user_id VARCHAR(255) NOT NULL,
+	to_user_id VARCHAR(255) NOT NULL,
+	status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at TIMESTAMP NOT NULL,
+	resolved_at TIMESTAMP,
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (from_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+	FOREIGN KEY (to_user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+	CHECK (status IN ('PENDING', 'ACCEPTED', 'DECLINED', 'EXPIRED'))
+);
+
+CREATE UNIQUE INDEX idx_reviewer_handoffs_pending ON reviewer_handoffs(pull_request_id, from_user_id)
+	WHERE status = 'PENDING';
+
+CREATE TABLE stats_user_load (
+	user_id VARCHAR(255) PRIMARY KEY,
+	open_reviews INTEGER NOT NULL DEFAULT 0,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE stats_team_weekly (
+	team_name VARCHAR(255) NOT NULL,
+	week_start DATE NOT NULL,
+	prs_created INTEGER NOT NULL DEFAULT 0,
+	prs_merged INTEGER NOT NULL DEFAULT 0,
+	assignments INTEGER NOT NULL DEFAULT 0,
+	turnaround_hours_sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+	PRIMARY KEY (team_name, week_start),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE jobs (
+	id BIGSERIAL PRIMARY KEY,
+	kind VARCHAR(50) NOT NULL,
+	payload JSONB NOT NULL DEFAULT '{}',
+	status VARCHAR(20) NOT NULL DEFAULT 'QUEUED',
+	attempts INTEGER NOT NULL DEFAULT 0,
+	max_attempts INTEGER NOT NULL DEFAULT 5,
+	run_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	locked_by VARCHAR(255),
+	locked_until TIMESTAMP,
+	last_error TEXT,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CHECK (status IN ('QUEUED', 'RUNNING', 'DONE', 'DEAD'))
+);
+
+CREATE INDEX idx_jobs_ready ON jobs(run_at) WHERE status IN ('QUEUED', 'RUNNING');
+
+CREATE TABLE scheduler_leases (
+	name VARCHAR(100) PRIMARY KEY,
+	holder VARCHAR(255) NOT NULL,
+	expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_pr_events_timeline ON pr_events(pull_request_id, created_at, id);
+CREATE INDEX idx_notifications_user ON notifications(user_id, delivered_at, id);
+
+CREATE TABLE reviewer_pools (
+	team_name VARCHAR(255) NOT NULL,
+	pool_name VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	PRIMARY KEY (team_name, pool_name, user_id),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE repositories (
+	repository_id VARCHAR(255) PRIMARY KEY,
+	team_name VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_repositories_team_name ON repositories(team_name);
+
+CREATE TABLE path_owners (
+	repository_id VARCHAR(255) NOT NULL,
+	path_prefix VARCHAR(1024) NOT NULL,
+	team_name VARCHAR(255) NOT NULL,
+	PRIMARY KEY (repository_id, path_prefix, team_name),
+	FOREIGN KEY (repository_id) REFERENCES repositories(repository_id) ON DELETE CASCADE,
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE team_size_rules (
+	team_name VARCHAR(255) NOT NULL,
+	size VARCHAR(2) NOT NULL,
+	max_lines INTEGER CHECK (max_lines >= 0),
+	reviewers INTEGER NOT NULL CHECK (reviewers > 0),
+	PRIMARY KEY (team_name, size),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE,
+	CHECK (size IN ('XS', 'S', 'M', 'L', 'XL'))
+);
+
+CREATE TABLE team_risk_rules (
+	team_name VARCHAR(255) NOT NULL,
+	min_score INTEGER NOT NULL CHECK (min_score BETWEEN 0 AND 100),
+	reviewers INTEGER NOT NULL CHECK (reviewers > 0),
+	senior_reviewers INTEGER NOT NULL DEFAULT 0 CHECK (senior_reviewers BETWEEN 0 AND reviewers),
+	PRIMARY KEY (team_name, min_score),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE team_risk_signals (
+	team_name VARCHAR(255) NOT NULL,
+	kind VARCHAR(10) NOT NULL CHECK (kind IN ('LABEL', 'PATH')),
+	value VARCHAR(255) NOT NULL,
+	weight INTEGER NOT NULL CHECK (weight BETWEEN 1 AND 100),
+	PRIMARY KEY (team_name, kind, value),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE review_phases (
+	pull_request_id VARCHAR(255) PRIMARY KEY,
+	phase SMALLINT NOT NULL DEFAULT 1,
+	second_phase_reviewers INTEGER NOT NULL CHECK (second_phase_reviewers > 0),
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	advanced_at TIMESTAMP,
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	CHECK (phase IN (1, 2))
+);
+
+CREATE INDEX idx_review_phases_first ON review_phases(pull_request_id) WHERE phase = 1;
+
+CREATE TABLE pr_shadow_reviewers (
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pull_request_id, user_id),
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pr_shadow_reviewers_user ON pr_shadow_reviewers(user_id, assigned_at, pull_request_id);
+
+CREATE TABLE review_sessions (
+	session_id BIGSERIAL PRIMARY KEY,
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	started_at TIMESTAMP NOT NULL,
+	finished_at TIMESTAMP,
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+	CHECK (finished_at IS NULL OR finished_at >= started_at)
+);
+
+CREATE UNIQUE INDEX idx_review_sessions_open ON review_sessions(pull_request_id, user_id) WHERE finished_at IS NULL;
+CREATE INDEX idx_review_sessions_started ON review_sessions(started_at);
+
+CREATE TABLE team_holidays (
+	team_name VARCHAR(255) NOT NULL,
+	holiday_date DATE NOT NULL,
+	region VARCHAR(50) NOT NULL DEFAULT '',
+	name VARCHAR(255) NOT NULL DEFAULT '',
+	PRIMARY KEY (team_name, holiday_date, region),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE pending_assignments (
+	pull_request_id VARCHAR(255) NOT NULL,
+	team_name VARCHAR(255) NOT NULL,
+	reviewers INTEGER NOT NULL CHECK (reviewers > 0),
+	queued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pull_request_id, team_name),
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE pr_lead_escalations (
+	pull_request_id VARCHAR(255) PRIMARY KEY,
+	escalated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
+
+CREATE TABLE review_decisions (
+	id BIGSERIAL PRIMARY KEY,
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	decision VARCHAR(20) NOT NULL,
+	revision VARCHAR(255) NOT NULL DEFAULT '',
+	decided_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT,
+	CHECK (decision IN ('APPROVED', 'CHANGES_REQUESTED'))
+);
+
+CREATE INDEX idx_review_decisions_pr ON review_decisions(pull_request_id, decided_at);
+
+CREATE TABLE pr_dependencies (
+	pull_request_id VARCHAR(255) NOT NULL,
+	depends_on_id VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pull_request_id, depends_on_id),
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (depends_on_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	CHECK (pull_request_id <> depends_on_id)
+);
+
+CREATE INDEX idx_pr_dependencies_depends_on ON pr_dependencies(depends_on_id);
+
+CREATE TABLE pr_stacks (
+	pull_request_id VARCHAR(255) PRIMARY KEY,
+	base_id VARCHAR(255) NOT NULL,
+	parent_id VARCHAR(255),
+	position INTEGER NOT NULL CHECK (position >= 0),
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (base_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (parent_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pr_stacks_base ON pr_stacks(base_id, position);
+
+CREATE TABLE api_tokens (
+	token_id BIGSERIAL PRIMARY KEY,
+	user_id VARCHAR(255) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	token_hash CHAR(64) NOT NULL UNIQUE,
+	scopes TEXT[] NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	last_used_at TIMESTAMP,
+	revoked_at TIMESTAMP,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+	CHECK (expires_at > created_at)
+);
+
+CREATE INDEX idx_api_tokens_user ON api_tokens(user_id, created_at);
+
+CREATE TABLE pull_requests_archive (
+	pull_request_id VARCHAR(255) NOT NULL,
+	pull_request_name VARCHAR(255) NOT NULL,
+	author_id VARCHAR(255) NOT NULL,
+	team_name VARCHAR(255) NOT NULL,
+	repository_id VARCHAR(255) NOT NULL,
+	priority VARCHAR(20) NOT NULL,
+	size VARCHAR(2) NOT NULL,
+	reviewer_pool VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	merged_at TIMESTAMP NOT NULL,
+	merged_by VARCHAR(255) NOT NULL,
+	merge_commit VARCHAR(255) NOT NULL,
+	merge_url VARCHAR(1024) NOT NULL,
+	external_id VARCHAR(255) NOT NULL DEFAULT '',
+	external_url VARCHAR(1024) NOT NULL DEFAULT '',
+	archived_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pull_request_id, merged_at)
+) PARTITION BY RANGE (merged_at);
+
+CREATE TABLE pr_reviewers_archive (
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	merged_at TIMESTAMP NOT NULL,
+	assigned_at TIMESTAMP NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	first_action_at TIMESTAMP,
+	assignment_type VARCHAR(20) NOT NULL,
+	team_name VARCHAR(255) NOT NULL DEFAULT '',
+	first_action_paused_seconds BIGINT NOT NULL DEFAULT 0,
+	PRIMARY KEY (pull_request_id, user_id, merged_at)
+) PARTITION BY RANGE (merged_at);
+
+CREATE TABLE pr_history_archive (
+	id BIGSERIAL PRIMARY KEY,
+	pull_request_id VARCHAR(255) NOT NULL,
+	source_table VARCHAR(255) NOT NULL,
+	row_data JSONB NOT NULL,
+	archived_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pull_requests_merged_at ON pull_requests(merged_at) WHERE status = 'MERGED';
+CREATE INDEX idx_pull_requests_archive_id ON pull_requests_archive(pull_request_id);
+CREATE INDEX idx_pr_reviewers_archive_user ON pr_reviewers_archive(user_id, merged_at);
+CREATE INDEX idx_pr_history_archive_pr ON pr_history_archive(pull_request_id);
+
+CREATE TABLE assignment_declines (
+	id BIGSERIAL PRIMARY KEY,
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	team_name VARCHAR(255) NOT NULL,
+	reason VARCHAR(20) NOT NULL CHECK (reason IN ('TOO_BUSY', 'CONFLICT', 'UNFAMILIAR_AREA', 'OTHER')),
+	comment TEXT NOT NULL DEFAULT '',
+	replaced_by VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_assignment_declines_team ON assignment_declines(team_name, created_at);
+
+CREATE TABLE user_events (
+	id BIGINT PRIMARY KEY DEFAULT nextval('pr_events_id_seq'),
+	user_id VARCHAR(255) NOT NULL,
+	event_type VARCHAR(50) NOT NULL,
+	actor_id VARCHAR(255),
+	payload JSONB NOT NULL DEFAULT '{}',
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_events_user ON user_events(user_id, created_at);
+
+CREATE TABLE user_identities (
+	provider VARCHAR(20) NOT NULL,
+	external_id VARCHAR(255) NOT NULL,
+	external_id_enc TEXT,
+	user_id VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (provider, external_id),
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_identities_user ON user_identities(user_id);
+
+CREATE TABLE pr_templates (
+	team_name VARCHAR(255) NOT NULL,
+	name VARCHAR(100) NOT NULL,
+	position INTEGER NOT NULL,
+	name_prefix VARCHAR(255) NOT NULL DEFAULT '',
+	labels TEXT[] NOT NULL DEFAULT '{}',
+	priority VARCHAR(20) NOT NULL DEFAULT '',
+	reviewer_pool VARCHAR(255) NOT NULL DEFAULT '',
+	PRIMARY KEY (team_name, name),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE,
+	CHECK (priority IN ('', 'LOW', 'NORMAL', 'HIGH', 'URGENT'))
+);
+
+CREATE TABLE assignment_rules (
+	team_name VARCHAR(255) NOT NULL,
+	name VARCHAR(100) NOT NULL,
+	position INTEGER NOT NULL,
+	conditions JSONB NOT NULL DEFAULT '[]',
+	action VARCHAR(30) NOT NULL CHECK (action IN ('ADD_POOL_REVIEWER', 'ADD_REVIEWER')),
+	reviewer_pool VARCHAR(255) NOT NULL DEFAULT '',
+	user_id VARCHAR(255) NOT NULL DEFAULT '',
+	count INTEGER NOT NULL DEFAULT 1 CHECK (count > 0),
+	PRIMARY KEY (team_name, name),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE team_policies (
+	team_name VARCHAR(255) PRIMARY KEY,
+	source TEXT NOT NULL,
+	updated_by VARCHAR(255) NOT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE data_keys (
+	key_id SERIAL PRIMARY KEY,
+	purpose VARCHAR(20) NOT NULL,
+	master_key_id VARCHAR(64) NOT NULL,
+	wrapped_key BYTEA NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CHECK (purpose IN ('data', 'index'))
+);
+
+CREATE UNIQUE INDEX idx_data_keys_index ON data_keys(purpose) WHERE purpose = 'index';
+
+CREATE TABLE pr_review_teams (
+	pull_request_id VARCHAR(255) NOT NULL,
+	team_name VARCHAR(255) NOT NULL,
+	required_approvals INTEGER NOT NULL DEFAULT 1 CHECK (required_approvals >= 0),
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pull_request_id, team_name),
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE TABLE pr_followups (
+	id BIGSERIAL PRIMARY KEY,
+	pull_request_id VARCHAR(255) NOT NULL,
+	user_id VARCHAR(255) NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	requested_by VARCHAR(255) NOT NULL,
+	sla_hours INTEGER NOT NULL CHECK (sla_hours > 0),
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	due_at TIMESTAMP NOT NULL,
+	completed_at TIMESTAMP,
+	breach_notified_at TIMESTAMP,
+	FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+	FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE RESTRICT
+);
+
+CREATE UNIQUE INDEX idx_pr_followups_open ON pr_followups(pull_request_id) WHERE completed_at IS NULL;
+CREATE INDEX idx_pr_followups_user ON pr_followups(user_id) WHERE completed_at IS NULL;
+
+CREATE TABLE pr_reverts (
+	pull_request_id VARCHAR(255) PRIMARY KEY,
+	reverted_id VARCHAR(255) NOT NULL,
+	team_name VARCHAR(255) NOT NULL,
+	reverted_merged_at TIMESTAMP NOT NULL,
+	reviewers TEXT[] NOT NULL DEFAULT '{}',
+	marked_by VARCHAR(255) NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CHECK (pull_request_id <> reverted_id),
+	FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_pr_reverts_team ON pr_reverts(team_name, reverted_merged_at);
+
+CREATE TABLE scheduler_runs (
+	job_name VARCHAR(100) PRIMARY KEY,
+	holder VARCHAR(255) NOT NULL,
+	started_at TIMESTAMP NOT NULL,
+	finished_at TIMESTAMP NOT NULL,
+	last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE webhook_deliveries (
+	source VARCHAR(50) NOT NULL,
+	delivery_id VARCHAR(255) NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (source, delivery_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_expires ON webhook_deliveries(expires_at);
+
+CREATE TABLE schema_version (
+	version INTEGER NOT NULL
+);
+
+INSERT INTO schema_version (version) VALUES (29);
@@ -0,0 +1,10 @@
+// Package migrations embeds the database schema the binary is built against
+package migrations
+
+import _ "embed"
+
+// Version of init.sql, bumped together with the schema_version row on every schema change
+const Version = 29
+
+//go:embed init.sql
+var InitSQL string